@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNoCredentials is returned by an Authenticator when the request carries no credentials
+	// it understands. ChainAuthenticators then tries the next one.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned for credentials that are present but not accepted.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller. Plugins record it as requestedBy/decidedBy.
+	Subject string
+	// Roles are looked up in the Policy.
+	Roles []string
+	// Namespace, when set, binds the caller to one namespace (see DefaultNamespaceResolver).
+	Namespace string
+}
+
+// Authenticator identifies the caller of a request.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+type AuthenticatorFunc func(r *http.Request) (*Principal, error)
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (*Principal, error) {
+	return f(r)
+}
+
+// ChainAuthenticators tries the authenticators in order until one of them accepts the request.
+// Several of them may read the same header, so rejected credentials only fail the request
+// when no later authenticator accepts them; the first rejection is returned then.
+func ChainAuthenticators(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) (*Principal, error) {
+		var rejected error
+		for _, authenticator := range authenticators {
+			principal, err := authenticator.Authenticate(r)
+			if err == nil {
+				return principal, nil
+			}
+
+			if !errors.Is(err, ErrNoCredentials) && !errors.Is(err, ErrInvalidCredentials) {
+				return nil, err
+			}
+			if rejected == nil && errors.Is(err, ErrInvalidCredentials) {
+				rejected = err
+			}
+		}
+
+		if rejected != nil {
+			return nil, rejected
+		}
+
+		return nil, ErrNoCredentials
+	})
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller authenticated by the server, if auth is enabled.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return principal, ok && principal != nil
+}
+
+// RequestUser returns the subject of the authenticated caller. Without auth it falls back to
+// the ExtractUserFn of the plugin, which may be nil when the server always authenticates.
+func RequestUser(r *http.Request, fallback func(r *http.Request) (string, error)) (string, error) {
+	if principal, ok := PrincipalFromContext(r.Context()); ok {
+		return principal.Subject, nil
+	}
+
+	if fallback == nil {
+		return "", errors.New("request is not authenticated")
+	}
+
+	return fallback(r)
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+// authMiddleware authenticates every request and checks the permission of the matched route.
+// Requests that match no route are only authenticated and left to the mux for a 404.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authenticator == nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		principal, err := s.authenticator.Authenticate(r)
+		if err == nil && principal == nil {
+			err = ErrNoCredentials
+		}
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="floxy"`)
+			if errors.Is(err, ErrNoCredentials) {
+				WriteErrorResponse(w, errors.New("authentication required"), http.StatusUnauthorized)
+
+				return
+			}
+
+			WriteErrorResponse(w, err, http.StatusUnauthorized)
+
+			return
+		}
+
+		if s.policy != nil {
+			if _, pattern := s.routes.Handler(r); pattern != "" {
+				allowed, err := s.policy.authorize(r, s.store, principal, pattern)
+				if err != nil {
+					WriteErrorResponse(w, fmt.Errorf("failed to authorize request: %w", err), http.StatusInternalServerError)
+
+					return
+				}
+				if !allowed {
+					WriteErrorResponse(w, errors.New("permission denied"), http.StatusForbidden)
+
+					return
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const defaultJWTRolesClaim = "roles"
+
+var jwtAlgorithms = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// JWTAuthenticator accepts HMAC-signed JWTs (HS256, HS384, HS512) sent as
+// "Authorization: Bearer <token>". The sub claim becomes the subject of the principal and
+// the roles claim, an array or a space-separated string, its roles. Tokens must carry an exp
+// claim unless WithJWTNonExpiringTokens is set.
+type JWTAuthenticator struct {
+	keys           map[string][]byte
+	issuer         string
+	audience       string
+	rolesClaim     string
+	namespaceClaim string
+	leeway         time.Duration
+	allowNoExpiry  bool
+	now            func() time.Time
+}
+
+type JWTOption func(*JWTAuthenticator)
+
+// WithJWTKey adds a key for tokens with the kid header, to rotate keys without downtime.
+func WithJWTKey(kid string, secret []byte) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.keys[kid] = secret
+	}
+}
+
+// WithJWTIssuer rejects tokens without this iss claim.
+func WithJWTIssuer(issuer string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.issuer = issuer
+	}
+}
+
+// WithJWTAudience rejects tokens without this value in the aud claim.
+func WithJWTAudience(audience string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.audience = audience
+	}
+}
+
+// WithJWTRolesClaim reads roles from another claim than "roles".
+func WithJWTRolesClaim(claim string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.rolesClaim = claim
+	}
+}
+
+// WithJWTNamespaceClaim binds the principal to the namespace in this claim. Tokens without
+// the claim are not bound.
+func WithJWTNamespaceClaim(claim string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.namespaceClaim = claim
+	}
+}
+
+// WithJWTLeeway tolerates clock skew when checking exp and nbf.
+func WithJWTLeeway(leeway time.Duration) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.leeway = leeway
+	}
+}
+
+// WithJWTNonExpiringTokens accepts tokens without an exp claim. Such a token stays valid
+// until its key is removed, so prefer short-lived tokens.
+func WithJWTNonExpiringTokens() JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.allowNoExpiry = true
+	}
+}
+
+// NewJWTAuthenticator verifies tokens without a kid header with secret, which may be nil
+// when every token names its key.
+func NewJWTAuthenticator(secret []byte, opts ...JWTOption) *JWTAuthenticator {
+	a := &JWTAuthenticator{
+		keys:       make(map[string][]byte),
+		rolesClaim: defaultJWTRolesClaim,
+		now:        time.Now,
+	}
+	if secret != nil {
+		a.keys[""] = secret
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrNoCredentials
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed token header", ErrInvalidCredentials)
+	}
+
+	newHash, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported token algorithm %q", ErrInvalidCredentials, header.Alg)
+	}
+
+	key, ok := a.keys[header.Kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown token key %q", ErrInvalidCredentials, header.Kid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token signature", ErrInvalidCredentials)
+	}
+
+	mac := hmac.New(newHash, key)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: token signature mismatch", ErrInvalidCredentials)
+	}
+
+	var claims map[string]any
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed token claims", ErrInvalidCredentials)
+	}
+
+	if err := a.validateClaims(claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentials, err)
+	}
+
+	principal := &Principal{
+		Subject: claims["sub"].(string),
+		Roles:   stringsClaim(claims[a.rolesClaim]),
+	}
+	if a.namespaceClaim != "" {
+		principal.Namespace, _ = claims[a.namespaceClaim].(string)
+	}
+
+	return principal, nil
+}
+
+func (a *JWTAuthenticator) validateClaims(claims map[string]any) error {
+	now := a.now()
+
+	exp, ok := claims["exp"].(float64)
+	switch {
+	case !ok && !a.allowNoExpiry:
+		return fmt.Errorf("token has no expiration")
+	case ok && now.After(time.Unix(int64(exp), 0).Add(a.leeway)):
+		return fmt.Errorf("token is expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(a.leeway).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+
+	if a.issuer != "" && claims["iss"] != a.issuer {
+		return fmt.Errorf("token issuer mismatch")
+	}
+	if a.audience != "" && !slices.Contains(stringsClaim(claims["aud"]), a.audience) {
+		return fmt.Errorf("token audience mismatch")
+	}
+
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return fmt.Errorf("token has no subject")
+	}
+
+	return nil
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// stringsClaim reads a claim that is either a string array or a space-separated string.
+func stringsClaim(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return strings.Fields(value)
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
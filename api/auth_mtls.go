@@ -0,0 +1,68 @@
+package api
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// ClientCertAuthenticator accepts TLS client certificates verified by the server. Configure
+// the http.Server with tls.Config.ClientAuth set to tls.VerifyClientCertIfGiven or
+// tls.RequireAndVerifyClientCert and the client CA pool.
+type ClientCertAuthenticator struct {
+	roles   map[string][]string
+	ouRoles bool
+}
+
+type ClientCertOption func(*ClientCertAuthenticator)
+
+// WithClientCertRoles assigns roles by the common name of the certificate subject.
+func WithClientCertRoles(rolesByCommonName map[string][]string) ClientCertOption {
+	return func(a *ClientCertAuthenticator) {
+		a.roles = rolesByCommonName
+	}
+}
+
+// WithClientCertOURoles also uses the organizational units of the certificate subject as roles.
+func WithClientCertOURoles() ClientCertOption {
+	return func(a *ClientCertAuthenticator) {
+		a.ouRoles = true
+	}
+}
+
+// NewClientCertAuthenticator authenticates the common name of the verified client certificate.
+func NewClientCertAuthenticator(opts ...ClientCertOption) *ClientCertAuthenticator {
+	a := &ClientCertAuthenticator{}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+func (a *ClientCertAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	// Unverified certificates reach the handler with tls.RequestClientCert
+	if len(r.TLS.VerifiedChains) == 0 {
+		return nil, fmt.Errorf("%w: client certificate is not verified", ErrInvalidCredentials)
+	}
+
+	return a.principal(r.TLS.PeerCertificates[0])
+}
+
+func (a *ClientCertAuthenticator) principal(cert *x509.Certificate) (*Principal, error) {
+	subject := cert.Subject.CommonName
+	if subject == "" {
+		return nil, fmt.Errorf("%w: client certificate has no common name", ErrInvalidCredentials)
+	}
+
+	roles := append([]string(nil), a.roles[subject]...)
+	if a.ouRoles {
+		roles = append(roles, cert.Subject.OrganizationalUnit...)
+	}
+
+	return &Principal{Subject: subject, Roles: roles}, nil
+}
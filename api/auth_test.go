@@ -0,0 +1,184 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signJWT(t *testing.T, header, claims map[string]any, secret []byte) string {
+	t.Helper()
+
+	encode := func(v map[string]any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signingInput := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func bearerRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/instances", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return req
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	authenticator := NewTokenAuthenticator(map[string]Principal{
+		"s3cret": {Subject: "ci-bot", Roles: []string{"operator"}},
+	})
+
+	principal, err := authenticator.Authenticate(bearerRequest("s3cret"))
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "ci-bot", Roles: []string{"operator"}}, principal)
+
+	_, err = authenticator.Authenticate(bearerRequest("wrong"))
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = authenticator.Authenticate(bearerRequest(""))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("local-key")
+	rotated := []byte("rotated-key")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	authenticator := NewJWTAuthenticator(secret,
+		WithJWTKey("k2", rotated),
+		WithJWTIssuer("floxy-auth"),
+		WithJWTAudience("floxy"),
+		WithJWTLeeway(time.Minute),
+		WithJWTNamespaceClaim("floxy_ns"),
+	)
+	authenticator.now = func() time.Time { return now }
+
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT"}
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"sub":   "alice",
+			"iss":   "floxy-auth",
+			"aud":   []string{"floxy", "other"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{"approver", "viewer"},
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+
+		return c
+	}
+
+	principal, err := authenticator.Authenticate(bearerRequest(signJWT(t, hs256, claims(nil), secret)))
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "alice", Roles: []string{"approver", "viewer"}}, principal)
+
+	withKid := map[string]any{"alg": "HS256", "kid": "k2"}
+	principal, err = authenticator.Authenticate(bearerRequest(
+		signJWT(t, withKid, claims(map[string]any{"roles": "viewer operator"}), rotated)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"viewer", "operator"}, principal.Roles)
+
+	principal, err = authenticator.Authenticate(bearerRequest(
+		signJWT(t, hs256, claims(map[string]any{"floxy_ns": "team-a"}), secret)))
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", principal.Namespace)
+
+	rejected := map[string]string{
+		"wrong key":       signJWT(t, hs256, claims(nil), rotated),
+		"unknown kid":     signJWT(t, map[string]any{"alg": "HS256", "kid": "k3"}, claims(nil), secret),
+		"alg none":        signJWT(t, map[string]any{"alg": "none"}, claims(nil), secret),
+		"expired":         signJWT(t, hs256, claims(map[string]any{"exp": now.Add(-2 * time.Minute).Unix()}), secret),
+		"not valid yet":   signJWT(t, hs256, claims(map[string]any{"nbf": now.Add(2 * time.Minute).Unix()}), secret),
+		"wrong issuer":    signJWT(t, hs256, claims(map[string]any{"iss": "someone"}), secret),
+		"wrong audience":  signJWT(t, hs256, claims(map[string]any{"aud": "other"}), secret),
+		"missing subject": signJWT(t, hs256, claims(map[string]any{"sub": ""}), secret),
+		"missing exp":     signJWT(t, hs256, claims(map[string]any{"exp": nil}), secret),
+	}
+	for name, token := range rejected {
+		_, err := authenticator.Authenticate(bearerRequest(token))
+		assert.ErrorIs(t, err, ErrInvalidCredentials, name)
+	}
+
+	// Within the leeway
+	_, err = authenticator.Authenticate(bearerRequest(
+		signJWT(t, hs256, claims(map[string]any{"exp": now.Add(-30 * time.Second).Unix()}), secret)))
+	require.NoError(t, err)
+
+	_, err = authenticator.Authenticate(bearerRequest("static-token"))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	nonExpiring := NewJWTAuthenticator(secret, WithJWTNonExpiringTokens())
+	principal, err = nonExpiring.Authenticate(bearerRequest(
+		signJWT(t, hs256, map[string]any{"sub": "ci-bot"}, secret)))
+	require.NoError(t, err)
+	assert.Equal(t, "ci-bot", principal.Subject)
+}
+
+func TestClientCertAuthenticator(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing-svc", OrganizationalUnit: []string{"operator"}}}
+	authenticator := NewClientCertAuthenticator(
+		WithClientCertRoles(map[string][]string{"billing-svc": {"viewer"}}),
+		WithClientCertOURoles(),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/instances", nil)
+	_, err := authenticator.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	_, err = authenticator.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+	principal, err := authenticator.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "billing-svc", Roles: []string{"viewer", "operator"}}, principal)
+}
+
+func TestChainAuthenticators(t *testing.T) {
+	secret := []byte("local-key")
+	authenticator := ChainAuthenticators(
+		NewClientCertAuthenticator(),
+		NewTokenAuthenticator(map[string]Principal{"s3cret": {Subject: "ci-bot"}}),
+		NewJWTAuthenticator(secret),
+	)
+
+	principal, err := authenticator.Authenticate(bearerRequest("s3cret"))
+	require.NoError(t, err)
+	assert.Equal(t, "ci-bot", principal.Subject)
+
+	token := signJWT(t, map[string]any{"alg": "HS256"}, map[string]any{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}, secret)
+	principal, err = authenticator.Authenticate(bearerRequest(token))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", principal.Subject)
+
+	_, err = authenticator.Authenticate(bearerRequest("wrong"))
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = authenticator.Authenticate(bearerRequest(""))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
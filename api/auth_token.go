@@ -0,0 +1,36 @@
+package api
+
+import (
+	"crypto/sha256"
+	"net/http"
+)
+
+// TokenAuthenticator accepts static API tokens sent as "Authorization: Bearer <token>".
+type TokenAuthenticator struct {
+	// Tokens are kept by digest, so that the lookup does not compare secrets byte by byte
+	principals map[[sha256.Size]byte]Principal
+}
+
+// NewTokenAuthenticator maps each token to the principal it authenticates.
+func NewTokenAuthenticator(tokens map[string]Principal) *TokenAuthenticator {
+	principals := make(map[[sha256.Size]byte]Principal, len(tokens))
+	for token, principal := range tokens {
+		principals[sha256.Sum256([]byte(token))] = principal
+	}
+
+	return &TokenAuthenticator{principals: principals}
+}
+
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	principal, ok := a.principals[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &principal, nil
+}
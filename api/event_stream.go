@@ -0,0 +1,213 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+const (
+	defaultEventStreamPollInterval = 500 * time.Millisecond
+	eventStreamHeartbeatInterval   = 15 * time.Second
+	eventStreamBatchSize           = 500
+	eventStreamRetryMillis         = 3000
+)
+
+// HandleInstanceEventsStream streams events of one instance as Server-Sent Events.
+// Without Last-Event-ID the whole event history is replayed first. The SSE id is the settled
+// cursor position, which trails the events sent by the settle window, so a reconnecting client
+// receives up to that window of events again and must de-duplicate them by event ID.
+func HandleInstanceEventsStream(store floxy.Store, pollInterval time.Duration) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idStr := r.PathValue("id")
+
+		instanceID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			WriteErrorResponse(w, errors.New("invalid instance ID"), http.StatusBadRequest)
+
+			return
+		}
+
+		lastEventID, err := parseLastEventID(r)
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		_, err = store.GetInstance(ctx, instanceID)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				WriteErrorResponse(w, errors.New("workflow instance not found"), http.StatusNotFound)
+
+				return
+			}
+
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch workflow instance: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		filter := floxy.EventFilter{InstanceID: instanceID, EventTypes: parseEventTypes(r)}
+		streamEvents(w, r, store, filter, lastEventID, pollInterval)
+	}
+}
+
+// HandleEventsStream streams events of all instances as Server-Sent Events, optionally
+// filtered by workflow_id and event_type (comma-separated or repeated).
+// Without Last-Event-ID only events logged after the connection are sent. Resumed streams
+// repeat events like HandleInstanceEventsStream does, so clients de-duplicate them by event ID.
+func HandleEventsStream(store floxy.Store, pollInterval time.Duration) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		lastEventID, err := parseLastEventID(r)
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		if lastEventID < 0 {
+			lastEventID, err = store.GetLastEventID(ctx)
+			if err != nil {
+				WriteErrorResponse(w, fmt.Errorf("failed to fetch last event ID: %w", err), http.StatusInternalServerError)
+
+				return
+			}
+		}
+
+		filter := floxy.EventFilter{
+			WorkflowID: r.URL.Query().Get("workflow_id"),
+			EventTypes: parseEventTypes(r),
+		}
+		streamEvents(w, r, store, filter, lastEventID, pollInterval)
+	}
+}
+
+// streamEvents tails the event log with a floxy.EventCursor until the client disconnects.
+// The SSE id is the cursor position rather than the event ID: a client resuming via
+// Last-Event-ID can receive events again and skips them by the event ID in the data.
+func streamEvents(
+	w http.ResponseWriter,
+	r *http.Request,
+	store floxy.Store,
+	filter floxy.EventFilter,
+	afterID int64,
+	pollInterval time.Duration,
+) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteErrorResponse(w, errors.New("streaming is not supported"), http.StatusInternalServerError)
+
+		return
+	}
+
+	cursor := floxy.NewEventCursor(store, filter, afterID, floxy.WithEventCursorBatchSize(eventStreamBatchSize))
+	sentPosition := cursor.Position()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(w, "retry: %d\n\n", eventStreamRetryMillis)
+	flusher.Flush()
+
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		events, err := cursor.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("[floxy] event stream poll failed", "error", err)
+		}
+
+		position := cursor.Position()
+		written := false
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				slog.Warn("[floxy] event stream marshal failed", "event_id", event.ID, "error", err)
+
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", position, data); err != nil {
+				return
+			}
+			written = true
+		}
+
+		// A message with only an id moves the resume position of the client
+		if !written && position != sentPosition {
+			if _, err := fmt.Fprintf(w, "id: %d\n\n", position); err != nil {
+				return
+			}
+			written = true
+		}
+		if written {
+			sentPosition = position
+			flusher.Flush()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-poll.C:
+		}
+	}
+}
+
+// parseLastEventID reads the resume position from the Last-Event-ID header or, for clients
+// that cannot set headers, the last_event_id query parameter. It returns -1 when neither is set.
+func parseLastEventID(r *http.Request) (int64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return -1, nil
+	}
+
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event ID: %q", v)
+	}
+
+	return id, nil
+}
+
+func parseEventTypes(r *http.Request) []string {
+	var eventTypes []string
+	for _, v := range r.URL.Query()["event_type"] {
+		for _, eventType := range strings.Split(v, ",") {
+			eventType = strings.TrimSpace(eventType)
+			if eventType != "" {
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+
+	return eventTypes
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+type sseMessage struct {
+	id    int64
+	event floxy.WorkflowEvent
+}
+
+// readSSE parses "id:"/"data:" messages from the stream and sends the ones with data to the channel.
+func readSSE(t *testing.T, resp *http.Response) <-chan sseMessage {
+	t.Helper()
+
+	messages := make(chan sseMessage, 100)
+	go func() {
+		defer close(messages)
+
+		var msg sseMessage
+		var hasData bool
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				msg.id, _ = strconv.ParseInt(strings.TrimPrefix(line, "id: "), 10, 64)
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg.event)
+				hasData = true
+			case line == "" && hasData:
+				messages <- msg
+				msg = sseMessage{}
+				hasData = false
+			}
+		}
+	}()
+
+	return messages
+}
+
+func nextSSE(t *testing.T, messages <-chan sseMessage) sseMessage {
+	t.Helper()
+
+	select {
+	case msg, ok := <-messages:
+		require.True(t, ok, "stream closed")
+
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+
+		return sseMessage{}
+	}
+}
+
+func openStream(t *testing.T, ctx context.Context, url string, lastEventID string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	return resp
+}
+
+func TestEventsStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := floxy.NewMemoryStore()
+	require.NoError(t, store.SaveWorkflowDefinition(ctx, &floxy.WorkflowDefinition{ID: "wf-v1", Name: "wf", Version: 1}))
+	instance, err := store.CreateInstance(ctx, "wf-v1", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	require.NoError(t, store.LogEvent(ctx, instance.ID, nil, floxy.EventWorkflowStarted, nil))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/instances/{id}/events/stream", HandleInstanceEventsStream(store, 10*time.Millisecond))
+	mux.HandleFunc("GET /api/events/stream", HandleEventsStream(store, 10*time.Millisecond))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	// Streams end only when the client disconnects, so cancel before closing the server
+	defer cancel()
+
+	instanceURL := srv.URL + "/api/instances/" + strconv.FormatInt(instance.ID, 10) + "/events/stream"
+
+	// The instance stream replays history, the global one starts at the tail
+	instanceStream := readSSE(t, openStream(t, ctx, instanceURL, ""))
+	globalStream := readSSE(t, openStream(t, ctx,
+		srv.URL+"/api/events/stream?workflow_id=wf-v1&event_type=step_failed,workflow_completed", ""))
+
+	// The id is the resume position, which stays behind events within the settle window
+	first := nextSSE(t, instanceStream)
+	assert.Equal(t, floxy.EventWorkflowStarted, first.event.EventType)
+	assert.Less(t, first.id, first.event.ID)
+
+	require.NoError(t, store.LogEvent(ctx, instance.ID, nil, floxy.EventStepStarted, nil))
+	require.NoError(t, store.LogEvent(ctx, instance.ID, nil, floxy.EventWorkflowCompleted, nil))
+
+	assert.Equal(t, floxy.EventStepStarted, nextSSE(t, instanceStream).event.EventType)
+	last := nextSSE(t, instanceStream)
+	assert.Equal(t, floxy.EventWorkflowCompleted, last.event.EventType)
+	assert.Equal(t, last.event.ID, nextSSE(t, globalStream).event.ID)
+
+	// Resume after the first event
+	resumed := readSSE(t, openStream(t, ctx, instanceURL, strconv.FormatInt(first.event.ID, 10)))
+	assert.Equal(t, floxy.EventStepStarted, nextSSE(t, resumed).event.EventType)
+}
+
+// lateCommitStore hides the events of a transaction that took a lower event ID and has not
+// committed yet.
+type lateCommitStore struct {
+	floxy.Store
+	mu          sync.Mutex
+	uncommitted map[int64]bool
+}
+
+func (s *lateCommitStore) GetEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter floxy.EventFilter,
+	limit int,
+) ([]floxy.WorkflowEvent, error) {
+	events, err := s.Store.GetEventsAfter(ctx, afterID, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.DeleteFunc(events, func(event floxy.WorkflowEvent) bool {
+		return s.uncommitted[event.ID]
+	}), nil
+}
+
+func (s *lateCommitStore) commit(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.uncommitted, id)
+}
+
+func TestEventsStream_LateCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	memory := floxy.NewMemoryStore()
+	require.NoError(t, memory.SaveWorkflowDefinition(ctx, &floxy.WorkflowDefinition{ID: "wf-v1", Name: "wf", Version: 1}))
+	instance, err := memory.CreateInstance(ctx, "wf-v1", json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	startID, err := memory.GetLastEventID(ctx)
+	require.NoError(t, err)
+	require.NoError(t, memory.LogEvent(ctx, instance.ID, nil, floxy.EventStepStarted, nil))
+	require.NoError(t, memory.LogEvent(ctx, instance.ID, nil, floxy.EventStepCompleted, nil))
+
+	// The transaction of the first event commits after the one of the second event
+	store := &lateCommitStore{Store: memory, uncommitted: map[int64]bool{startID + 1: true}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/events/stream", HandleEventsStream(store, 10*time.Millisecond))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	defer cancel()
+
+	stream := readSSE(t, openStream(t, ctx, srv.URL+"/api/events/stream", strconv.FormatInt(startID, 10)))
+	assert.Equal(t, floxy.EventStepCompleted, nextSSE(t, stream).event.EventType)
+
+	store.commit(startID + 1)
+	late := nextSSE(t, stream)
+	assert.Equal(t, floxy.EventStepStarted, late.event.EventType)
+	assert.Equal(t, startID, late.id)
+
+	select {
+	case msg := <-stream:
+		t.Fatalf("event %d sent twice", msg.event.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEventsStream_Errors(t *testing.T) {
+	store := floxy.NewMemoryStore()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/instances/404/events/stream", nil)
+	req.SetPathValue("id", "404")
+	w := httptest.NewRecorder()
+	HandleInstanceEventsStream(store, time.Second)(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/events/stream", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	w = httptest.NewRecorder()
+	HandleEventsStream(store, time.Second)(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+const (
+	graphFormatMermaid = "mermaid"
+	graphFormatDOT     = "dot"
+)
+
+func graphFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+		return graphFormatMermaid, nil
+	case graphFormatMermaid, graphFormatDOT:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid graph format %q, expected mermaid or dot", format)
+	}
+}
+
+func writeGraph(w http.ResponseWriter, format, graph string) {
+	if format == graphFormatDOT {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	_, _ = w.Write([]byte(graph))
+}
+
+// HandleGetWorkflowGraph renders a workflow definition as Mermaid (default) or Graphviz DOT,
+// selected by the format query parameter.
+func HandleGetWorkflowGraph(store floxy.Store) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id := r.PathValue("id")
+
+		format, err := graphFormat(r)
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		definition, err := store.GetWorkflowDefinition(ctx, id)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				WriteErrorResponse(w, errors.New("workflow definition not found"), http.StatusNotFound)
+
+				return
+			}
+
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch workflow definition: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		visualizer := floxy.NewVisualizer()
+		if format == graphFormatDOT {
+			writeGraph(w, format, visualizer.RenderDOT(definition))
+		} else {
+			writeGraph(w, format, visualizer.RenderMermaid(definition))
+		}
+	}
+}
+
+// HandleGetInstanceGraph renders the workflow of an instance with its steps colored by status.
+func HandleGetInstanceGraph(store floxy.Store) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idStr := r.PathValue("id")
+
+		instanceID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			WriteErrorResponse(w, errors.New("invalid instance ID"), http.StatusBadRequest)
+
+			return
+		}
+
+		format, err := graphFormat(r)
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		instance, err := store.GetInstance(ctx, instanceID)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				WriteErrorResponse(w, errors.New("workflow instance not found"), http.StatusNotFound)
+
+				return
+			}
+
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch workflow instance: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		definition, err := store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+		if err != nil {
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch workflow definition: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		steps, err := store.GetWorkflowSteps(ctx, instanceID)
+		if err != nil {
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch workflow steps: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		visualizer := floxy.NewVisualizer()
+		if format == graphFormatDOT {
+			writeGraph(w, format, visualizer.RenderInstanceDOT(definition, instance, steps))
+		} else {
+			writeGraph(w, format, visualizer.RenderInstanceMermaid(definition, instance, steps))
+		}
+	}
+}
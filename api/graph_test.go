@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+func TestWorkflowGraphRoutes(t *testing.T) {
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+
+	def, err := floxy.NewBuilder("graph", 1).
+		Step("first", "first").OnFailure("undo", "undo").
+		Then("second", "second").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveWorkflowDefinition(ctx, def))
+
+	instance, err := store.CreateInstance(ctx, def.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+	require.NoError(t, store.CreateStep(ctx, &floxy.WorkflowStep{
+		InstanceID: instance.ID,
+		StepName:   "first",
+		StepType:   floxy.StepTypeTask,
+		Status:     floxy.StepStatusCompleted,
+	}))
+
+	handler := New(nil, store).Mux()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := get("/api/workflows/" + def.ID + "/graph")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "flowchart TD")
+	assert.Contains(t, rec.Body.String(), "-.->|on failure|")
+
+	rec = get("/api/workflows/" + def.ID + "/graph?format=dot")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `digraph "graph-v1" {`)
+
+	instancePath := "/api/instances/" + strconv.FormatInt(instance.ID, 10) + "/graph"
+	rec = get(instancePath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "class s0 completed")
+
+	rec = get(instancePath + "?format=dot")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"first" [label="first\nfirst", shape=box, style=filled, fillcolor="#c8e6c9"];`)
+
+	assert.Equal(t, http.StatusBadRequest, get(instancePath+"?format=svg").Code)
+	assert.Equal(t, http.StatusNotFound, get("/api/workflows/missing-v1/graph").Code)
+	assert.Equal(t, http.StatusNotFound, get("/api/instances/999/graph").Code)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+var instanceSearchParams = []string{
+	"status",
+	"workflow_id",
+	"workflow_name",
+	"created_from",
+	"created_to",
+	"updated_from",
+	"updated_to",
+	"label",
+	"error",
+	"sort",
+	"order",
+	"cursor",
+	"limit",
+}
+
+// HasInstanceSearchParams reports whether any instance search parameter is present.
+func HasInstanceSearchParams(values url.Values) bool {
+	for _, param := range instanceSearchParams {
+		if values.Has(param) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseInstanceQuery builds an InstanceQuery from URL query parameters:
+//
+//	status=failed,running         (comma-separated or repeated)
+//	workflow_id=order-v1
+//	workflow_name=order
+//	created_from=<RFC3339>        created_to=<RFC3339>
+//	updated_from=<RFC3339>        updated_to=<RFC3339>
+//	label=customer_id:42          (repeated, all must match)
+//	error=timeout                 (case-insensitive substring)
+//	sort=created_at|updated_at|id order=asc|desc
+//	cursor=<next_cursor>          limit=1..1000
+func ParseInstanceQuery(values url.Values) (floxy.InstanceQuery, error) {
+	var query floxy.InstanceQuery
+
+	for _, v := range values["status"] {
+		for _, status := range strings.Split(v, ",") {
+			status = strings.TrimSpace(status)
+			if status != "" {
+				query.Statuses = append(query.Statuses, floxy.WorkflowStatus(status))
+			}
+		}
+	}
+
+	query.WorkflowID = values.Get("workflow_id")
+	query.WorkflowName = values.Get("workflow_name")
+	query.ErrorContains = values.Get("error")
+	query.Cursor = values.Get("cursor")
+
+	timeParams := []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &query.CreatedFrom},
+		{"created_to", &query.CreatedTo},
+		{"updated_from", &query.UpdatedFrom},
+		{"updated_to", &query.UpdatedTo},
+	}
+	for _, param := range timeParams {
+		v := values.Get(param.name)
+		if v == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return floxy.InstanceQuery{}, fmt.Errorf("invalid %s: %w", param.name, err)
+		}
+		*param.dst = &t
+	}
+
+	for _, v := range values["label"] {
+		key, value, ok := strings.Cut(v, ":")
+		if !ok || key == "" {
+			return floxy.InstanceQuery{}, fmt.Errorf("invalid label %q: expected key:value", v)
+		}
+
+		if query.Labels == nil {
+			query.Labels = make(map[string]string)
+		}
+		query.Labels[key] = value
+	}
+
+	switch sortBy := floxy.InstanceSortField(values.Get("sort")); sortBy {
+	case "", floxy.InstanceSortByCreatedAt, floxy.InstanceSortByUpdatedAt, floxy.InstanceSortByID:
+		query.SortBy = sortBy
+	default:
+		return floxy.InstanceQuery{}, fmt.Errorf("invalid sort: %s", sortBy)
+	}
+
+	switch order := floxy.SortOrder(values.Get("order")); order {
+	case "", floxy.SortOrderAsc, floxy.SortOrderDesc:
+		query.SortOrder = order
+	default:
+		return floxy.InstanceQuery{}, fmt.Errorf("invalid order: %s", order)
+	}
+
+	if v := values.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 1000 {
+			return floxy.InstanceQuery{}, fmt.Errorf("invalid limit: %s", v)
+		}
+		query.Limit = n
+	}
+
+	return query, nil
+}
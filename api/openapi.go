@@ -0,0 +1,10 @@
+package api
+
+import (
+	_ "embed"
+)
+
+// OpenAPISpec is the OpenAPI 3 document of the core routes and the bundled plugins.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
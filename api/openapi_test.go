@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rom8726/floxy-pro/api"
+	"github.com/rom8726/floxy-pro/plugins/api/abort"
+	"github.com/rom8726/floxy-pro/plugins/api/bulk"
+	"github.com/rom8726/floxy-pro/plugins/api/cancel"
+	"github.com/rom8726/floxy-pro/plugins/api/cleanup"
+	"github.com/rom8726/floxy-pro/plugins/api/dashboard"
+	"github.com/rom8726/floxy-pro/plugins/api/dlq"
+	humandecision "github.com/rom8726/floxy-pro/plugins/api/human-decision"
+	"github.com/rom8726/floxy-pro/plugins/api/skip"
+)
+
+// routeRecorder registers routes on a ServeMux and records their patterns.
+type routeRecorder struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func (r *routeRecorder) Handle(pattern string, handler http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+	r.ServeMux.Handle(pattern, handler)
+}
+
+func (r *routeRecorder) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+	r.ServeMux.HandleFunc(pattern, handler)
+}
+
+// TestOpenAPISpec_MatchesRoutes keeps openapi.yaml in sync with the routes: every pattern
+// registered by RegisterCoreRoutes and the bundled plugins has an operation, and every
+// operation is served by the mux under the same pattern.
+func TestOpenAPISpec_MatchesRoutes(t *testing.T) {
+	var spec struct {
+		OpenAPI string `yaml:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string `yaml:"operationId"`
+		}
+	}
+	require.NoError(t, yaml.Unmarshal(api.OpenAPISpec, &spec))
+	require.True(t, strings.HasPrefix(spec.OpenAPI, "3."))
+
+	operations := make(map[string]string)
+	operationIDs := make(map[string]bool)
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			if method == "parameters" {
+				continue
+			}
+			require.NotEmpty(t, op.OperationID, "%s %s has no operationId", method, path)
+			require.False(t, operationIDs[op.OperationID], "duplicate operationId %s", op.OperationID)
+			operationIDs[op.OperationID] = true
+			operations[strings.ToUpper(method)+" "+path] = op.OperationID
+		}
+	}
+
+	mux := &routeRecorder{ServeMux: http.NewServeMux()}
+	api.RegisterCoreRoutes(mux, nil)
+	for _, plugin := range []api.Plugin{
+		abort.New(nil, nil),
+		bulk.New(nil, nil),
+		cancel.New(nil, nil),
+		cleanup.New(nil),
+		dashboard.New(nil),
+		dlq.New(nil, nil, nil),
+		humandecision.New(nil, nil, nil),
+		skip.New(nil, nil),
+	} {
+		plugin.RegisterRoutes(mux)
+	}
+
+	registered := make([]string, 0, len(mux.patterns))
+	for _, pattern := range mux.patterns {
+		registered = append(registered, strings.ReplaceAll(pattern, "...}", "}"))
+	}
+
+	documented := make([]string, 0, len(operations))
+	for pattern := range operations {
+		documented = append(documented, pattern)
+	}
+	assert.ElementsMatch(t, registered, documented)
+
+	pathParamRe := regexp.MustCompile(`\{[^}]+\}`)
+	for pattern := range operations {
+		method, path, _ := strings.Cut(pattern, " ")
+		req := httptest.NewRequest(method, pathParamRe.ReplaceAllString(path, "1"), nil)
+
+		_, served := mux.Handler(req)
+		assert.Equal(t, pattern, strings.ReplaceAll(served, "...}", "}"), "operation %s", operations[pattern])
+	}
+}
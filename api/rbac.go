@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+type Permission string
+
+const (
+	// PermissionRead allows the GET routes.
+	PermissionRead Permission = "read"
+	// PermissionOperate allows cancel, abort, skip, DLQ and bulk operations.
+	PermissionOperate Permission = "operate"
+	// PermissionApprove allows human decisions.
+	PermissionApprove Permission = "approve"
+	// PermissionAdmin allows every route, cleanup included.
+	PermissionAdmin Permission = "admin"
+)
+
+// Role grants permissions on every workflow, or only on WorkflowIDs when it is set.
+// A scoped role only applies to routes of one workflow, instance, step or DLQ record;
+// lists, stats, bulk jobs and the dashboard need an unscoped role.
+type Role struct {
+	Permissions []Permission
+	WorkflowIDs []string
+}
+
+// Policy maps the roles of a Principal to route permissions.
+type Policy struct {
+	Roles map[string]Role
+	// RoutePermissions overrides DefaultRoutePermission for mux patterns such as "POST /api/bulk".
+	RoutePermissions map[string]Permission
+}
+
+// DefaultRoutePermission requires read for GET routes, approve for human decisions, admin for
+// cleanup and operate for the other routes.
+func DefaultRoutePermission(pattern string) Permission {
+	method, path, _ := strings.Cut(pattern, " ")
+
+	switch {
+	case method == http.MethodGet || method == http.MethodHead:
+		return PermissionRead
+	case strings.Contains(path, "/make-decision/"):
+		return PermissionApprove
+	case path == "/api/cleanup":
+		return PermissionAdmin
+	default:
+		return PermissionOperate
+	}
+}
+
+// Permission returns the permission required by a mux pattern.
+func (p *Policy) Permission(pattern string) Permission {
+	if permission, ok := p.RoutePermissions[pattern]; ok {
+		return permission
+	}
+
+	return DefaultRoutePermission(pattern)
+}
+
+// Allows reports whether the principal has the permission on every workflow or, when
+// workflowID is set, on that workflow.
+func (p *Policy) Allows(principal *Principal, permission Permission, workflowID string) bool {
+	for _, name := range principal.Roles {
+		role, ok := p.Roles[name]
+		if !ok {
+			continue
+		}
+
+		if !slices.Contains(role.Permissions, permission) && !slices.Contains(role.Permissions, PermissionAdmin) {
+			continue
+		}
+
+		if len(role.WorkflowIDs) == 0 || (workflowID != "" && slices.Contains(role.WorkflowIDs, workflowID)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorize checks the permission of the route, resolving the workflow the request addresses
+// only when no unscoped role allows it.
+func (p *Policy) authorize(r *http.Request, store floxy.Store, principal *Principal, pattern string) (bool, error) {
+	permission := p.Permission(pattern)
+	if p.Allows(principal, permission, "") {
+		return true, nil
+	}
+
+	workflowID, err := requestWorkflowID(r.Context(), store, pattern, r.URL.Path)
+	if err != nil || workflowID == "" {
+		return false, err
+	}
+
+	return p.Allows(principal, permission, workflowID), nil
+}
+
+// requestWorkflowID returns the workflow addressed by a request, or "" for routes that do not
+// address one. Missing entities address no workflow, so scoped roles get 403 rather than 404.
+func requestWorkflowID(ctx context.Context, store floxy.Store, pattern, path string) (string, error) {
+	_, patternPath, _ := strings.Cut(pattern, " ")
+	values := pathValues(patternPath, path)
+
+	var workflowID string
+	var err error
+	switch {
+	case values["workflow_id"] != "":
+		return values["workflow_id"], nil
+	case strings.HasPrefix(patternPath, "/api/workflows/{id}"):
+		return values["id"], nil
+	case strings.HasPrefix(patternPath, "/api/instances/{"):
+		workflowID, err = lookupWorkflowID(values["id"]+values["instance_id"], func(id int64) (string, error) {
+			instance, err := store.GetInstance(ctx, id)
+			if err != nil {
+				return "", err
+			}
+
+			return instance.WorkflowID, nil
+		})
+	case strings.HasPrefix(patternPath, "/api/dlq/{id}"):
+		workflowID, err = lookupWorkflowID(values["id"], func(id int64) (string, error) {
+			record, err := store.GetDeadLetterByID(ctx, id)
+			if err != nil {
+				return "", err
+			}
+
+			return record.WorkflowID, nil
+		})
+	case values["step_id"] != "":
+		workflowID, err = lookupWorkflowID(values["step_id"], func(id int64) (string, error) {
+			step, err := store.GetStepByID(ctx, id)
+			if err != nil {
+				return "", err
+			}
+
+			instance, err := store.GetInstance(ctx, step.InstanceID)
+			if err != nil {
+				return "", err
+			}
+
+			return instance.WorkflowID, nil
+		})
+	}
+
+	if errors.Is(err, floxy.ErrEntityNotFound) {
+		return "", nil
+	}
+
+	return workflowID, err
+}
+
+func lookupWorkflowID(value string, lookup func(id int64) (string, error)) (string, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "", nil
+	}
+
+	return lookup(id)
+}
+
+// pathValues matches the path of a mux pattern such as "/api/instances/{id}" against the
+// request path. The mux does not set path values before a handler runs.
+func pathValues(patternPath, path string) map[string]string {
+	values := make(map[string]string)
+	patternSegments := strings.Split(strings.Trim(patternPath, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range patternSegments {
+		if i >= len(pathSegments) {
+			break
+		}
+
+		name, ok := strings.CutPrefix(segment, "{")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSuffix(name, "}")
+
+		if name, ok := strings.CutSuffix(name, "..."); ok {
+			values[name] = strings.Join(pathSegments[i:], "/")
+
+			break
+		}
+		values[name] = pathSegments[i]
+	}
+
+	return values
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+func TestDefaultRoutePermission(t *testing.T) {
+	tests := map[string]Permission{
+		"GET /api/instances/{id}":                                PermissionRead,
+		"GET /dashboard/":                                        PermissionRead,
+		"POST /api/instances/{instance_id}/cancel":               PermissionOperate,
+		"POST /api/dlq/{id}/requeue":                             PermissionOperate,
+		"POST /api/bulk":                                         PermissionOperate,
+		"POST /api/instances/{instance_id}/make-decision/reject": PermissionApprove,
+		"POST /api/cleanup":                                      PermissionAdmin,
+	}
+	for pattern, want := range tests {
+		assert.Equal(t, want, DefaultRoutePermission(pattern), pattern)
+	}
+
+	policy := &Policy{RoutePermissions: map[string]Permission{"POST /api/bulk": PermissionAdmin}}
+	assert.Equal(t, PermissionAdmin, policy.Permission("POST /api/bulk"))
+	assert.Equal(t, PermissionOperate, policy.Permission("POST /api/dlq/{id}/requeue"))
+}
+
+func TestPolicyAllows(t *testing.T) {
+	policy := &Policy{Roles: map[string]Role{
+		"viewer":          {Permissions: []Permission{PermissionRead}},
+		"orders-approver": {Permissions: []Permission{PermissionApprove}, WorkflowIDs: []string{"orders-v1"}},
+		"admin":           {Permissions: []Permission{PermissionAdmin}},
+	}}
+
+	viewer := &Principal{Subject: "rita", Roles: []string{"viewer", "orders-approver"}}
+	assert.True(t, policy.Allows(viewer, PermissionRead, ""))
+	assert.True(t, policy.Allows(viewer, PermissionApprove, "orders-v1"))
+	assert.False(t, policy.Allows(viewer, PermissionApprove, "billing-v1"))
+	assert.False(t, policy.Allows(viewer, PermissionApprove, ""))
+	assert.False(t, policy.Allows(viewer, PermissionOperate, "orders-v1"))
+
+	assert.True(t, policy.Allows(&Principal{Roles: []string{"admin"}}, PermissionOperate, ""))
+	assert.False(t, policy.Allows(&Principal{Roles: []string{"unknown"}}, PermissionRead, ""))
+}
+
+func TestPathValues(t *testing.T) {
+	assert.Equal(t, map[string]string{"instance_id": "12"},
+		pathValues("/api/instances/{instance_id}/cancel", "/api/instances/12/cancel"))
+	assert.Equal(t, map[string]string{"id": "pool/worker-1"},
+		pathValues("/api/workers/{id...}", "/api/workers/pool/worker-1"))
+	assert.Empty(t, pathValues("/api/instances", "/api/instances"))
+}
+
+// pokePlugin is an operate route that answers with the user the plugins would record.
+type pokePlugin struct{}
+
+func (pokePlugin) Name() string        { return "poke" }
+func (pokePlugin) Description() string { return "Poke an instance" }
+
+func (pokePlugin) RegisterRoutes(mux Router) {
+	mux.HandleFunc("POST /api/instances/{instance_id}/poke", func(w http.ResponseWriter, r *http.Request) {
+		user, err := RequestUser(r, nil)
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, user)
+	})
+}
+
+func TestServerAuth(t *testing.T) {
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+
+	instanceIDs := make(map[string]int64)
+	for _, workflowID := range []string{"orders-v1", "billing-v1"} {
+		def := &floxy.WorkflowDefinition{
+			ID:         workflowID,
+			Name:       workflowID,
+			Version:    1,
+			Definition: floxy.GraphDefinition{Start: "a", Steps: map[string]*floxy.StepDefinition{}},
+		}
+		require.NoError(t, store.SaveWorkflowDefinition(ctx, def))
+
+		instance, err := store.CreateInstance(ctx, workflowID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		instanceIDs[workflowID] = instance.ID
+	}
+
+	authenticator := NewTokenAuthenticator(map[string]Principal{
+		"viewer-token": {Subject: "rita", Roles: []string{"viewer"}},
+		"orders-token": {Subject: "oscar", Roles: []string{"orders-operator"}},
+		"admin-token":  {Subject: "ada", Roles: []string{"admin"}},
+	})
+	policy := &Policy{Roles: map[string]Role{
+		"viewer": {Permissions: []Permission{PermissionRead}},
+		"orders-operator": {
+			Permissions: []Permission{PermissionRead, PermissionOperate},
+			WorkflowIDs: []string{"orders-v1"},
+		},
+		"admin": {Permissions: []Permission{PermissionAdmin}},
+	}}
+
+	handler := New(nil, store, WithAuth(authenticator, policy), WithPlugins(pokePlugin{})).Mux()
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+	poke := func(workflowID string) string {
+		return fmt.Sprintf("/api/instances/%d/poke", instanceIDs[workflowID])
+	}
+
+	rec := do(http.MethodGet, "/api/instances", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/instances", "wrong").Code)
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/instances", "viewer-token").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, poke("orders-v1"), "viewer-token").Code)
+
+	rec = do(http.MethodPost, poke("orders-v1"), "orders-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "oscar", rec.Body.String())
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, poke("billing-v1"), "orders-token").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/instances/999/poke", "orders-token").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/workflows/orders-v1", "orders-token").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/workflows/billing-v1", "orders-token").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/instances", "orders-token").Code)
+
+	rec = do(http.MethodPost, poke("billing-v1"), "admin-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ada", rec.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/unknown", "viewer-token").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/unknown", "").Code)
+}
@@ -17,7 +17,7 @@ type PaginatedInstancesResponse struct {
 	Total    int64                    `json:"total"`
 }
 
-func RegisterCoreRoutes(mux *http.ServeMux, store floxy.Store) {
+func RegisterCoreRoutes(mux Router, store floxy.Store) {
 	// Workflow definitions
 	mux.HandleFunc("GET /api/workflows", func(w http.ResponseWriter, req *http.Request) {
 		HandleGetWorkflowDefinitions(store)(w, req)
@@ -31,6 +31,10 @@ func RegisterCoreRoutes(mux *http.ServeMux, store floxy.Store) {
 		HandleGetWorkflowInstances(store)(w, req)
 	})
 
+	mux.HandleFunc("GET /api/workflows/{id}/graph", func(w http.ResponseWriter, req *http.Request) {
+		HandleGetWorkflowGraph(store)(w, req)
+	})
+
 	// Workflow instances
 	mux.HandleFunc("GET /api/instances", func(w http.ResponseWriter, req *http.Request) {
 		HandleGetAllInstances(store)(w, req)
@@ -48,6 +52,22 @@ func RegisterCoreRoutes(mux *http.ServeMux, store floxy.Store) {
 		HandleGetWorkflowEvents(store)(w, req)
 	})
 
+	mux.HandleFunc("GET /api/instances/{id}/graph", func(w http.ResponseWriter, req *http.Request) {
+		HandleGetInstanceGraph(store)(w, req)
+	})
+
+	mux.HandleFunc("GET /api/instances/{id}/timeline", func(w http.ResponseWriter, req *http.Request) {
+		HandleGetInstanceTimeline(store)(w, req)
+	})
+
+	mux.HandleFunc("GET /api/instances/{id}/events/stream", func(w http.ResponseWriter, req *http.Request) {
+		HandleInstanceEventsStream(store, defaultEventStreamPollInterval)(w, req)
+	})
+
+	mux.HandleFunc("GET /api/events/stream", func(w http.ResponseWriter, req *http.Request) {
+		HandleEventsStream(store, defaultEventStreamPollInterval)(w, req)
+	})
+
 	// Statistics
 	mux.HandleFunc("GET /api/stats", func(w http.ResponseWriter, req *http.Request) {
 		HandleGetStats(store)(w, req)
@@ -61,6 +81,15 @@ func RegisterCoreRoutes(mux *http.ServeMux, store floxy.Store) {
 	mux.HandleFunc("GET /api/instances/active", func(w http.ResponseWriter, req *http.Request) {
 		HandleGetActiveInstances(store)(w, req)
 	})
+
+	// Worker registry
+	mux.HandleFunc("GET /api/workers", func(w http.ResponseWriter, req *http.Request) {
+		HandleGetWorkers(store)(w, req)
+	})
+
+	mux.HandleFunc("GET /api/workers/{id...}", func(w http.ResponseWriter, req *http.Request) {
+		HandleGetWorker(store)(w, req)
+	})
 }
 
 func HandleGetWorkflowDefinitions(store floxy.Store) func(http.ResponseWriter, *http.Request) {
@@ -159,6 +188,13 @@ func HandleGetAllInstances(store floxy.Store) func(http.ResponseWriter, *http.Re
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
+		// Any search parameter switches the route to filtered, cursor-based search
+		if HasInstanceSearchParams(r.URL.Query()) {
+			HandleSearchInstances(store)(w, r)
+
+			return
+		}
+
 		// pagination: default page=1, page_size=20
 		page := 1
 		pageSize := 20
@@ -193,6 +229,35 @@ func HandleGetAllInstances(store floxy.Store) func(http.ResponseWriter, *http.Re
 	}
 }
 
+func HandleSearchInstances(store floxy.Store) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		query, err := ParseInstanceQuery(r.URL.Query())
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		result, err := store.SearchInstances(ctx, query)
+		if err != nil {
+			if errors.Is(err, floxy.ErrInvalidCursor) {
+				WriteErrorResponse(w, err, http.StatusBadRequest)
+
+				return
+			}
+
+			WriteErrorResponse(w, fmt.Errorf("failed to search instances: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	}
+}
+
 func HandleGetWorkflowInstance(store floxy.Store) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -346,3 +411,43 @@ func HandleGetActiveInstances(store floxy.Store) func(http.ResponseWriter, *http
 		_ = json.NewEncoder(w).Encode(instances)
 	}
 }
+
+func HandleGetWorkers(store floxy.Store) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		workers, err := store.ListWorkers(ctx)
+		if err != nil {
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch workers: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(workers)
+	}
+}
+
+// HandleGetWorker returns a worker registry entry. The ID may also be the attempted_by of a queue item.
+func HandleGetWorker(store floxy.Store) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id := floxy.WorkerRecordID(r.PathValue("id"))
+
+		worker, err := store.GetWorker(ctx, id)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				WriteErrorResponse(w, errors.New("worker not found"), http.StatusNotFound)
+
+				return
+			}
+
+			WriteErrorResponse(w, fmt.Errorf("failed to fetch worker: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(worker)
+	}
+}
@@ -1,21 +1,59 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	floxy "github.com/rom8726/floxy-pro"
 )
 
+// NamespaceHeader carries the caller namespace. Requests without a namespace see every namespace
+// unless the server requires one.
+const NamespaceHeader = "X-Floxy-Namespace"
+
+// NamespaceResolver extracts the caller namespace from a request. An empty result means the
+// request names no namespace; an error rejects the request with 403.
+type NamespaceResolver func(r *http.Request) (string, error)
+
 type Server struct {
-	engine  *floxy.Engine
-	store   floxy.Store
-	mux     *http.ServeMux
-	plugins []Plugin
+	engine            *floxy.Engine
+	store             floxy.Store
+	mux               *http.ServeMux
+	routes            *http.ServeMux
+	plugins           []Plugin
+	namespaceResolver NamespaceResolver
+	requireNamespace  bool
+	authenticator     Authenticator
+	policy            *Policy
 }
 
 type Option func(*Server)
 
+// WithNamespaceResolver replaces DefaultNamespaceResolver.
+func WithNamespaceResolver(resolver NamespaceResolver) Option {
+	return func(s *Server) {
+		s.namespaceResolver = resolver
+	}
+}
+
+// WithRequiredNamespace rejects requests without a namespace with 400 instead of letting them
+// see every namespace, for servers shared by several tenants.
+func WithRequiredNamespace() Option {
+	return func(s *Server) {
+		s.requireNamespace = true
+	}
+}
+
+// WithAuth requires every request to be authenticated and, with a non-nil policy, checks the
+// permission of the matched route. A nil policy lets any authenticated caller use every route.
+func WithAuth(authenticator Authenticator, policy *Policy) Option {
+	return func(s *Server) {
+		s.authenticator = authenticator
+		s.policy = policy
+	}
+}
+
 func WithPlugins(plugins ...Plugin) Option {
 	return func(s *Server) {
 		for _, p := range plugins {
@@ -26,27 +64,80 @@ func WithPlugins(plugins ...Plugin) Option {
 
 func New(engine *floxy.Engine, store floxy.Store, opts ...Option) *Server {
 	srv := &Server{
-		engine:  engine,
-		store:   store,
-		mux:     http.NewServeMux(),
-		plugins: make([]Plugin, 0),
+		engine:            engine,
+		store:             store,
+		mux:               http.NewServeMux(),
+		routes:            http.NewServeMux(),
+		plugins:           make([]Plugin, 0),
+		namespaceResolver: DefaultNamespaceResolver,
 	}
 
-	RegisterCoreRoutes(srv.mux, store)
+	RegisterCoreRoutes(srv.routes, store)
 
 	for _, opt := range opts {
 		opt(srv)
 	}
 
+	srv.mux.Handle("/", srv.authMiddleware(NamespaceMiddleware(srv.routes, srv.namespaceResolver, srv.requireNamespace)))
+
 	return srv
 }
 
 func (s *Server) RegisterPlugin(plugin Plugin) {
 	s.plugins = append(s.plugins, plugin)
-	plugin.RegisterRoutes(s.mux)
+	plugin.RegisterRoutes(s.routes)
 	fmt.Printf("[floxy] plugin registered: %s\n", plugin.Name())
 }
 
 func (s *Server) Mux() *http.ServeMux {
 	return s.mux
 }
+
+// DefaultNamespaceResolver returns the namespace of the authenticated principal when it has
+// one, and otherwise reads NamespaceHeader and falls back to the namespace query parameter.
+// A principal bound to a namespace may not name another one.
+func DefaultNamespaceResolver(r *http.Request) (string, error) {
+	namespace := r.Header.Get(NamespaceHeader)
+	if namespace == "" {
+		namespace = r.URL.Query().Get("namespace")
+	}
+
+	if principal, ok := PrincipalFromContext(r.Context()); ok && principal.Namespace != "" {
+		if namespace != "" && namespace != principal.Namespace {
+			return "", fmt.Errorf("namespace %q is not accessible to %s", namespace, principal.Subject)
+		}
+
+		return principal.Namespace, nil
+	}
+
+	return namespace, nil
+}
+
+// NamespaceMiddleware scopes the request context to the namespace returned by the resolver,
+// so that every store query and engine call made by the handler stays inside it. Requests
+// without a namespace see every namespace, or are rejected with 400 when requireNamespace is set.
+func NamespaceMiddleware(next http.Handler, resolver NamespaceResolver, requireNamespace bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace, err := resolver(r)
+		if err != nil {
+			WriteErrorResponse(w, err, http.StatusForbidden)
+
+			return
+		}
+
+		if namespace == "" {
+			if requireNamespace {
+				WriteErrorResponse(w, errors.New("namespace is required, set the "+NamespaceHeader+" header"),
+					http.StatusBadRequest)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(floxy.WithNamespace(r.Context(), namespace)))
+	})
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+func TestServerNamespaceScoping(t *testing.T) {
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+
+	instanceIDs := make(map[string]int64)
+	for _, namespace := range []string{"team-a", "team-b"} {
+		nsCtx := floxy.WithNamespace(ctx, namespace)
+		def := &floxy.WorkflowDefinition{
+			ID:         namespace + "-flow-v1",
+			Name:       namespace + "-flow",
+			Version:    1,
+			Definition: floxy.GraphDefinition{Start: "a", Steps: map[string]*floxy.StepDefinition{}},
+		}
+		require.NoError(t, store.SaveWorkflowDefinition(nsCtx, def))
+
+		instance, err := store.CreateInstance(nsCtx, def.ID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		instanceIDs[namespace] = instance.ID
+	}
+
+	handler := New(nil, store, WithRequiredNamespace()).Mux()
+	unscoped := New(nil, store).Mux()
+
+	serve := func(handler http.Handler, path, namespace string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if namespace != "" {
+			req.Header.Set(NamespaceHeader, namespace)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+	get := func(path, namespace string) *httptest.ResponseRecorder {
+		return serve(handler, path, namespace)
+	}
+
+	rec := get("/api/instances", "team-a")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page PaginatedInstancesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "team-a", page.Items[0].Namespace)
+
+	// Requests without a namespace see every namespace unless the server requires one
+	assert.Equal(t, http.StatusBadRequest, get("/api/instances", "").Code)
+	rec = serve(unscoped, "/api/instances", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.EqualValues(t, 2, page.Total)
+
+	otherInstance := "/api/instances/" + strconv.FormatInt(instanceIDs["team-b"], 10)
+	assert.Equal(t, http.StatusNotFound, get(otherInstance, "team-a").Code)
+	assert.Equal(t, http.StatusNotFound, get(otherInstance+"/steps", "team-a").Code)
+	assert.Equal(t, http.StatusOK, get(otherInstance, "team-b").Code)
+	assert.Equal(t, http.StatusOK, get(otherInstance+"?namespace=team-b", "").Code)
+
+	assert.Equal(t, http.StatusNotFound, get("/api/workflows/team-b-flow-v1", "team-a").Code)
+}
+
+func TestServerNamespaceBoundPrincipal(t *testing.T) {
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+
+	for _, namespace := range []string{"team-a", "team-b"} {
+		nsCtx := floxy.WithNamespace(ctx, namespace)
+		def := &floxy.WorkflowDefinition{
+			ID:         namespace + "-flow-v1",
+			Name:       namespace + "-flow",
+			Version:    1,
+			Definition: floxy.GraphDefinition{Start: "a", Steps: map[string]*floxy.StepDefinition{}},
+		}
+		require.NoError(t, store.SaveWorkflowDefinition(nsCtx, def))
+		_, err := store.CreateInstance(nsCtx, def.ID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+	}
+
+	authenticator := NewTokenAuthenticator(map[string]Principal{
+		"team-a-token": {Subject: "ann", Namespace: "team-a"},
+		"ops-token":    {Subject: "olga"},
+	})
+	// Unscoped requests are allowed, yet a bound principal never leaves its namespace
+	handler := New(nil, store, WithAuth(authenticator, nil)).Mux()
+
+	list := func(token, namespace string) (*httptest.ResponseRecorder, PaginatedInstancesResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/instances", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if namespace != "" {
+			req.Header.Set(NamespaceHeader, namespace)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var page PaginatedInstancesResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		}
+
+		return rec, page
+	}
+
+	rec, page := list("team-a-token", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "team-a", page.Items[0].Namespace)
+
+	rec, _ = list("team-a-token", "team-a")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec, _ = list("team-a-token", "team-b")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec, page = list("ops-token", "team-b")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "team-b", page.Items[0].Namespace)
+	rec, page = list("ops-token", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, 2, page.Total)
+}
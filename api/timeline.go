@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// HandleGetInstanceTimeline returns the execution timeline of an instance as JSON, or as an
+// HTML page with a Gantt chart when the format query parameter is html.
+func HandleGetInstanceTimeline(store floxy.Store) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		idStr := r.PathValue("id")
+
+		instanceID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			WriteErrorResponse(w, errors.New("invalid instance ID"), http.StatusBadRequest)
+
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "html" {
+			WriteErrorResponse(w, fmt.Errorf("invalid timeline format %q, expected json or html", format), http.StatusBadRequest)
+
+			return
+		}
+
+		timeline, err := floxy.LoadTimeline(ctx, store, instanceID, floxy.StoreClock(store).Now())
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				WriteErrorResponse(w, errors.New("workflow instance not found"), http.StatusNotFound)
+
+				return
+			}
+
+			WriteErrorResponse(w, fmt.Errorf("failed to build instance timeline: %w", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		if format == "html" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(floxy.NewVisualizer().RenderTimelineHTML(timeline)))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(timeline)
+	}
+}
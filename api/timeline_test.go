@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+func TestInstanceTimelineRoute(t *testing.T) {
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+
+	def, err := floxy.NewBuilder("timeline", 1).
+		Step("first", "first").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveWorkflowDefinition(ctx, def))
+
+	instance, err := store.CreateInstance(ctx, def.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+	step := &floxy.WorkflowStep{
+		InstanceID: instance.ID,
+		StepName:   "first",
+		StepType:   floxy.StepTypeTask,
+		Status:     floxy.StepStatusRunning,
+	}
+	require.NoError(t, store.CreateStep(ctx, step))
+	require.NoError(t, store.LogEvent(ctx, instance.ID, &step.ID, floxy.EventStepStarted, map[string]any{
+		floxy.KeyStepName: "first",
+	}))
+
+	handler := New(nil, store).Mux()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	path := "/api/instances/" + strconv.FormatInt(instance.ID, 10) + "/timeline"
+	rec := get(path)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var timeline floxy.Timeline
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &timeline))
+	assert.Equal(t, instance.ID, timeline.InstanceID)
+	require.Len(t, timeline.Steps, 1)
+	spans := timeline.Steps[0].Spans
+	require.Len(t, spans, 2)
+	assert.Equal(t, floxy.TimelineQueued, spans[0].Kind)
+	assert.Equal(t, floxy.TimelineRunning, spans[1].Kind)
+	assert.True(t, spans[1].Open)
+
+	rec = get(path + "?format=html")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "<!DOCTYPE html>"))
+
+	assert.Equal(t, http.StatusBadRequest, get(path+"?format=pdf").Code)
+	assert.Equal(t, http.StatusNotFound, get("/api/instances/999/timeline").Code)
+}
@@ -4,8 +4,14 @@ import (
 	"net/http"
 )
 
+// Router is the part of http.ServeMux routes are registered on.
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
 type Plugin interface {
 	Name() string
 	Description() string
-	RegisterRoutes(mux *http.ServeMux)
+	RegisterRoutes(mux Router)
 }
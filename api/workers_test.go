@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+func TestWorkersRoutes(t *testing.T) {
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+	now := time.Now()
+	require.NoError(t, store.RegisterWorker(ctx, &floxy.WorkerRecord{
+		ID:              "pool-1",
+		Host:            "node-a",
+		Handlers:        []string{"charge"},
+		TaskQueues:      []string{"charge"},
+		Concurrency:     4,
+		StartedAt:       now,
+		LastHeartbeatAt: now,
+	}))
+
+	mux := http.NewServeMux()
+	RegisterCoreRoutes(mux, store)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/workers", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var workers []floxy.WorkerRecord
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &workers))
+	require.Len(t, workers, 1)
+	assert.Equal(t, "node-a", workers[0].Host)
+	assert.Equal(t, floxy.WorkerStatusAlive, workers[0].Status)
+
+	// attempted_by of a pool worker resolves to the pool
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/workers/pool-1/2", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var worker floxy.WorkerRecord
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &worker))
+	assert.Equal(t, "pool-1", worker.ID)
+	assert.Equal(t, 4, worker.Concurrency)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/workers/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
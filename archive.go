@@ -0,0 +1,477 @@
+package floxy
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultArchiveOlderThan = 7 * 24 * time.Hour
+	defaultArchiveBatchSize = 100
+
+	archiveFileExt = ".jsonl.gz"
+
+	// LabelArchivedAt is set on instances written to the archive sink, in RFC 3339.
+	LabelArchivedAt = "archived_at"
+	// LabelRestoredFrom is set on instances restored from an archive and points to the archived instance ID.
+	LabelRestoredFrom = "restored_from"
+)
+
+var terminalWorkflowStatuses = []WorkflowStatus{StatusCompleted, StatusFailed, StatusCancelled, StatusAborted}
+
+// ArchivedInstance is the complete history of a finished instance as kept in archives.
+type ArchivedInstance struct {
+	Instance    WorkflowInstance      `json:"instance"`
+	Definition  *WorkflowDefinition   `json:"definition,omitempty"`
+	Steps       []WorkflowStep        `json:"steps"`
+	Events      []WorkflowEvent       `json:"events"`
+	Decisions   []HumanDecisionRecord `json:"decisions,omitempty"`
+	DeadLetters []DeadLetterRecord    `json:"dead_letters,omitempty"`
+	ArchivedAt  time.Time             `json:"archived_at"`
+}
+
+// ArchiveSink stores archived instances outside the database.
+type ArchiveSink interface {
+	// Write stores a batch of archived instances. Instances are marked archived, and may be deleted
+	// by cleanup, once Write returned nil, so it must not return before the batch is durable.
+	Write(ctx context.Context, batch []ArchivedInstance) error
+}
+
+// Archiver exports terminal instances with their steps, events, human decisions and DLQ records
+// to an ArchiveSink. Run it before the store cleanup with an age below the cleanup retention
+// (and below the partition retention of the PostgreSQL store), or use ArchiveAndCleanup.
+type Archiver struct {
+	store     Store
+	sink      ArchiveSink
+	olderThan time.Duration
+	batchSize int
+	clock     Clock
+}
+
+type ArchiverOption func(a *Archiver)
+
+// WithArchiveOlderThan sets how long an instance must be finished before it is archived. Default: 7 days.
+func WithArchiveOlderThan(d time.Duration) ArchiverOption {
+	return func(a *Archiver) {
+		if d >= 0 {
+			a.olderThan = d
+		}
+	}
+}
+
+// WithArchiveBatchSize sets how many instances are passed to the sink at once. Default: 100.
+func WithArchiveBatchSize(n int) ArchiverOption {
+	return func(a *Archiver) {
+		if n > 0 {
+			a.batchSize = n
+		}
+	}
+}
+
+// WithArchiveClock sets the clock the age threshold and archived_at are measured on.
+func WithArchiveClock(clock Clock) ArchiverOption {
+	return func(a *Archiver) {
+		if clock != nil {
+			a.clock = clock
+		}
+	}
+}
+
+func NewArchiver(store Store, sink ArchiveSink, opts ...ArchiverOption) *Archiver {
+	archiver := &Archiver{
+		store:     store,
+		sink:      sink,
+		olderThan: defaultArchiveOlderThan,
+		batchSize: defaultArchiveBatchSize,
+		clock:     SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(archiver)
+	}
+
+	return archiver
+}
+
+// Archive writes the terminal instances last updated before the age threshold that are not archived yet
+// to the sink and labels them with LabelArchivedAt. It returns the number of archived instances.
+func (a *Archiver) Archive(ctx context.Context) (int, error) {
+	cutoff := a.clock.Now().Add(-a.olderThan)
+
+	return a.archive(ctx, InstanceQuery{Statuses: terminalWorkflowStatuses, UpdatedTo: &cutoff})
+}
+
+// ArchiveCreatedBefore archives the terminal instances created before cutoff that are not archived yet.
+// It covers every instance a cleanup by completion time deletes, since instances finish after they start.
+func (a *Archiver) ArchiveCreatedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	return a.archive(ctx, InstanceQuery{Statuses: terminalWorkflowStatuses, CreatedTo: &cutoff})
+}
+
+// archivePartitions archives every instance created before cutoff whatever its status, because
+// dropping a partition deletes unfinished instances as well.
+func (a *Archiver) archivePartitions(ctx context.Context, cutoff time.Time) (int, error) {
+	return a.archive(ctx, InstanceQuery{CreatedTo: &cutoff})
+}
+
+func (a *Archiver) archive(ctx context.Context, query InstanceQuery) (int, error) {
+	query.WithoutLabels = []string{LabelArchivedAt}
+	query.SortBy = InstanceSortByID
+	query.SortOrder = SortOrderAsc
+	query.Limit = a.batchSize
+
+	archived := 0
+	for {
+		page, err := a.store.SearchInstances(ctx, query)
+		if err != nil {
+			return archived, fmt.Errorf("search instances: %w", err)
+		}
+
+		batch := make([]ArchivedInstance, 0, len(page.Items))
+		for _, instance := range page.Items {
+			rec, err := a.collect(ctx, instance)
+			if err != nil {
+				return archived, fmt.Errorf("collect instance %d: %w", instance.ID, err)
+			}
+			batch = append(batch, *rec)
+		}
+
+		if len(batch) > 0 {
+			if err := a.sink.Write(ctx, batch); err != nil {
+				return archived, fmt.Errorf("write archive: %w", err)
+			}
+
+			for _, rec := range batch {
+				labels := map[string]string{LabelArchivedAt: rec.ArchivedAt.UTC().Format(time.RFC3339)}
+				if err := a.store.SetInstanceLabels(ctx, rec.Instance.ID, labels); err != nil {
+					return archived, fmt.Errorf("mark instance %d archived: %w", rec.Instance.ID, err)
+				}
+				archived++
+			}
+		}
+
+		if page.NextCursor == "" {
+			return archived, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// ArchiveAndCleanup archives finished instances and runs the store cleanup only when archiving succeeded.
+func (a *Archiver) ArchiveAndCleanup(ctx context.Context) (int, error) {
+	archived, err := a.Archive(ctx)
+	if err != nil {
+		return archived, err
+	}
+
+	if err := a.store.CleanupOldWorkflows(ctx); err != nil {
+		return archived, fmt.Errorf("cleanup: %w", err)
+	}
+
+	return archived, nil
+}
+
+func (a *Archiver) collect(ctx context.Context, instance WorkflowInstance) (*ArchivedInstance, error) {
+	rec := &ArchivedInstance{
+		Instance:   instance,
+		ArchivedAt: a.clock.Now(),
+	}
+
+	def, err := a.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	switch {
+	case err == nil:
+		rec.Definition = def
+	case !errors.Is(err, ErrEntityNotFound):
+		return nil, fmt.Errorf("get workflow definition: %w", err)
+	}
+
+	rec.Steps, err = a.store.GetStepsByInstance(ctx, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get steps: %w", err)
+	}
+
+	rec.Events, err = a.store.GetWorkflowEvents(ctx, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+
+	for _, step := range rec.Steps {
+		if step.StepType != StepTypeHuman {
+			continue
+		}
+
+		decision, err := a.store.GetHumanDecision(ctx, step.ID)
+		if err != nil {
+			if errors.Is(err, ErrEntityNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("get human decision: %w", err)
+		}
+		rec.Decisions = append(rec.Decisions, *decision)
+	}
+
+	rec.DeadLetters, err = a.store.GetDeadLettersByInstance(ctx, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get dead letters: %w", err)
+	}
+
+	return rec, nil
+}
+
+// FileArchiveSink writes every batch to a gzip-compressed JSONL file in a local directory,
+// one ArchivedInstance per line.
+type FileArchiveSink struct {
+	dir string
+}
+
+func NewFileArchiveSink(dir string) *FileArchiveSink {
+	return &FileArchiveSink{dir: dir}
+}
+
+func (s *FileArchiveSink) Write(_ context.Context, batch []ArchivedInstance) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	// Named after the archive time of the batch, which the archiver takes from its clock
+	name := fmt.Sprintf("floxy-archive-%s-%d-%d%s",
+		batch[0].ArchivedAt.UTC().Format("20060102T150405.000000000Z"),
+		batch[0].Instance.ID, batch[len(batch)-1].Instance.ID, archiveFileExt,
+	)
+	path := filepath.Join(s.dir, name)
+
+	// Readers never see a partial file: it is renamed into place once synced
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := writeArchive(tmp, batch); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func writeArchive(w io.Writer, batch []ArchivedInstance) error {
+	zw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(zw)
+
+	for i := range batch {
+		if err := encoder.Encode(&batch[i]); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// ReadArchive calls fn for every instance of a gzip-compressed JSONL archive.
+func ReadArchive(r io.Reader, fn func(rec *ArchivedInstance) error) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	decoder := json.NewDecoder(bufio.NewReader(zr))
+	for {
+		var rec ArchivedInstance
+		if err := decoder.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+}
+
+// FindArchivedInstance looks up an instance in an archive file or in the archive files of a directory.
+// It returns ErrEntityNotFound when no archive contains the instance.
+func FindArchivedInstance(path string, instanceID int64) (*ArchivedInstance, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files = files[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), archiveFileExt) {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		// Newest archive first, an instance archived twice is restored from its latest copy
+		sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	}
+
+	errFound := errors.New("found")
+	for _, file := range files {
+		var found *ArchivedInstance
+		err := readArchiveFile(file, func(rec *ArchivedInstance) error {
+			if rec.Instance.ID != instanceID {
+				return nil
+			}
+			found = rec
+
+			return errFound
+		})
+		if err != nil && !errors.Is(err, errFound) {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, ErrEntityNotFound
+}
+
+func readArchiveFile(path string, fn func(rec *ArchivedInstance) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	return ReadArchive(f, fn)
+}
+
+// RestoreArchivedInstance recreates an archived instance in the store for inspection and returns its new ID.
+// The store assigns new IDs and creation times; the archived instance ID is kept in LabelRestoredFrom.
+// The restored instance keeps LabelArchivedAt of the archive, so the archiver does not archive it again.
+// The workflow definition is saved when the store does not have it. All writes run in one transaction:
+// with the PostgreSQL TxManager a failed restore leaves no partial instance behind, the MemoryTxManager
+// used with the memory and SQLite stores has no rollback, so there a failed restore may leave one.
+func RestoreArchivedInstance(ctx context.Context, store Store, txManager TxManager, rec *ArchivedInstance) (int64, error) {
+	var instanceID int64
+	err := txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		var err error
+		instanceID, err = restoreArchivedInstance(ctx, store, rec)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return instanceID, nil
+}
+
+func restoreArchivedInstance(ctx context.Context, store Store, rec *ArchivedInstance) (int64, error) {
+	if rec.Definition != nil {
+		_, err := store.GetWorkflowDefinition(ctx, rec.Definition.ID)
+		if errors.Is(err, ErrEntityNotFound) {
+			def := *rec.Definition
+			err = store.SaveWorkflowDefinition(ctx, &def)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("restore workflow definition: %w", err)
+		}
+	}
+
+	instance, err := store.CreateInstance(ctx, rec.Instance.WorkflowID, rec.Instance.Input)
+	if err != nil {
+		return 0, fmt.Errorf("create instance: %w", err)
+	}
+
+	if err := store.UpdateInstanceStatus(
+		ctx, instance.ID, rec.Instance.Status, rec.Instance.Output, rec.Instance.Error,
+	); err != nil {
+		return 0, fmt.Errorf("update instance status: %w", err)
+	}
+
+	labels := make(map[string]string, len(rec.Instance.Labels)+2)
+	for key, value := range rec.Instance.Labels {
+		labels[key] = value
+	}
+	labels[LabelRestoredFrom] = strconv.FormatInt(rec.Instance.ID, 10)
+	labels[LabelArchivedAt] = rec.ArchivedAt.UTC().Format(time.RFC3339)
+	if err := store.SetInstanceLabels(ctx, instance.ID, labels); err != nil {
+		return 0, fmt.Errorf("set instance labels: %w", err)
+	}
+
+	stepIDs := make(map[int64]int64, len(rec.Steps))
+	for _, archivedStep := range rec.Steps {
+		step := archivedStep
+		step.InstanceID = instance.ID
+		if err := store.CreateStep(ctx, &step); err != nil {
+			return 0, fmt.Errorf("create step %s: %w", step.StepName, err)
+		}
+		stepIDs[archivedStep.ID] = step.ID
+	}
+
+	for _, archivedDecision := range rec.Decisions {
+		decision := archivedDecision
+		decision.InstanceID = instance.ID
+		decision.StepID = stepIDs[archivedDecision.StepID]
+		if err := store.CreateHumanDecision(ctx, &decision); err != nil {
+			return 0, fmt.Errorf("create human decision: %w", err)
+		}
+	}
+
+	for _, event := range rec.Events {
+		var stepID *int64
+		if event.StepID != nil {
+			if id, ok := stepIDs[*event.StepID]; ok {
+				stepID = &id
+			}
+		}
+
+		if err := store.LogEvent(ctx, instance.ID, stepID, event.EventType, event.Payload); err != nil {
+			return 0, fmt.Errorf("log event: %w", err)
+		}
+	}
+
+	for _, archivedRecord := range rec.DeadLetters {
+		dlq := archivedRecord
+		dlq.InstanceID = instance.ID
+		dlq.StepID = stepIDs[archivedRecord.StepID]
+		dlq.NextRedriveAt = nil
+		if err := store.CreateDeadLetterRecord(ctx, &dlq); err != nil {
+			return 0, fmt.Errorf("create dead letter record: %w", err)
+		}
+	}
+
+	return instance.ID, nil
+}
@@ -0,0 +1,181 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type archiveSinkFunc func(ctx context.Context, batch []ArchivedInstance) error
+
+func (f archiveSinkFunc) Write(ctx context.Context, batch []ArchivedInstance) error {
+	return f(ctx, batch)
+}
+
+// txCheckingStore records whether instances are created inside a transaction of txManager.
+type txCheckingStore struct {
+	*MemoryStore
+	txManager   *trackingTxManager
+	createdInTx bool
+}
+
+func (s *txCheckingStore) CreateInstance(ctx context.Context, workflowID string, input json.RawMessage) (*WorkflowInstance, error) {
+	s.createdInTx = s.txManager.open.Load() > 0
+
+	return s.MemoryStore.CreateInstance(ctx, workflowID, input)
+}
+
+func seedArchivableInstance(t *testing.T, ctx context.Context, store Store, status WorkflowStatus) *WorkflowInstance {
+	t.Helper()
+
+	def := &WorkflowDefinition{
+		ID: "archived-v1", Name: "archived", Version: 1,
+		Definition: GraphDefinition{Start: "approve", Steps: map[string]*StepDefinition{
+			"approve": {Name: "approve", Type: StepTypeHuman},
+		}},
+	}
+	require.NoError(t, store.SaveWorkflowDefinition(ctx, def))
+
+	instance, err := store.CreateInstance(ctx, def.ID, json.RawMessage(`{"order":1}`))
+	require.NoError(t, err)
+	require.NoError(t, store.SetInstanceLabels(ctx, instance.ID, map[string]string{"customer_id": "42"}))
+
+	step := &WorkflowStep{InstanceID: instance.ID, StepName: "approve", StepType: StepTypeHuman, Status: StepStatusConfirmed}
+	require.NoError(t, store.CreateStep(ctx, step))
+	require.NoError(t, store.CreateHumanDecision(ctx, &HumanDecisionRecord{
+		InstanceID: instance.ID, StepID: step.ID, DecidedBy: "alice", Decision: HumanDecisionConfirmed,
+	}))
+	require.NoError(t, store.LogEvent(ctx, instance.ID, &step.ID, EventStepCompleted, map[string]any{KeyStepName: "approve"}))
+	require.NoError(t, store.CreateDeadLetterRecord(ctx, &DeadLetterRecord{
+		InstanceID: instance.ID, WorkflowID: def.ID, StepID: step.ID, StepName: "approve", Reason: "test",
+	}))
+	require.NoError(t, store.UpdateInstanceStatus(ctx, instance.ID, status, json.RawMessage(`{"done":true}`), nil))
+
+	return instance
+}
+
+func TestArchiver_ArchiveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	dir := t.TempDir()
+
+	finished := seedArchivableInstance(t, ctx, store, StatusCompleted)
+	running := seedArchivableInstance(t, ctx, store, StatusRunning)
+
+	archiver := NewArchiver(store, NewFileArchiveSink(dir), WithArchiveOlderThan(0))
+
+	archived, err := archiver.Archive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	instance, err := store.GetInstance(ctx, finished.ID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, instance.Labels[LabelArchivedAt])
+
+	// Already archived instances are skipped
+	archived, err = archiver.Archive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	_, err = FindArchivedInstance(dir, running.ID)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	rec, err := FindArchivedInstance(dir, finished.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, rec.Instance.Status)
+	require.NotNil(t, rec.Definition)
+	assert.Len(t, rec.Steps, 1)
+	assert.Len(t, rec.Events, 1)
+	assert.Len(t, rec.Decisions, 1)
+	assert.Len(t, rec.DeadLetters, 1)
+
+	txManager := &trackingTxManager{}
+	target := &txCheckingStore{MemoryStore: NewMemoryStore(), txManager: txManager}
+	restoredID, err := RestoreArchivedInstance(ctx, target, txManager, rec)
+	require.NoError(t, err)
+	assert.True(t, target.createdInTx)
+
+	restored, err := target.GetInstance(ctx, restoredID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, restored.Status)
+	assert.JSONEq(t, `{"done":true}`, string(restored.Output))
+	assert.Equal(t, "42", restored.Labels["customer_id"])
+	assert.Equal(t, strconv.FormatInt(finished.ID, 10), restored.Labels[LabelRestoredFrom])
+
+	steps, err := target.GetStepsByInstance(ctx, restoredID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, StepStatusConfirmed, steps[0].Status)
+
+	decision, err := target.GetHumanDecision(ctx, steps[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", decision.DecidedBy)
+
+	events, err := target.GetWorkflowEvents(ctx, restoredID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, steps[0].ID, *events[0].StepID)
+
+	deadLetters, err := target.GetDeadLettersByInstance(ctx, restoredID)
+	require.NoError(t, err)
+	assert.Len(t, deadLetters, 1)
+
+	// Restored instances keep their archive time and are not archived again
+	assert.Equal(t, rec.ArchivedAt.UTC().Format(time.RFC3339), restored.Labels[LabelArchivedAt])
+	archived, err = NewArchiver(target, NewFileArchiveSink(t.TempDir()), WithArchiveOlderThan(0)).Archive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+}
+
+func TestArchiver_SinkFailureKeepsInstances(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	finished := seedArchivableInstance(t, ctx, store, StatusFailed)
+
+	sinkErr := errors.New("disk full")
+	archiver := NewArchiver(store, archiveSinkFunc(func(context.Context, []ArchivedInstance) error {
+		return sinkErr
+	}), WithArchiveOlderThan(0))
+
+	_, err := archiver.ArchiveAndCleanup(ctx)
+	assert.ErrorIs(t, err, sinkErr)
+
+	instance, err := store.GetInstance(ctx, finished.ID)
+	require.NoError(t, err)
+	assert.Empty(t, instance.Labels[LabelArchivedAt])
+}
+
+func TestArchiver_ArchivePartitionsIncludesUnfinished(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	dir := t.TempDir()
+
+	finished := seedArchivableInstance(t, ctx, store, StatusCompleted)
+	running := seedArchivableInstance(t, ctx, store, StatusRunning)
+
+	archiver := NewArchiver(store, NewFileArchiveSink(dir))
+
+	archived, err := archiver.ArchiveCreatedBefore(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	archived, err = archiver.archivePartitions(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	for _, id := range []int64{finished.ID, running.ID} {
+		_, err := FindArchivedInstance(dir, id)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,119 @@
+package floxy
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultAutoscaleInterval = 5 * time.Second
+
+	// Share of polls that found an item above which the pool grows, and below which an idle pool shrinks
+	autoscaleBusyRate = 0.9
+	autoscaleIdleRate = 0.1
+)
+
+// WithAutoscaling lets the pool size vary between minWorkers and maxWorkers: it grows by half when
+// the ready queue (Store.GetQueueLength of its task queues) holds more items than the pool has
+// workers or when most polls claim an item, and shrinks by one worker when the queue is empty and
+// polls come back empty. The size passed to NewWorkerPool is the initial size.
+func WithAutoscaling(minWorkers, maxWorkers int) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		minWorkers = max(minWorkers, 1)
+		if maxWorkers >= minWorkers {
+			pool.minWorkers = minWorkers
+			pool.maxWorkers = maxWorkers
+		}
+	}
+}
+
+// WithAutoscaleInterval sets how often an autoscaling pool checks the queue (default 5s).
+func WithAutoscaleInterval(interval time.Duration) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		if interval > 0 {
+			pool.autoscaleInterval = interval
+		}
+	}
+}
+
+// poolStats counts the polls of the pool workers (claims of the dispatcher) since the last autoscaling check.
+type poolStats struct {
+	polls atomic.Int64
+	hits  atomic.Int64
+}
+
+func (s *poolStats) record(hit bool) {
+	s.polls.Add(1)
+	if hit {
+		s.hits.Add(1)
+	}
+}
+
+// hitRate returns the share of polls that claimed an item and resets the counters.
+func (s *poolStats) hitRate() float64 {
+	polls := s.polls.Swap(0)
+	hits := s.hits.Swap(0)
+	if polls == 0 {
+		return 0
+	}
+
+	return float64(hits) / float64(polls)
+}
+
+func (p *WorkerPool) autoscaling() bool {
+	return p.maxWorkers > 0
+}
+
+func (p *WorkerPool) autoscale(ctx context.Context) {
+	ticker := p.engine.clock.NewTicker(p.autoscaleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case <-ticker.C():
+			p.scale(ctx)
+		}
+	}
+}
+
+func (p *WorkerPool) scale(ctx context.Context) {
+	depth, err := p.engine.store.GetQueueLength(ctx, p.engine.pollQueues(p.taskQueues))
+	if err != nil {
+		log.Printf("Workflow worker pool %s: get queue length: %v", p.id, err)
+
+		return
+	}
+	hitRate := p.stats.hitRate()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current := len(p.workers)
+	target := autoscaleTarget(current, p.minWorkers, p.maxWorkers, depth, hitRate)
+	if target == current || p.stopped {
+		return
+	}
+
+	log.Printf("Workflow worker pool %s: scaling from %d to %d workers (queue depth: %d, hit rate: %.2f)",
+		p.id, current, target, depth, hitRate)
+	p.resizeLocked(target)
+}
+
+// autoscaleTarget returns the pool size for the observed ready queue depth and poll hit rate.
+func autoscaleTarget(current, minWorkers, maxWorkers, depth int, hitRate float64) int {
+	target := current
+	switch {
+	case depth > current || hitRate >= autoscaleBusyRate:
+		target = current + max(1, current/2)
+	case depth == 0 && hitRate <= autoscaleIdleRate:
+		target = current - 1
+	}
+
+	return min(max(target, minWorkers), maxWorkers)
+}
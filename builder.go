@@ -23,6 +23,7 @@ type Builder struct {
 	subBuilders       []*Builder
 	defaultMaxRetries int
 	dlqEnabled        bool
+	redrivePolicy     *RedrivePolicy
 
 	err error
 }
@@ -549,9 +550,10 @@ func (builder *Builder) Build() (*WorkflowDefinition, error) {
 		Name:    builder.name,
 		Version: builder.version,
 		Definition: GraphDefinition{
-			Start:      builder.startStep,
-			Steps:      builder.steps,
-			DLQEnabled: builder.dlqEnabled,
+			Start:         builder.startStep,
+			Steps:         builder.steps,
+			DLQEnabled:    builder.dlqEnabled,
+			RedrivePolicy: builder.redrivePolicy,
 		},
 	}
 
@@ -569,6 +571,15 @@ func (builder *Builder) Build() (*WorkflowDefinition, error) {
 }
 
 func ValidateWorkflowDefinition(def *WorkflowDefinition) error {
+	if policy := def.Definition.RedrivePolicy; policy != nil {
+		if !def.Definition.DLQEnabled {
+			return fmt.Errorf("def %q: redrive policy requires DLQ mode", def.Name)
+		}
+		if policy.MaxRedrives <= 0 || policy.Backoff <= 0 {
+			return fmt.Errorf("def %q: redrive policy requires positive max redrives and backoff", def.Name)
+		}
+	}
+
 	for stepName, stepDef := range def.Definition.Steps {
 		if err := validateStepName(stepName); err != nil {
 			return fmt.Errorf("def %q: %w", def.Name, err)
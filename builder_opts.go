@@ -56,6 +56,14 @@ func WithStepRetryStrategy(strategy RetryStrategy) StepOption {
 	}
 }
 
+// WithStepTaskQueue routes the step to a named task queue instead of the queue named after its handler.
+// Only workers subscribed to the queue execute the step.
+func WithStepTaskQueue(queue string) StepOption {
+	return func(step *StepDefinition) {
+		step.TaskQueue = queue
+	}
+}
+
 type BuilderOption func(builder *Builder)
 
 func WithBuilderMaxRetries(maxRetries int) BuilderOption {
@@ -71,3 +79,15 @@ func WithDLQEnabled(enabled bool) BuilderOption {
 		builder.dlqEnabled = enabled
 	}
 }
+
+// WithRedrivePolicy makes the engine requeue DLQ records of the workflow automatically
+// after a backoff, up to maxRedrives times per step. Requires DLQ mode.
+func WithRedrivePolicy(maxRedrives int, backoff time.Duration, strategy RetryStrategy) BuilderOption {
+	return func(builder *Builder) {
+		builder.redrivePolicy = &RedrivePolicy{
+			MaxRedrives: maxRedrives,
+			Backoff:     backoff,
+			Strategy:    strategy,
+		}
+	}
+}
@@ -0,0 +1,454 @@
+package floxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBulkRatePerSecond = 50
+	maxFinishedBulkJobs      = 100
+
+	// MaxBulkRatePerSecond is the highest rate a bulk operation may request.
+	MaxBulkRatePerSecond = 1000
+
+	// LabelRetryOf is set on instances started by a bulk retry and points to the original instance.
+	LabelRetryOf = "retry_of"
+)
+
+var (
+	ErrEmptyBulkSelector = errors.New("bulk selector matches no instances")
+	// ErrInvalidBulkRate is returned for a negative rate or one above MaxBulkRatePerSecond.
+	ErrInvalidBulkRate = errors.New("invalid bulk rate")
+)
+
+type BulkAction string
+
+const (
+	BulkActionCancel  BulkAction = "cancel"
+	BulkActionAbort   BulkAction = "abort"
+	BulkActionRequeue BulkAction = "requeue"
+	BulkActionRetry   BulkAction = "retry"
+)
+
+type BulkJobStatus string
+
+const (
+	BulkJobStatusRunning   BulkJobStatus = "running"
+	BulkJobStatusCompleted BulkJobStatus = "completed"
+	BulkJobStatusCancelled BulkJobStatus = "cancelled"
+)
+
+type BulkItemStatus string
+
+const (
+	BulkItemStatusSucceeded BulkItemStatus = "succeeded"
+	BulkItemStatusFailed    BulkItemStatus = "failed"
+)
+
+// BulkSelector selects instances by explicit IDs, by a search query and/or by
+// the DLQ records they own. The union is used. Query pagination fields are ignored.
+type BulkSelector struct {
+	InstanceIDs []int64
+	Query       *InstanceQuery
+	DeadLetters *DeadLetterFilter
+}
+
+type BulkRequest struct {
+	Action      BulkAction
+	Selector    BulkSelector
+	RequestedBy string
+	Reason      string
+	// RatePerSecond limits how many instances are processed per second, up to
+	// MaxBulkRatePerSecond (default: engine setting).
+	RatePerSecond float64
+}
+
+type BulkItemResult struct {
+	InstanceID    int64          `json:"instance_id"`
+	Status        BulkItemStatus `json:"status"`
+	Error         string         `json:"error,omitempty"`
+	NewInstanceID int64          `json:"new_instance_id,omitempty"` // retry only
+	Requeued      int            `json:"requeued,omitempty"`        // requeue only: number of DLQ records
+}
+
+type BulkJob struct {
+	ID          string           `json:"id"`
+	Namespace   string           `json:"namespace,omitempty"`
+	Action      BulkAction       `json:"action"`
+	RequestedBy string           `json:"requested_by"`
+	Reason      string           `json:"reason,omitempty"`
+	Status      BulkJobStatus    `json:"status"`
+	Total       int              `json:"total"`
+	Processed   int              `json:"processed"`
+	Succeeded   int              `json:"succeeded"`
+	Failed      int              `json:"failed"`
+	Items       []BulkItemResult `json:"items"`
+	CreatedAt   time.Time        `json:"created_at"`
+	FinishedAt  *time.Time       `json:"finished_at,omitempty"`
+}
+
+func (job *BulkJob) Done() bool {
+	return job.Status != BulkJobStatusRunning
+}
+
+type bulkJob struct {
+	mu     sync.RWMutex
+	job    BulkJob
+	cancel context.CancelFunc
+}
+
+func (j *bulkJob) snapshot() *BulkJob {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	job := j.job
+	job.Items = append([]BulkItemResult(nil), j.job.Items...)
+
+	return &job
+}
+
+func (j *bulkJob) record(result BulkItemResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.job.Items = append(j.job.Items, result)
+	j.job.Processed++
+	if result.Status == BulkItemStatusSucceeded {
+		j.job.Succeeded++
+	} else {
+		j.job.Failed++
+	}
+}
+
+func (j *bulkJob) finish(status BulkJobStatus, now time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.job.Status = status
+	j.job.FinishedAt = &now
+}
+
+// StartBulkOperation resolves the selector and starts a background job that applies the action
+// to every selected instance. Jobs are tracked in memory by the engine that started them.
+func (engine *Engine) StartBulkOperation(ctx context.Context, req BulkRequest) (*BulkJob, error) {
+	ctx = engine.scope(ctx)
+
+	switch req.Action {
+	case BulkActionCancel, BulkActionAbort, BulkActionRequeue, BulkActionRetry:
+	default:
+		return nil, fmt.Errorf("unsupported bulk action: %s", req.Action)
+	}
+
+	if err := ValidateBulkRate(req.RatePerSecond); err != nil {
+		return nil, err
+	}
+
+	if engine.isShutdown() {
+		return nil, errors.New("engine is shutting down")
+	}
+
+	// Selection is resolved up front: actions change instance statuses,
+	// so paging through the query while processing would skip instances.
+	instanceIDs, err := engine.resolveBulkSelector(ctx, req.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("resolve selector: %w", err)
+	}
+	if len(instanceIDs) == 0 {
+		return nil, ErrEmptyBulkSelector
+	}
+
+	rate := req.RatePerSecond
+	if rate <= 0 {
+		rate = engine.bulkRatePerSecond
+	}
+
+	jobCtx, cancel := context.WithCancel(engine.shutdownCtx)
+	namespace, _ := NamespaceFromContext(ctx)
+	if namespace != "" {
+		jobCtx = WithNamespace(jobCtx, namespace)
+	}
+	job := &bulkJob{
+		job: BulkJob{
+			ID:          uuid.NewString(),
+			Namespace:   namespace,
+			Action:      req.Action,
+			RequestedBy: req.RequestedBy,
+			Reason:      req.Reason,
+			Status:      BulkJobStatusRunning,
+			Total:       len(instanceIDs),
+			Items:       make([]BulkItemResult, 0, len(instanceIDs)),
+			CreatedAt:   engine.clock.Now(),
+		},
+		cancel: cancel,
+	}
+
+	engine.bulkJobsMu.Lock()
+	engine.pruneBulkJobsLocked()
+	engine.bulkJobs[job.job.ID] = job
+	engine.bulkJobsMu.Unlock()
+
+	go engine.runBulkJob(jobCtx, job, req, instanceIDs, rate)
+
+	return job.snapshot(), nil
+}
+
+// ValidateBulkRate accepts zero (the engine default) and rates up to MaxBulkRatePerSecond.
+func ValidateBulkRate(rate float64) error {
+	if math.IsNaN(rate) || rate < 0 || rate > MaxBulkRatePerSecond {
+		return fmt.Errorf("%w: %v per second, allowed up to %d", ErrInvalidBulkRate, rate, MaxBulkRatePerSecond)
+	}
+
+	return nil
+}
+
+// GetBulkOperation returns a snapshot of the bulk job progress.
+func (engine *Engine) GetBulkOperation(ctx context.Context, jobID string) (*BulkJob, error) {
+	job, ok := engine.lookupBulkJob(engine.scope(ctx), jobID)
+	if !ok {
+		return nil, ErrEntityNotFound
+	}
+
+	return job.snapshot(), nil
+}
+
+// CancelBulkOperation stops a running bulk job. Already processed instances are not reverted.
+func (engine *Engine) CancelBulkOperation(ctx context.Context, jobID string) error {
+	job, ok := engine.lookupBulkJob(engine.scope(ctx), jobID)
+	if !ok {
+		return ErrEntityNotFound
+	}
+
+	job.cancel()
+
+	return nil
+}
+
+// lookupBulkJob hides jobs started in another namespace from scoped callers.
+func (engine *Engine) lookupBulkJob(ctx context.Context, jobID string) (*bulkJob, bool) {
+	engine.bulkJobsMu.RLock()
+	job, ok := engine.bulkJobs[jobID]
+	engine.bulkJobsMu.RUnlock()
+
+	if !ok || !visibleIn(ctx, job.job.Namespace) {
+		return nil, false
+	}
+
+	return job, true
+}
+
+func (engine *Engine) resolveBulkSelector(ctx context.Context, selector BulkSelector) ([]int64, error) {
+	seen := make(map[int64]struct{})
+	ids := make([]int64, 0, len(selector.InstanceIDs))
+
+	add := func(id int64) {
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	for _, id := range selector.InstanceIDs {
+		add(id)
+	}
+
+	if selector.DeadLetters != nil {
+		for offset := 0; ; offset += maxInstanceQueryLimit {
+			records, _, err := engine.store.ListDeadLetters(ctx, *selector.DeadLetters, offset, maxInstanceQueryLimit)
+			if err != nil {
+				return nil, fmt.Errorf("list dead letters: %w", err)
+			}
+
+			for _, rec := range records {
+				add(rec.InstanceID)
+			}
+
+			if len(records) < maxInstanceQueryLimit {
+				break
+			}
+		}
+	}
+
+	if selector.Query == nil {
+		return ids, nil
+	}
+
+	query := *selector.Query
+	query.SortBy = InstanceSortByID
+	query.SortOrder = SortOrderAsc
+	query.Cursor = ""
+	query.Limit = maxInstanceQueryLimit
+
+	for {
+		result, err := engine.store.SearchInstances(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("search instances: %w", err)
+		}
+
+		for _, instance := range result.Items {
+			add(instance.ID)
+		}
+
+		if result.NextCursor == "" {
+			return ids, nil
+		}
+		query.Cursor = result.NextCursor
+	}
+}
+
+func (engine *Engine) runBulkJob(
+	ctx context.Context,
+	job *bulkJob,
+	req BulkRequest,
+	instanceIDs []int64,
+	rate float64,
+) {
+	defer job.cancel()
+
+	interval := time.Duration(float64(time.Second) / rate)
+	if interval <= 0 {
+		interval = time.Second / MaxBulkRatePerSecond
+	}
+	ticker := engine.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i, instanceID := range instanceIDs {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-ticker.C():
+			}
+		}
+
+		if ctx.Err() != nil {
+			job.finish(BulkJobStatusCancelled, engine.clock.Now())
+
+			return
+		}
+
+		job.record(engine.applyBulkAction(ctx, req, instanceID))
+	}
+
+	job.finish(BulkJobStatusCompleted, engine.clock.Now())
+
+	snapshot := job.snapshot()
+	slog.Info("[floxy] bulk operation completed",
+		"job_id", snapshot.ID,
+		"action", snapshot.Action,
+		"succeeded", snapshot.Succeeded,
+		"failed", snapshot.Failed,
+	)
+}
+
+func (engine *Engine) applyBulkAction(ctx context.Context, req BulkRequest, instanceID int64) BulkItemResult {
+	result := BulkItemResult{InstanceID: instanceID}
+
+	var err error
+	switch req.Action {
+	case BulkActionCancel:
+		err = engine.CancelWorkflow(ctx, instanceID, req.RequestedBy, req.Reason)
+	case BulkActionAbort:
+		err = engine.AbortWorkflow(ctx, instanceID, req.RequestedBy, req.Reason)
+	case BulkActionRequeue:
+		result.Requeued, err = engine.requeueInstanceDeadLetters(ctx, instanceID)
+	case BulkActionRetry:
+		result.NewInstanceID, err = engine.retryInstance(ctx, instanceID)
+	}
+
+	if err != nil {
+		result.Status = BulkItemStatusFailed
+		result.Error = err.Error()
+	} else {
+		result.Status = BulkItemStatusSucceeded
+	}
+
+	return result
+}
+
+func (engine *Engine) requeueInstanceDeadLetters(ctx context.Context, instanceID int64) (int, error) {
+	var requeued int
+
+	err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		records, err := engine.store.GetDeadLettersByInstance(ctx, instanceID)
+		if err != nil {
+			return fmt.Errorf("get dead letters: %w", err)
+		}
+
+		if len(records) == 0 {
+			return fmt.Errorf("workflow %d has no dead letter records", instanceID)
+		}
+
+		for i := range records {
+			if err := engine.requeueDeadLetter(ctx, &records[i], nil, EventDLQRequeued); err != nil {
+				return fmt.Errorf("requeue dead letter %d: %w", records[i].ID, err)
+			}
+		}
+
+		requeued = len(records)
+
+		return nil
+	})
+
+	return requeued, err
+}
+
+// retryInstance starts a new instance of the same workflow with the original input and labels.
+func (engine *Engine) retryInstance(ctx context.Context, instanceID int64) (int64, error) {
+	instance, err := engine.store.GetInstance(ctx, instanceID)
+	if err != nil {
+		return 0, fmt.Errorf("get instance: %w", err)
+	}
+
+	switch instance.Status {
+	case StatusFailed, StatusCancelled, StatusAborted:
+	default:
+		return 0, fmt.Errorf("workflow %d cannot be retried (current status: %s)", instanceID, instance.Status)
+	}
+
+	labels := make(map[string]string, len(instance.Labels)+1)
+	for key, value := range instance.Labels {
+		labels[key] = value
+	}
+	labels[LabelRetryOf] = strconv.FormatInt(instanceID, 10)
+
+	return engine.Start(ctx, instance.WorkflowID, instance.Input, WithStartLabels(labels))
+}
+
+// pruneBulkJobsLocked drops the oldest finished jobs so at most maxFinishedBulkJobs-1 remain.
+func (engine *Engine) pruneBulkJobsLocked() {
+	type finishedJob struct {
+		id         string
+		finishedAt time.Time
+	}
+
+	finished := make([]finishedJob, 0)
+	for id, job := range engine.bulkJobs {
+		job.mu.RLock()
+		if job.job.FinishedAt != nil {
+			finished = append(finished, finishedJob{id: id, finishedAt: *job.job.FinishedAt})
+		}
+		job.mu.RUnlock()
+	}
+
+	if len(finished) < maxFinishedBulkJobs {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].finishedAt.Before(finished[j].finishedAt)
+	})
+
+	for _, job := range finished[:len(finished)-maxFinishedBulkJobs+1] {
+		delete(engine.bulkJobs, job.id)
+	}
+}
@@ -0,0 +1,277 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkFlakyHandler struct {
+	healthy atomic.Bool
+}
+
+func (h *bulkFlakyHandler) Name() string { return "bulk-flaky" }
+
+func (h *bulkFlakyHandler) Execute(_ context.Context, _ StepContext, input json.RawMessage) (json.RawMessage, error) {
+	if !h.healthy.Load() {
+		return nil, errors.New("downstream outage")
+	}
+
+	return input, nil
+}
+
+func newBulkTestEngine(t *testing.T) (*Engine, *MemoryStore, *bulkFlakyHandler) {
+	t.Helper()
+
+	store := NewMemoryStore()
+	handler := &bulkFlakyHandler{}
+	engine := newMemoryTestEngine(t, store, []StepHandler{handler, &SimpleTestHandler{}}, WithBulkRateLimit(1000))
+
+	return engine, store, handler
+}
+
+func waitBulkJob(t *testing.T, ctx context.Context, engine *Engine, jobID string) *BulkJob {
+	t.Helper()
+
+	var job *BulkJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = engine.GetBulkOperation(ctx, jobID)
+		require.NoError(t, err)
+
+		return job.Done()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestBulkOperation_CancelByQuery(t *testing.T) {
+	ctx := context.Background()
+	engine, store, _ := newBulkTestEngine(t)
+
+	workflowDef, err := NewBuilder("bulk_cancel", 1).
+		Step("start", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	var outageIDs []int64
+	for i := 0; i < 3; i++ {
+		id, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`),
+			WithStartLabels(map[string]string{"batch": "outage"}))
+		require.NoError(t, err)
+		outageIDs = append(outageIDs, id)
+	}
+	otherID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	job, err := engine.StartBulkOperation(ctx, BulkRequest{
+		Action: BulkActionCancel,
+		Selector: BulkSelector{
+			Query: &InstanceQuery{Labels: map[string]string{"batch": "outage"}},
+		},
+		RequestedBy: "operator",
+		Reason:      "outage cleanup",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, job.Total)
+
+	job = waitBulkJob(t, ctx, engine, job.ID)
+	assert.Equal(t, BulkJobStatusCompleted, job.Status)
+	assert.Equal(t, 3, job.Succeeded)
+	assert.Equal(t, 0, job.Failed)
+
+	for _, id := range outageIDs {
+		req, err := store.GetCancelRequest(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "outage cleanup", *req.Reason)
+	}
+
+	_, err = store.GetCancelRequest(ctx, otherID)
+	assert.Error(t, err)
+}
+
+func TestBulkOperation_RequeueAndRetry(t *testing.T) {
+	ctx := context.Background()
+	engine, store, handler := newBulkTestEngine(t)
+
+	dlqDef, err := NewBuilder("bulk_dlq", 1, WithDLQEnabled(true)).
+		Step("call", "bulk-flaky", WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, dlqDef))
+
+	plainDef, err := NewBuilder("bulk_plain", 1).
+		Step("call", "bulk-flaky", WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, plainDef))
+
+	dlqID, err := engine.Start(ctx, dlqDef.ID, json.RawMessage(`{"n":1}`))
+	require.NoError(t, err)
+	failedID, err := engine.Start(ctx, plainDef.ID, json.RawMessage(`{"n":2}`),
+		WithStartLabels(map[string]string{"customer_id": "42"}))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	status, err := engine.GetStatus(ctx, dlqID)
+	require.NoError(t, err)
+	require.Equal(t, StatusDLQ, status)
+	status, err = engine.GetStatus(ctx, failedID)
+	require.NoError(t, err)
+	require.Equal(t, StatusFailed, status)
+
+	handler.healthy.Store(true)
+
+	job, err := engine.StartBulkOperation(ctx, BulkRequest{
+		Action:   BulkActionRequeue,
+		Selector: BulkSelector{InstanceIDs: []int64{dlqID, failedID}},
+	})
+	require.NoError(t, err)
+
+	job = waitBulkJob(t, ctx, engine, job.ID)
+	require.Len(t, job.Items, 2)
+	assert.Equal(t, BulkItemStatusSucceeded, job.Items[0].Status)
+	assert.Equal(t, 1, job.Items[0].Requeued)
+	assert.Equal(t, BulkItemStatusFailed, job.Items[1].Status)
+	assert.Contains(t, job.Items[1].Error, "no dead letter records")
+
+	job, err = engine.StartBulkOperation(ctx, BulkRequest{
+		Action:   BulkActionRetry,
+		Selector: BulkSelector{Query: &InstanceQuery{Statuses: []WorkflowStatus{StatusFailed}}},
+	})
+	require.NoError(t, err)
+
+	job = waitBulkJob(t, ctx, engine, job.ID)
+	require.Len(t, job.Items, 1)
+	require.Equal(t, BulkItemStatusSucceeded, job.Items[0].Status)
+	retryID := job.Items[0].NewInstanceID
+
+	drainQueue(t, ctx, engine)
+
+	for _, id := range []int64{dlqID, retryID} {
+		status, err := engine.GetStatus(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, StatusCompleted, status, "instance %d", id)
+	}
+
+	retried, err := store.GetInstance(ctx, retryID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"n":2}`, string(retried.Input))
+	assert.Equal(t, map[string]string{
+		"customer_id": "42",
+		LabelRetryOf:  strconv.FormatInt(failedID, 10),
+	}, retried.Labels)
+}
+
+func TestBulkOperation_RateLimitAndCancel(t *testing.T) {
+	ctx := context.Background()
+	engine, _, _ := newBulkTestEngine(t)
+
+	workflowDef, err := NewBuilder("bulk_rate", 1).
+		Step("start", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		id, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	job, err := engine.StartBulkOperation(ctx, BulkRequest{
+		Action:        BulkActionAbort,
+		Selector:      BulkSelector{InstanceIDs: ids},
+		RatePerSecond: 2,
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		job, err = engine.GetBulkOperation(ctx, job.ID)
+		require.NoError(t, err)
+
+		return job.Processed == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, engine.CancelBulkOperation(ctx, job.ID))
+
+	job = waitBulkJob(t, ctx, engine, job.ID)
+	assert.Equal(t, BulkJobStatusCancelled, job.Status)
+	assert.Less(t, job.Processed, job.Total)
+
+	_, err = engine.StartBulkOperation(ctx, BulkRequest{Action: BulkActionAbort})
+	assert.ErrorIs(t, err, ErrEmptyBulkSelector)
+
+	for _, rate := range []float64{-1, MaxBulkRatePerSecond + 1, 2e9} {
+		_, err = engine.StartBulkOperation(ctx, BulkRequest{
+			Action:        BulkActionAbort,
+			Selector:      BulkSelector{InstanceIDs: ids},
+			RatePerSecond: rate,
+		})
+		assert.ErrorIs(t, err, ErrInvalidBulkRate)
+	}
+
+	_, err = engine.GetBulkOperation(ctx, "missing")
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
+
+func TestBulkOperation_RateOnEngineClock(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	engine := newMemoryTestEngine(t, NewMemoryStore(WithStoreClock(clock)), []StepHandler{&SimpleTestHandler{}},
+		WithEngineClock(clock),
+	)
+
+	workflowDef, err := NewBuilder("bulk_clock", 1).
+		Step("start", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	var ids []int64
+	for i := 0; i < 2; i++ {
+		id, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	job, err := engine.StartBulkOperation(ctx, BulkRequest{
+		Action:        BulkActionAbort,
+		Selector:      BulkSelector{InstanceIDs: ids},
+		RatePerSecond: 1,
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		job, err = engine.GetBulkOperation(ctx, job.ID)
+		require.NoError(t, err)
+
+		return job.Processed == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The second instance waits for the engine clock, not for real time
+	time.Sleep(50 * time.Millisecond)
+	job, err = engine.GetBulkOperation(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, job.Processed)
+
+	require.Eventually(t, func() bool {
+		clock.Advance(time.Second)
+		job, err = engine.GetBulkOperation(ctx, job.ID)
+		require.NoError(t, err)
+
+		return job.Done()
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, BulkJobStatusCompleted, job.Status)
+	assert.Equal(t, 2, job.Processed)
+}
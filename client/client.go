@@ -0,0 +1,157 @@
+// Package client is a typed Go client for the HTTP API served by api.Server and the bundled
+// plugins, as described by api/openapi.yaml. Each operation of the document has a method of
+// the same name; plugin operations only work when the plugin is registered on the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rom8726/floxy-pro/api"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	namespace  string
+	header     http.Header
+}
+
+type Option func(c *Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set timeouts or a transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithNamespace scopes every request to the namespace via api.NamespaceHeader.
+func WithNamespace(namespace string) Option {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// WithHeader adds a header to every request, e.g. Authorization.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithToken authenticates every request with a bearer API token or JWT.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// New creates a client for the server at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		header:     make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// do sends a request with an optional JSON body. A 2xx response is decoded into out: as JSON,
+// or as text when out is a *string. Other responses are returned as *Error.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	resp, err := c.send(ctx, method, path, query, body, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch out := out.(type) {
+	case nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil
+	case *string:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+		*out = string(data)
+
+		return nil
+	default:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// send returns the response of a successful request; the caller closes its body.
+func (c *Client) send(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body any,
+	header http.Header,
+) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	for key, values := range c.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	for key, values := range header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.namespace != "" {
+		req.Header.Set(api.NamespaceHeader, c.namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer func() { _ = resp.Body.Close() }()
+
+		return nil, newError(resp)
+	}
+
+	return resp, nil
+}
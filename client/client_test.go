@@ -0,0 +1,316 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+	"github.com/rom8726/floxy-pro/plugins/api/cancel"
+	"github.com/rom8726/floxy-pro/plugins/api/dlq"
+	humandecision "github.com/rom8726/floxy-pro/plugins/api/human-decision"
+)
+
+func TestClient_CoversOpenAPISpec(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `yaml:"operationId"`
+			Client      *bool  `yaml:"x-floxy-client"`
+		}
+	}
+	require.NoError(t, yaml.Unmarshal(api.OpenAPISpec, &spec))
+
+	clientType := reflect.TypeOf(&Client{})
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			if op.OperationID == "" || (op.Client != nil && !*op.Client) {
+				continue
+			}
+
+			name := strings.ToUpper(op.OperationID[:1]) + op.OperationID[1:]
+			_, ok := clientType.MethodByName(name)
+			assert.True(t, ok, "no Client.%s for %s %s", name, strings.ToUpper(method), path)
+		}
+	}
+}
+
+func newTestStore(t *testing.T) (*floxy.MemoryStore, []int64) {
+	t.Helper()
+
+	ctx := context.Background()
+	store := floxy.NewMemoryStore()
+
+	def := &floxy.WorkflowDefinition{
+		ID:      "orders-v1",
+		Name:    "orders",
+		Version: 1,
+		Definition: floxy.GraphDefinition{
+			Start: "charge",
+			Steps: map[string]*floxy.StepDefinition{
+				"charge": {Name: "charge", Type: floxy.StepTypeTask, Handler: "charge", Next: []string{"ship"}},
+				"ship":   {Name: "ship", Type: floxy.StepTypeTask, Handler: "ship"},
+			},
+		},
+	}
+	require.NoError(t, store.SaveWorkflowDefinition(ctx, def))
+
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		instance, err := store.CreateInstance(ctx, def.ID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		ids = append(ids, instance.ID)
+	}
+	require.NoError(t, store.SetInstanceLabels(ctx, ids[1], map[string]string{"customer": "42"}))
+	require.NoError(t, store.SetInstanceLabels(ctx, ids[3], map[string]string{"customer": "42"}))
+
+	return store, ids
+}
+
+func TestClient_CoreRoutes(t *testing.T) {
+	ctx := context.Background()
+	store, ids := newTestStore(t)
+
+	server := httptest.NewServer(api.New(nil, store).Mux())
+	defer server.Close()
+	c := New(server.URL)
+
+	definition, err := c.GetWorkflow(ctx, "orders-v1")
+	require.NoError(t, err)
+	assert.Equal(t, "charge", definition.Definition.Start)
+
+	graph, err := c.GetWorkflowGraph(ctx, "orders-v1", GraphFormatDOT)
+	require.NoError(t, err)
+	assert.Contains(t, graph, `"charge" -> "ship"`)
+
+	instance, err := c.GetInstance(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, "orders-v1", instance.WorkflowID)
+
+	page, err := c.ListInstances(ctx, PageRequest{PageSize: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, int64(5), page.Total)
+
+	result, err := c.SearchInstances(ctx, floxy.InstanceQuery{Labels: map[string]string{"customer": "42"}})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, ids[3], result.Items[0].ID)
+
+	var searched []int64
+	for instance, err := range c.AllInstances(ctx, floxy.InstanceQuery{SortOrder: floxy.SortOrderAsc, Limit: 2}) {
+		require.NoError(t, err)
+		searched = append(searched, instance.ID)
+	}
+	assert.Equal(t, ids, searched)
+
+	var listed []int64
+	for instance, err := range c.AllWorkflowInstances(ctx, "orders-v1", 2) {
+		require.NoError(t, err)
+		listed = append(listed, instance.ID)
+	}
+	assert.ElementsMatch(t, ids, listed)
+
+	_, err = c.GetInstance(ctx, 999)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.True(t, errors.Is(err, floxy.ErrEntityNotFound))
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "workflow instance not found", apiErr.Message)
+
+	_, err = c.SearchInstances(ctx, floxy.InstanceQuery{Cursor: "garbage"})
+	assert.True(t, IsBadRequest(err))
+}
+
+func TestClient_Namespace(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+
+	nsCtx := floxy.WithNamespace(ctx, "team-a")
+	def := &floxy.WorkflowDefinition{
+		ID:         "team-a-flow-v1",
+		Name:       "team-a-flow",
+		Version:    1,
+		Definition: floxy.GraphDefinition{Start: "a", Steps: map[string]*floxy.StepDefinition{}},
+	}
+	require.NoError(t, store.SaveWorkflowDefinition(nsCtx, def))
+
+	server := httptest.NewServer(api.New(nil, store).Mux())
+	defer server.Close()
+
+	definitions, err := New(server.URL, WithNamespace("team-a")).ListWorkflows(ctx)
+	require.NoError(t, err)
+	require.Len(t, definitions, 1)
+	assert.Equal(t, def.ID, definitions[0].ID)
+
+	definitions, err = New(server.URL).ListWorkflows(ctx)
+	require.NoError(t, err)
+	assert.Len(t, definitions, 2)
+
+	scoped := httptest.NewServer(api.New(nil, store, api.WithRequiredNamespace()).Mux())
+	defer scoped.Close()
+
+	_, err = New(scoped.URL).ListWorkflows(ctx)
+	assert.True(t, IsBadRequest(err))
+	definitions, err = New(scoped.URL, WithNamespace("team-a")).ListWorkflows(ctx)
+	require.NoError(t, err)
+	assert.Len(t, definitions, 1)
+}
+
+func TestClient_PluginRoutes(t *testing.T) {
+	ctx := context.Background()
+	store, ids := newTestStore(t)
+	engine := floxy.NewMockIEngine(t)
+	extractUser := func(r *http.Request) (string, error) { return r.Header.Get("X-User"), nil }
+
+	for i, id := range ids {
+		require.NoError(t, store.CreateDeadLetterRecord(ctx, &floxy.DeadLetterRecord{
+			InstanceID: id,
+			WorkflowID: "orders-v1",
+			StepID:     int64(100 + i),
+			StepName:   "charge",
+			StepType:   string(floxy.StepTypeTask),
+			Input:      json.RawMessage(`{}`),
+		}))
+	}
+
+	server := httptest.NewServer(api.New(nil, store, api.WithPlugins(
+		cancel.New(engine, extractUser),
+		dlq.New(engine, store, extractUser),
+		humandecision.New(engine, store, extractUser),
+	)).Mux())
+	defer server.Close()
+	c := New(server.URL, WithHeader("X-User", "alice"))
+
+	engine.On("CancelWorkflow", mock.Anything, ids[0], "alice", "duplicate").Return(nil).Once()
+	require.NoError(t, c.CancelInstance(ctx, ids[0], "duplicate"))
+
+	engine.On("CancelWorkflow", mock.Anything, ids[1], "alice", "duplicate").
+		Return(errors.New("workflow is already in terminal state")).Once()
+	err := c.CancelInstance(ctx, ids[1], "duplicate")
+	assert.True(t, IsConflict(err))
+
+	err = c.ConfirmDecision(ctx, ids[2], "ok")
+	assert.True(t, IsNotFound(err))
+
+	newInput := json.RawMessage(`{"retry":true}`)
+	engine.On("RequeueFromDLQ", mock.Anything, int64(1), &newInput).Return(nil).Once()
+	require.NoError(t, c.RequeueDeadLetter(ctx, 1, newInput))
+
+	var steps []int64
+	for item, err := range c.AllDeadLetters(ctx, floxy.DeadLetterFilter{WorkflowID: "orders-v1"}, 2) {
+		require.NoError(t, err)
+		steps = append(steps, item.StepID)
+	}
+	assert.ElementsMatch(t, []int64{100, 101, 102, 103, 104}, steps)
+}
+
+func TestClient_StreamInstanceEvents(t *testing.T) {
+	ctx := context.Background()
+	store, ids := newTestStore(t)
+
+	for _, eventType := range []string{floxy.EventWorkflowStarted, floxy.EventStepStarted, floxy.EventStepCompleted} {
+		require.NoError(t, store.LogEvent(ctx, ids[0], nil, eventType, map[string]any{"n": 1}))
+	}
+
+	server := httptest.NewServer(api.New(nil, store).Mux())
+	defer server.Close()
+	c := New(server.URL)
+
+	errDone := errors.New("done")
+	var received []string
+	err := c.StreamInstanceEvents(ctx, ids[0], StreamOptions{}, func(event floxy.WorkflowEvent) error {
+		received = append(received, event.EventType)
+		if len(received) == 3 {
+			return errDone
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, errDone)
+	assert.Equal(t, []string{floxy.EventWorkflowStarted, floxy.EventStepStarted, floxy.EventStepCompleted}, received)
+
+	err = c.StreamInstanceEvents(ctx, 999, StreamOptions{}, func(floxy.WorkflowEvent) error { return nil })
+	assert.True(t, IsNotFound(err))
+}
+
+func TestReadEventStream_SkipsRepeatedEvents(t *testing.T) {
+	// A resumed stream repeats events after the position, a late commit arrives out of order
+	body := "retry: 3000\n\n" +
+		"id: 0\ndata: {\"id\":2}\n\n" +
+		"id: 0\ndata: {\"id\":1}\n\n" +
+		"id: 0\ndata: {\"id\":2}\n\n" +
+		": keepalive\n\n" +
+		"id: 2\n\n" +
+		"id: 2\ndata: {\"id\":3}\n\n"
+
+	cursor := &StreamCursor{}
+	var received []int64
+	err := readEventStream(strings.NewReader(body), cursor, func(event floxy.WorkflowEvent) error {
+		received = append(received, event.ID)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 1, 3}, received)
+
+	position, ok := cursor.Position()
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), position)
+
+	// Reconnecting with the cursor skips what it already received
+	received = nil
+	err = readEventStream(strings.NewReader("id: 2\ndata: {\"id\":3}\n\nid: 2\ndata: {\"id\":4}\n\n"), cursor,
+		func(event floxy.WorkflowEvent) error {
+			received = append(received, event.ID)
+
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, received)
+}
+
+func TestClient_Auth(t *testing.T) {
+	ctx := context.Background()
+	store, ids := newTestStore(t)
+	engine := floxy.NewMockIEngine(t)
+
+	authenticator := api.NewTokenAuthenticator(map[string]api.Principal{
+		"viewer-token":   {Subject: "rita", Roles: []string{"viewer"}},
+		"operator-token": {Subject: "oscar", Roles: []string{"operator"}},
+	})
+	policy := &api.Policy{Roles: map[string]api.Role{
+		"viewer":   {Permissions: []api.Permission{api.PermissionRead}},
+		"operator": {Permissions: []api.Permission{api.PermissionRead, api.PermissionOperate}},
+	}}
+
+	server := httptest.NewServer(api.New(nil, store,
+		api.WithAuth(authenticator, policy),
+		api.WithPlugins(cancel.New(engine, nil)),
+	).Mux())
+	defer server.Close()
+
+	_, err := New(server.URL).GetInstance(ctx, ids[0])
+	assert.True(t, IsUnauthorized(err))
+
+	_, err = New(server.URL, WithToken("viewer-token")).GetInstance(ctx, ids[0])
+	require.NoError(t, err)
+
+	err = New(server.URL, WithToken("viewer-token")).CancelInstance(ctx, ids[0], "duplicate")
+	assert.True(t, IsForbidden(err))
+
+	engine.On("CancelWorkflow", mock.Anything, ids[0], "oscar", "duplicate").Return(nil).Once()
+	require.NoError(t, New(server.URL, WithToken("operator-token")).CancelInstance(ctx, ids[0], "duplicate"))
+}
@@ -0,0 +1,269 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+)
+
+type GraphFormat string
+
+const (
+	GraphFormatMermaid GraphFormat = "mermaid"
+	GraphFormatDOT     GraphFormat = "dot"
+)
+
+// PageRequest selects a page of the page-numbered lists. Zero values use the server
+// defaults: page 1 of 20 items.
+type PageRequest struct {
+	Page     int
+	PageSize int
+}
+
+func (p PageRequest) values() url.Values {
+	values := make(url.Values)
+	if p.Page > 0 {
+		values.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize > 0 {
+		values.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	return values
+}
+
+func (c *Client) ListWorkflows(ctx context.Context) ([]floxy.WorkflowDefinition, error) {
+	var definitions []floxy.WorkflowDefinition
+	if err := c.do(ctx, "GET", "/api/workflows", nil, nil, &definitions); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+func (c *Client) GetWorkflow(ctx context.Context, workflowID string) (*floxy.WorkflowDefinition, error) {
+	var definition floxy.WorkflowDefinition
+	if err := c.do(ctx, "GET", "/api/workflows/"+url.PathEscape(workflowID), nil, nil, &definition); err != nil {
+		return nil, err
+	}
+
+	return &definition, nil
+}
+
+func (c *Client) ListWorkflowInstances(
+	ctx context.Context,
+	workflowID string,
+	page PageRequest,
+) (*api.PaginatedInstancesResponse, error) {
+	var resp api.PaginatedInstancesResponse
+	path := "/api/workflows/" + url.PathEscape(workflowID) + "/instances"
+	if err := c.do(ctx, "GET", path, page.values(), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetWorkflowGraph renders the definition as Mermaid or Graphviz DOT source.
+func (c *Client) GetWorkflowGraph(ctx context.Context, workflowID string, format GraphFormat) (string, error) {
+	var graph string
+	path := "/api/workflows/" + url.PathEscape(workflowID) + "/graph"
+	if err := c.do(ctx, "GET", path, graphValues(format), nil, &graph); err != nil {
+		return "", err
+	}
+
+	return graph, nil
+}
+
+// ListInstances returns a page of all instances, newest first. Use SearchInstances to filter.
+func (c *Client) ListInstances(ctx context.Context, page PageRequest) (*api.PaginatedInstancesResponse, error) {
+	var resp api.PaginatedInstancesResponse
+	if err := c.do(ctx, "GET", "/api/instances", page.values(), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SearchInstances returns a page of the instances matching the query. Pass NextCursor of
+// the result as the Cursor of the query to get the next page, or use AllInstances.
+func (c *Client) SearchInstances(ctx context.Context, query floxy.InstanceQuery) (*floxy.InstanceSearchResult, error) {
+	var result floxy.InstanceSearchResult
+	if err := c.do(ctx, "GET", "/api/instances", instanceQueryValues(query), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) ListActiveInstances(ctx context.Context) ([]floxy.ActiveWorkflowInstance, error) {
+	var instances []floxy.ActiveWorkflowInstance
+	if err := c.do(ctx, "GET", "/api/instances/active", nil, nil, &instances); err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+func (c *Client) GetInstance(ctx context.Context, instanceID int64) (*floxy.WorkflowInstance, error) {
+	var instance floxy.WorkflowInstance
+	if err := c.do(ctx, "GET", instancePath(instanceID), nil, nil, &instance); err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+func (c *Client) GetInstanceSteps(ctx context.Context, instanceID int64) ([]floxy.WorkflowStep, error) {
+	var steps []floxy.WorkflowStep
+	if err := c.do(ctx, "GET", instancePath(instanceID)+"/steps", nil, nil, &steps); err != nil {
+		return nil, err
+	}
+
+	return steps, nil
+}
+
+func (c *Client) GetInstanceEvents(ctx context.Context, instanceID int64) ([]floxy.WorkflowEvent, error) {
+	var events []floxy.WorkflowEvent
+	if err := c.do(ctx, "GET", instancePath(instanceID)+"/events", nil, nil, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetInstanceGraph renders the workflow of the instance with its steps colored by status.
+func (c *Client) GetInstanceGraph(ctx context.Context, instanceID int64, format GraphFormat) (string, error) {
+	var graph string
+	if err := c.do(ctx, "GET", instancePath(instanceID)+"/graph", graphValues(format), nil, &graph); err != nil {
+		return "", err
+	}
+
+	return graph, nil
+}
+
+func (c *Client) GetInstanceTimeline(ctx context.Context, instanceID int64) (*floxy.Timeline, error) {
+	var timeline floxy.Timeline
+	if err := c.do(ctx, "GET", instancePath(instanceID)+"/timeline", nil, nil, &timeline); err != nil {
+		return nil, err
+	}
+
+	return &timeline, nil
+}
+
+func (c *Client) GetStats(ctx context.Context) ([]floxy.WorkflowStats, error) {
+	var stats []floxy.WorkflowStats
+	if err := c.do(ctx, "GET", "/api/stats", nil, nil, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (c *Client) GetSummaryStats(ctx context.Context) (*floxy.SummaryStats, error) {
+	var stats floxy.SummaryStats
+	if err := c.do(ctx, "GET", "/api/stats/summary", nil, nil, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func (c *Client) ListWorkers(ctx context.Context) ([]floxy.WorkerRecord, error) {
+	var workers []floxy.WorkerRecord
+	if err := c.do(ctx, "GET", "/api/workers", nil, nil, &workers); err != nil {
+		return nil, err
+	}
+
+	return workers, nil
+}
+
+// GetWorker returns a worker by its ID or by the attempted_by of a queue item.
+func (c *Client) GetWorker(ctx context.Context, workerID string) (*floxy.WorkerRecord, error) {
+	var worker floxy.WorkerRecord
+	// Worker IDs may contain slashes, which the route matches as a path remainder
+	segments := strings.Split(workerID, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	if err := c.do(ctx, "GET", "/api/workers/"+strings.Join(segments, "/"), nil, nil, &worker); err != nil {
+		return nil, err
+	}
+
+	return &worker, nil
+}
+
+func instancePath(instanceID int64) string {
+	return "/api/instances/" + strconv.FormatInt(instanceID, 10)
+}
+
+func graphValues(format GraphFormat) url.Values {
+	values := make(url.Values)
+	if format != "" {
+		values.Set("format", string(format))
+	}
+
+	return values
+}
+
+// instanceQueryValues encodes the query with the parameters of api.ParseInstanceQuery. The sort
+// parameter is always set, so that the server searches even for an empty query.
+func instanceQueryValues(query floxy.InstanceQuery) url.Values {
+	values := make(url.Values)
+
+	if len(query.Statuses) > 0 {
+		statuses := make([]string, len(query.Statuses))
+		for i, status := range query.Statuses {
+			statuses[i] = string(status)
+		}
+		values.Set("status", strings.Join(statuses, ","))
+	}
+	setNonEmpty(values, "workflow_id", query.WorkflowID)
+	setNonEmpty(values, "workflow_name", query.WorkflowName)
+	setTime(values, "created_from", query.CreatedFrom)
+	setTime(values, "created_to", query.CreatedTo)
+	setTime(values, "updated_from", query.UpdatedFrom)
+	setTime(values, "updated_to", query.UpdatedTo)
+
+	keys := make([]string, 0, len(query.Labels))
+	for key := range query.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values.Add("label", key+":"+query.Labels[key])
+	}
+
+	setNonEmpty(values, "error", query.ErrorContains)
+
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = floxy.InstanceSortByCreatedAt
+	}
+	values.Set("sort", string(sortBy))
+	setNonEmpty(values, "order", string(query.SortOrder))
+	setNonEmpty(values, "cursor", query.Cursor)
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	return values
+}
+
+func setNonEmpty(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+func setTime(values url.Values, key string, t *time.Time) {
+	if t != nil {
+		values.Set(key, t.Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+)
+
+// Error is a non-2xx response, with the message written by api.WriteErrorResponse.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("floxy api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is makes a 404 match floxy.ErrEntityNotFound, as the server maps it the other way around.
+func (e *Error) Is(target error) bool {
+	return e.StatusCode == http.StatusNotFound && target == floxy.ErrEntityNotFound
+}
+
+// StatusCode returns the HTTP status of an *Error in the chain of err, or 0.
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
+func IsNotFound(err error) bool { return StatusCode(err) == http.StatusNotFound }
+
+func IsBadRequest(err error) bool { return StatusCode(err) == http.StatusBadRequest }
+
+// IsConflict reports a request that the current state does not allow, e.g. cancelling a
+// finished instance or deciding a step that does not wait for a decision.
+func IsConflict(err error) bool { return StatusCode(err) == http.StatusConflict }
+
+func IsUnauthorized(err error) bool { return StatusCode(err) == http.StatusUnauthorized }
+
+func IsForbidden(err error) bool { return StatusCode(err) == http.StatusForbidden }
+
+func newError(resp *http.Response) *Error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var errResp api.ErrorResponse
+	if err := json.Unmarshal(data, &errResp); err == nil && errResp.Message != "" {
+		apiErr.Message = errResp.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	return apiErr
+}
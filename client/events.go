@@ -0,0 +1,190 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// StreamOptions filter an event stream.
+type StreamOptions struct {
+	// EventTypes restricts the stream to these event types.
+	EventTypes []string
+	// LastEventID resumes the stream after this event. When nil, an instance stream replays
+	// the whole history and the stream of all instances starts with new events.
+	LastEventID *int64
+	// WorkflowID restricts the stream of all instances to one workflow.
+	WorkflowID string
+	// Cursor, when set, keeps the resume position across calls: once the cursor has one, it
+	// replaces LastEventID, and events repeated after the resume are skipped.
+	Cursor *StreamCursor
+}
+
+// StreamCursor tracks the resume position a stream reports in its SSE ids and the events
+// received after it. The server re-reads recent events so that events committed late are not
+// lost, which repeats events after a resume; the cursor skips them by event ID.
+// The zero value has no position.
+type StreamCursor struct {
+	mu          sync.Mutex
+	position    int64
+	hasPosition bool
+	seen        map[int64]struct{}
+}
+
+// Position returns the resume position, if the stream has reported one.
+func (c *StreamCursor) Position() (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.position, c.hasPosition
+}
+
+func (c *StreamCursor) setPosition(position int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.position = position
+	c.hasPosition = true
+	for id := range c.seen {
+		if id <= position {
+			delete(c.seen, id)
+		}
+	}
+}
+
+// markSeen reports whether the event is new and remembers it.
+func (c *StreamCursor) markSeen(id int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hasPosition && id <= c.position {
+		return false
+	}
+	if _, ok := c.seen[id]; ok {
+		return false
+	}
+	if c.seen == nil {
+		c.seen = make(map[int64]struct{})
+	}
+	c.seen[id] = struct{}{}
+
+	return true
+}
+
+// StreamInstanceEvents calls fn for each event of the instance until ctx is done, the server
+// closes the stream or fn returns an error, which is returned.
+func (c *Client) StreamInstanceEvents(
+	ctx context.Context,
+	instanceID int64,
+	opts StreamOptions,
+	fn func(floxy.WorkflowEvent) error,
+) error {
+	return c.stream(ctx, instancePath(instanceID)+"/events/stream", opts, fn)
+}
+
+// StreamEvents calls fn for each event of all instances until ctx is done, the server
+// closes the stream or fn returns an error, which is returned.
+func (c *Client) StreamEvents(ctx context.Context, opts StreamOptions, fn func(floxy.WorkflowEvent) error) error {
+	return c.stream(ctx, "/api/events/stream", opts, fn)
+}
+
+func (c *Client) stream(ctx context.Context, path string, opts StreamOptions, fn func(floxy.WorkflowEvent) error) error {
+	values := make(url.Values)
+	setNonEmpty(values, "workflow_id", opts.WorkflowID)
+	if len(opts.EventTypes) > 0 {
+		values.Set("event_type", strings.Join(opts.EventTypes, ","))
+	}
+
+	cursor := opts.Cursor
+	if cursor == nil {
+		cursor = &StreamCursor{}
+	}
+
+	header := http.Header{"Accept": []string{"text/event-stream"}}
+	if position, ok := cursor.Position(); ok {
+		header.Set("Last-Event-ID", strconv.FormatInt(position, 10))
+	} else if opts.LastEventID != nil {
+		header.Set("Last-Event-ID", strconv.FormatInt(*opts.LastEventID, 10))
+	}
+
+	resp, err := c.send(ctx, "GET", path, values, nil, header)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	err = readEventStream(resp.Body, cursor, fn)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// readEventStream decodes the data of each Server-Sent Events message as a WorkflowEvent and
+// records the message id as the resume position of the cursor. Comments (heartbeats) and
+// retry fields are skipped, events the cursor has seen are not passed to fn.
+func readEventStream(body io.Reader, cursor *StreamCursor, fn func(floxy.WorkflowEvent) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+
+	var data strings.Builder
+	var id string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			var event floxy.WorkflowEvent
+			if data.Len() > 0 {
+				if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+					return fmt.Errorf("decode event: %w", err)
+				}
+			}
+			isNew := data.Len() > 0 && cursor.markSeen(event.ID)
+			data.Reset()
+
+			// Like EventSource, a message without data still moves the position
+			if position, err := strconv.ParseInt(id, 10, 64); err == nil {
+				cursor.setPosition(position)
+			}
+			id = ""
+
+			if isNew {
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, "id:"); ok {
+			id = strings.TrimPrefix(value, " ")
+
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+	}
+
+	if err := scanner.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("read event stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/plugins/api/dlq"
+)
+
+// AllInstances iterates over all instances matching the query, following next_cursor.
+// The iteration stops after the first error, which is yielded with a zero instance.
+func (c *Client) AllInstances(ctx context.Context, query floxy.InstanceQuery) iter.Seq2[floxy.WorkflowInstance, error] {
+	return func(yield func(floxy.WorkflowInstance, error) bool) {
+		for {
+			result, err := c.SearchInstances(ctx, query)
+			if err != nil {
+				yield(floxy.WorkflowInstance{}, err)
+
+				return
+			}
+
+			for _, instance := range result.Items {
+				if !yield(instance, nil) {
+					return
+				}
+			}
+
+			if result.NextCursor == "" || len(result.Items) == 0 {
+				return
+			}
+			query.Cursor = result.NextCursor
+		}
+	}
+}
+
+// AllWorkflowInstances iterates over all instances of a workflow, pageSize per request
+// (server default when zero).
+func (c *Client) AllWorkflowInstances(
+	ctx context.Context,
+	workflowID string,
+	pageSize int,
+) iter.Seq2[floxy.WorkflowInstance, error] {
+	return func(yield func(floxy.WorkflowInstance, error) bool) {
+		page := PageRequest{Page: 1, PageSize: pageSize}
+		for {
+			resp, err := c.ListWorkflowInstances(ctx, workflowID, page)
+			if err != nil {
+				yield(floxy.WorkflowInstance{}, err)
+
+				return
+			}
+
+			for _, instance := range resp.Items {
+				if !yield(instance, nil) {
+					return
+				}
+			}
+
+			if !hasNextPage(resp.Page, resp.PageSize, len(resp.Items), resp.Total) {
+				return
+			}
+			page.Page++
+		}
+	}
+}
+
+// AllDeadLetters iterates over all DLQ records matching the filter, pageSize per request
+// (server default when zero).
+func (c *Client) AllDeadLetters(
+	ctx context.Context,
+	filter floxy.DeadLetterFilter,
+	pageSize int,
+) iter.Seq2[dlq.DeadLetterResponse, error] {
+	return func(yield func(dlq.DeadLetterResponse, error) bool) {
+		page := PageRequest{Page: 1, PageSize: pageSize}
+		for {
+			resp, err := c.ListDeadLetters(ctx, filter, page)
+			if err != nil {
+				yield(dlq.DeadLetterResponse{}, err)
+
+				return
+			}
+
+			for _, item := range resp.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !hasNextPage(resp.Page, resp.PageSize, len(resp.Items), resp.Total) {
+				return
+			}
+			page.Page++
+		}
+	}
+}
+
+func hasNextPage(page, pageSize, items int, total int64) bool {
+	return items > 0 && int64(page)*int64(pageSize) < total
+}
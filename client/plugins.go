@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/plugins/api/abort"
+	"github.com/rom8726/floxy-pro/plugins/api/bulk"
+	"github.com/rom8726/floxy-pro/plugins/api/cancel"
+	"github.com/rom8726/floxy-pro/plugins/api/cleanup"
+	"github.com/rom8726/floxy-pro/plugins/api/dashboard"
+	"github.com/rom8726/floxy-pro/plugins/api/dlq"
+	humandecision "github.com/rom8726/floxy-pro/plugins/api/human-decision"
+	"github.com/rom8726/floxy-pro/plugins/api/skip"
+)
+
+// CancelInstance cancels the instance and rolls back its completed steps (cancel plugin).
+func (c *Client) CancelInstance(ctx context.Context, instanceID int64, reason string) error {
+	return c.do(ctx, "POST", instancePath(instanceID)+"/cancel", nil, cancel.CancelRequest{Reason: reason}, nil)
+}
+
+// AbortInstance stops the instance without rollback (abort plugin).
+func (c *Client) AbortInstance(ctx context.Context, instanceID int64, reason string) error {
+	return c.do(ctx, "POST", instancePath(instanceID)+"/abort", nil, abort.AbortRequest{Reason: reason}, nil)
+}
+
+// ConfirmDecision confirms the human step of the instance with an optional comment
+// (human-decision plugin).
+func (c *Client) ConfirmDecision(ctx context.Context, instanceID int64, message string) error {
+	path := instancePath(instanceID) + "/make-decision/confirm"
+
+	return c.do(ctx, "POST", path, nil, humandecision.DecisionRequest{Message: message}, nil)
+}
+
+// RejectDecision rejects the human step of the instance with an optional comment
+// (human-decision plugin).
+func (c *Client) RejectDecision(ctx context.Context, instanceID int64, message string) error {
+	path := instancePath(instanceID) + "/make-decision/reject"
+
+	return c.do(ctx, "POST", path, nil, humandecision.DecisionRequest{Message: message}, nil)
+}
+
+// SkipStep completes a stuck step with the given output (skip plugin).
+func (c *Client) SkipStep(ctx context.Context, stepID int64, output json.RawMessage, reason string) error {
+	path := "/api/steps/" + strconv.FormatInt(stepID, 10) + "/skip"
+
+	return c.do(ctx, "POST", path, nil, skip.SkipRequest{Output: output, Reason: reason}, nil)
+}
+
+// ListDeadLetters returns a page of the DLQ records matching the filter (dlq plugin).
+func (c *Client) ListDeadLetters(
+	ctx context.Context,
+	filter floxy.DeadLetterFilter,
+	page PageRequest,
+) (*dlq.ListResponse, error) {
+	values := page.values()
+	setNonEmpty(values, "workflow_id", filter.WorkflowID)
+	setNonEmpty(values, "step_name", filter.StepName)
+	setTime(values, "created_from", filter.CreatedFrom)
+	setTime(values, "created_to", filter.CreatedTo)
+	setNonEmpty(values, "error", filter.ErrorContains)
+
+	var resp dlq.ListResponse
+	if err := c.do(ctx, "GET", "/api/dlq", values, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetDeadLetter(ctx context.Context, id int64) (*dlq.DeadLetterResponse, error) {
+	var resp dlq.DeadLetterResponse
+	if err := c.do(ctx, "GET", deadLetterPath(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// RequeueDeadLetter requeues the failed step of a DLQ record. A nil newInput keeps the
+// original input of the step.
+func (c *Client) RequeueDeadLetter(ctx context.Context, id int64, newInput json.RawMessage) error {
+	var req dlq.RequeueRequest
+	if newInput != nil {
+		req.NewInput = &newInput
+	}
+
+	return c.do(ctx, "POST", deadLetterPath(id)+"/requeue", nil, req, nil)
+}
+
+func (c *Client) DiscardDeadLetter(ctx context.Context, id int64, reason string) error {
+	return c.do(ctx, "POST", deadLetterPath(id)+"/discard", nil, dlq.DiscardRequest{Reason: reason}, nil)
+}
+
+// BulkRequeueDeadLetters starts a bulk job requeueing the instances of the matched DLQ records.
+// Poll it with GetBulkOperation.
+func (c *Client) BulkRequeueDeadLetters(ctx context.Context, req dlq.BulkRequeueRequest) (*floxy.BulkJob, error) {
+	var job floxy.BulkJob
+	if err := c.do(ctx, "POST", "/api/dlq/requeue", nil, req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// StartBulkOperation starts a bulk cancel, abort, requeue or retry job (bulk plugin).
+func (c *Client) StartBulkOperation(ctx context.Context, req bulk.BulkRequest) (*floxy.BulkJob, error) {
+	var job floxy.BulkJob
+	if err := c.do(ctx, "POST", "/api/bulk", nil, req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (c *Client) GetBulkOperation(ctx context.Context, jobID string) (*floxy.BulkJob, error) {
+	var job floxy.BulkJob
+	if err := c.do(ctx, "GET", "/api/bulk/"+url.PathEscape(jobID), nil, nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (c *Client) CancelBulkOperation(ctx context.Context, jobID string) error {
+	return c.do(ctx, "POST", "/api/bulk/"+url.PathEscape(jobID)+"/cancel", nil, nil, nil)
+}
+
+// Cleanup deletes old finished instances (cleanup plugin). The server refuses it for
+// namespaced clients.
+func (c *Client) Cleanup(ctx context.Context) (*cleanup.CleanupResponse, error) {
+	var resp cleanup.CleanupResponse
+	if err := c.do(ctx, "POST", "/api/cleanup", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListApprovals returns the human steps of active instances that wait for a decision,
+// oldest first (dashboard plugin).
+func (c *Client) ListApprovals(ctx context.Context) ([]dashboard.Approval, error) {
+	var resp dashboard.ApprovalsResponse
+	if err := c.do(ctx, "GET", "/api/dashboard/approvals", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Items, nil
+}
+
+func deadLetterPath(id int64) string {
+	return "/api/dlq/" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,286 @@
+package floxy
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the engine, workers and stores: timestamps, scheduled_at of
+// delayed steps and retry backoff, queue aging, leases, heartbeats, step timeouts and polling.
+// The shutdown timeout and PostgreSQL reconnects always use real time.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// SystemClock is the real time clock used by default.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTicker(d time.Duration) Ticker { return systemTicker{time.NewTicker(d)} }
+
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+
+type systemTicker struct{ ticker *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.ticker.C }
+
+func (t systemTicker) Stop() { t.ticker.Stop() }
+
+type systemTimer struct{ timer *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.timer.C }
+
+func (t systemTimer) Stop() bool { return t.timer.Stop() }
+
+// StoreClock returns the clock of the store, or SystemClock for a store that has none.
+func StoreClock(store Store) Clock {
+	if clocked, ok := store.(interface{ Clock() Clock }); ok {
+		return clocked.Clock()
+	}
+
+	return SystemClock
+}
+
+// StoreOption configures NewStore, NewMemoryStore and the SQLite store constructors.
+type StoreOption func(opts *storeOptions)
+
+type storeOptions struct {
+	clock Clock
+}
+
+// WithStoreClock sets the clock of the store, used for scheduled_at of queue items, queue aging
+// and the timestamps it writes.
+func WithStoreClock(clock Clock) StoreOption {
+	return func(opts *storeOptions) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
+
+func applyStoreOptions(opts []StoreOption) storeOptions {
+	options := storeOptions{clock: SystemClock}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// withClockTimeout is context.WithTimeout measured on the clock. Err of the returned context is
+// context.DeadlineExceeded once the clock passes the deadline, like for context.WithTimeout.
+func withClockTimeout(parent context.Context, clock Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := clock.(systemClock); ok {
+		return context.WithTimeout(parent, d)
+	}
+
+	ctx := &clockTimeoutContext{
+		Context:  parent,
+		deadline: clock.Now().Add(d),
+		done:     make(chan struct{}),
+	}
+
+	timer := clock.NewTimer(d)
+	stop := make(chan struct{})
+	go func() {
+		defer timer.Stop()
+
+		select {
+		case <-parent.Done():
+			ctx.finish(parent.Err())
+		case <-timer.C():
+			ctx.finish(context.DeadlineExceeded)
+		case <-stop:
+		}
+	}()
+
+	var stopOnce sync.Once
+
+	return ctx, func() {
+		stopOnce.Do(func() { close(stop) })
+		ctx.finish(context.Canceled)
+	}
+}
+
+type clockTimeoutContext struct {
+	context.Context
+
+	deadline time.Time
+	done     chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func (ctx *clockTimeoutContext) Deadline() (time.Time, bool) {
+	if deadline, ok := ctx.Context.Deadline(); ok && deadline.Before(ctx.deadline) {
+		return deadline, true
+	}
+
+	return ctx.deadline, true
+}
+
+func (ctx *clockTimeoutContext) Done() <-chan struct{} {
+	return ctx.done
+}
+
+func (ctx *clockTimeoutContext) Err() error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	return ctx.err
+}
+
+func (ctx *clockTimeoutContext) finish(err error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if ctx.err == nil {
+		ctx.err = err
+		close(ctx.done)
+	}
+}
+
+// ManualClock is a Clock for tests that moves only when advanced. Timers and tickers fire
+// on Advance and Set; like time.Ticker, a ticker drops ticks a slow receiver does not take.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*manualWaiter
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Advance moves the clock forward by d and fires the timers and tickers due meanwhile.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	now := c.now.Add(d)
+	c.mu.Unlock()
+
+	c.Set(now)
+}
+
+// Set moves the clock to t and fires the timers and tickers due by then.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t
+
+	// Fire in due order, so that receivers observe timers in the order they expire
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].at.Before(c.waiters[j].at)
+	})
+
+	active := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.stopped {
+			continue
+		}
+
+		if !w.at.After(t) {
+			select {
+			case w.c <- t:
+			default:
+			}
+
+			if w.period <= 0 {
+				w.stopped = true
+
+				continue
+			}
+			for !w.at.After(t) {
+				w.at = w.at.Add(w.period)
+			}
+		}
+
+		active = append(active, w)
+	}
+	c.waiters = active
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("floxy: non-positive interval for NewTicker")
+	}
+
+	return manualTicker{c.addWaiter(d, d)}
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	w := c.addWaiter(d, 0)
+	if d <= 0 {
+		c.Set(c.Now())
+	}
+
+	return w
+}
+
+func (c *ManualClock) addWaiter(d, period time.Duration) *manualWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &manualWaiter{
+		clock:  c,
+		c:      make(chan time.Time, 1),
+		at:     c.now.Add(d),
+		period: period,
+	}
+	c.waiters = append(c.waiters, w)
+
+	return w
+}
+
+type manualWaiter struct {
+	clock   *ManualClock
+	c       chan time.Time
+	at      time.Time
+	period  time.Duration
+	stopped bool
+}
+
+func (w *manualWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *manualWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+
+	wasActive := !w.stopped
+	w.stopped = true
+
+	return wasActive
+}
+
+type manualTicker struct{ waiter *manualWaiter }
+
+func (t manualTicker) C() <-chan time.Time { return t.waiter.C() }
+
+func (t manualTicker) Stop() { t.waiter.Stop() }
@@ -0,0 +1,110 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	timer := clock.NewTimer(time.Minute)
+	ticker := clock.NewTicker(20 * time.Second)
+	stopped := clock.NewTimer(time.Second)
+	assert.True(t, stopped.Stop())
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), clock.Now())
+	assert.Len(t, ticker.C(), 1)
+	assert.Empty(t, timer.C())
+	<-ticker.C()
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-timer.C())
+	assert.Len(t, ticker.C(), 1)
+	assert.False(t, timer.Stop())
+	assert.Empty(t, stopped.C())
+
+	ticker.Stop()
+	<-ticker.C()
+	clock.Advance(time.Hour)
+	assert.Empty(t, ticker.C())
+}
+
+func TestWithClockTimeout(t *testing.T) {
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	ctx, cancel := withClockTimeout(context.Background(), clock, time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, clock.Now().Add(time.Minute), deadline)
+	assert.NoError(t, ctx.Err())
+
+	clock.Advance(time.Minute)
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+	ctx, cancel = withClockTimeout(context.Background(), clock, time.Minute)
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestEngine_ManualClockDelaysSteps(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := NewMemoryStore(WithStoreClock(clock))
+
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+		WithEngineClock(clock),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	handler := &countingHandler{}
+	engine.RegisterHandler(handler)
+
+	wf, err := NewBuilder("delayed", 1).
+		Step("first", "counting").
+		Then("second", "counting", WithStepDelay(time.Hour)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, wf))
+
+	instanceID, err := engine.Start(ctx, wf.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	runAll := func() {
+		for {
+			empty, err := engine.ExecuteNext(ctx, "worker")
+			require.NoError(t, err)
+			if empty {
+				return
+			}
+		}
+	}
+
+	runAll()
+	assert.EqualValues(t, 1, handler.calls.Load())
+
+	clock.Advance(59 * time.Minute)
+	runAll()
+	assert.EqualValues(t, 1, handler.calls.Load())
+
+	clock.Advance(time.Minute)
+	runAll()
+	assert.EqualValues(t, 2, handler.calls.Load())
+
+	instance, err := store.GetInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, instance.Status)
+	assert.Equal(t, clock.Now(), *instance.CompletedAt)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -14,9 +15,11 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
 	"gopkg.in/yaml.v3"
 
 	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/grpcapi"
 	"github.com/rom8726/floxy-pro/internal/floxyd"
 	"github.com/rom8726/floxy-pro/internal/handlers"
 )
@@ -108,7 +111,16 @@ func main() {
 		log.Printf("Registered handler: %s", handlerDef.Name)
 	}
 
-	workerPool := floxy.NewWorkerPool(engine, config.Workers, config.WorkerInterval)
+	poolOpts := []floxy.WorkerPoolOption{
+		floxy.WithPoolVersion(version),
+		floxy.WithHeartbeatInterval(config.HeartbeatInterval),
+		floxy.WithWorkerStaleTimeout(config.WorkerStaleTimeout),
+	}
+	if config.MaxWorkers > 0 {
+		poolOpts = append(poolOpts, floxy.WithAutoscaling(config.MinWorkers, config.MaxWorkers))
+	}
+
+	workerPool := floxy.NewWorkerPool(engine, config.Workers, config.WorkerInterval, poolOpts...)
 
 	workerCtx, workerCancel := context.WithCancel(ctx)
 	defer workerCancel()
@@ -125,8 +137,19 @@ func main() {
 
 	go startTechServer(techServerCtx, pool)
 
-	log.Printf("Floxyd started with %d workers", config.Workers)
+	var grpcServer *grpc.Server
+	if config.GRPCAddr != "" {
+		grpcServer, err = startGRPCServer(config.GRPCAddr, config.GRPCAllowUnscoped, engine, pool)
+		if err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}
+
+	log.Printf("Floxyd started with %d workers", workerPool.Size())
 	log.Printf("Tech server started on port 8081 (metrics: http://localhost:8081/metrics, health: http://localhost:8081/health)")
+	if grpcServer != nil {
+		log.Printf("gRPC server started on %s", config.GRPCAddr)
+	}
 	log.Println("Press Ctrl+C to stop")
 
 	sigCh := make(chan os.Signal, 1)
@@ -135,7 +158,17 @@ func main() {
 	<-sigCh
 	log.Println("Shutting down...")
 
-	workerPool.Stop()
+	if grpcServer != nil {
+		stopGRPCServer(grpcServer, 5*time.Second)
+	}
+
+	// Finish the steps in progress without claiming new ones
+	drainCtx, drainCancel := context.WithTimeout(ctx, config.DrainTimeout)
+	if err := workerPool.Drain(drainCtx); err != nil {
+		log.Printf("Drain interrupted: %v", err)
+	}
+	drainCancel()
+
 	workerCancel()
 	statsCancel()
 	techServerCancel()
@@ -266,6 +299,47 @@ func startTechServer(ctx context.Context, pool *pgxpool.Pool) {
 	server.Shutdown(shutdownCtx)
 }
 
+func startGRPCServer(addr string, allowUnscoped bool, engine *floxy.Engine, pool *pgxpool.Pool) (*grpc.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	var scopeOpts []grpcapi.NamespaceOption
+	if allowUnscoped {
+		scopeOpts = append(scopeOpts, grpcapi.WithUnscopedCalls())
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcapi.NamespaceUnaryInterceptor(scopeOpts...)),
+		grpc.ChainStreamInterceptor(grpcapi.NamespaceStreamInterceptor(scopeOpts...)),
+	)
+	grpcapi.New(engine, floxy.NewStore(pool)).Register(server)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Printf("gRPC server error: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+// stopGRPCServer waits for in-flight calls and closes the rest, event streams included, after timeout.
+func stopGRPCServer(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		server.Stop()
+	}
+}
+
 func printBanner() {
 	banner := `
 ███████╗██╗      ██████╗ ██╗  ██╗██╗   ██╗██████╗ 
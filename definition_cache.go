@@ -0,0 +1,165 @@
+package floxy
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultDefinitionCacheTTL = 10 * time.Minute
+
+// definitionCacheInvalidator is implemented by stores keeping their own definition cache,
+// which must be dropped together with the engine cache when another node saves a definition.
+type definitionCacheInvalidator interface {
+	InvalidateDefinitionCache(id string)
+	ClearDefinitionCache()
+}
+
+type cachedDefinition struct {
+	def       *WorkflowDefinition
+	graph     *graphIndex
+	expiresAt time.Time
+}
+
+// definitionCache keeps decoded workflow definitions with their graph index by ID.
+// Entries are dropped by RegisterWorkflow, by definition notifications of other nodes
+// and, as a fallback for lost notifications, after the TTL.
+type definitionCache struct {
+	ttl   time.Duration
+	clock Clock
+
+	mu      sync.RWMutex
+	entries map[string]*cachedDefinition
+	// generation is bumped by every invalidation, so that a load racing with it is not cached
+	generation uint64
+}
+
+func newDefinitionCache(ttl time.Duration, clock Clock) *definitionCache {
+	return &definitionCache{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[string]*cachedDefinition),
+	}
+}
+
+func (c *definitionCache) get(
+	ctx context.Context,
+	id string,
+	load func(ctx context.Context, id string) (*WorkflowDefinition, error),
+) (*cachedDefinition, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[id]
+	generation := c.generation
+	c.mu.RUnlock()
+
+	if ok && c.clock.Now().Before(entry.expiresAt) {
+		if !visibleIn(ctx, entry.def.Namespace) {
+			return nil, ErrEntityNotFound
+		}
+
+		return entry, nil
+	}
+
+	def, err := load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &cachedDefinition{
+		def:       def,
+		graph:     buildGraphIndex(def),
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+
+	if c.ttl > 0 {
+		c.mu.Lock()
+		if c.generation == generation {
+			c.entries[id] = entry
+		}
+		c.mu.Unlock()
+	}
+
+	return entry, nil
+}
+
+// lookup returns the cached entry of exactly this definition value, if any.
+func (c *definitionCache) lookup(def *WorkflowDefinition) *cachedDefinition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if entry, ok := c.entries[def.ID]; ok && entry.def == def {
+		return entry
+	}
+
+	return nil
+}
+
+func (c *definitionCache) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	delete(c.entries, id)
+}
+
+func (c *definitionCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	clear(c.entries)
+}
+
+// getDefinition returns the workflow definition from the engine cache, loading it from the store on a miss.
+func (engine *Engine) getDefinition(ctx context.Context, id string) (*WorkflowDefinition, error) {
+	entry, err := engine.definitions.get(ctx, id, engine.store.GetWorkflowDefinition)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.def, nil
+}
+
+// graphOf returns the graph index of a definition, built on the fly for definitions that do not
+// come from the cache.
+func (engine *Engine) graphOf(def *WorkflowDefinition) *graphIndex {
+	if entry := engine.definitions.lookup(def); entry != nil {
+		return entry.graph
+	}
+
+	return buildGraphIndex(def)
+}
+
+// invalidateDefinition drops a definition from the engine cache and from the store cache.
+// An empty id drops all definitions.
+func (engine *Engine) invalidateDefinition(id string) {
+	invalidator, _ := engine.store.(definitionCacheInvalidator)
+
+	if id == "" {
+		engine.definitions.clear()
+		if invalidator != nil {
+			invalidator.ClearDefinitionCache()
+		}
+
+		return
+	}
+
+	engine.definitions.invalidate(id)
+	if invalidator != nil {
+		invalidator.InvalidateDefinitionCache(id)
+	}
+}
+
+// definitionInvalidationWorker drops cached definitions saved by other nodes.
+func (engine *Engine) definitionInvalidationWorker(changes <-chan string, unsubscribe func()) {
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-engine.shutdownCtx.Done():
+			return
+		case id := <-changes:
+			engine.invalidateDefinition(id)
+		}
+	}
+}
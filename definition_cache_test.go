@@ -0,0 +1,115 @@
+package floxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitionCache(t *testing.T) {
+	ctx := context.Background()
+	def := &WorkflowDefinition{ID: "wf-v1", Namespace: "team-a", Definition: GraphDefinition{Steps: map[string]*StepDefinition{}}}
+
+	loads := 0
+	load := func(context.Context, string) (*WorkflowDefinition, error) {
+		loads++
+
+		return def, nil
+	}
+
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := newDefinitionCache(time.Minute, clock)
+
+	entry, err := cache.get(ctx, def.ID, load)
+	require.NoError(t, err)
+	assert.Same(t, def, entry.def)
+	assert.NotNil(t, entry.graph)
+
+	_, err = cache.get(WithNamespace(ctx, "team-a"), def.ID, load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loads)
+	assert.Same(t, entry, cache.lookup(def))
+
+	_, err = cache.get(WithNamespace(ctx, "team-b"), def.ID, load)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	cache.invalidate(def.ID)
+	assert.Nil(t, cache.lookup(def))
+	_, err = cache.get(ctx, def.ID, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+
+	clock.Advance(time.Minute)
+	_, err = cache.get(ctx, def.ID, load)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loads)
+
+	// A load racing with an invalidation is not cached
+	racing := func(ctx context.Context, id string) (*WorkflowDefinition, error) {
+		cache.clear()
+
+		return load(ctx, id)
+	}
+	cache.clear()
+	_, err = cache.get(ctx, def.ID, racing)
+	require.NoError(t, err)
+	assert.Nil(t, cache.lookup(def))
+
+	disabled := newDefinitionCache(0, SystemClock)
+	_, err = disabled.get(ctx, def.ID, load)
+	require.NoError(t, err)
+	assert.Nil(t, disabled.lookup(def))
+}
+
+func TestDefinitionCache_InvalidatedByOtherEngine(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	newEngine := func() *Engine {
+		engine := NewEngine(nil,
+			WithEngineStore(store),
+			WithEngineTxManager(NewMemoryTxManager()),
+		)
+		t.Cleanup(func() { _ = engine.Shutdown() })
+
+		return engine
+	}
+	reader, writer := newEngine(), newEngine()
+
+	wf, err := NewBuilder("cached", 1).Step("first", "first").Build()
+	require.NoError(t, err)
+	require.NoError(t, writer.RegisterWorkflow(ctx, wf))
+
+	cached, err := reader.getDefinition(ctx, wf.ID)
+	require.NoError(t, err)
+	require.Same(t, wf, cached)
+
+	changed, err := NewBuilder("cached", 1).Step("first", "first").Then("second", "second").Build()
+	require.NoError(t, err)
+	require.NoError(t, writer.RegisterWorkflow(ctx, changed))
+
+	assert.Eventually(t, func() bool {
+		def, err := reader.getDefinition(ctx, wf.ID)
+
+		return err == nil && len(def.Definition.Steps) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestBuildGraphIndex(t *testing.T) {
+	graph := buildGraphIndex(buildForkJoinDef())
+
+	assert.Equal(t, "F", graph.parallelFork["A"])
+	assert.Equal(t, "", graph.parallelFork["A1"])
+	assert.Equal(t, "F", graph.forkOfBranch("T"))
+	assert.Equal(t, "F", graph.forkOfBranch("B1"))
+	assert.Equal(t, "", graph.forkOfBranch("F"))
+	assert.Equal(t, "J", graph.forkJoin["F"])
+
+	assert.True(t, graph.isDescendantOf("T", "A"))
+	assert.True(t, graph.isDescendantOf("T", "T"))
+	assert.False(t, graph.isDescendantOf("T", "B"))
+	assert.False(t, graph.isDescendantOf("unknown", "unknown"))
+}
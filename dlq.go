@@ -0,0 +1,217 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDLQRedriveInterval = 5 * time.Second
+	dlqRedriveBatchSize       = 100
+)
+
+// DeadLetterFilter narrows ListDeadLetters. Zero-valued fields do not filter.
+// The time range is [CreatedFrom, CreatedTo).
+type DeadLetterFilter struct {
+	WorkflowID    string
+	StepName      string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	ErrorContains string // case-insensitive substring of the record error
+}
+
+func (f *DeadLetterFilter) matches(rec *DeadLetterRecord) bool {
+	if f.WorkflowID != "" && rec.WorkflowID != f.WorkflowID {
+		return false
+	}
+	if f.StepName != "" && rec.StepName != f.StepName {
+		return false
+	}
+	if f.CreatedFrom != nil && rec.CreatedAt.Before(*f.CreatedFrom) {
+		return false
+	}
+	if f.CreatedTo != nil && !rec.CreatedAt.Before(*f.CreatedTo) {
+		return false
+	}
+	if f.ErrorContains != "" {
+		if rec.Error == nil ||
+			!strings.Contains(strings.ToLower(*rec.Error), strings.ToLower(f.ErrorContains)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DiscardFromDLQ gives up on a DLQ record: the step is marked as failed, completed steps are
+// compensated up to the nearest save point (or root) and the instance fails.
+// Remaining DLQ records of the instance are discarded as well.
+func (engine *Engine) DiscardFromDLQ(ctx context.Context, dlqID int64, discardedBy, reason string) error {
+	ctx = engine.scope(ctx)
+
+	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		rec, err := engine.store.GetDeadLetterByID(ctx, dlqID)
+		if err != nil {
+			return fmt.Errorf("get dead letter: %w", err)
+		}
+
+		instance, err := engine.store.GetInstance(ctx, rec.InstanceID)
+		if err != nil {
+			return fmt.Errorf("get instance: %w", err)
+		}
+
+		records, err := engine.store.GetDeadLettersByInstance(ctx, instance.ID)
+		if err != nil {
+			return fmt.Errorf("get dead letters: %w", err)
+		}
+
+		for _, other := range records {
+			if err := engine.store.DeleteDeadLetter(ctx, other.ID); err != nil {
+				return fmt.Errorf("delete dead letter %d: %w", other.ID, err)
+			}
+
+			_ = engine.store.LogEvent(ctx, instance.ID, &other.StepID, EventDLQDiscarded, map[string]any{
+				KeyDLQID:       other.ID,
+				KeyStepName:    other.StepName,
+				KeyRequestedBy: discardedBy,
+				KeyReason:      reason,
+			})
+		}
+
+		// Compensation records of an already failed instance: there is nothing left to fail
+		if engine.isTerminalStatus(instance.Status) && instance.Status != StatusDLQ {
+			return nil
+		}
+
+		step, err := engine.store.GetStepByID(ctx, rec.StepID)
+		if err != nil {
+			return fmt.Errorf("get step: %w", err)
+		}
+
+		def, err := engine.getDefinition(ctx, instance.WorkflowID)
+		if err != nil {
+			return fmt.Errorf("get workflow definition: %w", err)
+		}
+
+		if err := engine.store.UpdateStep(ctx, step.ID, StepStatusFailed, nil, rec.Error); err != nil {
+			return fmt.Errorf("update step: %w", err)
+		}
+		step.Status = StepStatusFailed
+
+		errMsg := fmt.Sprintf("Discarded from DLQ by %s: %s", discardedBy, reason)
+
+		return engine.failWorkflow(ctx, instance, step, def, errMsg)
+	})
+}
+
+// requeueDeadLetter requeues the record's step and logs the transition with the given event type.
+func (engine *Engine) requeueDeadLetter(
+	ctx context.Context,
+	rec *DeadLetterRecord,
+	newInput *json.RawMessage,
+	eventType string,
+) error {
+	taskQueue := DefaultTaskQueue
+	if def, err := engine.getDefinition(ctx, rec.WorkflowID); err == nil {
+		if stepDef, ok := def.Definition.Steps[rec.StepName]; ok {
+			taskQueue = taskQueueOf(stepDef)
+		}
+	}
+
+	if err := engine.store.RequeueDeadLetter(ctx, rec.ID, taskQueue, newInput); err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		KeyDLQID:    rec.ID,
+		KeyStepName: rec.StepName,
+	}
+	if eventType == EventDLQRedriven {
+		payload[KeyRedriveCount] = rec.RedriveCount + 1
+	}
+	_ = engine.store.LogEvent(ctx, rec.InstanceID, &rec.StepID, eventType, payload)
+
+	return nil
+}
+
+// scheduleRedrive fills the redrive state of a new DLQ record according to the workflow policy.
+// Redrives already done for the step are counted from its EventDLQRedriven events.
+func (engine *Engine) scheduleRedrive(ctx context.Context, rec *DeadLetterRecord, policy *RedrivePolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	events, err := engine.store.GetWorkflowEvents(ctx, rec.InstanceID)
+	if err != nil {
+		return fmt.Errorf("get workflow events: %w", err)
+	}
+
+	for _, event := range events {
+		if event.EventType == EventDLQRedriven && event.StepID != nil && *event.StepID == rec.StepID {
+			rec.RedriveCount++
+		}
+	}
+
+	if rec.RedriveCount < policy.MaxRedrives {
+		next := engine.clock.Now().Add(CalculateRetryDelay(policy.Strategy, policy.Backoff, rec.RedriveCount+1))
+		rec.NextRedriveAt = &next
+	}
+
+	return nil
+}
+
+func (engine *Engine) logDeadLetterCreated(ctx context.Context, rec *DeadLetterRecord) {
+	payload := map[string]any{
+		KeyDLQID:        rec.ID,
+		KeyStepName:     rec.StepName,
+		KeyReason:       rec.Reason,
+		KeyRedriveCount: rec.RedriveCount,
+	}
+	if rec.NextRedriveAt != nil {
+		payload[KeyNextRedriveAt] = rec.NextRedriveAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	_ = engine.store.LogEvent(ctx, rec.InstanceID, &rec.StepID, EventDLQCreated, payload)
+}
+
+func (engine *Engine) dlqRedriveWorker() {
+	ticker := engine.clock.NewTicker(engine.dlqRedriveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-engine.shutdownCh:
+			return
+		case <-ticker.C():
+			engine.processDeadLetterRedrives(engine.shutdownCtx)
+		}
+	}
+}
+
+// processDeadLetterRedrives requeues DLQ records whose redrive backoff has elapsed.
+// Each record is requeued in its own transaction; records already requeued or
+// discarded elsewhere are skipped.
+func (engine *Engine) processDeadLetterRedrives(ctx context.Context) {
+	records, err := engine.store.GetDueDeadLetters(ctx, engine.clock.Now(), dlqRedriveBatchSize)
+	if err != nil {
+		slog.Error("[floxy] get due dead letters failed", "error", err)
+
+		return
+	}
+
+	for i := range records {
+		rec := &records[i]
+
+		err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+			return engine.requeueDeadLetter(ctx, rec, nil, EventDLQRedriven)
+		})
+		if err != nil && !errors.Is(err, ErrEntityNotFound) {
+			slog.Warn("[floxy] dlq redrive failed", "dlq_id", rec.ID, "error", err)
+		}
+	}
+}
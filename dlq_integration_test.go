@@ -560,7 +560,7 @@ func TestDLQ_ForkJoinParallelFlow(t *testing.T) {
 // Helper function to get DLQ records for an instance
 func getDLQRecordsForInstance(ctx context.Context, store Store, instanceID int64) ([]DeadLetterRecord, error) {
 	// Get all DLQ records and filter by instanceID
-	allRecords, _, err := store.ListDeadLetters(ctx, 0, 1000)
+	allRecords, _, err := store.ListDeadLetters(ctx, DeadLetterFilter{}, 0, 1000)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,204 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDLQTestEngine(t *testing.T, store Store) (*Engine, *bulkFlakyHandler) {
+	t.Helper()
+
+	handler := &bulkFlakyHandler{}
+	engine := newMemoryTestEngine(t, store, []StepHandler{handler, &SimpleTestHandler{}},
+		// Redrives are triggered explicitly via processDeadLetterRedrives
+		WithDLQRedriveInterval(0),
+	)
+
+	return engine, handler
+}
+
+func countEvents(t *testing.T, ctx context.Context, store Store, instanceID int64, eventType string) int {
+	t.Helper()
+
+	events, err := store.GetWorkflowEvents(ctx, instanceID)
+	require.NoError(t, err)
+
+	var count int
+	for _, event := range events {
+		if event.EventType == eventType {
+			count++
+		}
+	}
+
+	return count
+}
+
+// testDLQRedrivePolicy runs a redrive policy against a permanently failing step and checks
+// the redrive limit, the emitted events and the ListDeadLetters filters.
+func testDLQRedrivePolicy(t *testing.T, store Store) {
+	ctx := context.Background()
+	engine, _ := newDLQTestEngine(t, store)
+
+	workflowDef, err := NewBuilder("dlq_redrive", 1,
+		WithDLQEnabled(true),
+		WithRedrivePolicy(2, 10*time.Millisecond, RetryStrategyFixed),
+	).
+		Step("call", "bulk-flaky", WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	startedAt := time.Now().Add(-time.Second)
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	for redrive := 0; redrive <= 2; redrive++ {
+		drainQueue(t, ctx, engine)
+
+		records, err := store.GetDeadLettersByInstance(ctx, instanceID)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, redrive, records[0].RedriveCount)
+
+		if redrive < 2 {
+			require.NotNil(t, records[0].NextRedriveAt, "redrive %d", redrive)
+		} else {
+			assert.Nil(t, records[0].NextRedriveAt)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		engine.processDeadLetterRedrives(ctx)
+	}
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDLQ, status)
+
+	assert.Equal(t, 3, countEvents(t, ctx, store, instanceID, EventDLQCreated))
+	assert.Equal(t, 2, countEvents(t, ctx, store, instanceID, EventDLQRedriven))
+
+	future := time.Now().Add(time.Hour)
+	cases := []struct {
+		name   string
+		filter DeadLetterFilter
+		total  int64
+	}{
+		{"no filter", DeadLetterFilter{}, 1},
+		{"workflow", DeadLetterFilter{WorkflowID: workflowDef.ID}, 1},
+		{"other workflow", DeadLetterFilter{WorkflowID: "missing"}, 0},
+		{"step", DeadLetterFilter{StepName: "call"}, 1},
+		{"error case-insensitive", DeadLetterFilter{ErrorContains: "OUTAGE"}, 1},
+		{"other error", DeadLetterFilter{ErrorContains: "timeout"}, 0},
+		{"time range", DeadLetterFilter{CreatedFrom: &startedAt, CreatedTo: &future}, 1},
+		{"before range", DeadLetterFilter{CreatedTo: &startedAt}, 0},
+	}
+
+	for _, tc := range cases {
+		records, total, err := store.ListDeadLetters(ctx, tc.filter, 0, 10)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.total, total, tc.name)
+		assert.Len(t, records, int(tc.total), tc.name)
+	}
+}
+
+func TestDLQRedrivePolicy_MemoryStore(t *testing.T) {
+	testDLQRedrivePolicy(t, NewMemoryStore())
+}
+
+func TestDLQRedrivePolicy_RecoversAfterOutage(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine, handler := newDLQTestEngine(t, store)
+
+	workflowDef, err := NewBuilder("dlq_recover", 1,
+		WithDLQEnabled(true),
+		WithRedrivePolicy(3, 10*time.Millisecond, RetryStrategyExponential),
+	).
+		Step("call", "bulk-flaky", WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	// Not due yet
+	engine.processDeadLetterRedrives(ctx)
+	records, err := store.GetDeadLettersByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	handler.healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+	engine.processDeadLetterRedrives(ctx)
+	drainQueue(t, ctx, engine)
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+	assert.Equal(t, 1, countEvents(t, ctx, store, instanceID, EventDLQRedriven))
+}
+
+func TestDiscardFromDLQ_FailsWithCompensation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine, _ := newDLQTestEngine(t, store)
+
+	workflowDef, err := NewBuilder("dlq_discard", 1, WithDLQEnabled(true)).
+		Step("reserve", "simple-test").
+		OnFailure("release", "simple-test").
+		Then("call", "bulk-flaky", WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	records, err := store.GetDeadLettersByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	dlqID := records[0].ID
+	require.NoError(t, engine.DiscardFromDLQ(ctx, dlqID, "operator", "customer refunded"))
+
+	drainQueue(t, ctx, engine)
+
+	instance, err := store.GetInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, instance.Status)
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StepStatusRolledBack, findStepByName(steps, "reserve").Status)
+
+	records, err = store.GetDeadLettersByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	events, err := store.GetWorkflowEvents(ctx, instanceID)
+	require.NoError(t, err)
+	var discarded *WorkflowEvent
+	for i := range events {
+		if events[i].EventType == EventDLQDiscarded {
+			discarded = &events[i]
+		}
+	}
+	require.NotNil(t, discarded)
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(discarded.Payload, &payload))
+	assert.Equal(t, "operator", payload[KeyRequestedBy])
+	assert.Equal(t, "customer refunded", payload[KeyReason])
+
+	err = engine.DiscardFromDLQ(ctx, dlqID, "operator", "again")
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
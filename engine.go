@@ -8,9 +8,12 @@ import (
 	"log/slog"
 	"math/rand"
 	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -36,6 +39,7 @@ type Engine struct {
 	shutdownCtx    context.Context
 	shutdownCancel context.CancelFunc
 	activeSteps    sync.WaitGroup
+	inFlight       atomic.Int64 // handler runs in progress, reported by the worker registry
 	isShuttingDown bool
 	shutdownMu     sync.RWMutex
 
@@ -50,6 +54,36 @@ type Engine struct {
 	missingHandlerJitterPct   float64
 	skipLogMu                 sync.Mutex
 	skipLogNextAllowed        map[string]time.Time
+
+	// Bulk operations
+	bulkJobs          map[string]*bulkJob
+	bulkJobsMu        sync.RWMutex
+	bulkRatePerSecond float64
+
+	dlqRedriveInterval time.Duration
+
+	// Push-based wakeup of workers and awaiters; nil means polling only
+	notifier               Notifier
+	notifyFallbackInterval time.Duration
+
+	// Visibility timeout of claimed queue items, renewed while handlers run
+	leaseDuration       time.Duration
+	leaseReaperInterval time.Duration
+	leaseReaperID       string
+
+	// Namespace the engine is bound to ("" for all) and running instance limits per namespace
+	namespace       string
+	namespaceLimits map[string]int
+
+	// Decoded workflow definitions with their graph indexes
+	definitions        *definitionCache
+	definitionCacheTTL time.Duration
+
+	// RegisterWorkflow rejects definitions with lint warnings, except those of the ignored rules
+	lintOnRegister bool
+	lintIgnore     []LintRule
+
+	clock Clock
 }
 
 // StartAwaitResult contains the result of StartAwait operation.
@@ -78,14 +112,55 @@ func NewEngine(pool *pgxpool.Pool, opts ...EngineOption) *Engine {
 		missingHandlerLogThrottle: 5 * time.Second,
 		missingHandlerJitterPct:   0.2,
 		skipLogNextAllowed:        make(map[string]time.Time),
+		bulkJobs:                  make(map[string]*bulkJob),
+		bulkRatePerSecond:         defaultBulkRatePerSecond,
+		dlqRedriveInterval:        defaultDLQRedriveInterval,
+		notifyFallbackInterval:    defaultNotifyFallbackInterval,
+		leaseDuration:             defaultLeaseDuration,
+		leaseReaperInterval:       defaultLeaseReaperInterval,
+		leaseReaperID:             "lease-reaper-" + uuid.NewString(),
+		namespaceLimits:           make(map[string]int),
+		definitionCacheTTL:        defaultDefinitionCacheTTL,
+		clock:                     SystemClock,
 	}
 
 	for _, opt := range opts {
 		opt(engine)
 	}
 
+	engine.definitions = newDefinitionCache(engine.definitionCacheTTL, engine.clock)
+
+	// Background workers see only the namespace of a bound engine
+	engine.shutdownCtx = engine.scope(engine.shutdownCtx)
+
+	if engine.notifier == nil {
+		if notifier, ok := engine.store.(Notifier); ok {
+			engine.notifier = notifier
+		} else if store, ok := engine.store.(*StoreImpl); ok {
+			if storePool, ok := store.db.(*pgxpool.Pool); ok && storePool != nil {
+				notifier := newPGNotifier(storePool)
+				go notifier.run(engine.shutdownCtx)
+				engine.notifier = notifier
+			}
+		}
+	}
+
 	go engine.cancelRequestsWorker()
 
+	if engine.notifier != nil && engine.definitionCacheTTL > 0 {
+		// Subscribed before returning, so that no definition saved after NewEngine is missed
+		changes, unsubscribe := engine.notifier.Subscribe(NotifyChannelDefinition)
+		go engine.definitionInvalidationWorker(changes, unsubscribe)
+	}
+
+	if engine.dlqRedriveInterval > 0 {
+		go engine.dlqRedriveWorker()
+	}
+
+	if engine.leaseReaperInterval > 0 {
+		go engine.leaseReaperWorker()
+	}
+
 	return engine
 }
 
@@ -115,7 +190,7 @@ func (engine *Engine) shouldLogSkip(key string) bool {
 		return true
 	}
 
-	now := time.Now()
+	now := engine.clock.Now()
 
 	engine.skipLogMu.Lock()
 	defer engine.skipLogMu.Unlock()
@@ -147,18 +222,39 @@ func (engine *Engine) RegisterPlugin(plugin Plugin) {
 }
 
 func (engine *Engine) RegisterWorkflow(ctx context.Context, def *WorkflowDefinition) error {
+	ctx = engine.scope(ctx)
+
 	if err := engine.validateDefinition(def); err != nil {
 		return fmt.Errorf("invalid workflow definition: %w", err)
 	}
 
-	return engine.store.SaveWorkflowDefinition(ctx, def)
+	if engine.lintOnRegister {
+		if warnings := engine.LintWorkflow(def, LintOptions{Ignore: engine.lintIgnore}); len(warnings) > 0 {
+			return lintError(warnings)
+		}
+	}
+
+	if err := engine.store.SaveWorkflowDefinition(ctx, def); err != nil {
+		return err
+	}
+
+	engine.definitions.invalidate(def.ID)
+
+	return nil
 }
 
 // RequeueFromDLQ extracts a record from the DLQ and re-enqueues its step.
 // If newInput is non-nil, it will be used as the step input before enqueueing.
 func (engine *Engine) RequeueFromDLQ(ctx context.Context, dlqID int64, newInput *json.RawMessage) error {
+	ctx = engine.scope(ctx)
+
 	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
-		if err := engine.store.RequeueDeadLetter(ctx, dlqID, newInput); err != nil {
+		rec, err := engine.store.GetDeadLetterByID(ctx, dlqID)
+		if err != nil {
+			return fmt.Errorf("get dead letter: %w", err)
+		}
+
+		if err := engine.requeueDeadLetter(ctx, rec, newInput, EventDLQRequeued); err != nil {
 			return fmt.Errorf("requeue dead letter: %w", err)
 		}
 
@@ -166,11 +262,23 @@ func (engine *Engine) RequeueFromDLQ(ctx context.Context, dlqID int64, newInput
 	})
 }
 
-func (engine *Engine) Start(ctx context.Context, workflowID string, input json.RawMessage) (int64, error) {
+func (engine *Engine) Start(
+	ctx context.Context,
+	workflowID string,
+	input json.RawMessage,
+	opts ...StartOption,
+) (int64, error) {
+	ctx = engine.scope(ctx)
+
+	var startOpts startOptions
+	for _, opt := range opts {
+		opt(&startOpts)
+	}
+
 	var instanceID int64
 
 	err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
-		def, err := engine.store.GetWorkflowDefinition(ctx, workflowID)
+		def, err := engine.getDefinition(ctx, workflowID)
 		if err != nil {
 			return fmt.Errorf("get workflow definition: %w", err)
 		}
@@ -179,11 +287,26 @@ func (engine *Engine) Start(ctx context.Context, workflowID string, input json.R
 			return fmt.Errorf("invalid workflow definition: %w", err)
 		}
 
+		if err := engine.checkNamespaceLimit(ctx, def.Namespace); err != nil {
+			return err
+		}
+
 		instance, err := engine.store.CreateInstance(ctx, workflowID, input)
 		if err != nil {
 			return fmt.Errorf("create instance: %w", err)
 		}
 
+		if len(startOpts.labels) > 0 {
+			if err := engine.store.SetInstanceLabels(ctx, instance.ID, startOpts.labels); err != nil {
+				return fmt.Errorf("set instance labels: %w", err)
+			}
+
+			instance, err = engine.store.GetInstance(ctx, instance.ID)
+			if err != nil {
+				return fmt.Errorf("get instance: %w", err)
+			}
+		}
+
 		// PLUGIN HOOK: OnWorkflowStart
 		if engine.pluginManager != nil {
 			if err := engine.pluginManager.ExecuteWorkflowStart(ctx, instance); err != nil {
@@ -219,11 +342,35 @@ func (engine *Engine) Start(ctx context.Context, workflowID string, input json.R
 	return instanceID, nil
 }
 
+// SetInstanceLabels merges labels into the instance labels, overwriting existing keys.
+func (engine *Engine) SetInstanceLabels(ctx context.Context, instanceID int64, labels map[string]string) error {
+	ctx = engine.scope(ctx)
+
+	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		if err := engine.checkInstanceNamespace(ctx, instanceID); err != nil {
+			return fmt.Errorf("get instance: %w", err)
+		}
+
+		if err := engine.store.SetInstanceLabels(ctx, instanceID, labels); err != nil {
+			return fmt.Errorf("set instance labels: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // StartAwait starts a workflow and waits for its completion.
 // The method blocks until the workflow reaches a terminal state
 // (completed, failed, cancelled, aborted, or dlq) or the context is cancelled.
-func (engine *Engine) StartAwait(ctx context.Context, workflowID string, input json.RawMessage) (*StartAwaitResult, error) {
-	instanceID, err := engine.Start(ctx, workflowID, input)
+func (engine *Engine) StartAwait(
+	ctx context.Context,
+	workflowID string,
+	input json.RawMessage,
+	opts ...StartOption,
+) (*StartAwaitResult, error) {
+	ctx = engine.scope(ctx)
+
+	instanceID, err := engine.Start(ctx, workflowID, input, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("start workflow: %w", err)
 	}
@@ -237,30 +384,53 @@ func (engine *Engine) StartAwait(ctx context.Context, workflowID string, input j
 }
 
 // awaitCompletion waits for the workflow instance to reach a terminal state.
+// With a notifier the instance is re-read on its status changes and polled only as a fallback.
 func (engine *Engine) awaitCompletion(ctx context.Context, instanceID int64) (*StartAwaitResult, error) {
-	ticker := time.NewTicker(engine.awaitPollInterval)
+	pollInterval := engine.awaitPollInterval
+	var statusChanges <-chan string
+	if engine.notifier != nil {
+		var unsubscribe func()
+		statusChanges, unsubscribe = engine.notifier.Subscribe(NotifyChannelInstance)
+		defer unsubscribe()
+
+		pollInterval = max(pollInterval, engine.notifyFallbackInterval)
+	}
+
+	ticker := engine.clock.NewTicker(pollInterval)
 	defer ticker.Stop()
 
+	// The instance may have finished before the subscription
+	checkNow := engine.notifier != nil
+	instanceIDStr := strconv.FormatInt(instanceID, 10)
+
 	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-engine.shutdownCh:
-			return nil, errors.New("engine shutdown")
-		case <-ticker.C:
-			instance, err := engine.store.GetInstance(ctx, instanceID)
-			if err != nil {
-				return nil, fmt.Errorf("get instance: %w", err)
+		if !checkNow {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-engine.shutdownCh:
+				return nil, errors.New("engine shutdown")
+			case payload := <-statusChanges:
+				if payload != instanceIDStr {
+					continue
+				}
+			case <-ticker.C():
 			}
+		}
+		checkNow = false
 
-			if engine.isTerminalStatus(instance.Status) {
-				return &StartAwaitResult{
-					InstanceID: instance.ID,
-					Status:     instance.Status,
-					Output:     instance.Output,
-					Error:      instance.Error,
-				}, nil
-			}
+		instance, err := engine.store.GetInstance(ctx, instanceID)
+		if err != nil {
+			return nil, fmt.Errorf("get instance: %w", err)
+		}
+
+		if engine.isTerminalStatus(instance.Status) {
+			return &StartAwaitResult{
+				InstanceID: instance.ID,
+				Status:     instance.Status,
+				Output:     instance.Output,
+				Error:      instance.Error,
+			}, nil
 		}
 	}
 }
@@ -315,11 +485,28 @@ func (engine *Engine) Shutdown(timeoutOpt ...time.Duration) error {
 	return shutdownErr
 }
 
+// ExecuteNext claims the next queue item and executes its step. The item is claimed and leased
+// in a short transaction; task and compensation handlers run after it is committed, and their
+// results are recorded in a second transaction only while the lease is still held.
+// Only items of the default task queue and of the engine's TaskQueues are claimed.
 func (engine *Engine) ExecuteNext(ctx context.Context, workerID string) (empty bool, err error) {
+	return engine.executeNext(ctx, workerID, engine.pollQueues(nil))
+}
+
+func (engine *Engine) executeNext(ctx context.Context, workerID string, taskQueues []string) (empty bool, err error) {
+	ctx = engine.scope(ctx)
+
 	if engine.isShutdown() {
 		return true, nil
 	}
 
+	engine.activeSteps.Add(1)
+	defer engine.activeSteps.Done()
+
+	var (
+		item *QueueItem
+		run  *stepRun
+	)
 	err = engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
 		if engine.isShutdown() {
 			empty = true
@@ -327,7 +514,8 @@ func (engine *Engine) ExecuteNext(ctx context.Context, workerID string) (empty b
 			return nil
 		}
 
-		item, err := engine.store.DequeueStep(ctx, workerID)
+		var err error
+		item, err = engine.store.DequeueStep(ctx, workerID, taskQueues)
 		if err != nil {
 			return fmt.Errorf("dequeue step: %w", err)
 		}
@@ -345,160 +533,218 @@ func (engine *Engine) ExecuteNext(ctx context.Context, workerID string) (empty b
 			return nil
 		}
 
-		engine.activeSteps.Add(1)
-		defer engine.activeSteps.Done()
+		if err := engine.leaseQueueItem(ctx, item, workerID); err != nil {
+			return err
+		}
 
-		removeFromQueue := true
-		defer func() {
-			if removeFromQueue {
-				_ = engine.store.RemoveFromQueue(ctx, item.ID)
-			}
-		}()
+		run, err = engine.executeQueueItem(ctx, item)
 
-		instance, err := engine.store.GetInstance(ctx, item.InstanceID)
-		if err != nil {
-			return fmt.Errorf("get instance: %w", err)
+		return err
+	})
+	if err != nil {
+		return empty, err
+	}
+
+	if run != nil {
+		return false, engine.runLeased(ctx, item, run)
+	}
+
+	return empty, nil
+}
+
+// executeClaimedItem executes a queue item claimed and leased earlier by a dispatcher.
+// The claim is committed already, so on failure or shutdown the item is released back to the queue.
+func (engine *Engine) executeClaimedItem(ctx context.Context, item *QueueItem) error {
+	ctx = engine.scope(ctx)
+
+	if engine.isShutdown() {
+		return engine.store.ReleaseQueueItem(ctx, item.ID)
+	}
+
+	engine.activeSteps.Add(1)
+	defer engine.activeSteps.Done()
+
+	var run *stepRun
+	err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		if err := engine.renewLease(ctx, item); err != nil {
+			return err
 		}
 
-		// If workflow is in DLQ state, skip execution to prevent progress until operator intervention
-		if instance.Status == StatusDLQ {
-			_ = engine.store.LogEvent(ctx, instance.ID, nil, EventStepFailed, map[string]any{
-				KeyMessage: "instance in DLQ state, skipping queued item",
-			})
-			return nil
+		var err error
+		run, err = engine.executeQueueItem(ctx, item)
+
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, ErrLeaseLost) {
+			_ = engine.store.ReleaseQueueItem(context.WithoutCancel(ctx), item.ID)
 		}
 
-		var step *WorkflowStep
-		if item.StepID == nil {
-			step, err = engine.createFirstStep(ctx, instance)
-			if err != nil {
-				return fmt.Errorf("create first step: %w", err)
-			}
-		} else {
-			steps, err := engine.store.GetStepsByInstance(ctx, instance.ID)
-			if err != nil {
-				return fmt.Errorf("get steps: %w", err)
-			}
+		return err
+	}
 
-			for _, currStep := range steps {
-				currStep := currStep
-				if currStep.ID == *item.StepID {
-					step = &currStep
+	if run != nil {
+		return engine.runLeased(ctx, item, run)
+	}
 
-					break
-				}
-			}
+	return nil
+}
 
-			if step == nil {
-				return fmt.Errorf("step not found: %d", *item.StepID)
-			}
+// executeQueueItem runs the step of a claimed queue item within the caller's transaction.
+// A handler run is returned instead of executed, and the item then stays in the queue until
+// the run is finished (see runLeased).
+func (engine *Engine) executeQueueItem(ctx context.Context, item *QueueItem) (*stepRun, error) {
+	removeFromQueue := true
+	defer func() {
+		if removeFromQueue {
+			_ = engine.store.RemoveFromQueue(ctx, item.ID)
 		}
+	}()
 
-		// Do not execute skipped or paused steps
-		if step.Status == StepStatusSkipped ||
-			step.Status == StepStatusPaused ||
-			step.Status == StepStatusRolledBack {
-			if err := engine.store.RemoveFromQueue(ctx, step.ID); err != nil {
-				return fmt.Errorf("remove step from queue: %w", err)
-			}
+	instance, err := engine.store.GetInstance(ctx, item.InstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
 
-			return nil
-		}
+	// If workflow is in DLQ state, skip execution to prevent progress until operator intervention
+	if instance.Status == StatusDLQ {
+		_ = engine.store.LogEvent(ctx, instance.ID, nil, EventStepFailed, map[string]any{
+			KeyMessage: "instance in DLQ state, skipping queued item",
+		})
+		return nil, nil
+	}
 
-		// Check if this is a compensation
-		if step.Status == StepStatusCompensation {
-			// For distributed setup: if local engine doesn't have the compensation handler,
-			// release the queue item so another service can execute the compensation.
-			def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
-			if err != nil {
-				return fmt.Errorf("get workflow definition: %w", err)
-			}
-			stepDef, ok := def.Definition.Steps[step.StepName]
-			if !ok {
-				return fmt.Errorf("step definition not found: %s", step.StepName)
-			}
-			if stepDef.OnFailure != "" {
-				if onFailDef, ok := def.Definition.Steps[stepDef.OnFailure]; ok {
-					engine.mu.RLock()
-					_, has := engine.handlers[onFailDef.Handler]
-					engine.mu.RUnlock()
-					if !has {
-						// Reschedule with cooldown and release so another service can pick it up later
-						delay := engine.jitteredCooldown()
-						if delay > 0 {
-							if err := engine.store.RescheduleAndReleaseQueueItem(ctx, item.ID, delay); err != nil {
-								return fmt.Errorf("reschedule queue item: %w", err)
-							}
-						} else {
-							if err := engine.store.ReleaseQueueItem(ctx, item.ID); err != nil {
-								return fmt.Errorf("release queue item: %w", err)
-							}
-						}
+	var step *WorkflowStep
+	if item.StepID == nil {
+		step, err = engine.createFirstStep(ctx, instance)
+		if err != nil {
+			return nil, fmt.Errorf("create first step: %w", err)
+		}
+	} else {
+		steps, err := engine.store.GetStepsByInstance(ctx, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get steps: %w", err)
+		}
 
-						removeFromQueue = false
-						// Throttle skip logs to avoid flooding
-						logKey := fmt.Sprintf("comp-skip:%d:%s", instance.ID, step.StepName)
-						if engine.shouldLogSkip(logKey) {
-							_ = engine.store.LogEvent(ctx, instance.ID, nil, EventStepSkippedMissingHandler, map[string]any{
-								KeyStepName: step.StepName,
-								KeyMessage:  "no local compensation handler registered; rescheduled",
-							})
-						}
+		for _, currStep := range steps {
+			currStep := currStep
+			if currStep.ID == *item.StepID {
+				step = &currStep
 
-						return nil
-					}
-				}
+				break
 			}
+		}
 
-			return engine.executeCompensationStep(ctx, instance, step)
+		if step == nil {
+			return nil, fmt.Errorf("step not found: %d", *item.StepID)
+		}
+	}
+
+	// Do not execute skipped or paused steps
+	if step.Status == StepStatusSkipped ||
+		step.Status == StepStatusPaused ||
+		step.Status == StepStatusRolledBack {
+		if err := engine.store.RemoveFromQueue(ctx, step.ID); err != nil {
+			return nil, fmt.Errorf("remove step from queue: %w", err)
 		}
 
-		// Distributed handlers: if this is a task step and no local handler is registered,
-		// release the queue item so another service can pick it up, without failing the step.
-		def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+		return nil, nil
+	}
+
+	// Check if this is a compensation
+	if step.Status == StepStatusCompensation {
+		// For distributed setup: if local engine doesn't have the compensation handler,
+		// release the queue item so another service can execute the compensation.
+		def, err := engine.getDefinition(ctx, instance.WorkflowID)
 		if err != nil {
-			return fmt.Errorf("get workflow definition: %w", err)
+			return nil, fmt.Errorf("get workflow definition: %w", err)
 		}
 		stepDef, ok := def.Definition.Steps[step.StepName]
 		if !ok {
-			return fmt.Errorf("step definition not found: %s", step.StepName)
-		}
-		if step.StepType == StepTypeTask {
-			engine.mu.RLock()
-			_, has := engine.handlers[stepDef.Handler]
-			engine.mu.RUnlock()
-			if !has {
-				// Reschedule with cooldown and release so another service can pick it up later
-				delay := engine.jitteredCooldown()
-				if delay > 0 {
-					if err := engine.store.RescheduleAndReleaseQueueItem(ctx, item.ID, delay); err != nil {
-						return fmt.Errorf("reschedule queue item: %w", err)
+			return nil, fmt.Errorf("step definition not found: %s", step.StepName)
+		}
+		if stepDef.OnFailure != "" {
+			if onFailDef, ok := def.Definition.Steps[stepDef.OnFailure]; ok {
+				engine.mu.RLock()
+				_, has := engine.handlers[onFailDef.Handler]
+				engine.mu.RUnlock()
+				if !has {
+					// Reschedule with cooldown and release so another service can pick it up later
+					delay := engine.jitteredCooldown()
+					if delay > 0 {
+						if err := engine.store.RescheduleAndReleaseQueueItem(ctx, item.ID, delay); err != nil {
+							return nil, fmt.Errorf("reschedule queue item: %w", err)
+						}
+					} else {
+						if err := engine.store.ReleaseQueueItem(ctx, item.ID); err != nil {
+							return nil, fmt.Errorf("release queue item: %w", err)
+						}
 					}
-				} else {
-					if err := engine.store.ReleaseQueueItem(ctx, item.ID); err != nil {
-						return fmt.Errorf("release queue item: %w", err)
+
+					removeFromQueue = false
+					// Throttle skip logs to avoid flooding
+					logKey := fmt.Sprintf("comp-skip:%d:%s", instance.ID, step.StepName)
+					if engine.shouldLogSkip(logKey) {
+						_ = engine.store.LogEvent(ctx, instance.ID, nil, EventStepSkippedMissingHandler, map[string]any{
+							KeyStepName: step.StepName,
+							KeyMessage:  "no local compensation handler registered; rescheduled",
+						})
 					}
+
+					return nil, nil
 				}
-				removeFromQueue = false
-				// Throttle skip logs to avoid flooding
-				logKey := fmt.Sprintf("task-skip:%d:%s", instance.ID, step.StepName)
-				if engine.shouldLogSkip(logKey) {
-					_ = engine.store.LogEvent(ctx, instance.ID, nil, EventStepSkippedMissingHandler, map[string]any{
-						KeyStepName: step.StepName,
-						KeyMessage:  "no local handler registered; rescheduled",
-					})
-				}
-				return nil
 			}
 		}
 
-		return engine.executeStep(ctx, instance, step)
-	})
+		run, err := engine.prepareCompensationStep(ctx, instance, step)
+		removeFromQueue = run == nil
+
+		return run, err
+	}
+
+	// Distributed handlers: if this is a task step and no local handler is registered,
+	// release the queue item so another service can pick it up, without failing the step.
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
-		return empty, err
+		return nil, fmt.Errorf("get workflow definition: %w", err)
+	}
+	stepDef, ok := def.Definition.Steps[step.StepName]
+	if !ok {
+		return nil, fmt.Errorf("step definition not found: %s", step.StepName)
+	}
+	if step.StepType == StepTypeTask {
+		engine.mu.RLock()
+		_, has := engine.handlers[stepDef.Handler]
+		engine.mu.RUnlock()
+		if !has {
+			// Reschedule with cooldown and release so another service can pick it up later
+			delay := engine.jitteredCooldown()
+			if delay > 0 {
+				if err := engine.store.RescheduleAndReleaseQueueItem(ctx, item.ID, delay); err != nil {
+					return nil, fmt.Errorf("reschedule queue item: %w", err)
+				}
+			} else {
+				if err := engine.store.ReleaseQueueItem(ctx, item.ID); err != nil {
+					return nil, fmt.Errorf("release queue item: %w", err)
+				}
+			}
+			removeFromQueue = false
+			// Throttle skip logs to avoid flooding
+			logKey := fmt.Sprintf("task-skip:%d:%s", instance.ID, step.StepName)
+			if engine.shouldLogSkip(logKey) {
+				_ = engine.store.LogEvent(ctx, instance.ID, nil, EventStepSkippedMissingHandler, map[string]any{
+					KeyStepName: step.StepName,
+					KeyMessage:  "no local handler registered; rescheduled",
+				})
+			}
+			return nil, nil
+		}
 	}
 
-	return empty, nil
+	run, err := engine.prepareStep(ctx, instance, step)
+	removeFromQueue = run == nil
+
+	return run, err
 }
 
 func (engine *Engine) MakeHumanDecision(
@@ -508,6 +754,8 @@ func (engine *Engine) MakeHumanDecision(
 	decision HumanDecision,
 	comment *string,
 ) error {
+	ctx = engine.scope(ctx)
+
 	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
 		step, err := engine.store.GetStepByID(ctx, stepID)
 		if err != nil {
@@ -522,13 +770,17 @@ func (engine *Engine) MakeHumanDecision(
 			return fmt.Errorf("step %d is not waiting for decision (current status: %s)", stepID, step.Status)
 		}
 
+		if err := engine.checkInstanceNamespace(ctx, step.InstanceID); err != nil {
+			return fmt.Errorf("get instance: %w", err)
+		}
+
 		decisionRecord := &HumanDecisionRecord{
 			InstanceID: step.InstanceID,
 			StepID:     stepID,
 			DecidedBy:  decidedBy,
 			Decision:   decision,
 			Comment:    comment,
-			DecidedAt:  time.Now(),
+			DecidedAt:  engine.clock.Now(),
 		}
 
 		if err := engine.store.CreateHumanDecision(ctx, decisionRecord); err != nil {
@@ -577,6 +829,8 @@ func (engine *Engine) MakeHumanDecision(
 }
 
 func (engine *Engine) CancelWorkflow(ctx context.Context, instanceID int64, requestedBy, reason string) error {
+	ctx = engine.scope(ctx)
+
 	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
 		instance, err := engine.store.GetInstance(ctx, instanceID)
 		if err != nil {
@@ -612,6 +866,8 @@ func (engine *Engine) CancelWorkflow(ctx context.Context, instanceID int64, requ
 }
 
 func (engine *Engine) AbortWorkflow(ctx context.Context, instanceID int64, requestedBy, reason string) error {
+	ctx = engine.scope(ctx)
+
 	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
 		instance, err := engine.store.GetInstance(ctx, instanceID)
 		if err != nil {
@@ -646,6 +902,84 @@ func (engine *Engine) AbortWorkflow(ctx context.Context, instanceID int64, reque
 	})
 }
 
+// SkipStep marks a stuck step as skipped by an operator and continues the workflow
+// as if the step had completed with the given output. Like decidedBy and requestedBy of the other
+// operator actions, skippedBy is passed explicitly and recorded in the step_skipped event.
+// A step claimed by a worker meanwhile is not skipped, the error wraps ErrStepNotSkippable.
+func (engine *Engine) SkipStep(
+	ctx context.Context,
+	stepID int64,
+	skippedBy string,
+	output json.RawMessage,
+	reason string,
+) error {
+	ctx = engine.scope(ctx)
+
+	return engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		step, err := engine.store.GetStepByID(ctx, stepID)
+		if err != nil {
+			return fmt.Errorf("get step: %w", err)
+		}
+
+		switch step.Status {
+		case StepStatusPending, StepStatusFailed, StepStatusWaitingDecision:
+		default:
+			return fmt.Errorf("step %d cannot be skipped (current status: %s): %w", stepID, step.Status, ErrStepNotSkippable)
+		}
+
+		if step.StepType == StepTypeFork || step.StepType == StepTypeParallel {
+			return fmt.Errorf("step %d cannot be skipped (step type: %s): %w", stepID, step.StepType, ErrStepNotSkippable)
+		}
+
+		instance, err := engine.store.GetInstance(ctx, step.InstanceID)
+		if err != nil {
+			return fmt.Errorf("get instance: %w", err)
+		}
+
+		if engine.isTerminalStatus(instance.Status) {
+			return fmt.Errorf("workflow %d is already in terminal state: %s: %w", instance.ID, instance.Status, ErrStepNotSkippable)
+		}
+
+		if instance.Status != StatusPending && instance.Status != StatusRunning {
+			return fmt.Errorf("step %d cannot be skipped (workflow status: %s): %w", stepID, instance.Status, ErrStepNotSkippable)
+		}
+
+		def, err := engine.getDefinition(ctx, instance.WorkflowID)
+		if err != nil {
+			return fmt.Errorf("get workflow definition: %w", err)
+		}
+
+		stepDef, ok := def.Definition.Steps[step.StepName]
+		if !ok {
+			return fmt.Errorf("step definition not found: %s", step.StepName)
+		}
+
+		if len(output) == 0 {
+			output = step.Input
+		}
+
+		// A worker may have claimed the step since it was read, the skip only applies if it has not.
+		// The queued item for this step (if any) is dropped by a worker of its task queue once it sees the skipped status
+		if err := engine.store.SkipPendingStep(ctx, step.ID, output); err != nil {
+			return fmt.Errorf("skip step %d: %w", step.ID, err)
+		}
+
+		_ = engine.store.LogEvent(ctx, instance.ID, &step.ID, EventStepSkipped, map[string]any{
+			KeyStepName:  step.StepName,
+			KeySkippedBy: skippedBy,
+			KeyReason:    reason,
+		})
+
+		if instance.Status == StatusPending {
+			if err := engine.store.UpdateInstanceStatus(ctx, instance.ID, StatusRunning, nil, nil); err != nil {
+				return fmt.Errorf("update instance status: %w", err)
+			}
+		}
+
+		return engine.continueWorkflowAfterStep(ctx, instance, step, stepDef, output, true)
+	})
+}
+
 func (engine *Engine) isShutdown() bool {
 	engine.shutdownMu.RLock()
 	defer engine.shutdownMu.RUnlock()
@@ -654,14 +988,14 @@ func (engine *Engine) isShutdown() bool {
 }
 
 func (engine *Engine) cancelRequestsWorker() {
-	ticker := time.NewTicker(engine.cancelWorkerInterval)
+	ticker := engine.clock.NewTicker(engine.cancelWorkerInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-engine.shutdownCh:
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			engine.processCancelRequests()
 		}
 	}
@@ -744,47 +1078,48 @@ func (engine *Engine) stopActiveSteps(ctx context.Context, instanceID int64) err
 }
 
 func (engine *Engine) executeStep(ctx context.Context, instance *WorkflowInstance, step *WorkflowStep) error {
+	run, err := engine.prepareStep(ctx, instance, step)
+	if err != nil || run == nil {
+		return err
+	}
+
+	output, canceled, stepErr := engine.runStep(ctx, run)
+
+	return engine.finishStep(ctx, run, output, canceled, stepErr)
+}
+
+// prepareStep marks the step as running and executes it, except for task handlers: these are
+// returned as a stepRun to be executed outside the transaction of the caller.
+func (engine *Engine) prepareStep(ctx context.Context, instance *WorkflowInstance, step *WorkflowStep) (*stepRun, error) {
 	// If workflow is in DLQ state, do not execute any steps until operator requeues
 	if instance.Status == StatusDLQ {
-		return nil
+		return nil, nil
 	}
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
-		return fmt.Errorf("get workflow definition: %w", err)
+		return nil, fmt.Errorf("get workflow definition: %w", err)
 	}
 
 	stepDef, ok := def.Definition.Steps[step.StepName]
 	if !ok {
-		return fmt.Errorf("step definition not found: %s", step.StepName)
+		return nil, fmt.Errorf("step definition not found: %s", step.StepName)
 	}
 
 	// PLUGIN HOOK: OnStepStart
 	if engine.pluginManager != nil {
 		if err := engine.pluginManager.ExecuteStepStart(ctx, instance, step); err != nil {
-			return fmt.Errorf("plugin hook OnStepStart failed: %w", err)
+			return nil, fmt.Errorf("plugin hook OnStepStart failed: %w", err)
 		}
 	}
 
-	handlerCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	engine.registerInstanceContext(instance.ID, step.ID, cancel)
-	defer engine.unregisterInstanceContext(instance.ID, step.ID)
-
 	cancelReq, err := engine.store.GetCancelRequest(ctx, instance.ID)
 	if err == nil && cancelReq != nil {
-		return engine.handleCancellation(ctx, instance, step, cancelReq)
-	}
-
-	if stepDef.Timeout != 0 {
-		var timeoutCancel context.CancelFunc
-		handlerCtx, timeoutCancel = context.WithTimeout(handlerCtx, stepDef.Timeout)
-		defer timeoutCancel()
+		return nil, engine.handleCancellation(ctx, instance, step, cancelReq)
 	}
 
 	if err := engine.store.UpdateStep(ctx, step.ID, StepStatusRunning, nil, nil); err != nil {
-		return fmt.Errorf("update step status: %w", err)
+		return nil, fmt.Errorf("update step status: %w", err)
 	}
 
 	_ = engine.store.LogEvent(ctx, instance.ID, &step.ID, EventStepStarted, map[string]any{
@@ -792,13 +1127,18 @@ func (engine *Engine) executeStep(ctx context.Context, instance *WorkflowInstanc
 		KeyStepType: stepDef.Type,
 	})
 
+	if stepDef.Type == StepTypeTask {
+		return &stepRun{instance: instance, step: step, stepDef: stepDef}, nil
+	}
+
+	handlerCtx, cancel := engine.stepContext(ctx, instance.ID, step.ID, stepDef.Timeout)
+	defer cancel()
+
 	var output json.RawMessage
 	var stepErr error
 	next := true
 
 	switch stepDef.Type {
-	case StepTypeTask:
-		output, stepErr = engine.executeTask(handlerCtx, instance, step, stepDef)
 	case StepTypeFork:
 		output, stepErr = engine.executeFork(handlerCtx, instance, step, stepDef)
 	case StepTypeJoin:
@@ -813,14 +1153,52 @@ func (engine *Engine) executeStep(ctx context.Context, instance *WorkflowInstanc
 		var aborted bool
 		output, aborted, stepErr = engine.executeHuman(handlerCtx, instance, step, stepDef)
 		if stepErr == nil && aborted {
-			return nil
+			return nil, nil
 		}
 	default:
 		stepErr = fmt.Errorf("unsupported step type: %s", stepDef.Type)
 	}
 
-	if errors.Is(handlerCtx.Err(), context.Canceled) {
-		cancelReq, err = engine.store.GetCancelRequest(ctx, instance.ID)
+	canceled := errors.Is(handlerCtx.Err(), context.Canceled)
+
+	return nil, engine.completeStep(ctx, instance, step, stepDef, output, next, canceled, stepErr)
+}
+
+// stepContext returns the context of a running step: cancelled by cancel requests for
+// the instance (see stopActiveSteps) and bounded by the step timeout.
+func (engine *Engine) stepContext(
+	ctx context.Context,
+	instanceID, stepID int64,
+	timeout time.Duration,
+) (context.Context, context.CancelFunc) {
+	handlerCtx, cancel := context.WithCancel(ctx)
+	engine.registerInstanceContext(instanceID, stepID, cancel)
+
+	timeoutCancel := func() {}
+	if timeout != 0 {
+		handlerCtx, timeoutCancel = withClockTimeout(handlerCtx, engine.clock, timeout)
+	}
+
+	return handlerCtx, func() {
+		timeoutCancel()
+		engine.unregisterInstanceContext(instanceID, stepID)
+		cancel()
+	}
+}
+
+// completeStep records the outcome of an executed step.
+func (engine *Engine) completeStep(
+	ctx context.Context,
+	instance *WorkflowInstance,
+	step *WorkflowStep,
+	stepDef *StepDefinition,
+	output json.RawMessage,
+	next bool,
+	canceled bool,
+	stepErr error,
+) error {
+	if canceled {
+		cancelReq, err := engine.store.GetCancelRequest(ctx, instance.ID)
 		if err == nil && cancelReq != nil {
 			return engine.handleCancellation(ctx, instance, step, cancelReq)
 		}
@@ -830,7 +1208,7 @@ func (engine *Engine) executeStep(ctx context.Context, instance *WorkflowInstanc
 		// PLUGIN HOOK: OnStepFailed
 		if engine.pluginManager != nil {
 			if errPlugin := engine.pluginManager.ExecuteStepFailed(ctx, instance, step, stepErr); errPlugin != nil {
-				slog.Warn("[floxy] plugin hook OnStepFailed failed", "error", err)
+				slog.Warn("[floxy] plugin hook OnStepFailed failed", "error", errPlugin)
 			}
 		}
 
@@ -868,7 +1246,7 @@ func (engine *Engine) handleCancellation(
 			return fmt.Errorf("update instance status to cancelling: %w", err)
 		}
 
-		def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+		def, err := engine.getDefinition(ctx, instance.WorkflowID)
 		if err != nil {
 			return fmt.Errorf("get workflow definition: %w", err)
 		}
@@ -939,56 +1317,64 @@ func (engine *Engine) handleCancellation(
 }
 
 func (engine *Engine) executeCompensationStep(ctx context.Context, instance *WorkflowInstance, step *WorkflowStep) error {
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	run, err := engine.prepareCompensationStep(ctx, instance, step)
+	if err != nil || run == nil {
+		return err
+	}
+
+	output, canceled, compensationErr := engine.runStep(ctx, run)
+
+	return engine.finishStep(ctx, run, output, canceled, compensationErr)
+}
+
+// prepareCompensationStep returns the run of the compensation handler of the step.
+// Steps without a compensation handler are marked as rolled back right away.
+func (engine *Engine) prepareCompensationStep(
+	ctx context.Context,
+	instance *WorkflowInstance,
+	step *WorkflowStep,
+) (*stepRun, error) {
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
-		return fmt.Errorf("get workflow definition: %w", err)
+		return nil, fmt.Errorf("get workflow definition: %w", err)
 	}
 
 	stepDef, ok := def.Definition.Steps[step.StepName]
 	if !ok {
-		return fmt.Errorf("step definition not found: %s", step.StepName)
-	}
-
-	if stepDef.Timeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, stepDef.Timeout)
-		defer cancel()
+		return nil, fmt.Errorf("step definition not found: %s", step.StepName)
 	}
 
 	onFailureStep, ok := def.Definition.Steps[stepDef.OnFailure]
 	if !ok {
 		// No compensation handler, mark as rolled back
 		if err := engine.store.UpdateStep(ctx, step.ID, StepStatusRolledBack, step.Input, nil); err != nil {
-			return fmt.Errorf("update step status: %w", err)
+			return nil, fmt.Errorf("update step status: %w", err)
 		}
-		return nil
+		return nil, nil
 	}
 
-	handler, exists := engine.handlers[onFailureStep.Handler]
+	engine.mu.RLock()
+	_, exists := engine.handlers[onFailureStep.Handler]
+	engine.mu.RUnlock()
 	if !exists {
 		// Handler not found, mark as rolled back
 		if err := engine.store.UpdateStep(ctx, step.ID, StepStatusRolledBack, step.Input, nil); err != nil {
-			return fmt.Errorf("update step status: %w", err)
+			return nil, fmt.Errorf("update step status: %w", err)
 		}
-		return nil
-	}
-
-	variables := make(map[string]any, len(onFailureStep.Metadata)+1)
-	for k, v := range onFailureStep.Metadata {
-		variables[k] = v
+		return nil, nil
 	}
-	variables["reason"] = "compensation"
 
-	stepCtx := executionContext{
-		instanceID:     step.InstanceID,
-		stepName:       step.StepName,
-		idempotencyKey: step.IdempotencyKey,
-		retryCount:     step.CompensationRetryCount,
-		variables:      variables,
-	}
+	return &stepRun{instance: instance, step: step, stepDef: stepDef, onFailure: onFailureStep}, nil
+}
 
-	// Execute the compensation handler
-	_, compensationErr := handler.Execute(ctx, &stepCtx, step.Input)
+// completeCompensation records the outcome of a compensation handler run.
+func (engine *Engine) completeCompensation(
+	ctx context.Context,
+	instance *WorkflowInstance,
+	step *WorkflowStep,
+	onFailureStep *StepDefinition,
+	compensationErr error,
+) error {
 	if compensationErr != nil {
 		// Compensation failed, check if we can retry
 		if step.CompensationRetryCount < onFailureStep.MaxRetries {
@@ -1003,7 +1389,8 @@ func (engine *Engine) executeCompensationStep(ctx context.Context, instance *Wor
 			if retryDelay == 0 {
 				retryDelay = onFailureStep.Delay
 			}
-			if err := engine.store.EnqueueStep(ctx, step.InstanceID, &step.ID, PriorityHigh, retryDelay); err != nil {
+			err := engine.store.EnqueueStep(ctx, step.InstanceID, &step.ID, taskQueueOf(onFailureStep), PriorityHigh, retryDelay)
+			if err != nil {
 				return fmt.Errorf("enqueue compensation retry: %w", err)
 			}
 
@@ -1101,6 +1488,36 @@ func (engine *Engine) executeTask(
 	return handler.Execute(ctx, execCtx, step.Input)
 }
 
+func (engine *Engine) executeCompensation(
+	ctx context.Context,
+	step *WorkflowStep,
+	onFailureStep *StepDefinition,
+) (json.RawMessage, error) {
+	engine.mu.RLock()
+	handler, ok := engine.handlers[onFailureStep.Handler]
+	engine.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("handler not found: %s", onFailureStep.Handler)
+	}
+
+	variables := make(map[string]any, len(onFailureStep.Metadata)+1)
+	for k, v := range onFailureStep.Metadata {
+		variables[k] = v
+	}
+	variables["reason"] = "compensation"
+
+	stepCtx := executionContext{
+		instanceID:     step.InstanceID,
+		stepName:       step.StepName,
+		idempotencyKey: step.IdempotencyKey,
+		retryCount:     step.CompensationRetryCount,
+		variables:      variables,
+	}
+
+	return handler.Execute(ctx, &stepCtx, step.Input)
+}
+
 func (engine *Engine) executeFork(
 	ctx context.Context,
 	instance *WorkflowInstance,
@@ -1111,7 +1528,7 @@ func (engine *Engine) executeFork(
 		KeyParallelSteps: stepDef.Parallel,
 	})
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return nil, fmt.Errorf("get workflow definition: %w", err)
 	}
@@ -1135,7 +1552,9 @@ func (engine *Engine) executeFork(
 			return nil, fmt.Errorf("create fork step %s: %w", parallelStepName, err)
 		}
 
-		if err := engine.store.EnqueueStep(ctx, instance.ID, &parallelStep.ID, PriorityNormal, parallelStepDef.Delay); err != nil {
+		err := engine.store.EnqueueStep(ctx, instance.ID, &parallelStep.ID,
+			taskQueueOf(parallelStepDef), PriorityNormal, parallelStepDef.Delay)
+		if err != nil {
 			return nil, fmt.Errorf("enqueue fork step %s: %w", parallelStepName, err)
 		}
 	}
@@ -1208,7 +1627,11 @@ func (engine *Engine) executeJoin(
 	outputs := make(map[string]json.RawMessage)
 	for _, s := range steps {
 		for _, waitFor := range joinState.WaitingFor {
-			if s.StepName == waitFor && s.Status == StepStatusCompleted {
+			if s.StepName != waitFor {
+				continue
+			}
+			// Steps skipped by an operator carry the supplied output
+			if s.Status == StepStatusCompleted || (s.Status == StepStatusSkipped && s.Output != nil) {
 				outputs[s.StepName] = s.Output
 			}
 		}
@@ -1286,7 +1709,7 @@ func (engine *Engine) executeHuman(
 		return nil, false, fmt.Errorf("update step status to waiting_decision: %w", err)
 	}
 
-	if err := engine.store.EnqueueStep(ctx, instance.ID, &step.ID, PriorityHigher, stepDef.Delay); err != nil {
+	if err := engine.store.EnqueueStep(ctx, instance.ID, &step.ID, taskQueueOf(stepDef), PriorityHigher, stepDef.Delay); err != nil {
 		return nil, false, fmt.Errorf("enqueue step: %w", err)
 	}
 
@@ -1394,7 +1817,7 @@ func (engine *Engine) continueWorkflowAfterHumanDecision(
 	step *WorkflowStep,
 ) error {
 	// Get workflow definition
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return fmt.Errorf("get workflow definition: %w", err)
 	}
@@ -1431,15 +1854,29 @@ func (engine *Engine) handleStepSuccess(
 		KeyStepName: step.StepName,
 	})
 
+	return engine.continueWorkflowAfterStep(ctx, instance, step, stepDef, output, next)
+}
+
+// continueWorkflowAfterStep notifies joins waiting on the finished step and enqueues
+// its successors (or completes the workflow when nothing is left to run).
+// The step status must already be persisted by the caller.
+func (engine *Engine) continueWorkflowAfterStep(
+	ctx context.Context,
+	instance *WorkflowInstance,
+	step *WorkflowStep,
+	stepDef *StepDefinition,
+	output json.RawMessage,
+	next bool,
+) error {
 	// Check if this is a terminal step in a fork branch
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err == nil {
 		// First, check if we're in a Condition branch and need to replace virtual step
 		conditionStepName := engine.findConditionStepInBranch(stepDef, def)
 		if conditionStepName != "" {
 			// Find Join step for this fork branch
-			joinStepName, err := engine.findJoinStepForForkBranch(ctx, instance.ID, step.StepName, def)
-			if err == nil && joinStepName != "" {
+			joinStepName := engine.findJoinStepForForkBranch(step.StepName, def)
+			if joinStepName != "" {
 				virtualStep := fmt.Sprintf("cond#%s", conditionStepName)
 				// Replace virtual step with real terminal step
 				if err := engine.store.ReplaceInJoinWaitFor(ctx, instance.ID, joinStepName, virtualStep, step.StepName); err != nil {
@@ -1462,10 +1899,10 @@ func (engine *Engine) handleStepSuccess(
 					}
 				}
 			}
-		} else if engine.isTerminalStepInForkBranch(ctx, instance.ID, step.StepName, def) {
+		} else if engine.isTerminalStepInForkBranch(step.StepName, def) {
 			// Not in Condition branch, use dynamic detection
-			joinStepName, err := engine.findJoinStepForForkBranch(ctx, instance.ID, step.StepName, def)
-			if err == nil && joinStepName != "" {
+			joinStepName := engine.findJoinStepForForkBranch(step.StepName, def)
+			if joinStepName != "" {
 				// Check if this step is not already in the WaitFor list
 				joinState, err := engine.store.GetJoinState(ctx, instance.ID, joinStepName)
 				if err == nil && joinState != nil {
@@ -1531,7 +1968,7 @@ func (engine *Engine) handleStepSuccess(
 	// Get workflow definition if we haven't already
 	if def == nil {
 		var err error
-		def, err = engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+		def, err = engine.getDefinition(ctx, instance.WorkflowID)
 		if err != nil {
 			return fmt.Errorf("get workflow definition: %w", err)
 		}
@@ -1583,11 +2020,11 @@ func (engine *Engine) handleStepFailure(
 			KeyError:      errMsg,
 		})
 
-		return engine.store.EnqueueStep(ctx, instance.ID, &step.ID, PriorityHigh, stepDef.Delay)
+		return engine.store.EnqueueStep(ctx, instance.ID, &step.ID, taskQueueOf(stepDef), PriorityHigh, stepDef.Delay)
 	}
 
 	// If DLQ mode is enabled, pause instead of failing and skip rollback
-	if def, defErr := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID); defErr == nil && def.Definition.DLQEnabled {
+	if def, defErr := engine.getDefinition(ctx, instance.WorkflowID); defErr == nil && def.Definition.DLQEnabled {
 		// Mark step as paused with error
 		if err := engine.store.UpdateStep(ctx, step.ID, StepStatusPaused, nil, &errMsg); err != nil {
 			return fmt.Errorf("update step (paused): %w", err)
@@ -1616,9 +2053,13 @@ func (engine *Engine) handleStepFailure(
 			Error:      &errMsg,
 			Reason:     reason,
 		}
+		if err := engine.scheduleRedrive(ctx, rec, def.Definition.RedrivePolicy); err != nil {
+			return fmt.Errorf("schedule redrive: %w", err)
+		}
 		if err := engine.store.CreateDeadLetterRecord(ctx, rec); err != nil {
 			return fmt.Errorf("create dead letter record: %w", err)
 		}
+		engine.logDeadLetterCreated(ctx, rec)
 
 		// Freeze execution: pause active running steps and clear the instance queue
 		if err := engine.store.PauseActiveStepsAndClearQueue(ctx, instance.ID); err != nil {
@@ -1644,14 +2085,14 @@ func (engine *Engine) handleStepFailure(
 
 	// Check if this is a terminal step in a fork branch with Condition
 	// If so, replace virtual step with real step before notifying Join
-	def, defErr := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, defErr := engine.getDefinition(ctx, instance.WorkflowID)
 	if defErr == nil {
 		// Check if we're in a Condition branch and need to replace virtual step
 		conditionStepName := engine.findConditionStepInBranch(stepDef, def)
 		if conditionStepName != "" {
 			// Find Join step for this fork branch
-			joinStepName, err := engine.findJoinStepForForkBranch(ctx, instance.ID, step.StepName, def)
-			if err == nil && joinStepName != "" {
+			joinStepName := engine.findJoinStepForForkBranch(step.StepName, def)
+			if joinStepName != "" {
 				virtualStep := fmt.Sprintf("cond#%s", conditionStepName)
 				// Replace virtual step with real terminal step (even though it failed)
 				if err := engine.store.ReplaceInJoinWaitFor(ctx, instance.ID, joinStepName, virtualStep, step.StepName); err != nil {
@@ -1686,7 +2127,7 @@ func (engine *Engine) handleStepFailure(
 	// However, this doesn't guarantee success (step might already be executing in worker)
 	if def == nil {
 		var defErr error
-		def, defErr = engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+		def, defErr = engine.getDefinition(ctx, instance.WorkflowID)
 		if defErr != nil {
 			def = nil
 		}
@@ -1694,7 +2135,7 @@ func (engine *Engine) handleStepFailure(
 
 	if def != nil {
 		// Check if this step is part of a fork branch
-		if engine.isStepInForkBranch(ctx, instance.ID, step.StepName, def) {
+		if engine.isStepInForkBranch(step.StepName, def) {
 			// Stop all active steps in the same fork branch (parallel siblings)
 			if err := engine.stopParallelBranchesInFork(ctx, instance.ID, step.StepName, def); err != nil {
 				slog.Warn("[floxy] failed to stop parallel branches", "error", err)
@@ -1702,6 +2143,18 @@ func (engine *Engine) handleStepFailure(
 		}
 	}
 
+	return engine.failWorkflow(ctx, instance, step, def, errMsg)
+}
+
+// failWorkflow rolls back to the nearest save point (or root) starting from the failed step
+// and marks the instance as failed. Compensation steps are executed by workers afterwards.
+func (engine *Engine) failWorkflow(
+	ctx context.Context,
+	instance *WorkflowInstance,
+	step *WorkflowStep,
+	def *WorkflowDefinition,
+	errMsg string,
+) error {
 	// Try to rollback to save point before handling failure
 	if def != nil {
 		if rollbackErr := engine.rollbackToSavePointOrRoot(ctx, instance.ID, step, def); rollbackErr != nil {
@@ -1744,7 +2197,7 @@ func (engine *Engine) notifyJoinStepsForStep(
 	}
 
 	// Get Join step definition to check strategy
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return err
 	}
@@ -1823,7 +2276,7 @@ func (engine *Engine) notifyJoinStepsForStep(
 				if err := engine.store.CreateStep(ctx, joinStep); err != nil {
 					return fmt.Errorf("create join step: %w", err)
 				}
-				if err := engine.store.EnqueueStep(ctx, instanceID, &joinStep.ID, PriorityNormal, 0); err != nil {
+				if err := engine.store.EnqueueStep(ctx, instanceID, &joinStep.ID, DefaultTaskQueue, PriorityNormal, 0); err != nil {
 					return fmt.Errorf("enqueue join step: %w", err)
 				}
 				_ = engine.store.LogEvent(ctx, instanceID, &joinStep.ID, EventJoinReady, map[string]any{
@@ -1847,7 +2300,7 @@ func (engine *Engine) notifyJoinSteps(
 		return err
 	}
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return err
 	}
@@ -1959,7 +2412,7 @@ func (engine *Engine) notifyJoinSteps(
 					if err := engine.store.CreateStep(ctx, joinStep); err != nil {
 						return fmt.Errorf("create join step: %w", err)
 					}
-					if err := engine.store.EnqueueStep(ctx, instanceID, &joinStep.ID, PriorityNormal, 0); err != nil {
+					if err := engine.store.EnqueueStep(ctx, instanceID, &joinStep.ID, DefaultTaskQueue, PriorityNormal, 0); err != nil {
 						return fmt.Errorf("enqueue join step: %w", err)
 					}
 					_ = engine.store.LogEvent(ctx, instanceID, &joinStep.ID, EventJoinReady, map[string]any{
@@ -2018,6 +2471,16 @@ func (engine *Engine) hasStepsInCompensation(ctx context.Context, instanceID int
 	return false
 }
 
+// stepCreatedAfter orders steps by creation, by ID for steps created at the same time,
+// as with a manual clock or a coarse store timestamp.
+func stepCreatedAfter(a, b *WorkflowStep) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+
+	return a.ID > b.ID
+}
+
 // enqueueCompletedStepsForRollback finds completed steps after savepoint and enqueues them for compensation.
 // This follows the saga pattern: rollback happens step-by-step through the queue, not in one transaction.
 func (engine *Engine) enqueueCompletedStepsForRollback(ctx context.Context, instanceID int64) error {
@@ -2031,7 +2494,7 @@ func (engine *Engine) enqueueCompletedStepsForRollback(ctx context.Context, inst
 	for i := range steps {
 		step := &steps[i]
 		if step.StepType == StepTypeSavePoint {
-			if lastSavePoint == nil || step.CreatedAt.After(lastSavePoint.CreatedAt) {
+			if lastSavePoint == nil || stepCreatedAfter(step, lastSavePoint) {
 				lastSavePoint = step
 			}
 		}
@@ -2043,7 +2506,7 @@ func (engine *Engine) enqueueCompletedStepsForRollback(ctx context.Context, inst
 		return fmt.Errorf("get instance: %w", err)
 	}
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return fmt.Errorf("get workflow definition: %w", err)
 	}
@@ -2057,7 +2520,7 @@ func (engine *Engine) enqueueCompletedStepsForRollback(ctx context.Context, inst
 			continue
 		}
 		// If there's a savepoint, only rollback steps created after it
-		if lastSavePoint != nil && step.CreatedAt.After(lastSavePoint.CreatedAt) {
+		if lastSavePoint != nil && stepCreatedAfter(step, lastSavePoint) {
 			// Check if step has compensation handler defined
 			stepDef, ok := def.Definition.Steps[step.StepName]
 			if ok && stepDef.OnFailure != "" {
@@ -2090,7 +2553,7 @@ func (engine *Engine) enqueueCompletedStepsForRollback(ctx context.Context, inst
 	}
 
 	sort.Slice(stepsToRollback, func(i, j int) bool {
-		return stepsToRollback[i].CreatedAt.After(stepsToRollback[j].CreatedAt)
+		return stepCreatedAfter(stepsToRollback[i], stepsToRollback[j])
 	})
 
 	// Mark each step as requiring compensation and enqueue for processing
@@ -2103,7 +2566,11 @@ func (engine *Engine) enqueueCompletedStepsForRollback(ctx context.Context, inst
 
 		// Enqueue step for compensation processing
 		delay := time.Millisecond * time.Duration(idx*10)
-		if err := engine.store.EnqueueStep(ctx, instanceID, &step.ID, PriorityHigh, delay); err != nil {
+		taskQueue := DefaultTaskQueue
+		if stepDef, ok := def.Definition.Steps[step.StepName]; ok {
+			taskQueue = compensationTaskQueueOf(def, stepDef)
+		}
+		if err := engine.store.EnqueueStep(ctx, instanceID, &step.ID, taskQueue, PriorityHigh, delay); err != nil {
 			slog.Warn("[floxy] failed to enqueue step for compensation", "step_id", step.ID, "error", err)
 			continue
 		}
@@ -2133,7 +2600,7 @@ func (engine *Engine) enqueueNextSteps(
 		return nil
 	}
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return fmt.Errorf("get workflow definition: %w", err)
 	}
@@ -2157,7 +2624,7 @@ func (engine *Engine) enqueueNextSteps(
 			return fmt.Errorf("create step: %w", err)
 		}
 
-		if err := engine.store.EnqueueStep(ctx, instanceID, &step.ID, PriorityNormal, stepDef.Delay); err != nil {
+		if err := engine.store.EnqueueStep(ctx, instanceID, &step.ID, taskQueueOf(stepDef), PriorityNormal, stepDef.Delay); err != nil {
 			return fmt.Errorf("enqueue step: %w", err)
 		}
 	}
@@ -2166,7 +2633,7 @@ func (engine *Engine) enqueueNextSteps(
 }
 
 func (engine *Engine) createFirstStep(ctx context.Context, instance *WorkflowInstance) (*WorkflowStep, error) {
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return nil, err
 	}
@@ -2264,6 +2731,8 @@ func (engine *Engine) completeWorkflow(ctx context.Context, instance *WorkflowIn
 }
 
 func (engine *Engine) GetStatus(ctx context.Context, instanceID int64) (WorkflowStatus, error) {
+	ctx = engine.scope(ctx)
+
 	instance, err := engine.store.GetInstance(ctx, instanceID)
 	if err != nil {
 		return "", fmt.Errorf("get instance: %w", err)
@@ -2273,6 +2742,12 @@ func (engine *Engine) GetStatus(ctx context.Context, instanceID int64) (Workflow
 }
 
 func (engine *Engine) GetSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
+	ctx = engine.scope(ctx)
+
+	if err := engine.checkInstanceNamespace(ctx, instanceID); err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+
 	return engine.store.GetStepsByInstance(ctx, instanceID)
 }
 
@@ -2537,6 +3012,7 @@ func (engine *Engine) rollbackStep(ctx context.Context, step *WorkflowStep, def
 		if err := engine.store.CreateDeadLetterRecord(ctx, rec); err != nil {
 			return fmt.Errorf("create dead letter record: %w", err)
 		}
+		engine.logDeadLetterCreated(ctx, rec)
 
 		return nil
 	}
@@ -2552,7 +3028,8 @@ func (engine *Engine) rollbackStep(ctx context.Context, step *WorkflowStep, def
 	if retryDelay == 0 {
 		retryDelay = onFailureStep.Delay
 	}
-	if err := engine.store.EnqueueStep(ctx, step.InstanceID, &step.ID, PriorityHigh, retryDelay); err != nil {
+	err := engine.store.EnqueueStep(ctx, step.InstanceID, &step.ID, taskQueueOf(onFailureStep), PriorityHigh, retryDelay)
+	if err != nil {
 		return fmt.Errorf("enqueue compensation step: %w", err)
 	}
 
@@ -2631,7 +3108,7 @@ func (engine *Engine) hasPendingStepsInParallelBranches(
 		return false
 	}
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return false
 	}
@@ -2673,23 +3150,12 @@ func (engine *Engine) findForkStepForParallelStep(ctx context.Context, instanceI
 		return ""
 	}
 
-	def, err := engine.store.GetWorkflowDefinition(ctx, instance.WorkflowID)
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
 	if err != nil {
 		return ""
 	}
 
-	// Look for fork steps that have this step in their Parallel array
-	for stepName, stepDef := range def.Definition.Steps {
-		if stepDef.Type == StepTypeFork {
-			for _, parallelStep := range stepDef.Parallel {
-				if parallelStep == parallelStepName {
-					return stepName
-				}
-			}
-		}
-	}
-
-	return ""
+	return engine.graphOf(def).parallelFork[parallelStepName]
 }
 
 // findForkStepForStepInBranch finds the nearest Fork step that contains the given step
@@ -2786,13 +3252,8 @@ func (engine *Engine) isStepReachableFrom(targetStep, startStep string, def *Wor
 }
 
 // isStepInForkBranch checks if a step is part of any fork/parallel branch.
-func (engine *Engine) isStepInForkBranch(
-	ctx context.Context,
-	instanceID int64,
-	stepName string,
-	def *WorkflowDefinition,
-) bool {
-	return engine.findForkStepForParallelStep(ctx, instanceID, stepName) != ""
+func (engine *Engine) isStepInForkBranch(stepName string, def *WorkflowDefinition) bool {
+	return engine.graphOf(def).parallelFork[stepName] != ""
 }
 
 // stopParallelBranchesInFork stops all active steps in parallel branches of the same fork.
@@ -2806,7 +3267,7 @@ func (engine *Engine) stopParallelBranchesInFork(
 	def *WorkflowDefinition,
 ) error {
 	// Find the fork step that contains this failed step
-	forkStepName := engine.findForkStepForParallelStep(ctx, instanceID, failedStepName)
+	forkStepName := engine.graphOf(def).parallelFork[failedStepName]
 	if forkStepName == "" {
 		// Not in a fork, nothing to stop
 		return nil
@@ -2867,8 +3328,9 @@ func (engine *Engine) isStepInParallelBranch(stepName string, forkStepDef *StepD
 	}
 
 	// Check if this step is a descendant of any parallel step
+	graph := engine.graphOf(def)
 	for _, parallelStep := range forkStepDef.Parallel {
-		if engine.isStepDescendantOf(stepName, parallelStep, def) {
+		if graph.isDescendantOf(stepName, parallelStep) {
 			return true
 		}
 	}
@@ -2878,42 +3340,12 @@ func (engine *Engine) isStepInParallelBranch(stepName string, forkStepDef *StepD
 
 // isStepDescendantOf checks if a step is a descendant of another step
 func (engine *Engine) isStepDescendantOf(stepName, ancestorStepName string, def *WorkflowDefinition) bool {
-	visited := make(map[string]bool)
-
-	for stepName != "" {
-		if visited[stepName] {
-			break // Prevent infinite loops
-		}
-		visited[stepName] = true
-
-		stepDef, ok := def.Definition.Steps[stepName]
-		if !ok {
-			break
-		}
-
-		if stepName == ancestorStepName {
-			return true
-		}
-
-		// Check if this step is a descendant through Next or Else
-		if stepDef.Prev != "" {
-			stepName = stepDef.Prev
-		} else {
-			break
-		}
-	}
-
-	return false
+	return engine.graphOf(def).isDescendantOf(stepName, ancestorStepName)
 }
 
 // isTerminalStepInForkBranch checks if a step is a terminal step in a fork branch.
 // A step is terminal if it has no Next steps, is not a Join step, and is in a fork branch.
-func (engine *Engine) isTerminalStepInForkBranch(
-	ctx context.Context,
-	instanceID int64,
-	stepName string,
-	def *WorkflowDefinition,
-) bool {
+func (engine *Engine) isTerminalStepInForkBranch(stepName string, def *WorkflowDefinition) bool {
 	stepDef, ok := def.Definition.Steps[stepName]
 	if !ok {
 		return false
@@ -2929,125 +3361,20 @@ func (engine *Engine) isTerminalStepInForkBranch(
 		return false
 	}
 
-	// Check if this step is actually in a fork branch by finding the fork step
-	forkStepName := engine.findForkStepForParallelStep(ctx, instanceID, stepName)
-	if forkStepName != "" {
-		return true
-	}
-
-	// Try to find fork by traversing backwards through Prev
-	visited := make(map[string]bool)
-	current := stepDef.Prev
-	for current != "" && current != rootStepName {
-		if visited[current] {
-			break
-		}
-		visited[current] = true
-
-		currentDef, ok := def.Definition.Steps[current]
-		if !ok {
-			break
-		}
-
-		if currentDef.Type == StepTypeFork {
-			return true
-		}
-
-		// Check if this step is in the Parallel array of a fork
-		for _, defStep := range def.Definition.Steps {
-			if defStep.Type == StepTypeFork {
-				for _, parallelStep := range defStep.Parallel {
-					if parallelStep == current {
-						return true
-					}
-				}
-			}
-		}
-
-		current = currentDef.Prev
-	}
-
-	return false
+	return engine.graphOf(def).forkOfBranch(stepName) != ""
 }
 
 // findJoinStepForForkBranch finds the Join step that should wait for steps in the given fork branch.
 // It looks for a Join step that follows the fork step which created the branch containing stepName.
-func (engine *Engine) findJoinStepForForkBranch(
-	ctx context.Context,
-	instanceID int64,
-	stepName string,
-	def *WorkflowDefinition,
-) (string, error) {
-	// Find the fork step that created the branch containing this step
-	forkStepName := engine.findForkStepForParallelStep(ctx, instanceID, stepName)
-	if forkStepName == "" {
-		// Try to find fork by traversing backwards through Prev
-		stepDef, ok := def.Definition.Steps[stepName]
-		if !ok {
-			return "", nil
-		}
-
-		// Traverse backwards to find a fork step
-		visited := make(map[string]bool)
-		current := stepDef.Prev
-		for current != "" && current != rootStepName {
-			if visited[current] {
-				break
-			}
-			visited[current] = true
-
-			currentDef, ok := def.Definition.Steps[current]
-			if !ok {
-				break
-			}
-
-			if currentDef.Type == StepTypeFork {
-				forkStepName = current
-				break
-			}
-
-			// Check if this step is in the Parallel array of a fork
-			for name, defStep := range def.Definition.Steps {
-				if defStep.Type == StepTypeFork {
-					for _, parallelStep := range defStep.Parallel {
-						if parallelStep == current {
-							forkStepName = name
-							break
-						}
-					}
-					if forkStepName != "" {
-						break
-					}
-				}
-			}
-
-			if forkStepName != "" {
-				break
-			}
-
-			current = currentDef.Prev
-		}
-	}
+func (engine *Engine) findJoinStepForForkBranch(stepName string, def *WorkflowDefinition) string {
+	graph := engine.graphOf(def)
 
+	forkStepName := graph.forkOfBranch(stepName)
 	if forkStepName == "" {
-		return "", nil // Not in a fork branch
-	}
-
-	// Find Join step that follows this fork step
-	forkStepDef, ok := def.Definition.Steps[forkStepName]
-	if !ok {
-		return "", nil
-	}
-
-	// Look for Join step in Next steps of the fork
-	for _, nextStepName := range forkStepDef.Next {
-		nextStepDef, ok := def.Definition.Steps[nextStepName]
-		if ok && nextStepDef.Type == StepTypeJoin {
-			return nextStepName, nil
-		}
+		return "" // Not in a fork branch
 	}
 
-	return "", nil
+	return graph.forkJoin[forkStepName]
 }
 
 // findConditionStepInBranch finds the Condition step in the branch that contains stepName.
@@ -1,7 +1,6 @@
 package floxy
 
 import (
-	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,68 +88,45 @@ func newTestEngineWithStore(t *testing.T) (*Engine, *MockStore) {
 }
 
 func Test_isTerminalStepInForkBranch_TerminalTrue(t *testing.T) {
-	ctx := context.Background()
 	def := buildForkJoinDef()
 
-	engine, store := newTestEngineWithStore(t)
-
-	// Instance and def lookups invoked inside findForkStepForParallelStep
-	instanceID := int64(100)
-	store.EXPECT().GetInstance(mock.Anything, instanceID).
-		Return(&WorkflowInstance{ID: instanceID, WorkflowID: def.ID}, nil).Maybe()
-	store.EXPECT().GetWorkflowDefinition(mock.Anything, def.ID).Return(def, nil).Maybe()
+	engine, _ := newTestEngineWithStore(t)
 
 	// Step T has no Next and is within fork branch -> terminal
-	got := engine.isTerminalStepInForkBranch(ctx, instanceID, "T", def)
+	got := engine.isTerminalStepInForkBranch("T", def)
 	assert.True(t, got)
 }
 
 func Test_isTerminalStepInForkBranch_NotTerminal_HasNext(t *testing.T) {
-	ctx := context.Background()
 	def := buildForkJoinDef()
 
-	engine, store := newTestEngineWithStore(t)
-
-	instanceID := int64(101)
-	store.EXPECT().GetInstance(mock.Anything, instanceID).
-		Return(&WorkflowInstance{ID: instanceID, WorkflowID: def.ID}, nil).Maybe()
-	store.EXPECT().GetWorkflowDefinition(mock.Anything, def.ID).Return(def, nil).Maybe()
+	engine, _ := newTestEngineWithStore(t)
 
 	// A1 has Next -> not terminal even though in fork branch
-	got := engine.isTerminalStepInForkBranch(ctx, instanceID, "A1", def)
+	got := engine.isTerminalStepInForkBranch("A1", def)
 	assert.False(t, got)
 }
 
 func Test_isTerminalStepInForkBranch_JoinStep_False(t *testing.T) {
-	ctx := context.Background()
 	def := buildForkJoinDef()
 
 	engine, _ := newTestEngineWithStore(t)
 
-	instanceID := int64(102)
-	// Join step should be false regardless of store calls
-	got := engine.isTerminalStepInForkBranch(ctx, instanceID, "J", def)
+	// Join step should be false regardless of its position
+	got := engine.isTerminalStepInForkBranch("J", def)
 	assert.False(t, got)
 }
 
 func Test_findJoinStepForForkBranch_FindsJoin(t *testing.T) {
-	ctx := context.Background()
 	def := buildForkJoinDef()
 
-	engine, store := newTestEngineWithStore(t)
-
-	instanceID := int64(103)
-	store.EXPECT().GetInstance(mock.Anything, instanceID).
-		Return(&WorkflowInstance{ID: instanceID, WorkflowID: def.ID}, nil).Maybe()
-	store.EXPECT().GetWorkflowDefinition(mock.Anything, def.ID).Return(def, nil).Maybe()
+	engine, _ := newTestEngineWithStore(t)
 
-	join, err := engine.findJoinStepForForkBranch(ctx, instanceID, "T", def)
-	assert.NoError(t, err)
+	join := engine.findJoinStepForForkBranch("T", def)
 	assert.Equal(t, "J", join)
 }
 
 func Test_findJoinStepForForkBranch_NotInFork_ReturnsEmpty(t *testing.T) {
-	ctx := context.Background()
 	// A simple linear definition without fork
 	def := &WorkflowDefinition{
 		ID:   "wf2",
@@ -164,15 +140,9 @@ func Test_findJoinStepForForkBranch_NotInFork_ReturnsEmpty(t *testing.T) {
 		},
 	}
 
-	engine, store := newTestEngineWithStore(t)
-
-	instanceID := int64(104)
-	store.EXPECT().GetInstance(mock.Anything, instanceID).
-		Return(&WorkflowInstance{ID: instanceID, WorkflowID: def.ID}, nil).Maybe()
-	store.EXPECT().GetWorkflowDefinition(mock.Anything, def.ID).Return(def, nil).Maybe()
+	engine, _ := newTestEngineWithStore(t)
 
-	join, err := engine.findJoinStepForForkBranch(ctx, instanceID, "S2", def)
-	assert.NoError(t, err)
+	join := engine.findJoinStepForForkBranch("S2", def)
 	assert.Equal(t, "", join)
 }
 
@@ -32,4 +32,26 @@ type IEngine interface {
 		dlqID int64,
 		newInput *json.RawMessage,
 	) error
+	// DiscardFromDLQ drops a DLQ record and fails its instance with compensation.
+	DiscardFromDLQ(
+		ctx context.Context,
+		dlqID int64,
+		discardedBy string,
+		reason string,
+	) error
+	// SkipStep marks a stuck step as skipped and continues the workflow with the given output.
+	// If output is empty, the step input is passed through to the next steps.
+	SkipStep(
+		ctx context.Context,
+		stepID int64,
+		skippedBy string,
+		output json.RawMessage,
+		reason string,
+	) error
+	// StartBulkOperation applies an action to every instance matched by the selector
+	// in a rate-limited background job.
+	StartBulkOperation(ctx context.Context, req BulkRequest) (*BulkJob, error)
+	// GetBulkOperation returns bulk job progress and per-instance results.
+	GetBulkOperation(ctx context.Context, jobID string) (*BulkJob, error)
+	CancelBulkOperation(ctx context.Context, jobID string) error
 }
@@ -79,3 +79,100 @@ func WithQueueAgingRate(rate float64) EngineOption {
 		e.store.SetAgingRate(rate)
 	}
 }
+
+// WithBulkRateLimit sets the default number of instances processed per second by bulk operations,
+// capped at MaxBulkRatePerSecond.
+func WithBulkRateLimit(perSecond float64) EngineOption {
+	return func(e *Engine) {
+		if perSecond > 0 {
+			e.bulkRatePerSecond = min(perSecond, MaxBulkRatePerSecond)
+		}
+	}
+}
+
+// WithDLQRedriveInterval sets how often the engine looks for DLQ records due for automatic redrive.
+// Zero disables the redrive worker on this engine.
+func WithDLQRedriveInterval(interval time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.dlqRedriveInterval = interval
+	}
+}
+
+// WithEngineNotifier sets the notifier used to wake up workers and StartAwait.
+// By default stores implementing Notifier are used, and Postgres LISTEN/NOTIFY when the engine has a pool.
+func WithEngineNotifier(notifier Notifier) EngineOption {
+	return func(e *Engine) {
+		e.notifier = notifier
+	}
+}
+
+// WithNotifyFallbackInterval sets how often workers and StartAwait poll when notifications are enabled.
+// Polling covers notifications lost on reconnects and queue changes that are not notified.
+func WithNotifyFallbackInterval(interval time.Duration) EngineOption {
+	return func(e *Engine) {
+		if interval > 0 {
+			e.notifyFallbackInterval = interval
+		}
+	}
+}
+
+// WithLeaseDuration sets the visibility timeout of claimed queue items. Workers renew the lease
+// while a handler runs; an item whose lease expired can be taken over by another worker.
+func WithLeaseDuration(d time.Duration) EngineOption {
+	return func(e *Engine) {
+		if d > 0 {
+			e.leaseDuration = d
+		}
+	}
+}
+
+// WithLeaseReaperInterval sets how often the engine reclaims queue items whose worker stopped
+// renewing the lease, e.g. because its process died. Zero disables the reaper on this engine.
+func WithLeaseReaperInterval(interval time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.leaseReaperInterval = interval
+	}
+}
+
+// WithDefinitionCacheTTL sets how long the engine keeps decoded workflow definitions. Definitions saved
+// on other nodes are dropped on notification, the TTL only bounds staleness when notifications are lost
+// or unavailable. Zero disables the cache.
+func WithDefinitionCacheTTL(ttl time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.definitionCacheTTL = ttl
+	}
+}
+
+// WithWorkflowLint makes RegisterWorkflow reject definitions with lint warnings (see LintWorkflowDefinition),
+// except those of the ignored rules. Handlers are checked against the ones registered on the engine, so
+// register them first, or ignore LintUnregisteredHandler when they run on other nodes.
+func WithWorkflowLint(ignore ...LintRule) EngineOption {
+	return func(e *Engine) {
+		e.lintOnRegister = true
+		e.lintIgnore = ignore
+	}
+}
+
+// WithEngineClock sets the clock of the engine and of its workers and worker pools.
+// Pass the same clock to the store (WithStoreClock), which schedules delayed steps.
+func WithEngineClock(clock Clock) EngineOption {
+	return func(e *Engine) {
+		if clock != nil {
+			e.clock = clock
+		}
+	}
+}
+
+type StartOption func(opts *startOptions)
+
+type startOptions struct {
+	labels map[string]string
+}
+
+// WithStartLabels attaches labels (e.g. business keys like customer_id) to the started instance.
+// Labels can be used as filters in Store.SearchInstances.
+func WithStartLabels(labels map[string]string) StartOption {
+	return func(opts *startOptions) {
+		opts.labels = labels
+	}
+}
@@ -65,7 +65,7 @@ func Test_rollbackStep_WithCompensation_RetryAvailable(t *testing.T) {
 	}
 
 	store.EXPECT().UpdateStepCompensationRetry(mock.Anything, step.ID, 1, StepStatusCompensation).Return(nil)
-	store.EXPECT().EnqueueStep(mock.Anything, step.InstanceID, &step.ID, PriorityHigh, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueStep(mock.Anything, step.InstanceID, &step.ID, mock.Anything, PriorityHigh, mock.Anything).Return(nil)
 	store.EXPECT().LogEvent(mock.Anything, step.InstanceID, &step.ID, EventStepStarted, mock.Anything).Return(nil)
 
 	err := engine.rollbackStep(ctx, step, def)
@@ -104,6 +104,7 @@ func Test_rollbackStep_MaxRetriesExceeded_DLQ(t *testing.T) {
 	store.EXPECT().CreateDeadLetterRecord(mock.Anything, mock.MatchedBy(func(rec *DeadLetterRecord) bool {
 		return rec != nil && rec.InstanceID == step.InstanceID && rec.StepID == step.ID && rec.WorkflowID == def.ID
 	})).Return(nil)
+	store.EXPECT().LogEvent(mock.Anything, step.InstanceID, &step.ID, EventDLQCreated, mock.Anything).Return(nil)
 
 	err := engine.rollbackStep(ctx, step, def)
 	assert.NoError(t, err)
@@ -0,0 +1,228 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSkipStepTestEngine builds an engine that leaves steps without a local handler pending,
+// which is exactly what a stuck step looks like.
+func newSkipStepTestEngine(t *testing.T, store Store) *Engine {
+	t.Helper()
+
+	return newMemoryTestEngine(t, store, []StepHandler{&SimpleTestHandler{}}, WithMissingHandlerCooldown(time.Hour))
+}
+
+func drainQueue(t *testing.T, ctx context.Context, engine *Engine) {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		empty, err := engine.ExecuteNext(ctx, "worker1")
+		require.NoError(t, err)
+		if empty {
+			return
+		}
+	}
+
+	t.Fatal("queue was not drained")
+}
+
+func TestSkipStep_ContinuesWithOperatorOutput(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := newSkipStepTestEngine(t, store)
+
+	workflowDef, err := NewBuilder("skip_linear", 1).
+		Step("start", "simple-test").
+		Then("stuck", "unregistered-handler").
+		Then("final", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{"order_id":"A-1"}`))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	stuckStep := findStepByName(steps, "stuck")
+	require.NotNil(t, stuckStep)
+	require.Equal(t, StepStatusPending, stuckStep.Status)
+
+	output := json.RawMessage(`{"order_id":"A-1","resolved":true}`)
+	err = engine.SkipStep(ctx, stuckStep.ID, "operator@example.com", output, "resolved manually")
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	instance, err := store.GetInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, instance.Status)
+	assert.JSONEq(t, string(output), string(instance.Output))
+
+	steps, err = store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	stuckStep = findStepByName(steps, "stuck")
+	require.NotNil(t, stuckStep)
+	assert.Equal(t, StepStatusSkipped, stuckStep.Status)
+	assert.JSONEq(t, string(output), string(stuckStep.Output))
+
+	finalStep := findStepByName(steps, "final")
+	require.NotNil(t, finalStep)
+	assert.JSONEq(t, string(output), string(finalStep.Input))
+
+	events, err := store.GetWorkflowEvents(ctx, instanceID)
+	require.NoError(t, err)
+
+	var skipEvent *WorkflowEvent
+	for i := range events {
+		if events[i].EventType == EventStepSkipped {
+			skipEvent = &events[i]
+		}
+	}
+	require.NotNil(t, skipEvent)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(skipEvent.Payload, &payload))
+	assert.Equal(t, "operator@example.com", payload[KeySkippedBy])
+	assert.Equal(t, "resolved manually", payload[KeyReason])
+}
+
+func TestSkipStep_NotifiesWaitingJoin(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := newSkipStepTestEngine(t, store)
+
+	workflowDef, err := NewBuilder("skip_join", 1).
+		Fork("fork", func(branch1 *Builder) {
+			branch1.Step("stuck", "unregistered-handler")
+		}, func(branch2 *Builder) {
+			branch2.Step("ok", "simple-test")
+		}).
+		Join("join", JoinStrategyAll).
+		Then("final", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Nil(t, findStepByName(steps, "join"), "join must wait for the stuck branch")
+
+	stuckStep := findStepByName(steps, "stuck")
+	require.NotNil(t, stuckStep)
+
+	err = engine.SkipStep(ctx, stuckStep.ID, "operator", json.RawMessage(`{"skipped":true}`), "dependency restored")
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	instance, err := store.GetInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, instance.Status)
+
+	steps, err = store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+
+	joinStep := findStepByName(steps, "join")
+	require.NotNil(t, joinStep)
+	assert.Equal(t, StepStatusCompleted, joinStep.Status)
+
+	var joinOutput map[string]any
+	require.NoError(t, json.Unmarshal(joinStep.Output, &joinOutput))
+	outputs, ok := joinOutput[KeyOutputs].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"skipped": true}, outputs["stuck"])
+}
+
+func TestSkipStep_RejectsFinishedStep(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := newSkipStepTestEngine(t, store)
+
+	workflowDef, err := NewBuilder("skip_finished", 1).
+		Step("start", "simple-test").
+		Then("stuck", "unregistered-handler").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	startStep := findStepByName(steps, "start")
+	require.NotNil(t, startStep)
+
+	err = engine.SkipStep(ctx, startStep.ID, "operator", nil, "oops")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be skipped")
+
+	err = engine.SkipStep(ctx, 999999, "operator", nil, "oops")
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
+
+// claimOnReadStore lets a worker claim a step right after SkipStep has read it.
+type claimOnReadStore struct {
+	*MemoryStore
+}
+
+func (s claimOnReadStore) GetStepByID(ctx context.Context, stepID int64) (*WorkflowStep, error) {
+	step, err := s.MemoryStore.GetStepByID(ctx, stepID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.MemoryStore.UpdateStep(ctx, stepID, StepStatusRunning, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
+
+func TestSkipStep_RejectsStepClaimedByWorker(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := newSkipStepTestEngine(t, claimOnReadStore{MemoryStore: store})
+
+	workflowDef, err := NewBuilder("skip_claimed", 1).
+		Step("start", "simple-test").
+		Then("stuck", "unregistered-handler").
+		Then("final", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	drainQueue(t, ctx, engine)
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	stuckStep := findStepByName(steps, "stuck")
+	require.NotNil(t, stuckStep)
+	require.Equal(t, StepStatusPending, stuckStep.Status)
+
+	err = engine.SkipStep(ctx, stuckStep.ID, "operator", json.RawMessage(`{}`), "late")
+	assert.ErrorIs(t, err, ErrStepNotSkippable)
+
+	steps, err = store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StepStatusRunning, findStepByName(steps, "stuck").Status)
+	assert.Nil(t, findStepByName(steps, "final"), "successors of a claimed step must not be enqueued")
+}
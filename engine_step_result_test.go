@@ -67,7 +67,7 @@ func Test_handleStepSuccess_SimpleNextFlow(t *testing.T) {
 		ws.ID = 2002
 		return nil
 	})
-	store.EXPECT().EnqueueStep(mock.Anything, instance.ID, mock.Anything, PriorityNormal, time.Duration(0)).Return(nil)
+	store.EXPECT().EnqueueStep(mock.Anything, instance.ID, mock.Anything, mock.Anything, PriorityNormal, time.Duration(0)).Return(nil)
 
 	err := engine.handleStepSuccess(ctx, instance, step, stepDef, output, true)
 	assert.NoError(t, err)
@@ -103,7 +103,7 @@ func Test_handleStepFailure_RetryAvailable(t *testing.T) {
 	// Expectations: mark failed, log retry, re-enqueue with delay stepDef.Delay (0)
 	store.EXPECT().UpdateStep(mock.Anything, step.ID, StepStatusFailed, json.RawMessage(nil), &errMsg).Return(nil)
 	store.EXPECT().LogEvent(mock.Anything, instance.ID, &step.ID, EventStepRetry, mock.Anything).Return(nil)
-	store.EXPECT().EnqueueStep(mock.Anything, instance.ID, &step.ID, PriorityHigh, stepDef.Delay).Return(nil)
+	store.EXPECT().EnqueueStep(mock.Anything, instance.ID, &step.ID, mock.Anything, PriorityHigh, stepDef.Delay).Return(nil)
 
 	err := engine.handleStepFailure(ctx, instance, step, stepDef, stepErr)
 	assert.NoError(t, err)
@@ -142,6 +142,7 @@ func Test_handleStepFailure_DLQEnabled_NoRetry(t *testing.T) {
 	store.EXPECT().CreateDeadLetterRecord(mock.Anything, mock.MatchedBy(func(rec *DeadLetterRecord) bool {
 		return rec != nil && rec.InstanceID == instance.ID && rec.StepID == step.ID && rec.WorkflowID == def.ID && rec.Reason != ""
 	})).Return(nil)
+	store.EXPECT().LogEvent(mock.Anything, instance.ID, &step.ID, EventDLQCreated, mock.Anything).Return(nil)
 	// 6) Freeze execution
 	store.EXPECT().PauseActiveStepsAndClearQueue(mock.Anything, instance.ID).Return(nil)
 	// 7) Update instance status -> DLQ
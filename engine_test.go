@@ -273,7 +273,7 @@ func TestEngine_Start_Success(t *testing.T) {
 	mockStore.EXPECT().CreateStep(mock.Anything, mock.MatchedBy(func(step *WorkflowStep) bool {
 		return step.InstanceID == instance.ID && step.StepName == "step1"
 	})).Return(nil)
-	mockStore.EXPECT().EnqueueStep(mock.Anything, instance.ID, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
+	mockStore.EXPECT().EnqueueStep(mock.Anything, instance.ID, mock.Anything, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
 
 	instanceID, err := engine.Start(context.Background(), workflowID, input)
 
@@ -393,7 +393,7 @@ func TestEngine_ExecuteNext_NoQueueItem(t *testing.T) {
 	workerID := "worker-1"
 
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(nil, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(nil, nil)
 		fn(ctx)
 	}).Return(nil)
 
@@ -413,7 +413,7 @@ func TestEngine_ExecuteNext_DequeueError(t *testing.T) {
 	workerID := "worker-1"
 
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(nil, errors.New("dequeue failed"))
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(nil, errors.New("dequeue failed"))
 		fn(ctx)
 	}).Return(errors.New("dequeue step: dequeue failed"))
 
@@ -450,7 +450,8 @@ func TestEngine_ExecuteNext_StepNotFound(t *testing.T) {
 	steps := []WorkflowStep{}
 
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		mockStore.EXPECT().RemoveFromQueue(mock.Anything, queueItem.ID).Return(nil)
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return(steps, nil)
@@ -680,11 +681,11 @@ func TestEngine_ExecuteFork_Success(t *testing.T) {
 	mockStore.EXPECT().CreateStep(mock.Anything, mock.MatchedBy(func(s *WorkflowStep) bool {
 		return s.InstanceID == instanceID && s.StepName == "parallel1"
 	})).Return(nil)
-	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
+	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, mock.Anything, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
 	mockStore.EXPECT().CreateStep(mock.Anything, mock.MatchedBy(func(s *WorkflowStep) bool {
 		return s.InstanceID == instanceID && s.StepName == "parallel2"
 	})).Return(nil)
-	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
+	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, mock.Anything, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
 	mockStore.EXPECT().CreateJoinState(mock.Anything, instanceID, "join-step", []string{"parallel1", "parallel2"}, JoinStrategyAll).Return(nil)
 	mockStore.EXPECT().LogEvent(mock.Anything, instanceID, &stepID, EventJoinStateCreated, mock.Anything).Return(nil)
 
@@ -943,7 +944,7 @@ func TestEngine_HandleStepSuccess_WithNextSteps(t *testing.T) {
 	mockStore.EXPECT().CreateStep(mock.Anything, mock.MatchedBy(func(s *WorkflowStep) bool {
 		return s.InstanceID == instanceID && s.StepName == "step2"
 	})).Return(nil)
-	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
+	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, mock.Anything, mock.Anything, PriorityNormal, mock.Anything).Return(nil)
 
 	err := engine.handleStepSuccess(context.Background(), instance, &step, stepDef, output, true)
 
@@ -990,7 +991,7 @@ func TestEngine_HandleStepFailure_WithRetries(t *testing.T) {
 	mockStore.EXPECT().LogEvent(mock.Anything, instanceID, &stepID, EventStepRetry, mock.MatchedBy(func(data map[string]any) bool {
 		return data[KeyRetryCount] == 2 // RetryCount was incremented from 1 to 2
 	})).Return(nil)
-	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, &stepID, PriorityHigh, mock.Anything).Return(nil)
+	mockStore.EXPECT().EnqueueStep(mock.Anything, instanceID, &stepID, mock.Anything, PriorityHigh, mock.Anything).Return(nil)
 
 	err := engine.handleStepFailure(context.Background(), instance, step, stepDef, stepErr)
 
@@ -1566,7 +1567,8 @@ func TestEngine_ExecuteNext_CompensationMissingHandler_RescheduleAndRelease(t *t
 	// Expectations inside transaction
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
 		// Dequeue a specific item
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		// Lookup instance and steps
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return([]WorkflowStep{step}, nil)
@@ -1623,7 +1625,8 @@ func TestEngine_ExecuteNext_CompensationMissingHandler_ReleaseOnly(t *testing.T)
 	}
 
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return([]WorkflowStep{step}, nil)
 		mockStore.EXPECT().GetWorkflowDefinition(mock.Anything, instance.WorkflowID).Return(def, nil)
@@ -1678,7 +1681,8 @@ func TestEngine_ExecuteNext_TaskMissingHandler_RescheduleAndRelease(t *testing.T
 	}
 
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return([]WorkflowStep{step}, nil)
 		mockStore.EXPECT().GetWorkflowDefinition(mock.Anything, instance.WorkflowID).Return(def, nil)
@@ -1732,7 +1736,8 @@ func TestEngine_ExecuteNext_TaskMissingHandler_ReleaseOnly(t *testing.T) {
 	}
 
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return([]WorkflowStep{step}, nil)
 		mockStore.EXPECT().GetWorkflowDefinition(mock.Anything, instance.WorkflowID).Return(def, nil)
@@ -1787,7 +1792,8 @@ func TestEngine_ExecuteNext_TaskMissingHandler_LogThrottling(t *testing.T) {
 
 	// First execution: should log
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem1, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem1, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem1.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return([]WorkflowStep{step1}, nil)
 		mockStore.EXPECT().GetWorkflowDefinition(mock.Anything, instance.WorkflowID).Return(def, nil)
@@ -1799,7 +1805,8 @@ func TestEngine_ExecuteNext_TaskMissingHandler_LogThrottling(t *testing.T) {
 
 	// Second execution immediately: should NOT log again due to throttling
 	mockTxManager.EXPECT().ReadCommitted(mock.Anything, mock.Anything).Run(func(ctx context.Context, fn func(ctx context.Context) error) {
-		mockStore.EXPECT().DequeueStep(mock.Anything, workerID).Return(queueItem2, nil)
+		mockStore.EXPECT().DequeueStep(mock.Anything, workerID, mock.Anything).Return(queueItem2, nil)
+		mockStore.EXPECT().LeaseQueueItem(mock.Anything, queueItem2.ID, workerID, mock.Anything, mock.Anything).Return(nil)
 		mockStore.EXPECT().GetInstance(mock.Anything, instanceID).Return(instance, nil)
 		mockStore.EXPECT().GetStepsByInstance(mock.Anything, instanceID).Return([]WorkflowStep{step2}, nil)
 		mockStore.EXPECT().GetWorkflowDefinition(mock.Anything, instance.WorkflowID).Return(def, nil)
@@ -392,6 +392,22 @@ func findStepByName(steps []WorkflowStep, name string) *WorkflowStep {
 	return nil
 }
 
+// newMemoryTestEngine builds an engine on store with the memory tx manager and the given handlers
+// and shuts it down when the test ends.
+func newMemoryTestEngine(t testing.TB, store Store, handlers []StepHandler, opts ...EngineOption) *Engine {
+	t.Helper()
+
+	opts = append([]EngineOption{WithEngineStore(store), WithEngineTxManager(NewMemoryTxManager())}, opts...)
+	engine := NewEngine(nil, opts...)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	for _, handler := range handlers {
+		engine.RegisterHandler(handler)
+	}
+
+	return engine
+}
+
 func TestInMemoryStoreMultipleInstances(t *testing.T) {
 	ctx := context.Background()
 	store := NewMemoryStore()
@@ -512,10 +528,10 @@ func TestInMemoryStoreQueueOperations(t *testing.T) {
 	instanceID := int64(1)
 	stepID := int64(10)
 
-	err := store.EnqueueStep(ctx, instanceID, &stepID, PriorityNormal, 0)
+	err := store.EnqueueStep(ctx, instanceID, &stepID, "", PriorityNormal, 0)
 	require.NoError(t, err)
 
-	item, err := store.DequeueStep(ctx, "worker-1")
+	item, err := store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	require.NotNil(t, item)
 	assert.Equal(t, instanceID, item.InstanceID)
@@ -524,7 +540,7 @@ func TestInMemoryStoreQueueOperations(t *testing.T) {
 	err = store.RemoveFromQueue(ctx, item.ID)
 	require.NoError(t, err)
 
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 }
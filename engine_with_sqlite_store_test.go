@@ -25,7 +25,7 @@ import (
 // - ReleaseResourceHandler
 // - CleanupResourceHandler
 
-func newSQLiteStoreForTest(t *testing.T) *SQLiteStore {
+func newSQLiteStoreForTest(t testing.TB) *SQLiteStore {
 	store, err := NewSQLiteInMemoryStore()
 	require.NoError(t, err)
 	return store
@@ -85,9 +85,9 @@ func TestSQLiteStoreQueueOperations(t *testing.T) {
 	instanceID := int64(1)
 	stepID := int64(10)
 	// enqueue
-	require.NoError(t, store.EnqueueStep(ctx, instanceID, &stepID, PriorityNormal, 0))
+	require.NoError(t, store.EnqueueStep(ctx, instanceID, &stepID, "", PriorityNormal, 0))
 	// dequeue
-	item, err := store.DequeueStep(ctx, "worker-1")
+	item, err := store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	require.NotNil(t, item)
 	assert.Equal(t, instanceID, item.InstanceID)
@@ -96,7 +96,7 @@ func TestSQLiteStoreQueueOperations(t *testing.T) {
 	// remove
 	require.NoError(t, store.RemoveFromQueue(ctx, item.ID))
 	// next dequeue returns nil
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 }
@@ -247,47 +247,47 @@ func TestSQLiteStoreAgingRateValidation(t *testing.T) {
 	store.SetAgingRate(math.NaN())
 	store.SetAgingEnabled(true)
 	// Should not cause SQL error when dequeuing
-	item, err := store.DequeueStep(ctx, "worker-1")
+	item, err := store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item) // No items in queue, but no SQL error
 
 	// Test positive infinity - should be clamped to 0.0
 	store.SetAgingRate(math.Inf(1))
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
 	// Test negative infinity - should be clamped to 0.0
 	store.SetAgingRate(math.Inf(-1))
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
 	// Test negative value - should be clamped to 0.0
 	store.SetAgingRate(-10.0)
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
 	// Test value above maximum - should be clamped to MaxAgingRate (100.0)
 	store.SetAgingRate(1000.0)
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
 	// Test valid values - should work without clamping
 	store.SetAgingRate(0.5)
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
 	store.SetAgingRate(50.0)
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
 	store.SetAgingRate(100.0)
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	assert.Nil(t, item)
 
@@ -295,11 +295,75 @@ func TestSQLiteStoreAgingRateValidation(t *testing.T) {
 	store.SetAgingRate(0.5)
 	instanceID := int64(1)
 	stepID := int64(10)
-	require.NoError(t, store.EnqueueStep(ctx, instanceID, &stepID, PriorityNormal, 0))
+	require.NoError(t, store.EnqueueStep(ctx, instanceID, &stepID, "", PriorityNormal, 0))
 
 	// Dequeue should work with aging enabled
-	item, err = store.DequeueStep(ctx, "worker-1")
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
 	require.NoError(t, err)
 	require.NotNil(t, item)
 	assert.Equal(t, instanceID, item.InstanceID)
 }
+
+func TestSQLiteStoreDLQRedrivePolicy(t *testing.T) {
+	testDLQRedrivePolicy(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreGetEventsAfter(t *testing.T) {
+	testGetEventsAfter(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreDequeueSteps(t *testing.T) {
+	testDequeueSteps(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreDequeueTieBreak(t *testing.T) {
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store, err := NewSQLiteInMemoryStore(WithStoreClock(clock))
+	require.NoError(t, err)
+
+	testDequeueTieBreak(t, store)
+}
+
+func TestSQLiteStoreDequeueTaskQueues(t *testing.T) {
+	testDequeueTaskQueues(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreQueueLeases(t *testing.T) {
+	testQueueLeases(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreReclaimExpiredQueueItems(t *testing.T) {
+	testReclaimExpiredQueueItems(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreWorkerRegistry(t *testing.T) {
+	testWorkerRegistry(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreGetQueueLength(t *testing.T) {
+	testGetQueueLength(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreNamespaces(t *testing.T) {
+	testNamespaces(t, newSQLiteStoreForTest(t))
+}
+
+func TestSQLiteStoreClock(t *testing.T) {
+	ctx := context.Background()
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store, err := NewSQLiteInMemoryStore(WithStoreClock(clock))
+	require.NoError(t, err)
+
+	stepID := int64(10)
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "", PriorityNormal, time.Minute))
+
+	item, err := store.DequeueStep(ctx, "worker-1", nil)
+	require.NoError(t, err)
+	assert.Nil(t, item)
+
+	clock.Advance(time.Minute)
+	item, err = store.DequeueStep(ctx, "worker-1", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.True(t, item.ScheduledAt.Equal(clock.Now()))
+}
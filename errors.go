@@ -6,4 +6,13 @@ import (
 
 var (
 	ErrEntityNotFound = errors.New("entity not found")
+	// ErrLeaseLost is returned when a queue item lease expired and was taken over, or the item was released.
+	ErrLeaseLost = errors.New("queue item lease lost")
+	// ErrNamespaceMismatch is returned when a workflow definition ID is already used in another namespace.
+	ErrNamespaceMismatch = errors.New("workflow definition belongs to another namespace")
+	// ErrNamespaceLimitExceeded is returned by Start when the namespace has its maximum of running instances.
+	ErrNamespaceLimitExceeded = errors.New("namespace running instance limit exceeded")
+	// ErrStepNotSkippable is returned when the step type, the step status or the instance status does not
+	// allow a skip, for example because a worker claimed the step before it could be skipped.
+	ErrStepNotSkippable = errors.New("step cannot be skipped")
 )
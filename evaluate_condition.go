@@ -18,6 +18,19 @@ var (
 	templateMutex sync.RWMutex
 )
 
+var conditionFuncs = template.FuncMap{
+	"eq": func(a, b any) bool { return compareEqualDecimal(a, b) },
+	"ne": func(a, b any) bool { return !compareEqualDecimal(a, b) },
+	"gt": func(a, b any) bool { return compareNumbersDecimal(a, b) > 0 },
+	"lt": func(a, b any) bool { return compareNumbersDecimal(a, b) < 0 },
+	"ge": func(a, b any) bool { return compareNumbersDecimal(a, b) >= 0 },
+	"le": func(a, b any) bool { return compareNumbersDecimal(a, b) <= 0 },
+	// Additional helper functions
+	"contains":  func(s, substr string) bool { return strings.Contains(s, substr) },
+	"hasPrefix": func(s, prefix string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix": func(s, suffix string) bool { return strings.HasSuffix(s, suffix) },
+}
+
 func evaluateCondition(expr string, stepCtx StepContext) (bool, error) {
 	// Cache compiled templates for better performance and thread safety
 	templateMutex.RLock()
@@ -30,18 +43,7 @@ func evaluateCondition(expr string, stepCtx StepContext) (bool, error) {
 		tpl, exists = templateCache[expr]
 		if !exists {
 			var err error
-			tpl, err = template.New("condition").Funcs(template.FuncMap{
-				"eq": func(a, b any) bool { return compareEqualDecimal(a, b) },
-				"ne": func(a, b any) bool { return !compareEqualDecimal(a, b) },
-				"gt": func(a, b any) bool { return compareNumbersDecimal(a, b) > 0 },
-				"lt": func(a, b any) bool { return compareNumbersDecimal(a, b) < 0 },
-				"ge": func(a, b any) bool { return compareNumbersDecimal(a, b) >= 0 },
-				"le": func(a, b any) bool { return compareNumbersDecimal(a, b) <= 0 },
-				// Additional helper functions
-				"contains":  func(s, substr string) bool { return strings.Contains(s, substr) },
-				"hasPrefix": func(s, prefix string) bool { return strings.HasPrefix(s, prefix) },
-				"hasSuffix": func(s, suffix string) bool { return strings.HasSuffix(s, suffix) },
-			}).Parse(expr)
+			tpl, err = template.New("condition").Funcs(conditionFuncs).Parse(expr)
 			if err != nil {
 				templateMutex.Unlock()
 				return false, fmt.Errorf("parse condition: %w", err)
@@ -20,8 +20,13 @@ const (
 	EventConditionCheck            = "condition_check"
 	EventCancellationStarted       = "cancellation_started"
 	EventAbortStarted              = "abort_started"
+	EventDLQCreated                = "dlq_created"
 	EventDLQRequeued               = "dlq_requeued"
+	EventDLQRedriven               = "dlq_redriven"
+	EventDLQDiscarded              = "dlq_discarded"
 	EventStepSkippedMissingHandler = "step_skipped_missing_handler"
+	EventStepSkipped               = "step_skipped"
+	EventQueueItemRecovered        = "queue_item_recovered"
 
 	// Event data keys
 	KeyWorkflowID    = "workflow_id"
@@ -47,4 +52,11 @@ const (
 	KeyMessage       = "message"
 	KeyRequestedBy   = "requested_by"
 	KeyCancelType    = "cancel_type"
+	KeySkippedBy     = "skipped_by"
+	KeyDLQID         = "dlq_id"
+	KeyRedriveCount  = "redrive_count"
+	KeyNextRedriveAt = "next_redrive_at"
+	KeyQueueID       = "queue_id"
+	KeyWorkerID      = "worker_id"
+	KeyLeaseExpiry   = "lease_expires_at"
 )
@@ -0,0 +1,156 @@
+package floxy
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// EventFilter narrows GetEventsAfter. Zero-valued fields do not filter.
+type EventFilter struct {
+	InstanceID int64
+	WorkflowID string
+	EventTypes []string
+}
+
+func (f *EventFilter) matches(event *WorkflowEvent, workflowID string) bool {
+	if f.InstanceID != 0 && event.InstanceID != f.InstanceID {
+		return false
+	}
+	if f.WorkflowID != "" && workflowID != f.WorkflowID {
+		return false
+	}
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, event.EventType) {
+		return false
+	}
+
+	return true
+}
+
+const (
+	// DefaultEventSettleWindow bounds how long a transaction may hold an event ID before it
+	// commits. Longer transactions can still commit events an EventCursor never returns.
+	DefaultEventSettleWindow = 5 * time.Second
+
+	defaultEventCursorBatchSize = 500
+)
+
+// EventCursor tails the event log with GetEventsAfter. Event IDs are taken before commit, so
+// an event can become visible after events with higher IDs. The cursor therefore re-reads the
+// IDs logged within the settle window on every poll and skips the events it already returned.
+type EventCursor struct {
+	store     Store
+	filter    EventFilter
+	settle    time.Duration
+	batchSize int
+	clock     Clock
+
+	position  int64
+	delivered map[int64]struct{}
+	// marks are the last event IDs seen by previous polls, oldest first
+	marks []eventMark
+}
+
+type eventMark struct {
+	id int64
+	at time.Time
+}
+
+type EventCursorOption func(*EventCursor)
+
+// WithEventCursorSettleWindow sets how long the cursor re-reads an ID range, see
+// DefaultEventSettleWindow.
+func WithEventCursorSettleWindow(settle time.Duration) EventCursorOption {
+	return func(c *EventCursor) {
+		c.settle = settle
+	}
+}
+
+// WithEventCursorBatchSize sets the page size of GetEventsAfter.
+func WithEventCursorBatchSize(batchSize int) EventCursorOption {
+	return func(c *EventCursor) {
+		c.batchSize = batchSize
+	}
+}
+
+func WithEventCursorClock(clock Clock) EventCursorOption {
+	return func(c *EventCursor) {
+		c.clock = clock
+	}
+}
+
+// NewEventCursor starts after the event position afterID, as returned by Position.
+func NewEventCursor(store Store, filter EventFilter, afterID int64, opts ...EventCursorOption) *EventCursor {
+	c := &EventCursor{
+		store:     store,
+		filter:    filter,
+		settle:    DefaultEventSettleWindow,
+		batchSize: defaultEventCursorBatchSize,
+		clock:     SystemClock,
+		position:  max(afterID, 0),
+		delivered: make(map[int64]struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Next returns the matching events that became visible since the previous call, in ID order
+// within one call. On error it returns the events read before the error as well.
+func (c *EventCursor) Next(ctx context.Context) ([]WorkflowEvent, error) {
+	lastID, err := c.store.GetLastEventID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := c.clock.Now()
+	c.marks = append(c.marks, eventMark{id: lastID, at: now})
+
+	var result []WorkflowEvent
+	afterID := c.position
+	for {
+		events, err := c.store.GetEventsAfter(ctx, afterID, c.filter, c.batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, event := range events {
+			afterID = event.ID
+			if _, ok := c.delivered[event.ID]; ok {
+				continue
+			}
+
+			c.delivered[event.ID] = struct{}{}
+			result = append(result, event)
+		}
+
+		if len(events) < c.batchSize {
+			break
+		}
+	}
+
+	// Every event up to a mark seen a settle window ago has committed by now, and the reads
+	// above started at or below it, so the cursor does not need to read that range again.
+	settled := 0
+	for settled < len(c.marks) && !c.marks[settled].at.After(now.Add(-c.settle)) {
+		c.position = max(c.position, c.marks[settled].id)
+		settled++
+	}
+	c.marks = c.marks[settled:]
+
+	for id := range c.delivered {
+		if id <= c.position {
+			delete(c.delivered, id)
+		}
+	}
+
+	return result, nil
+}
+
+// Position returns the resume position: every event up to it has been returned. Events after
+// it may have been returned too, so a stream resumed from it can repeat events, which
+// consumers skip by event ID.
+func (c *EventCursor) Position() int64 {
+	return c.position
+}
@@ -0,0 +1,225 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventIDs(events []WorkflowEvent) []int64 {
+	ids := make([]int64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+// testGetEventsAfter checks ID tailing and filters of Store.GetEventsAfter.
+func testGetEventsAfter(t *testing.T, store Store) {
+	ctx := context.Background()
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+	engine.RegisterHandler(&SimpleTestHandler{})
+
+	lastID, err := store.GetLastEventID(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, lastID)
+
+	var instanceIDs []int64
+	for _, name := range []string{"events_a", "events_b"} {
+		workflowDef, err := NewBuilder(name, 1).Step("start", "simple-test").Build()
+		require.NoError(t, err)
+		require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+		instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+
+	all, err := store.GetEventsAfter(ctx, 0, EventFilter{}, 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+	for i := 1; i < len(all); i++ {
+		assert.Less(t, all[i-1].ID, all[i].ID)
+	}
+
+	lastID, err = store.GetLastEventID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all[len(all)-1].ID, lastID)
+
+	tail, err := store.GetEventsAfter(ctx, all[0].ID, EventFilter{}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{all[1].ID}, eventIDs(tail))
+
+	none, err := store.GetEventsAfter(ctx, lastID, EventFilter{}, 100)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	byInstance, err := store.GetEventsAfter(ctx, 0, EventFilter{InstanceID: instanceIDs[1]}, 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, byInstance)
+	byWorkflow, err := store.GetEventsAfter(ctx, 0, EventFilter{WorkflowID: "events_b-v1"}, 100)
+	require.NoError(t, err)
+	assert.Equal(t, eventIDs(byInstance), eventIDs(byWorkflow))
+
+	byType, err := store.GetEventsAfter(ctx, 0, EventFilter{EventTypes: []string{EventWorkflowStarted}}, 100)
+	require.NoError(t, err)
+	require.Len(t, byType, 2)
+	assert.Equal(t, instanceIDs[0], byType[0].InstanceID)
+	assert.Equal(t, instanceIDs[1], byType[1].InstanceID)
+}
+
+func TestGetEventsAfter_MemoryStore(t *testing.T) {
+	testGetEventsAfter(t, NewMemoryStore())
+}
+
+// lateCommitStore hides events of uncommitted transactions, which hold lower IDs than
+// events committed meanwhile.
+type lateCommitStore struct {
+	Store
+	uncommitted map[int64]bool
+}
+
+func (s *lateCommitStore) GetEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter EventFilter,
+	limit int,
+) ([]WorkflowEvent, error) {
+	events, err := s.Store.GetEventsAfter(ctx, afterID, filter, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]WorkflowEvent, 0, len(events))
+	for _, event := range events {
+		if !s.uncommitted[event.ID] && len(visible) < limit {
+			visible = append(visible, event)
+		}
+	}
+
+	return visible, nil
+}
+
+func (s *lateCommitStore) GetLastEventID(ctx context.Context) (int64, error) {
+	events, err := s.GetEventsAfter(ctx, 0, EventFilter{}, 1000)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	return events[len(events)-1].ID, nil
+}
+
+func TestEventCursor_LateCommit(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryStore()
+	workflowDef, err := NewBuilder("cursor", 1).Step("start", "simple-test").Build()
+	require.NoError(t, err)
+	require.NoError(t, memory.SaveWorkflowDefinition(ctx, workflowDef))
+	instance, err := memory.CreateInstance(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	var ids []int64
+	for range 3 {
+		require.NoError(t, memory.LogEvent(ctx, instance.ID, nil, EventStepStarted, nil))
+		lastID, err := memory.GetLastEventID(ctx)
+		require.NoError(t, err)
+		ids = append(ids, lastID)
+	}
+
+	store := &lateCommitStore{Store: memory, uncommitted: map[int64]bool{ids[0]: true}}
+	clock := NewManualClock(time.Now())
+	cursor := NewEventCursor(store, EventFilter{}, 0, WithEventCursorClock(clock), WithEventCursorBatchSize(1))
+
+	events, err := cursor.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ids[1:], eventIDs(events))
+	assert.Zero(t, cursor.Position())
+
+	store.uncommitted[ids[0]] = false
+	clock.Advance(time.Second)
+	events, err = cursor.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ids[:1], eventIDs(events))
+
+	clock.Advance(DefaultEventSettleWindow)
+	events, err = cursor.Next(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, ids[2], cursor.Position())
+
+	// Resuming from a position repeats the events after it
+	resumed := NewEventCursor(store, EventFilter{}, ids[0], WithEventCursorClock(clock))
+	events, err = resumed.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ids[1:], eventIDs(events))
+}
+
+func TestIntegration_EventCursorLateCommit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	if useSQLite {
+		// TestEventCursor_LateCommit covers the cursor logic on the memory store
+		t.Skip("the SQLite tx manager has no transaction isolation to hold back a commit")
+	}
+
+	ctx := context.Background()
+	store, txManager, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	workflowDef, err := NewBuilder("cursor", 1).Step("start", "simple-test").Build()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveWorkflowDefinition(ctx, workflowDef))
+	instance, err := store.CreateInstance(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	startID, err := store.GetLastEventID(ctx)
+	require.NoError(t, err)
+	cursor := NewEventCursor(store, EventFilter{InstanceID: instance.ID}, startID)
+
+	// The first transaction takes the lower event ID and commits after the second one
+	logged := make(chan struct{})
+	commit := make(chan struct{})
+	firstDone := make(chan error, 1)
+	go func() {
+		firstDone <- txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+			if err := store.LogEvent(ctx, instance.ID, nil, EventStepStarted, nil); err != nil {
+				close(logged)
+
+				return err
+			}
+			close(logged)
+			<-commit
+
+			return nil
+		})
+	}()
+	<-logged
+
+	require.NoError(t, txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		return store.LogEvent(ctx, instance.ID, nil, EventStepCompleted, nil)
+	}))
+
+	events, err := cursor.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventStepCompleted, events[0].EventType)
+
+	close(commit)
+	require.NoError(t, <-firstDone)
+
+	events, err = cursor.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventStepStarted, events[0].EventType)
+	assert.Equal(t, startID, cursor.Position())
+}
@@ -0,0 +1,274 @@
+// Package floxytest runs workflows deterministically in tests: the engine executes queue items
+// one at a time on the calling goroutine, handlers are scripted mocks and the engine and store
+// run on a floxy.ManualClock.
+package floxytest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+const (
+	defaultMaxSteps = 10000
+	workerID        = "floxytest"
+)
+
+// Env is a test environment around an engine with an in-memory store.
+type Env struct {
+	t testing.TB
+
+	Engine *floxy.Engine
+	Store  floxy.Store
+	Clock  *floxy.ManualClock
+
+	store    *clockedStore
+	ctx      context.Context
+	maxSteps int
+	handlers map[string]*HandlerMock
+}
+
+type Option func(env *Env)
+
+// WithStartTime sets the initial time of the clock. It defaults to 2025-01-01 UTC.
+func WithStartTime(t time.Time) Option {
+	return func(env *Env) {
+		env.Clock.Set(t)
+	}
+}
+
+// WithMaxSteps bounds the queue items Run executes before the test fails. It defaults to 10000.
+func WithMaxSteps(n int) Option {
+	return func(env *Env) {
+		env.maxSteps = n
+	}
+}
+
+// WithContext sets the context of engine calls, e.g. one scoped by floxy.WithNamespace.
+func WithContext(ctx context.Context) Option {
+	return func(env *Env) {
+		env.ctx = ctx
+	}
+}
+
+// New creates a test environment. The engine is shut down on test cleanup.
+func New(t testing.TB, opts ...Option) *Env {
+	t.Helper()
+
+	clock := floxy.NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := newClockedStore(clock)
+
+	env := &Env{
+		t:        t,
+		Store:    store,
+		Clock:    clock,
+		store:    store,
+		ctx:      context.Background(),
+		maxSteps: defaultMaxSteps,
+		handlers: make(map[string]*HandlerMock),
+	}
+	for _, opt := range opts {
+		opt(env)
+	}
+
+	// Lease reaping and DLQ redrives would run concurrently with Step on clock ticks
+	env.Engine = floxy.NewEngine(nil,
+		floxy.WithEngineStore(store),
+		floxy.WithEngineTxManager(floxy.NewMemoryTxManager()),
+		floxy.WithEngineClock(clock),
+		floxy.WithLeaseReaperInterval(0),
+		floxy.WithDLQRedriveInterval(0),
+	)
+	t.Cleanup(func() { _ = env.Engine.Shutdown() })
+
+	return env
+}
+
+// Handler returns the mock registered under name, registering it on first use.
+func (env *Env) Handler(name string) *HandlerMock {
+	if mock, ok := env.handlers[name]; ok {
+		return mock
+	}
+
+	mock := newHandlerMock(name, env.Clock)
+	env.handlers[name] = mock
+	env.Engine.RegisterHandler(mock)
+
+	return mock
+}
+
+// Register registers the workflow and a mock for every handler of its steps not registered yet.
+func (env *Env) Register(def *floxy.WorkflowDefinition) {
+	env.t.Helper()
+
+	for _, step := range def.Definition.Steps {
+		if step.Handler != "" {
+			env.Handler(step.Handler)
+		}
+	}
+
+	if err := env.Engine.RegisterWorkflow(env.ctx, def); err != nil {
+		env.t.Fatalf("floxytest: register workflow %s: %v", def.ID, err)
+	}
+}
+
+// Start starts an instance of a registered workflow. The input is marshaled to JSON unless it
+// is json.RawMessage already.
+func (env *Env) Start(workflowID string, input any, opts ...floxy.StartOption) int64 {
+	env.t.Helper()
+
+	data, ok := input.(json.RawMessage)
+	if !ok {
+		var err error
+		data, err = json.Marshal(input)
+		if err != nil {
+			env.t.Fatalf("floxytest: marshal input: %v", err)
+		}
+	}
+
+	instanceID, err := env.Engine.Start(env.ctx, workflowID, data, opts...)
+	if err != nil {
+		env.t.Fatalf("floxytest: start %s: %v", workflowID, err)
+	}
+
+	return instanceID
+}
+
+// Execute registers the workflow, starts it and runs it until it settles.
+func (env *Env) Execute(def *floxy.WorkflowDefinition, input any) int64 {
+	env.t.Helper()
+
+	env.Register(def)
+	instanceID := env.Start(def.ID, input)
+	env.Run()
+
+	return instanceID
+}
+
+// Step executes the next due queue item. It returns false when no item is due.
+func (env *Env) Step() bool {
+	env.t.Helper()
+
+	empty, err := env.Engine.ExecuteNext(env.ctx, workerID)
+	if err != nil {
+		env.t.Fatalf("floxytest: execute next: %v", err)
+	}
+
+	return !empty
+}
+
+// Run executes queue items until none is left, advancing the clock to delayed items when
+// nothing else is due. It stops early at steps waiting for a human decision.
+func (env *Env) Run() {
+	env.t.Helper()
+
+	for i := 0; i < env.maxSteps; i++ {
+		if env.Step() {
+			continue
+		}
+
+		next, ok := env.store.nextDue()
+		if !ok {
+			return
+		}
+		if delay := next.Sub(env.Clock.Now()); delay > 0 {
+			env.Clock.Advance(delay)
+		}
+	}
+
+	env.t.Fatalf("floxytest: workflows did not settle within %d steps", env.maxSteps)
+}
+
+// RunFor executes queue items due within d on the clock and leaves the clock at now+d.
+func (env *Env) RunFor(d time.Duration) {
+	env.t.Helper()
+
+	deadline := env.Clock.Now().Add(d)
+	for i := 0; i < env.maxSteps; i++ {
+		if env.Step() {
+			continue
+		}
+
+		next, ok := env.store.nextDue()
+		if !ok || next.After(deadline) {
+			env.Clock.Set(deadline)
+
+			return
+		}
+		if delay := next.Sub(env.Clock.Now()); delay > 0 {
+			env.Clock.Advance(delay)
+		}
+	}
+
+	env.t.Fatalf("floxytest: workflows did not settle within %d steps", env.maxSteps)
+}
+
+// Decide makes a human decision on the waiting step of the instance and runs on.
+func (env *Env) Decide(instanceID int64, stepName string, decision floxy.HumanDecision) {
+	env.t.Helper()
+
+	step := env.step(instanceID, stepName)
+	if err := env.Engine.MakeHumanDecision(env.ctx, step.ID, workerID, decision, nil); err != nil {
+		env.t.Fatalf("floxytest: decide %s: %v", stepName, err)
+	}
+	env.store.unpark(step.ID)
+
+	env.Run()
+}
+
+// Cancel cancels the instance and runs its compensations.
+func (env *Env) Cancel(instanceID int64, reason string) {
+	env.t.Helper()
+
+	if err := env.Engine.CancelWorkflow(env.ctx, instanceID, workerID, reason); err != nil {
+		env.t.Fatalf("floxytest: cancel %d: %v", instanceID, err)
+	}
+
+	env.Run()
+}
+
+// Status returns the status of the instance.
+func (env *Env) Status(instanceID int64) floxy.WorkflowStatus {
+	env.t.Helper()
+
+	status, err := env.Engine.GetStatus(env.ctx, instanceID)
+	if err != nil {
+		env.t.Fatalf("floxytest: get status of %d: %v", instanceID, err)
+	}
+
+	return status
+}
+
+// Output returns the output of the instance.
+func (env *Env) Output(instanceID int64) json.RawMessage {
+	env.t.Helper()
+
+	instance, err := env.Store.GetInstance(env.ctx, instanceID)
+	if err != nil {
+		env.t.Fatalf("floxytest: get instance %d: %v", instanceID, err)
+	}
+
+	return instance.Output
+}
+
+func (env *Env) step(instanceID int64, stepName string) floxy.WorkflowStep {
+	env.t.Helper()
+
+	steps, err := env.Engine.GetSteps(env.ctx, instanceID)
+	if err != nil {
+		env.t.Fatalf("floxytest: get steps of %d: %v", instanceID, err)
+	}
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].StepName == stepName {
+			return steps[i]
+		}
+	}
+
+	env.t.Fatalf("floxytest: step %s of instance %d not found", stepName, instanceID)
+
+	return floxy.WorkflowStep{}
+}
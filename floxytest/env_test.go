@@ -0,0 +1,143 @@
+package floxytest
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+func TestEnv_RetriesWithBackoffOnClock(t *testing.T) {
+	env := New(t)
+
+	def, err := floxy.NewBuilder("retry", 1).
+		Step("charge", "charge",
+			floxy.WithStepMaxRetries(3),
+			floxy.WithStepRetryDelay(time.Minute),
+			floxy.WithStepRetryStrategy(floxy.RetryStrategyExponential)).
+		Then("ship", "ship", floxy.WithStepDelay(time.Hour)).
+		Build()
+	require.NoError(t, err)
+
+	charge := env.Handler("charge").
+		Fail(errors.New("declined")).
+		Panic("boom").
+		Return(map[string]any{"charged": true})
+
+	start := env.Clock.Now()
+	instanceID := env.Execute(def, map[string]any{"order": 1})
+
+	env.AssertStatus(instanceID, floxy.StatusCompleted)
+	env.AssertExecuted(instanceID, "charge", "charge", "charge", "ship")
+	assert.JSONEq(t, `{"charged":true}`, string(env.Output(instanceID)))
+
+	calls := charge.Calls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, []int{0, 1, 2}, []int{calls[0].RetryCount, calls[1].RetryCount, calls[2].RetryCount})
+	assert.ErrorContains(t, calls[1].Err, "boom")
+
+	// The retries and the step delay passed on the clock only
+	assert.GreaterOrEqual(t, env.Clock.Now().Sub(start), time.Hour)
+}
+
+func TestEnv_CompensationOrder(t *testing.T) {
+	env := New(t)
+
+	def, err := floxy.NewBuilder("saga", 1).
+		Step("reserve", "reserve").OnFailure("release", "release").
+		Then("charge", "charge").OnFailure("refund", "refund").
+		Then("ship", "ship", floxy.WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+
+	env.Register(def)
+	env.Handler("ship").Fail(errors.New("no courier")).Always()
+
+	instanceID := env.Start(def.ID, map[string]any{})
+	env.Run()
+
+	env.AssertStatus(instanceID, floxy.StatusFailed)
+	env.AssertStepOrder(instanceID, "reserve", "charge", "ship")
+	env.AssertCompensated(instanceID, "charge", "reserve")
+	assert.Equal(t, 1, env.Handler("refund").CallCount())
+	assert.True(t, env.Handler("release").Calls()[0].Compensation)
+}
+
+func TestEnv_JoinOutcomeAndGolden(t *testing.T) {
+	env := New(t)
+
+	def, err := floxy.NewBuilder("fanout", 1).
+		Fork("fork",
+			func(branch *floxy.Builder) { branch.Step("left", "left") },
+			func(branch *floxy.Builder) { branch.Step("right", "right") },
+		).
+		Join("join", floxy.JoinStrategyAll).
+		Then("done", "done").
+		Build()
+	require.NoError(t, err)
+
+	instanceID := env.Execute(def, map[string]any{})
+
+	env.AssertStatus(instanceID, floxy.StatusCompleted)
+	env.AssertStepOrder(instanceID, "fork", "join", "done")
+	env.AssertJoin(instanceID, "join", JoinOutcome{
+		Status:    floxy.StepStatusCompleted,
+		Completed: []string{"left", "right"},
+		Failed:    []string{},
+	})
+
+	snapshot := env.Snapshot(instanceID)
+	assert.Contains(t, snapshot, "join_completed join success\n")
+	assert.Contains(t, snapshot, "workflow_completed\n")
+
+	path := filepath.Join(t.TempDir(), "fanout.golden")
+	*updateGolden = true
+	env.AssertGolden(instanceID, path)
+	*updateGolden = false
+	env.AssertGolden(instanceID, path)
+}
+
+func TestEnv_HumanDecision(t *testing.T) {
+	env := New(t)
+
+	def, err := floxy.NewBuilder("approval", 1).
+		Step("prepare", "prepare").
+		WaitHumanConfirm("approve").
+		Then("publish", "publish").
+		Build()
+	require.NoError(t, err)
+
+	instanceID := env.Execute(def, map[string]any{})
+	env.AssertExecuted(instanceID, "prepare", "approve")
+	assert.Equal(t, 0, env.Handler("publish").CallCount())
+
+	env.Decide(instanceID, "approve", floxy.HumanDecisionConfirmed)
+
+	env.AssertStatus(instanceID, floxy.StatusCompleted)
+	env.AssertExecuted(instanceID, "prepare", "approve", "publish")
+}
+
+func TestEnv_TimeOutAdvancesClock(t *testing.T) {
+	env := New(t)
+
+	def, err := floxy.NewBuilder("slow", 1).
+		Step("call", "call", floxy.WithStepTimeout(time.Hour), floxy.WithStepMaxRetries(1)).
+		Build()
+	require.NoError(t, err)
+
+	env.Handler("call").TimeOut()
+
+	start := env.Clock.Now()
+	instanceID := env.Execute(def, map[string]any{})
+
+	env.AssertStatus(instanceID, floxy.StatusCompleted)
+	assert.Equal(t, 2, env.Handler("call").CallCount())
+	assert.ErrorIs(t, env.Handler("call").Calls()[0].Err, context.DeadlineExceeded)
+	assert.Greater(t, env.Clock.Now().Sub(start), 59*time.Minute)
+}
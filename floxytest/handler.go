@@ -0,0 +1,192 @@
+package floxytest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+var _ floxy.StepHandler = (*HandlerMock)(nil)
+
+// Behavior produces the result of one handler call.
+type Behavior func(ctx context.Context, stepCtx floxy.StepContext, input json.RawMessage) (json.RawMessage, error)
+
+// Call is a recorded handler call.
+type Call struct {
+	StepName     string
+	Input        json.RawMessage
+	RetryCount   int
+	Compensation bool
+	Output       json.RawMessage
+	Err          error
+}
+
+// HandlerMock is a step handler running scripted behaviors, one per call, in the order they
+// were added. Once the script is exhausted it echoes the input, unless Always made the last
+// scripted behavior permanent.
+type HandlerMock struct {
+	name  string
+	clock *floxy.ManualClock
+
+	mu       sync.Mutex
+	script   []Behavior
+	fallback Behavior
+	calls    []Call
+}
+
+func newHandlerMock(name string, clock *floxy.ManualClock) *HandlerMock {
+	return &HandlerMock{
+		name:     name,
+		clock:    clock,
+		fallback: echo,
+	}
+}
+
+func echo(_ context.Context, _ floxy.StepContext, input json.RawMessage) (json.RawMessage, error) {
+	return input, nil
+}
+
+func (m *HandlerMock) Name() string {
+	return m.name
+}
+
+func (m *HandlerMock) Execute(
+	ctx context.Context,
+	stepCtx floxy.StepContext,
+	input json.RawMessage,
+) (output json.RawMessage, err error) {
+	m.mu.Lock()
+	behavior := m.fallback
+	if len(m.script) > 0 {
+		behavior = m.script[0]
+		m.script = m.script[1:]
+	}
+	m.mu.Unlock()
+
+	reason, _ := stepCtx.GetVariableAsString("reason")
+	call := Call{
+		StepName:     stepCtx.StepName(),
+		Input:        input,
+		RetryCount:   stepCtx.RetryCount(),
+		Compensation: reason == "compensation",
+	}
+
+	defer func() {
+		call.Output, call.Err = output, err
+		if r := recover(); r != nil {
+			call.Err = fmt.Errorf("panic: %v", r)
+			m.record(call)
+
+			panic(r)
+		}
+		m.record(call)
+	}()
+
+	return behavior(ctx, stepCtx, input)
+}
+
+func (m *HandlerMock) record(call Call) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, call)
+}
+
+// Do adds a custom behavior to the script.
+func (m *HandlerMock) Do(behavior Behavior) *HandlerMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.script = append(m.script, behavior)
+
+	return m
+}
+
+// Return adds a call returning output, marshaled to JSON unless it is json.RawMessage already.
+func (m *HandlerMock) Return(output any) *HandlerMock {
+	data, ok := output.(json.RawMessage)
+	if !ok {
+		var err error
+		data, err = json.Marshal(output)
+		if err != nil {
+			panic(fmt.Sprintf("floxytest: marshal output of %q: %v", m.name, err))
+		}
+	}
+
+	return m.Do(func(context.Context, floxy.StepContext, json.RawMessage) (json.RawMessage, error) {
+		return data, nil
+	})
+}
+
+// Fail adds a call returning err.
+func (m *HandlerMock) Fail(err error) *HandlerMock {
+	return m.Do(func(context.Context, floxy.StepContext, json.RawMessage) (json.RawMessage, error) {
+		return nil, err
+	})
+}
+
+// Panic adds a call panicking with v.
+func (m *HandlerMock) Panic(v any) *HandlerMock {
+	return m.Do(func(context.Context, floxy.StepContext, json.RawMessage) (json.RawMessage, error) {
+		panic(v)
+	})
+}
+
+// TimeOut adds a call running into the step timeout: the clock is advanced to the deadline of
+// the step and the call returns once the engine cancelled it. Without a step timeout it returns
+// context.DeadlineExceeded right away.
+func (m *HandlerMock) TimeOut() *HandlerMock {
+	return m.Do(func(ctx context.Context, _ floxy.StepContext, _ json.RawMessage) (json.RawMessage, error) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return nil, context.DeadlineExceeded
+		}
+
+		m.clock.Advance(deadline.Sub(m.clock.Now()))
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	})
+}
+
+// Take adds a call taking d on the clock before it echoes the input.
+func (m *HandlerMock) Take(d time.Duration) *HandlerMock {
+	return m.Do(func(_ context.Context, _ floxy.StepContext, input json.RawMessage) (json.RawMessage, error) {
+		m.clock.Advance(d)
+
+		return input, nil
+	})
+}
+
+// Always makes the last scripted behavior the result of every further call.
+func (m *HandlerMock) Always() *HandlerMock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.script) > 0 {
+		m.fallback = m.script[len(m.script)-1]
+		m.script = m.script[:len(m.script)-1]
+	}
+
+	return m
+}
+
+// Calls returns the recorded calls, compensation calls included.
+func (m *HandlerMock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallCount returns the number of recorded calls.
+func (m *HandlerMock) CallCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.calls)
+}
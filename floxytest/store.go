@@ -0,0 +1,91 @@
+package floxytest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// clockedStore is a memory store on the manual clock that remembers when delayed queue items
+// become due, so that Run can advance the clock to them.
+// Human steps re-enqueued while they wait for a decision are parked instead of being polled:
+// the decision continues the workflow on its own.
+type clockedStore struct {
+	*floxy.MemoryStore
+
+	clock *floxy.ManualClock
+
+	mu     sync.Mutex
+	dueAt  []time.Time
+	parked map[int64]struct{}
+}
+
+func newClockedStore(clock *floxy.ManualClock) *clockedStore {
+	return &clockedStore{
+		MemoryStore: floxy.NewMemoryStore(floxy.WithStoreClock(clock)),
+		clock:       clock,
+		parked:      make(map[int64]struct{}),
+	}
+}
+
+func (s *clockedStore) EnqueueStep(
+	ctx context.Context,
+	instanceID int64,
+	stepID *int64,
+	taskQueue string,
+	priority floxy.Priority,
+	delay time.Duration,
+) error {
+	if stepID != nil {
+		step, err := s.MemoryStore.GetStepByID(ctx, *stepID)
+		if err == nil && step.Status == floxy.StepStatusWaitingDecision {
+			s.mu.Lock()
+			s.parked[*stepID] = struct{}{}
+			s.mu.Unlock()
+
+			return nil
+		}
+	}
+
+	if delay > 0 {
+		s.mu.Lock()
+		s.dueAt = append(s.dueAt, s.clock.Now().Add(delay))
+		s.mu.Unlock()
+	}
+
+	return s.MemoryStore.EnqueueStep(ctx, instanceID, stepID, taskQueue, priority, delay)
+}
+
+// unpark drops the parked item of a human step once its decision is made.
+func (s *clockedStore) unpark(stepID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.parked, stepID)
+}
+
+// nextDue returns the earliest due time of a delayed item that is still in the future.
+func (s *clockedStore) nextDue() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	var next time.Time
+	future := s.dueAt[:0]
+	for _, dueAt := range s.dueAt {
+		if !dueAt.After(now) {
+			continue
+		}
+
+		future = append(future, dueAt)
+		if next.IsZero() || dueAt.Before(next) {
+			next = dueAt
+		}
+	}
+	s.dueAt = future
+
+	return next, !next.IsZero()
+}
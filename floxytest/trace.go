@@ -0,0 +1,201 @@
+package floxytest
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+var updateGolden = flag.Bool("floxytest.update", false, "rewrite floxytest golden files")
+
+// TraceEntry is one event of the executed path, see floxy.BuildTrace.
+type TraceEntry = floxy.TraceEntry
+
+// Trace returns the executed path of the instance in event order.
+func (env *Env) Trace(instanceID int64) []TraceEntry {
+	env.t.Helper()
+
+	events, err := env.Store.GetWorkflowEvents(env.ctx, instanceID)
+	if err != nil {
+		env.t.Fatalf("floxytest: get events of %d: %v", instanceID, err)
+	}
+
+	steps, err := env.Engine.GetSteps(env.ctx, instanceID)
+	if err != nil {
+		env.t.Fatalf("floxytest: get steps of %d: %v", instanceID, err)
+	}
+
+	return floxy.BuildTrace(events, steps)
+}
+
+// Executed returns the steps of the instance in the order they were started.
+func (env *Env) Executed(instanceID int64) []string {
+	env.t.Helper()
+
+	var steps []string
+	for _, entry := range env.Trace(instanceID) {
+		if entry.Event == floxy.EventStepStarted && entry.Detail == "" {
+			steps = append(steps, entry.Step)
+		}
+	}
+
+	return steps
+}
+
+// Compensated returns the steps of the instance in the order they were compensated.
+func (env *Env) Compensated(instanceID int64) []string {
+	env.t.Helper()
+
+	var steps []string
+	for _, entry := range env.Trace(instanceID) {
+		if entry.Event == floxy.EventStepCompleted && entry.Detail == "compensated" {
+			steps = append(steps, entry.Step)
+		}
+	}
+
+	return steps
+}
+
+// Snapshot renders the trace of the instance, one entry per line, for golden tests.
+func (env *Env) Snapshot(instanceID int64) string {
+	env.t.Helper()
+
+	var sb strings.Builder
+	for _, entry := range env.Trace(instanceID) {
+		sb.WriteString(entry.String())
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// AssertStatus checks the status of the instance.
+func (env *Env) AssertStatus(instanceID int64, want floxy.WorkflowStatus) bool {
+	env.t.Helper()
+
+	return assert.Equal(env.t, want, env.Status(instanceID), "status of instance %d", instanceID)
+}
+
+// AssertExecuted checks that exactly these steps were started, in this order.
+func (env *Env) AssertExecuted(instanceID int64, steps ...string) bool {
+	env.t.Helper()
+
+	return assert.Equal(env.t, steps, env.Executed(instanceID), "executed steps of instance %d", instanceID)
+}
+
+// AssertStepOrder checks that these steps were started in this relative order; other steps
+// may run in between.
+func (env *Env) AssertStepOrder(instanceID int64, steps ...string) bool {
+	env.t.Helper()
+
+	executed := env.Executed(instanceID)
+	pos := 0
+	for _, step := range executed {
+		if pos < len(steps) && step == steps[pos] {
+			pos++
+		}
+	}
+
+	if pos < len(steps) {
+		return assert.Fail(env.t, "unexpected step order",
+			"want %v in this order, executed %v (missing from %q)", steps, executed, steps[pos])
+	}
+
+	return true
+}
+
+// AssertCompensated checks that exactly these steps were compensated, in this order.
+func (env *Env) AssertCompensated(instanceID int64, steps ...string) bool {
+	env.t.Helper()
+
+	return assert.Equal(env.t, steps, env.Compensated(instanceID), "compensated steps of instance %d", instanceID)
+}
+
+// JoinOutcome is the expected outcome of a join step. Empty fields are not checked.
+type JoinOutcome struct {
+	Status    floxy.StepStatus
+	Completed []string
+	Failed    []string
+}
+
+// AssertJoin checks the status of the join step and the branches it saw complete or fail.
+func (env *Env) AssertJoin(instanceID int64, joinStep string, want JoinOutcome) bool {
+	env.t.Helper()
+
+	ok := true
+	if want.Status != "" {
+		ok = assert.Equal(env.t, want.Status, env.step(instanceID, joinStep).Status, "status of join %s", joinStep)
+	}
+
+	if want.Completed == nil && want.Failed == nil {
+		return ok
+	}
+
+	completed, failed, err := env.joinBranches(instanceID, joinStep)
+	if err != nil {
+		return assert.Fail(env.t, "join state not found", "join %s of instance %d: %v", joinStep, instanceID, err)
+	}
+
+	if want.Completed != nil {
+		ok = assert.ElementsMatch(env.t, want.Completed, completed, "completed branches of join %s", joinStep) && ok
+	}
+	if want.Failed != nil {
+		ok = assert.ElementsMatch(env.t, want.Failed, failed, "failed branches of join %s", joinStep) && ok
+	}
+
+	return ok
+}
+
+// joinBranches returns the branches a join saw complete and fail: from the output of the join
+// when it succeeded, otherwise from its join state.
+func (env *Env) joinBranches(instanceID int64, joinStep string) (completed, failed []string, err error) {
+	step := env.step(instanceID, joinStep)
+	if step.Status == floxy.StepStatusCompleted {
+		var output struct {
+			Completed []string `json:"completed"`
+			Failed    []string `json:"failed"`
+		}
+		if err := json.Unmarshal(step.Output, &output); err == nil {
+			return append([]string{}, output.Completed...), append([]string{}, output.Failed...), nil
+		}
+	}
+
+	state, err := env.Store.GetJoinState(env.ctx, instanceID, joinStep)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append([]string{}, state.Completed...), append([]string{}, state.Failed...), nil
+}
+
+// AssertGolden compares the snapshot of the instance with the golden file at path.
+// Run the tests with -floxytest.update to rewrite golden files.
+func (env *Env) AssertGolden(instanceID int64, path string) bool {
+	env.t.Helper()
+
+	got := env.Snapshot(instanceID)
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			env.t.Fatalf("floxytest: create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			env.t.Fatalf("floxytest: write golden file: %v", err)
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		env.t.Fatalf("floxytest: read golden file (run with -floxytest.update to create it): %v", err)
+	}
+
+	return assert.Equal(env.t, string(want), got, "trace of instance %d differs from %s", instanceID, path)
+}
@@ -0,0 +1,133 @@
+package floxy
+
+import (
+	"sort"
+)
+
+// graphIndex holds lookups derived from a workflow graph, so that step transitions and
+// rollbacks do not walk the graph on every hop. It is built once per cached definition.
+type graphIndex struct {
+	// parallelFork maps a step listed in the Parallel of a fork to that fork
+	parallelFork map[string]string
+	// branchFork maps a step to the fork whose branch contains it, found through Prev links
+	branchFork map[string]string
+	// forkJoin maps a fork to the join step among its Next steps
+	forkJoin map[string]string
+	// ancestors holds the steps on the Prev chain of each step, the step itself included
+	ancestors map[string]map[string]struct{}
+}
+
+func buildGraphIndex(def *WorkflowDefinition) *graphIndex {
+	steps := def.Definition.Steps
+
+	names := make([]string, 0, len(steps))
+	for name := range steps {
+		names = append(names, name)
+	}
+	// A step listed by several forks is attributed to the first fork by name
+	sort.Strings(names)
+
+	index := &graphIndex{
+		parallelFork: make(map[string]string),
+		branchFork:   make(map[string]string),
+		forkJoin:     make(map[string]string),
+		ancestors:    make(map[string]map[string]struct{}, len(steps)),
+	}
+
+	for _, name := range names {
+		stepDef := steps[name]
+		if stepDef.Type != StepTypeFork {
+			continue
+		}
+
+		for _, parallelStep := range stepDef.Parallel {
+			if _, ok := index.parallelFork[parallelStep]; !ok {
+				index.parallelFork[parallelStep] = name
+			}
+		}
+
+		for _, next := range stepDef.Next {
+			if nextDef, ok := steps[next]; ok && nextDef.Type == StepTypeJoin {
+				index.forkJoin[name] = next
+
+				break
+			}
+		}
+	}
+
+	for _, name := range names {
+		index.ancestors[name] = prevChain(name, steps)
+
+		if fork := index.findBranchFork(steps[name].Prev, steps); fork != "" {
+			index.branchFork[name] = fork
+		}
+	}
+
+	return index
+}
+
+// prevChain collects the steps reached from name through Prev links, stopping at unknown steps and cycles.
+func prevChain(name string, steps map[string]*StepDefinition) map[string]struct{} {
+	chain := make(map[string]struct{})
+
+	for name != "" {
+		if _, seen := chain[name]; seen {
+			break
+		}
+
+		stepDef, ok := steps[name]
+		if !ok {
+			break
+		}
+		chain[name] = struct{}{}
+
+		name = stepDef.Prev
+	}
+
+	return chain
+}
+
+// findBranchFork walks Prev links from current up to the first fork or step started by a fork.
+func (index *graphIndex) findBranchFork(current string, steps map[string]*StepDefinition) string {
+	visited := make(map[string]bool)
+
+	for current != "" && current != rootStepName {
+		if visited[current] {
+			break
+		}
+		visited[current] = true
+
+		currentDef, ok := steps[current]
+		if !ok {
+			break
+		}
+
+		if currentDef.Type == StepTypeFork {
+			return current
+		}
+
+		if fork := index.parallelFork[current]; fork != "" {
+			return fork
+		}
+
+		current = currentDef.Prev
+	}
+
+	return ""
+}
+
+// forkOfBranch returns the fork that started the branch containing the step, or "" outside of fork branches.
+func (index *graphIndex) forkOfBranch(stepName string) string {
+	if fork := index.parallelFork[stepName]; fork != "" {
+		return fork
+	}
+
+	return index.branchFork[stepName]
+}
+
+// isDescendantOf reports whether ancestor is on the Prev chain of the step, the step itself included.
+func (index *graphIndex) isDescendantOf(stepName, ancestor string) bool {
+	_, ok := index.ancestors[stepName][ancestor]
+
+	return ok
+}
@@ -0,0 +1,65 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/grpcapi/floxyv1"
+)
+
+func instanceToProto(instance *floxy.WorkflowInstance) *floxyv1.Instance {
+	return &floxyv1.Instance{
+		Id:          instance.ID,
+		WorkflowId:  instance.WorkflowID,
+		Namespace:   instance.Namespace,
+		Status:      string(instance.Status),
+		Input:       instance.Input,
+		Output:      instance.Output,
+		Error:       instance.Error,
+		Labels:      instance.Labels,
+		StartedAt:   timestampOrNil(instance.StartedAt),
+		CompletedAt: timestampOrNil(instance.CompletedAt),
+		CreatedAt:   timestamppb.New(instance.CreatedAt),
+		UpdatedAt:   timestamppb.New(instance.UpdatedAt),
+	}
+}
+
+func stepToProto(step *floxy.WorkflowStep) *floxyv1.Step {
+	return &floxyv1.Step{
+		Id:          step.ID,
+		InstanceId:  step.InstanceID,
+		StepName:    step.StepName,
+		StepType:    string(step.StepType),
+		Status:      string(step.Status),
+		Input:       step.Input,
+		Output:      step.Output,
+		Error:       step.Error,
+		RetryCount:  int32(step.RetryCount),
+		MaxRetries:  int32(step.MaxRetries),
+		StartedAt:   timestampOrNil(step.StartedAt),
+		CompletedAt: timestampOrNil(step.CompletedAt),
+		CreatedAt:   timestamppb.New(step.CreatedAt),
+	}
+}
+
+func eventToProto(event *floxy.WorkflowEvent) *floxyv1.Event {
+	return &floxyv1.Event{
+		Id:         event.ID,
+		InstanceId: event.InstanceID,
+		Namespace:  event.Namespace,
+		StepId:     event.StepID,
+		EventType:  event.EventType,
+		Payload:    event.Payload,
+		CreatedAt:  timestamppb.New(event.CreatedAt),
+	}
+}
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
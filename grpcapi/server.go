@@ -0,0 +1,460 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/grpcapi/floxyv1"
+)
+
+const (
+	// NamespaceMetadataKey carries the caller namespace. The namespace interceptors reject calls
+	// without one unless they allow unscoped calls.
+	NamespaceMetadataKey = "x-floxy-namespace"
+	// UserMetadataKey carries the user recorded by the default ExtractUserFn.
+	UserMetadataKey = "x-floxy-user"
+
+	defaultEventStreamPollInterval = 500 * time.Millisecond
+	eventStreamBatchSize           = 500
+	// A stream ends with Unavailable after this many polls in a row failed
+	eventStreamMaxPollFailures = 5
+)
+
+// Engine is the part of *floxy.Engine the service calls.
+type Engine interface {
+	floxy.IEngine
+	Start(ctx context.Context, workflowID string, input json.RawMessage, opts ...floxy.StartOption) (int64, error)
+}
+
+// ExtractUserFn returns the user that makes a decision, cancels or aborts an instance.
+type ExtractUserFn func(ctx context.Context) (string, error)
+
+var _ floxyv1.FloxyServiceServer = (*Server)(nil)
+
+type Server struct {
+	floxyv1.UnimplementedFloxyServiceServer
+
+	engine        Engine
+	store         floxy.Store
+	extractUserFn ExtractUserFn
+	pollInterval  time.Duration
+}
+
+type Option func(*Server)
+
+// WithExtractUserFn replaces the default, which reads UserMetadataKey. Use it to take the user
+// from an authenticated identity.
+func WithExtractUserFn(fn ExtractUserFn) Option {
+	return func(s *Server) {
+		s.extractUserFn = fn
+	}
+}
+
+// WithEventStreamPollInterval sets how often StreamInstanceEvents polls the store for new events.
+func WithEventStreamPollInterval(interval time.Duration) Option {
+	return func(s *Server) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+	}
+}
+
+func New(engine Engine, store floxy.Store, opts ...Option) *Server {
+	srv := &Server{
+		engine:        engine,
+		store:         store,
+		extractUserFn: DefaultExtractUserFn,
+		pollInterval:  defaultEventStreamPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// Register adds the service to a gRPC server. Add NamespaceUnaryInterceptor and
+// NamespaceStreamInterceptor to the server to scope calls by namespace.
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	floxyv1.RegisterFloxyServiceServer(registrar, s)
+}
+
+// DefaultExtractUserFn reads UserMetadataKey of the incoming call.
+func DefaultExtractUserFn(ctx context.Context) (string, error) {
+	if user := firstMetadataValue(ctx, UserMetadataKey); user != "" {
+		return user, nil
+	}
+
+	return "", status.Errorf(codes.Unauthenticated, "%s metadata is required", UserMetadataKey)
+}
+
+func (s *Server) StartWorkflow(
+	ctx context.Context,
+	req *floxyv1.StartWorkflowRequest,
+) (*floxyv1.StartWorkflowResponse, error) {
+	if req.GetWorkflowId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "workflow_id is required")
+	}
+
+	input := json.RawMessage("{}")
+	if len(req.GetInput()) > 0 {
+		if !json.Valid(req.GetInput()) {
+			return nil, status.Error(codes.InvalidArgument, "input is not valid JSON")
+		}
+		input = req.GetInput()
+	}
+
+	var opts []floxy.StartOption
+	if len(req.GetLabels()) > 0 {
+		opts = append(opts, floxy.WithStartLabels(req.GetLabels()))
+	}
+
+	instanceID, err := s.engine.Start(ctx, req.GetWorkflowId(), input, opts...)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &floxyv1.StartWorkflowResponse{InstanceId: instanceID}, nil
+}
+
+func (s *Server) GetInstance(ctx context.Context, req *floxyv1.GetInstanceRequest) (*floxyv1.Instance, error) {
+	instance, err := s.store.GetInstance(ctx, req.GetInstanceId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return instanceToProto(instance), nil
+}
+
+func (s *Server) GetInstanceSteps(
+	ctx context.Context,
+	req *floxyv1.GetInstanceStepsRequest,
+) (*floxyv1.GetInstanceStepsResponse, error) {
+	// Steps are not namespaced, the instance lookup scopes them
+	if _, err := s.store.GetInstance(ctx, req.GetInstanceId()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	steps, err := s.store.GetStepsByInstance(ctx, req.GetInstanceId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &floxyv1.GetInstanceStepsResponse{Steps: make([]*floxyv1.Step, 0, len(steps))}
+	for i := range steps {
+		resp.Steps = append(resp.Steps, stepToProto(&steps[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *Server) MakeHumanDecision(
+	ctx context.Context,
+	req *floxyv1.MakeHumanDecisionRequest,
+) (*floxyv1.MakeHumanDecisionResponse, error) {
+	var decision floxy.HumanDecision
+	switch req.GetDecision() {
+	case floxyv1.Decision_DECISION_CONFIRMED:
+		decision = floxy.HumanDecisionConfirmed
+	case floxyv1.Decision_DECISION_REJECTED:
+		decision = floxy.HumanDecisionRejected
+	default:
+		return nil, status.Error(codes.InvalidArgument, "decision is required")
+	}
+
+	user, err := s.extractUserFn(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// Steps are not namespaced, a scoped caller must see the instance first
+	if _, scoped := floxy.NamespaceFromContext(ctx); scoped {
+		if _, err := s.store.GetInstance(ctx, req.GetInstanceId()); err != nil {
+			return nil, toStatusError(err)
+		}
+	}
+
+	step, err := s.store.GetHumanDecisionStepByInstanceID(ctx, req.GetInstanceId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if step.Status != floxy.StepStatusWaitingDecision {
+		return nil, status.Error(codes.FailedPrecondition, "step is not waiting for human decision")
+	}
+
+	var commentRef *string
+	if req.GetComment() != "" {
+		comment := req.GetComment()
+		commentRef = &comment
+	}
+
+	if err := s.engine.MakeHumanDecision(ctx, step.ID, user, decision, commentRef); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &floxyv1.MakeHumanDecisionResponse{}, nil
+}
+
+func (s *Server) CancelInstance(
+	ctx context.Context,
+	req *floxyv1.CancelInstanceRequest,
+) (*floxyv1.CancelInstanceResponse, error) {
+	if req.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	user, err := s.extractUserFn(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.engine.CancelWorkflow(ctx, req.GetInstanceId(), user, req.GetReason()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &floxyv1.CancelInstanceResponse{}, nil
+}
+
+func (s *Server) AbortInstance(
+	ctx context.Context,
+	req *floxyv1.AbortInstanceRequest,
+) (*floxyv1.AbortInstanceResponse, error) {
+	if req.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	user, err := s.extractUserFn(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.engine.AbortWorkflow(ctx, req.GetInstanceId(), user, req.GetReason()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &floxyv1.AbortInstanceResponse{}, nil
+}
+
+// StreamInstanceEvents tails the event log of the instance by ID with a floxy.EventCursor until
+// the client cancels the call. Clients resume a broken stream with the resume_position of the last
+// received event and skip events received again by ID. The call fails with Unavailable when the
+// store cannot be polled eventStreamMaxPollFailures times in a row.
+func (s *Server) StreamInstanceEvents(
+	req *floxyv1.StreamInstanceEventsRequest,
+	stream grpc.ServerStreamingServer[floxyv1.Event],
+) error {
+	ctx := stream.Context()
+
+	if _, err := s.store.GetInstance(ctx, req.GetInstanceId()); err != nil {
+		return toStatusError(err)
+	}
+
+	filter := floxy.EventFilter{InstanceID: req.GetInstanceId(), EventTypes: req.GetEventTypes()}
+	cursor := floxy.NewEventCursor(s.store, filter, req.GetLastEventId(),
+		floxy.WithEventCursorBatchSize(eventStreamBatchSize))
+
+	poll := time.NewTicker(s.pollInterval)
+	defer poll.Stop()
+
+	failures := 0
+	for {
+		events, err := cursor.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			failures++
+			if failures >= eventStreamMaxPollFailures {
+				return status.Errorf(codes.Unavailable, "event stream poll failed: %v", err)
+			}
+			slog.Warn("[floxy] grpc event stream poll failed", "error", err, "failures", failures)
+		} else {
+			failures = 0
+		}
+
+		position := cursor.Position()
+		for i := range events {
+			event := eventToProto(&events[i])
+			event.ResumePosition = position
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-poll.C:
+		}
+	}
+}
+
+// NamespaceResolver returns the namespace of a call. An empty result means the call names no
+// namespace; an error rejects the call with PermissionDenied.
+type NamespaceResolver func(ctx context.Context) (string, error)
+
+// DefaultNamespaceResolver reads NamespaceMetadataKey.
+func DefaultNamespaceResolver(ctx context.Context) (string, error) {
+	return firstMetadataValue(ctx, NamespaceMetadataKey), nil
+}
+
+// PeerCertificateNamespaceResolver takes the namespace from the first organizational unit of the
+// verified client certificate. A NamespaceMetadataKey naming another namespace is rejected.
+// It requires a server with TLS credentials that verify client certificates.
+func PeerCertificateNamespaceResolver(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", errors.New("no peer")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", errors.New("no verified client certificate")
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	if len(cert.Subject.OrganizationalUnit) == 0 {
+		return "", fmt.Errorf("client certificate %q has no organizational unit", cert.Subject.CommonName)
+	}
+
+	namespace := cert.Subject.OrganizationalUnit[0]
+	if requested := firstMetadataValue(ctx, NamespaceMetadataKey); requested != "" && requested != namespace {
+		return "", fmt.Errorf("namespace %q is not accessible to %s", requested, cert.Subject.CommonName)
+	}
+
+	return namespace, nil
+}
+
+type namespaceScope struct {
+	resolver      NamespaceResolver
+	allowUnscoped bool
+}
+
+type NamespaceOption func(*namespaceScope)
+
+// WithNamespaceResolver replaces DefaultNamespaceResolver, for example with
+// PeerCertificateNamespaceResolver to scope calls by the authenticated peer.
+func WithNamespaceResolver(resolver NamespaceResolver) NamespaceOption {
+	return func(s *namespaceScope) {
+		s.resolver = resolver
+	}
+}
+
+// WithUnscopedCalls lets calls without a namespace see every namespace, for servers that do
+// not use namespaces or only serve trusted operators.
+func WithUnscopedCalls() NamespaceOption {
+	return func(s *namespaceScope) {
+		s.allowUnscoped = true
+	}
+}
+
+func newNamespaceScope(opts []NamespaceOption) *namespaceScope {
+	scope := &namespaceScope{resolver: DefaultNamespaceResolver}
+	for _, opt := range opts {
+		opt(scope)
+	}
+
+	return scope
+}
+
+// NamespaceUnaryInterceptor scopes unary calls to the namespace of the call. Calls without one
+// fail with InvalidArgument unless WithUnscopedCalls is set.
+func NamespaceUnaryInterceptor(opts ...NamespaceOption) grpc.UnaryServerInterceptor {
+	scope := newNamespaceScope(opts)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := scope.withCallNamespace(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// NamespaceStreamInterceptor scopes streaming calls like NamespaceUnaryInterceptor.
+func NamespaceStreamInterceptor(opts ...NamespaceOption) grpc.StreamServerInterceptor {
+	scope := newNamespaceScope(opts)
+
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := scope.withCallNamespace(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &namespacedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type namespacedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *namespacedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *namespaceScope) withCallNamespace(ctx context.Context) (context.Context, error) {
+	namespace, err := s.resolver(ctx)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if namespace == "" {
+		if !s.allowUnscoped {
+			return nil, status.Errorf(codes.InvalidArgument, "%s metadata is required", NamespaceMetadataKey)
+		}
+
+		return ctx, nil
+	}
+
+	return floxy.WithNamespace(ctx, namespace), nil
+}
+
+func firstMetadataValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// toStatusError maps engine and store errors to the codes the HTTP plugins use as statuses.
+// Errors that already carry a status are returned as is.
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, floxy.ErrEntityNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, floxy.ErrNamespaceLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case strings.Contains(err.Error(), "already in terminal state"):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,394 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/grpcapi/floxyv1"
+)
+
+// newTestClient serves the service over an in-process bufconn listener and allows unscoped calls.
+func newTestClient(t *testing.T, engine Engine, store floxy.Store, opts ...Option) floxyv1.FloxyServiceClient {
+	t.Helper()
+
+	return newScopedTestClient(t, []NamespaceOption{WithUnscopedCalls()}, engine, store, opts...)
+}
+
+func newScopedTestClient(
+	t *testing.T,
+	scopeOpts []NamespaceOption,
+	engine Engine,
+	store floxy.Store,
+	opts ...Option,
+) floxyv1.FloxyServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(NamespaceUnaryInterceptor(scopeOpts...)),
+		grpc.ChainStreamInterceptor(NamespaceStreamInterceptor(scopeOpts...)),
+	)
+	New(engine, store, opts...).Register(server)
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return floxyv1.NewFloxyServiceClient(conn)
+}
+
+// mockEngine adds Start to the IEngine mock.
+type mockEngine struct {
+	*floxy.MockIEngine
+}
+
+func (m mockEngine) Start(
+	ctx context.Context,
+	workflowID string,
+	input json.RawMessage,
+	opts ...floxy.StartOption,
+) (int64, error) {
+	args := m.Called(ctx, workflowID, input)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func newTestEngine(t *testing.T) (*floxy.Engine, *floxy.MemoryStore, *floxy.WorkflowDefinition) {
+	t.Helper()
+
+	store := floxy.NewMemoryStore()
+	engine := floxy.NewEngine(nil,
+		floxy.WithEngineStore(store),
+		floxy.WithEngineTxManager(floxy.NewMemoryTxManager()),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	def, err := floxy.NewBuilder("orders", 1).
+		Step("charge", "charge").
+		Then("ship", "ship").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(floxy.WithNamespace(context.Background(), "team-a"), def))
+
+	return engine, store, def
+}
+
+func withMetadata(ctx context.Context, kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func TestServer_StartWorkflow(t *testing.T) {
+	ctx := context.Background()
+	engine, store, def := newTestEngine(t)
+	client := newTestClient(t, engine, store)
+
+	started, err := client.StartWorkflow(ctx, &floxyv1.StartWorkflowRequest{
+		WorkflowId: def.ID,
+		Input:      []byte(`{"order_id":42}`),
+		Labels:     map[string]string{"customer": "7"},
+	})
+	require.NoError(t, err)
+
+	instance, err := client.GetInstance(ctx, &floxyv1.GetInstanceRequest{InstanceId: started.GetInstanceId()})
+	require.NoError(t, err)
+	assert.Equal(t, def.ID, instance.GetWorkflowId())
+	assert.Equal(t, "team-a", instance.GetNamespace())
+	assert.JSONEq(t, `{"order_id":42}`, string(instance.GetInput()))
+	assert.Equal(t, map[string]string{"customer": "7"}, instance.GetLabels())
+	assert.NotNil(t, instance.GetCreatedAt())
+
+	_, err = client.GetInstanceSteps(ctx, &floxyv1.GetInstanceStepsRequest{InstanceId: started.GetInstanceId()})
+	require.NoError(t, err)
+
+	_, err = client.StartWorkflow(ctx, &floxyv1.StartWorkflowRequest{WorkflowId: "missing-v1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.StartWorkflow(ctx, &floxyv1.StartWorkflowRequest{WorkflowId: def.ID, Input: []byte(`{`)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.StartWorkflow(ctx, &floxyv1.StartWorkflowRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetInstance(ctx, &floxyv1.GetInstanceRequest{InstanceId: 999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_Namespace(t *testing.T) {
+	ctx := context.Background()
+	engine, store, def := newTestEngine(t)
+	client := newScopedTestClient(t, nil, engine, store)
+
+	started, err := client.StartWorkflow(withMetadata(ctx, NamespaceMetadataKey, "team-a"),
+		&floxyv1.StartWorkflowRequest{WorkflowId: def.ID})
+	require.NoError(t, err)
+	req := &floxyv1.GetInstanceRequest{InstanceId: started.GetInstanceId()}
+
+	_, err = client.GetInstance(withMetadata(ctx, NamespaceMetadataKey, "team-a"), req)
+	require.NoError(t, err)
+
+	_, err = client.GetInstance(withMetadata(ctx, NamespaceMetadataKey, "team-b"), req)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.StartWorkflow(withMetadata(ctx, NamespaceMetadataKey, "team-b"),
+		&floxyv1.StartWorkflowRequest{WorkflowId: def.ID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := client.StreamInstanceEvents(withMetadata(ctx, NamespaceMetadataKey, "team-b"),
+		&floxyv1.StreamInstanceEventsRequest{InstanceId: started.GetInstanceId()})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Calls without a namespace are rejected
+	_, err = client.GetInstance(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err = client.StreamInstanceEvents(ctx, &floxyv1.StreamInstanceEventsRequest{InstanceId: started.GetInstanceId()})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPeerCertificateNamespaceResolver(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "orders-service", OrganizationalUnit: []string{"team-a"}}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+	}})
+
+	namespace, err := PeerCertificateNamespaceResolver(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", namespace)
+
+	namespace, err = PeerCertificateNamespaceResolver(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(NamespaceMetadataKey, "team-a")))
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", namespace)
+
+	_, err = PeerCertificateNamespaceResolver(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(NamespaceMetadataKey, "team-b")))
+	assert.Error(t, err)
+
+	_, err = PeerCertificateNamespaceResolver(peer.NewContext(context.Background(), &peer.Peer{}))
+	assert.Error(t, err)
+}
+
+func TestServer_PeerNamespaceResolver(t *testing.T) {
+	ctx := context.Background()
+	engine, store, def := newTestEngine(t)
+	resolver := func(ctx context.Context) (string, error) {
+		if firstMetadataValue(ctx, "x-test-peer") == "intruder" {
+			return "", errors.New("unknown peer")
+		}
+
+		return "team-a", nil
+	}
+	client := newScopedTestClient(t, []NamespaceOption{WithNamespaceResolver(resolver)}, engine, store)
+
+	started, err := client.StartWorkflow(ctx, &floxyv1.StartWorkflowRequest{WorkflowId: def.ID})
+	require.NoError(t, err)
+
+	instance, err := store.GetInstance(ctx, started.GetInstanceId())
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", instance.Namespace)
+
+	_, err = client.StartWorkflow(withMetadata(ctx, "x-test-peer", "intruder"),
+		&floxyv1.StartWorkflowRequest{WorkflowId: def.ID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServer_MakeHumanDecision(t *testing.T) {
+	ctx := withMetadata(context.Background(), UserMetadataKey, "alice")
+	engine := floxy.NewMockIEngine(t)
+	store := floxy.NewMockStore(t)
+	client := newTestClient(t, mockEngine{engine}, store)
+
+	comment := "looks good"
+	store.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(1)).
+		Return(&floxy.WorkflowStep{ID: 10, Status: floxy.StepStatusWaitingDecision}, nil)
+	engine.On("MakeHumanDecision", mock.Anything, int64(10), "alice", floxy.HumanDecisionConfirmed, &comment).
+		Return(nil).Once()
+
+	_, err := client.MakeHumanDecision(ctx, &floxyv1.MakeHumanDecisionRequest{
+		InstanceId: 1,
+		Decision:   floxyv1.Decision_DECISION_CONFIRMED,
+		Comment:    comment,
+	})
+	require.NoError(t, err)
+
+	engine.On("MakeHumanDecision", mock.Anything, int64(10), "alice", floxy.HumanDecisionRejected, (*string)(nil)).
+		Return(nil).Once()
+
+	_, err = client.MakeHumanDecision(ctx, &floxyv1.MakeHumanDecisionRequest{
+		InstanceId: 1,
+		Decision:   floxyv1.Decision_DECISION_REJECTED,
+	})
+	require.NoError(t, err)
+
+	store.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(2)).
+		Return(&floxy.WorkflowStep{ID: 20, Status: floxy.StepStatusCompleted}, nil)
+
+	_, err = client.MakeHumanDecision(ctx, &floxyv1.MakeHumanDecisionRequest{
+		InstanceId: 2,
+		Decision:   floxyv1.Decision_DECISION_CONFIRMED,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	store.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(3)).
+		Return(nil, floxy.ErrEntityNotFound)
+
+	_, err = client.MakeHumanDecision(ctx, &floxyv1.MakeHumanDecisionRequest{
+		InstanceId: 3,
+		Decision:   floxyv1.Decision_DECISION_CONFIRMED,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.MakeHumanDecision(ctx, &floxyv1.MakeHumanDecisionRequest{InstanceId: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.MakeHumanDecision(context.Background(), &floxyv1.MakeHumanDecisionRequest{
+		InstanceId: 1,
+		Decision:   floxyv1.Decision_DECISION_CONFIRMED,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_CancelAndAbort(t *testing.T) {
+	ctx := withMetadata(context.Background(), UserMetadataKey, "alice")
+	engine := floxy.NewMockIEngine(t)
+	client := newTestClient(t, mockEngine{engine}, floxy.NewMockStore(t))
+
+	engine.On("CancelWorkflow", mock.Anything, int64(1), "alice", "duplicate").Return(nil).Once()
+	_, err := client.CancelInstance(ctx, &floxyv1.CancelInstanceRequest{InstanceId: 1, Reason: "duplicate"})
+	require.NoError(t, err)
+
+	engine.On("CancelWorkflow", mock.Anything, int64(2), "alice", "duplicate").
+		Return(errors.New("workflow 2 is already in terminal state: completed")).Once()
+	_, err = client.CancelInstance(ctx, &floxyv1.CancelInstanceRequest{InstanceId: 2, Reason: "duplicate"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	engine.On("AbortWorkflow", mock.Anything, int64(3), "alice", "stuck").Return(nil).Once()
+	_, err = client.AbortInstance(ctx, &floxyv1.AbortInstanceRequest{InstanceId: 3, Reason: "stuck"})
+	require.NoError(t, err)
+
+	engine.On("AbortWorkflow", mock.Anything, int64(4), "alice", "stuck").
+		Return(floxy.ErrEntityNotFound).Once()
+	_, err = client.AbortInstance(ctx, &floxyv1.AbortInstanceRequest{InstanceId: 4, Reason: "stuck"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.CancelInstance(ctx, &floxyv1.CancelInstanceRequest{InstanceId: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	client = newTestClient(t, mockEngine{engine}, floxy.NewMockStore(t), WithExtractUserFn(func(context.Context) (string, error) {
+		return "", floxy.ErrEntityNotFound
+	}))
+	_, err = client.AbortInstance(ctx, &floxyv1.AbortInstanceRequest{InstanceId: 3, Reason: "stuck"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_StreamInstanceEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	store := floxy.NewMemoryStore()
+	instance, err := store.CreateInstance(ctx, "orders-v1", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	for _, eventType := range []string{floxy.EventWorkflowStarted, floxy.EventStepStarted, floxy.EventStepCompleted} {
+		require.NoError(t, store.LogEvent(ctx, instance.ID, nil, eventType, map[string]any{"n": 1}))
+	}
+
+	client := newTestClient(t, mockEngine{floxy.NewMockIEngine(t)}, store,
+		WithEventStreamPollInterval(10*time.Millisecond))
+
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	stream, err := client.StreamInstanceEvents(streamCtx, &floxyv1.StreamInstanceEventsRequest{InstanceId: instance.ID})
+	require.NoError(t, err)
+
+	var received []*floxyv1.Event
+	for range 3 {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		received = append(received, event)
+	}
+	assert.Equal(t, floxy.EventWorkflowStarted, received[0].GetEventType())
+	assert.Equal(t, floxy.EventStepCompleted, received[2].GetEventType())
+	assert.JSONEq(t, `{"n":1}`, string(received[0].GetPayload()))
+	assert.LessOrEqual(t, received[2].GetResumePosition(), received[2].GetId())
+
+	// Events logged while streaming are delivered by the next poll
+	require.NoError(t, store.LogEvent(ctx, instance.ID, nil, floxy.EventWorkflowCompleted, map[string]any{}))
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, floxy.EventWorkflowCompleted, event.GetEventType())
+	streamCancel()
+
+	lastEventID := received[0].GetId()
+	stream, err = client.StreamInstanceEvents(ctx, &floxyv1.StreamInstanceEventsRequest{
+		InstanceId:  instance.ID,
+		LastEventId: &lastEventID,
+		EventTypes:  []string{floxy.EventStepCompleted},
+	})
+	require.NoError(t, err)
+	event, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, received[2].GetId(), event.GetId())
+
+	stream, err = client.StreamInstanceEvents(ctx, &floxyv1.StreamInstanceEventsRequest{InstanceId: 999})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// failingEventStore fails every event poll.
+type failingEventStore struct {
+	*floxy.MemoryStore
+}
+
+func (s failingEventStore) GetEventsAfter(context.Context, int64, floxy.EventFilter, int) ([]floxy.WorkflowEvent, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestServer_StreamInstanceEventsUnavailable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	store := floxy.NewMemoryStore()
+	instance, err := store.CreateInstance(ctx, "orders-v1", json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	client := newTestClient(t, mockEngine{floxy.NewMockIEngine(t)}, failingEventStore{store},
+		WithEventStreamPollInterval(time.Millisecond))
+
+	stream, err := client.StreamInstanceEvents(ctx, &floxyv1.StreamInstanceEventsRequest{InstanceId: instance.ID})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,226 @@
+package floxy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInstanceQueryLimit = 20
+	maxInstanceQueryLimit     = 1000
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type InstanceSortField string
+
+const (
+	InstanceSortByCreatedAt InstanceSortField = "created_at"
+	InstanceSortByUpdatedAt InstanceSortField = "updated_at"
+	InstanceSortByID        InstanceSortField = "id"
+)
+
+type SortOrder string
+
+const (
+	SortOrderDesc SortOrder = "desc"
+	SortOrderAsc  SortOrder = "asc"
+)
+
+// InstanceQuery describes a filtered, cursor-paginated search over workflow instances.
+// Zero-valued fields do not filter. Time ranges are [From, To).
+type InstanceQuery struct {
+	Statuses      []WorkflowStatus
+	WorkflowID    string
+	WorkflowName  string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	UpdatedFrom   *time.Time
+	UpdatedTo     *time.Time
+	Labels        map[string]string // every label must match
+	WithoutLabels []string          // none of these label keys may be set
+	ErrorContains string            // case-insensitive substring of the instance error
+
+	SortBy    InstanceSortField // default: created_at
+	SortOrder SortOrder         // default: desc
+	Cursor    string            // NextCursor from the previous page
+	Limit     int               // default: 20, max: 1000
+}
+
+type InstanceSearchResult struct {
+	Items      []WorkflowInstance `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+type instanceCursor struct {
+	ID int64     `json:"id"`
+	At time.Time `json:"at,omitempty"`
+}
+
+// normalize applies defaults, validates sort options and decodes the cursor.
+func (q *InstanceQuery) normalize() (*instanceCursor, error) {
+	switch q.SortBy {
+	case "":
+		q.SortBy = InstanceSortByCreatedAt
+	case InstanceSortByCreatedAt, InstanceSortByUpdatedAt, InstanceSortByID:
+	default:
+		return nil, fmt.Errorf("unsupported sort field: %s", q.SortBy)
+	}
+
+	switch q.SortOrder {
+	case "":
+		q.SortOrder = SortOrderDesc
+	case SortOrderAsc, SortOrderDesc:
+	default:
+		return nil, fmt.Errorf("unsupported sort order: %s", q.SortOrder)
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = defaultInstanceQueryLimit
+	}
+	if q.Limit > maxInstanceQueryLimit {
+		q.Limit = maxInstanceQueryLimit
+	}
+
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor instanceCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// sortTime returns the time value the query is sorted by.
+func (q *InstanceQuery) sortTime(instance *WorkflowInstance) time.Time {
+	if q.SortBy == InstanceSortByUpdatedAt {
+		return instance.UpdatedAt
+	}
+
+	return instance.CreatedAt
+}
+
+func (q *InstanceQuery) encodeCursor(instance *WorkflowInstance) string {
+	cursor := instanceCursor{ID: instance.ID}
+	if q.SortBy != InstanceSortByID {
+		cursor.At = q.sortTime(instance)
+	}
+
+	raw, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// pageResult trims the extra row fetched to detect the next page and builds the result.
+func (q *InstanceQuery) pageResult(instances []WorkflowInstance) *InstanceSearchResult {
+	result := &InstanceSearchResult{Items: instances}
+	if len(instances) > q.Limit {
+		result.Items = instances[:q.Limit]
+		result.NextCursor = q.encodeCursor(&result.Items[q.Limit-1])
+	}
+
+	return result
+}
+
+// matches reports whether the instance satisfies the query filters (cursor excluded).
+func (q *InstanceQuery) matches(instance *WorkflowInstance, workflowName string) bool {
+	if len(q.Statuses) > 0 {
+		found := false
+		for _, status := range q.Statuses {
+			if instance.Status == status {
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.WorkflowID != "" && instance.WorkflowID != q.WorkflowID {
+		return false
+	}
+	if q.WorkflowName != "" && workflowName != q.WorkflowName {
+		return false
+	}
+
+	if q.CreatedFrom != nil && instance.CreatedAt.Before(*q.CreatedFrom) {
+		return false
+	}
+	if q.CreatedTo != nil && !instance.CreatedAt.Before(*q.CreatedTo) {
+		return false
+	}
+	if q.UpdatedFrom != nil && instance.UpdatedAt.Before(*q.UpdatedFrom) {
+		return false
+	}
+	if q.UpdatedTo != nil && !instance.UpdatedAt.Before(*q.UpdatedTo) {
+		return false
+	}
+
+	for key, value := range q.Labels {
+		if v, ok := instance.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	for _, key := range q.WithoutLabels {
+		if _, ok := instance.Labels[key]; ok {
+			return false
+		}
+	}
+
+	if q.ErrorContains != "" {
+		if instance.Error == nil ||
+			!strings.Contains(strings.ToLower(*instance.Error), strings.ToLower(q.ErrorContains)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// less reports whether a goes before b in the query sort order.
+func (q *InstanceQuery) less(a, b *WorkflowInstance) bool {
+	cmp := 0
+	if q.SortBy != InstanceSortByID {
+		cmp = q.sortTime(a).Compare(q.sortTime(b))
+	}
+	if cmp == 0 {
+		switch {
+		case a.ID < b.ID:
+			cmp = -1
+		case a.ID > b.ID:
+			cmp = 1
+		}
+	}
+
+	if q.SortOrder == SortOrderAsc {
+		return cmp < 0
+	}
+
+	return cmp > 0
+}
+
+// afterCursor reports whether the instance comes strictly after the cursor position.
+func (q *InstanceQuery) afterCursor(instance *WorkflowInstance, cursor *instanceCursor) bool {
+	if cursor == nil {
+		return true
+	}
+
+	pivot := &WorkflowInstance{ID: cursor.ID, CreatedAt: cursor.At, UpdatedAt: cursor.At}
+
+	return q.less(pivot, instance)
+}
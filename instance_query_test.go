@@ -0,0 +1,197 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchTestStores(t *testing.T) map[string]Store {
+	t.Helper()
+
+	sqliteStore, err := NewSQLiteInMemoryStore()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"sqlite": sqliteStore,
+	}
+}
+
+func seedSearchInstances(t *testing.T, ctx context.Context, store Store) []int64 {
+	t.Helper()
+
+	for _, def := range []*WorkflowDefinition{
+		{ID: "order-v1", Name: "order", Version: 1, Definition: GraphDefinition{Start: "a", Steps: map[string]*StepDefinition{}}},
+		{ID: "refund-v1", Name: "refund", Version: 1, Definition: GraphDefinition{Start: "a", Steps: map[string]*StepDefinition{}}},
+	} {
+		require.NoError(t, store.SaveWorkflowDefinition(ctx, def))
+	}
+
+	seed := []struct {
+		workflowID string
+		status     WorkflowStatus
+		errMsg     string
+		customer   string
+	}{
+		{"order-v1", StatusCompleted, "", "42"},
+		{"order-v1", StatusFailed, "payment gateway Timeout", "42"},
+		{"refund-v1", StatusFailed, "bank declined", "42"},
+		{"order-v1", StatusFailed, "stock timeout", "7"},
+		{"order-v1", StatusRunning, "", "42"},
+	}
+
+	ids := make([]int64, 0, len(seed))
+	for _, s := range seed {
+		instance, err := store.CreateInstance(ctx, s.workflowID, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		require.NoError(t, store.SetInstanceLabels(ctx, instance.ID, map[string]string{"customer_id": s.customer}))
+
+		var errMsg *string
+		if s.errMsg != "" {
+			errMsg = &s.errMsg
+		}
+		require.NoError(t, store.UpdateInstanceStatus(ctx, instance.ID, s.status, nil, errMsg))
+
+		ids = append(ids, instance.ID)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	return ids
+}
+
+func instanceIDs(instances []WorkflowInstance) []int64 {
+	ids := make([]int64, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.ID)
+	}
+
+	return ids
+}
+
+func TestSearchInstances_Filters(t *testing.T) {
+	for name, store := range searchTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ids := seedSearchInstances(t, ctx, store)
+
+			result, err := store.SearchInstances(ctx, InstanceQuery{
+				Statuses:     []WorkflowStatus{StatusFailed},
+				WorkflowName: "order",
+				Labels:       map[string]string{"customer_id": "42"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []int64{ids[1]}, instanceIDs(result.Items))
+			assert.Equal(t, map[string]string{"customer_id": "42"}, result.Items[0].Labels)
+			assert.Empty(t, result.NextCursor)
+
+			result, err = store.SearchInstances(ctx, InstanceQuery{ErrorContains: "TIMEOUT"})
+			require.NoError(t, err)
+			assert.Equal(t, []int64{ids[3], ids[1]}, instanceIDs(result.Items))
+
+			result, err = store.SearchInstances(ctx, InstanceQuery{
+				WorkflowID: "refund-v1",
+				Statuses:   []WorkflowStatus{StatusFailed, StatusCompleted},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []int64{ids[2]}, instanceIDs(result.Items))
+
+			require.NoError(t, store.SetInstanceLabels(ctx, ids[0], map[string]string{LabelArchivedAt: "2025-01-01T00:00:00Z"}))
+			result, err = store.SearchInstances(ctx, InstanceQuery{
+				Labels:        map[string]string{"customer_id": "42"},
+				WithoutLabels: []string{LabelArchivedAt},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []int64{ids[4], ids[2], ids[1]}, instanceIDs(result.Items))
+
+			future := time.Now().Add(time.Hour)
+			result, err = store.SearchInstances(ctx, InstanceQuery{CreatedFrom: &future})
+			require.NoError(t, err)
+			assert.Empty(t, result.Items)
+
+			instance, err := store.GetInstance(ctx, ids[0])
+			require.NoError(t, err)
+			assert.Equal(t, "42", instance.Labels["customer_id"])
+		})
+	}
+}
+
+func TestSearchInstances_CursorPagination(t *testing.T) {
+	for name, store := range searchTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ids := seedSearchInstances(t, ctx, store)
+
+			for _, tc := range []struct {
+				sortBy   InstanceSortField
+				order    SortOrder
+				expected []int64
+			}{
+				{InstanceSortByCreatedAt, SortOrderDesc, []int64{ids[4], ids[3], ids[2], ids[1], ids[0]}},
+				{InstanceSortByCreatedAt, SortOrderAsc, ids},
+				{InstanceSortByUpdatedAt, SortOrderAsc, ids},
+				{InstanceSortByID, SortOrderDesc, []int64{ids[4], ids[3], ids[2], ids[1], ids[0]}},
+			} {
+				var (
+					got    []int64
+					cursor string
+				)
+				for page := 0; page < 10; page++ {
+					result, err := store.SearchInstances(ctx, InstanceQuery{
+						SortBy:    tc.sortBy,
+						SortOrder: tc.order,
+						Cursor:    cursor,
+						Limit:     2,
+					})
+					require.NoError(t, err)
+					got = append(got, instanceIDs(result.Items)...)
+
+					cursor = result.NextCursor
+					if cursor == "" {
+						break
+					}
+				}
+
+				assert.Equal(t, tc.expected, got, "sort=%s order=%s", tc.sortBy, tc.order)
+			}
+
+			_, err := store.SearchInstances(ctx, InstanceQuery{Cursor: "not-a-cursor"})
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestEngineStart_WithLabels(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+	)
+	defer engine.Shutdown()
+
+	workflowDef, err := NewBuilder("labels", 1).
+		Step("start", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`),
+		WithStartLabels(map[string]string{"customer_id": "42"}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, engine.SetInstanceLabels(ctx, instanceID, map[string]string{"order_id": "A-1"}))
+
+	result, err := store.SearchInstances(ctx, InstanceQuery{
+		Labels: map[string]string{"customer_id": "42", "order_id": "A-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, instanceID, result.Items[0].ID)
+}
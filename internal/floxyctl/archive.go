@@ -0,0 +1,91 @@
+package floxyctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// CreateArchive writes the finished instances last updated before olderThan to archive files in dir
+// and, when cleanup is set, runs the store cleanup once archiving succeeded.
+func CreateArchive(ctx context.Context, pool *pgxpool.Pool, dir string, olderThan time.Duration, cleanup bool) error {
+	sink := floxy.NewFileArchiveSink(dir)
+	store := floxy.NewStore(pool)
+	// The cleanup also drops expired partitions, whose unfinished instances Archive does not cover
+	store.SetArchiveSink(sink)
+	archiver := floxy.NewArchiver(store, sink, floxy.WithArchiveOlderThan(olderThan))
+
+	var archived int
+	var err error
+	if cleanup {
+		archived, err = archiver.ArchiveAndCleanup(ctx)
+	} else {
+		archived, err = archiver.Archive(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to archive instances after %d archived: %w", archived, err)
+	}
+
+	fmt.Printf("Archived %d instance(s) to %s\n", archived, dir)
+
+	return nil
+}
+
+// RestoreToSQLite restores an archived instance into a SQLite file, creating it when missing.
+func RestoreToSQLite(ctx context.Context, sqlitePath, archivePath, objectID string) error {
+	store, err := floxy.NewSQLiteStore(sqlitePath)
+	if err != nil {
+		return fmt.Errorf("failed to open sqlite store: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	// The SQLite store runs its own transactions, a restore there is not atomic
+	return RestoreArchivedInstance(ctx, store, floxy.NewMemoryTxManager(), archivePath, objectID)
+}
+
+// RestoreToDB restores an archived instance into a PostgreSQL database.
+func RestoreToDB(ctx context.Context, pool *pgxpool.Pool, archivePath, objectID string) error {
+	if err := floxy.RunMigrations(ctx, pool); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return RestoreArchivedInstance(ctx, floxy.NewStore(pool), floxy.NewTxManager(pool), archivePath, objectID)
+}
+
+// RestoreArchivedInstance copies an instance from an archive file or directory into the store.
+func RestoreArchivedInstance(
+	ctx context.Context,
+	store floxy.Store,
+	txManager floxy.TxManager,
+	archivePath, objectID string,
+) error {
+	instanceID, err := strconv.ParseInt(objectID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid instance ID: %w", err)
+	}
+
+	rec, err := floxy.FindArchivedInstance(archivePath, instanceID)
+	if err != nil {
+		if errors.Is(err, floxy.ErrEntityNotFound) {
+			return fmt.Errorf("instance %d not found in %s", instanceID, archivePath)
+		}
+
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	restoredID, err := floxy.RestoreArchivedInstance(ctx, store, txManager, rec)
+	if err != nil {
+		return fmt.Errorf("failed to restore instance: %w", err)
+	}
+
+	fmt.Printf("Archived instance %d (archived at %s) restored as instance %d\n",
+		instanceID, rec.ArchivedAt.Format("2006-01-02 15:04:05"), restoredID)
+
+	return nil
+}
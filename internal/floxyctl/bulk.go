@@ -0,0 +1,134 @@
+package floxyctl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+)
+
+const bulkProgressInterval = time.Second
+
+type BulkConfig struct {
+	Action        string
+	InstanceIDs   string // comma-separated
+	Filter        string // same syntax as GET /api/instances query string
+	RequestedBy   string
+	Reason        string
+	RatePerSecond float64
+}
+
+func RunBulkOperation(ctx context.Context, pool *pgxpool.Pool, config BulkConfig) error {
+	action := floxy.BulkAction(config.Action)
+	switch action {
+	case floxy.BulkActionCancel, floxy.BulkActionAbort, floxy.BulkActionRequeue, floxy.BulkActionRetry:
+	default:
+		return fmt.Errorf("unsupported action: %s (expected cancel, abort, requeue or retry)", config.Action)
+	}
+
+	var selector floxy.BulkSelector
+	for _, idStr := range strings.Split(config.InstanceIDs, ",") {
+		idStr = strings.TrimSpace(idStr)
+		if idStr == "" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid instance ID %q: %w", idStr, err)
+		}
+		selector.InstanceIDs = append(selector.InstanceIDs, id)
+	}
+
+	if config.Filter != "" {
+		values, err := url.ParseQuery(config.Filter)
+		if err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
+
+		query, err := api.ParseInstanceQuery(values)
+		if err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
+		selector.Query = &query
+	}
+
+	if len(selector.InstanceIDs) == 0 && selector.Query == nil {
+		return fmt.Errorf("either --ids or --filter is required")
+	}
+
+	engine, err := CreateEngineFromDB(ctx, pool)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer engine.Shutdown()
+
+	if config.RequestedBy == "" {
+		config.RequestedBy = os.Getenv("USER")
+		if config.RequestedBy == "" {
+			config.RequestedBy = "floxyctl"
+		}
+	}
+
+	if config.Reason == "" {
+		config.Reason = fmt.Sprintf("Bulk %s via floxyctl", action)
+	}
+
+	job, err := engine.StartBulkOperation(ctx, floxy.BulkRequest{
+		Action:        action,
+		Selector:      selector,
+		RequestedBy:   config.RequestedBy,
+		Reason:        config.Reason,
+		RatePerSecond: config.RatePerSecond,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start bulk operation: %w", err)
+	}
+
+	fmt.Printf("Bulk %s started for %d instances\n", action, job.Total)
+
+	ticker := time.NewTicker(bulkProgressInterval)
+	defer ticker.Stop()
+
+	for !job.Done() {
+		select {
+		case <-ctx.Done():
+			_ = engine.CancelBulkOperation(context.Background(), job.ID)
+
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		job, err = engine.GetBulkOperation(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get bulk operation: %w", err)
+		}
+
+		fmt.Printf("Progress: %d/%d (succeeded: %d, failed: %d)\n", job.Processed, job.Total, job.Succeeded, job.Failed)
+	}
+
+	for _, item := range job.Items {
+		switch {
+		case item.Status == floxy.BulkItemStatusFailed:
+			fmt.Printf("  instance %d: failed: %s\n", item.InstanceID, item.Error)
+		case item.NewInstanceID != 0:
+			fmt.Printf("  instance %d: retried as %d\n", item.InstanceID, item.NewInstanceID)
+		}
+	}
+
+	fmt.Printf("Bulk %s %s: %d succeeded, %d failed\n", action, job.Status, job.Succeeded, job.Failed)
+
+	if job.Failed > 0 {
+		return fmt.Errorf("%d of %d instances failed", job.Failed, job.Total)
+	}
+
+	return nil
+}
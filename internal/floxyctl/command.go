@@ -54,6 +54,101 @@ Examples:
 		os.Exit(1)
 	}
 
+	simulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate workflow from YAML file",
+		Long: `Predict the execution path of a workflow from YAML file without running its handlers.
+
+The workflow runs on an in-memory store with stub handlers that echo their input.
+Delays and retry backoff take no real time, human steps are confirmed unless a
+decision is given. The trace lists executed steps, condition results, joins and rollbacks.
+
+Examples:
+  # Simulate with input file
+  floxyctl simulate -f workflow.yaml -i input.json
+
+  # Script the output of a step to drive a condition
+  floxyctl simulate -f workflow.yaml --output 'classify={"kind":"digital"}'
+
+  # Fail a step to see the rollback, print the result as JSON
+  floxyctl simulate -f workflow.yaml --fail ship --json
+
+  # Reject a human step
+  floxyctl simulate -f workflow.yaml --decision approve=rejected`,
+		RunE: simulateCommand,
+	}
+
+	simulateCmd.Flags().StringP("file", "f", "", "YAML file with workflow configuration (required)")
+	simulateCmd.Flags().StringP("input", "i", "", "JSON file with initial input (optional)")
+	simulateCmd.Flags().StringArray("fail", nil, "Step whose handler fails on every attempt (repeatable)")
+	simulateCmd.Flags().StringArray("output", nil, "Scripted step output as step=json (repeatable)")
+	simulateCmd.Flags().StringArray("decision", nil, "Human step decision as step=confirmed|rejected (repeatable)")
+	simulateCmd.Flags().Bool("json", false, "Print the simulation result as JSON")
+
+	if err := simulateCmd.MarkFlagRequired("file"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking file flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
+	lintCmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Lint workflows from YAML file",
+		Long: `Check the workflows of a YAML file for likely mistakes and exit with an error on warnings.
+
+Rules:
+  unreachable_step         step is not reachable from the start step
+  missing_compensation     task step without on_failure (set metadata side_effects: false if read-only)
+  fork_without_join        fork whose branches are never joined
+  unsatisfiable_join       join waiting for a step on one branch of a condition
+  retried_non_idempotent   no_idempotent step with max_retries above 1
+  invalid_condition        condition expression does not parse
+  unknown_condition_field  condition reads a field missing from its input
+  unregistered_handler     step handler is not defined in handlers
+
+Examples:
+  # Lint a workflow file
+  floxyctl lint -f workflow.yaml
+
+  # Check condition fields against a sample input, print JSON for CI
+  floxyctl lint -f workflow.yaml -i input.json --json
+
+  # Ignore a rule
+  floxyctl lint -f workflow.yaml --ignore missing_compensation`,
+		RunE: lintCommand,
+	}
+
+	lintCmd.Flags().StringP("file", "f", "", "YAML file with workflow configuration (required)")
+	lintCmd.Flags().StringP("input", "i", "", "JSON file with a sample input for condition checks (optional)")
+	lintCmd.Flags().StringArray("ignore", nil, "Rule to ignore (repeatable)")
+	lintCmd.Flags().Bool("json", false, "Print the warnings as JSON")
+
+	if err := lintCmd.MarkFlagRequired("file"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking file flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Render workflows from YAML file as a graph",
+		Long: `Render the workflows of a YAML file as a Mermaid flowchart or a Graphviz DOT digraph.
+
+Examples:
+  # Print a Mermaid flowchart
+  floxyctl graph -f workflow.yaml
+
+  # Render a PNG with Graphviz
+  floxyctl graph -f workflow.yaml --format dot | dot -Tpng -o workflow.png`,
+		RunE: graphCommand,
+	}
+
+	graphCmd.Flags().StringP("file", "f", "", "YAML file with workflow configuration (required)")
+	graphCmd.Flags().String("format", "mermaid", "Output format: mermaid or dot")
+
+	if err := graphCmd.MarkFlagRequired("file"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking file flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
 	startCmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start workflow instance from database",
@@ -149,10 +244,229 @@ Examples:
 		os.Exit(1)
 	}
 
+	skipCmd := &cobra.Command{
+		Use:   "skip",
+		Short: "Skip a stuck workflow step",
+		Long: `Mark a stuck workflow step as skipped and continue the workflow with operator-supplied output.
+
+If no output file is given, the step input is passed to the next steps.
+
+Password can be provided via:
+  - -W flag (prompts for password)
+  - PG_PASSWORD environment variable
+  - If neither is provided, empty password is used
+
+Examples:
+  # Skip step with output file (password from prompt)
+  floxyctl skip -o 456 -i output.json --host localhost --port 5432 --user user --database mydb -W
+
+  # Skip with password from environment variable
+  PG_PASSWORD=mypassword floxyctl skip -o 456 --host localhost --port 5432 --user user --database mydb
+
+  # Skip with custom reason
+  floxyctl skip -o 456 --host localhost --port 5432 --user user --database mydb -W --reason "Payment confirmed manually"`,
+		RunE: skipCommand,
+	}
+
+	addDBFlags(skipCmd)
+	skipCmd.Flags().StringP("object", "o", "", "Workflow step ID (required)")
+	skipCmd.Flags().StringP("input", "i", "", "JSON file with step output (optional)")
+	skipCmd.Flags().String("skipped-by", "", "User/system skipping the step (default: $USER)")
+	skipCmd.Flags().String("reason", "", "Reason for skipping (default: 'Skipped via floxyctl')")
+
+	if err := skipCmd.MarkFlagRequired("object"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking object flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
+	bulkCmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Apply an action to many workflow instances",
+		Long: `Cancel, abort, requeue from DLQ or retry many workflow instances at once.
+
+Instances are selected by ID list and/or by a filter using the same syntax as
+the GET /api/instances query string. Progress is printed until the operation finishes.
+
+Password can be provided via:
+  - -W flag (prompts for password)
+  - PG_PASSWORD environment variable
+  - If neither is provided, empty password is used
+
+Examples:
+  # Cancel all running instances of a workflow for a customer
+  floxyctl bulk -a cancel --filter "status=running&workflow_name=order&label=customer_id:42" --host localhost --port 5432 --user user --database mydb -W
+
+  # Requeue DLQ records of the given instances, 20 instances per second
+  floxyctl bulk -a requeue --ids 101,102,103 --rate 20 --host localhost --port 5432 --user user --database mydb -W
+
+  # Retry failed instances as new instances
+  floxyctl bulk -a retry --filter "status=failed&error=timeout" --host localhost --port 5432 --user user --database mydb -W`,
+		RunE: bulkCommand,
+	}
+
+	addDBFlags(bulkCmd)
+	bulkCmd.Flags().StringP("action", "a", "", "Action: cancel, abort, requeue or retry (required)")
+	bulkCmd.Flags().String("ids", "", "Comma-separated workflow instance IDs")
+	bulkCmd.Flags().String("filter", "", "Instance filter, e.g. 'status=failed&label=customer_id:42'")
+	bulkCmd.Flags().String("requested-by", "", "User/system requesting the operation (default: $USER)")
+	bulkCmd.Flags().String("reason", "", "Reason for cancel/abort (default: 'Bulk <action> via floxyctl')")
+	bulkCmd.Flags().Float64("rate", 0, "Instances processed per second (default: engine setting)")
+
+	if err := bulkCmd.MarkFlagRequired("action"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking action flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
+	workersCmd := &cobra.Command{
+		Use:   "workers",
+		Short: "List registered workers",
+		Long: `List the worker pools registered by the engines with their status, host, version,
+in-flight handler runs and task queues.
+
+A single worker can be shown by its ID or by the attempted_by of a queue item.
+
+Password can be provided via:
+  - -W flag (prompts for password)
+  - PG_PASSWORD environment variable
+  - If neither is provided, empty password is used
+
+Examples:
+  # List all workers (password from prompt)
+  floxyctl workers --host localhost --port 5432 --user user --database mydb -W
+
+  # Show the worker that claimed a queue item
+  PG_PASSWORD=mypassword floxyctl workers -o 6f1c2a3e-8d4b-4c3f-9a51-2b7e0d9c1f00/3 --host localhost --port 5432 --user user --database mydb`,
+		RunE: workersCommand,
+	}
+
+	addDBFlags(workersCmd)
+	workersCmd.Flags().StringP("object", "o", "", "Worker ID or queue item attempted_by (optional)")
+
+	timelineCmd := &cobra.Command{
+		Use:   "timeline",
+		Short: "Show the execution timeline of a workflow instance",
+		Long: `Show where the time of a workflow instance went: per step the time queued, running,
+waiting for a retry, waiting for a human decision, waiting on a join and compensating.
+
+Password can be provided via:
+  - -W flag (prompts for password)
+  - PG_PASSWORD environment variable
+  - If neither is provided, empty password is used
+
+Examples:
+  # Print the timeline as text (password from prompt)
+  floxyctl timeline -o 123 --host localhost --port 5432 --user user --database mydb -W
+
+  # Write an HTML Gantt chart
+  PG_PASSWORD=mypassword floxyctl timeline -o 123 --format html --host localhost --port 5432 --user user --database mydb > timeline.html`,
+		RunE: timelineCommand,
+	}
+
+	addDBFlags(timelineCmd)
+	timelineCmd.Flags().StringP("object", "o", "", "Workflow instance ID (required)")
+	timelineCmd.Flags().String("format", "text", "Output format: text, json or html")
+
+	if err := timelineCmd.MarkFlagRequired("object"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking object flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Work with archives of finished workflow instances",
+	}
+
+	archiveRestoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore an archived workflow instance",
+		Long: `Restore one instance from the archive files written by floxy.Archiver into a store for inspection.
+
+The archive can be a single .jsonl.gz file or a directory of archive files. The restored
+instance gets a new ID; the archived ID is kept in the restored_from label.
+
+The target is a SQLite file (--sqlite) or a PostgreSQL database (--host, --port, --user, --database).
+
+Password can be provided via:
+  - -W flag (prompts for password)
+  - PG_PASSWORD environment variable
+  - If neither is provided, empty password is used
+
+Examples:
+  # Restore instance 123 into a local SQLite file
+  floxyctl archive restore -f /var/lib/floxy/archive -o 123 --sqlite inspect.db
+
+  # Restore instance 123 into PostgreSQL
+  floxyctl archive restore -f /var/lib/floxy/archive -o 123 --host localhost --port 5432 --user user --database mydb -W`,
+		RunE: archiveRestoreCommand,
+	}
+
+	archiveRestoreCmd.Flags().StringP("file", "f", "", "Archive file or directory (required)")
+	archiveRestoreCmd.Flags().StringP("object", "o", "", "Archived workflow instance ID (required)")
+	archiveRestoreCmd.Flags().String("sqlite", "", "SQLite database file to restore into")
+	archiveRestoreCmd.Flags().String("host", "", "Database host")
+	archiveRestoreCmd.Flags().String("port", "", "Database port")
+	archiveRestoreCmd.Flags().String("user", "", "Database user")
+	archiveRestoreCmd.Flags().BoolP("password", "W", false, "Prompt for password (otherwise uses PG_PASSWORD env var or empty)")
+	archiveRestoreCmd.Flags().String("database", "", "Database name")
+	archiveRestoreCmd.MarkFlagsOneRequired("sqlite", "host")
+	archiveRestoreCmd.MarkFlagsMutuallyExclusive("sqlite", "host")
+	archiveRestoreCmd.MarkFlagsRequiredTogether("host", "port", "user", "database")
+
+	for _, name := range []string{"file", "object"} {
+		if err := archiveRestoreCmd.MarkFlagRequired(name); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error marking %s flag as required: %v\n", name, err)
+			os.Exit(1)
+		}
+	}
+
+	archiveCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Archive finished workflow instances",
+		Long: `Write the finished instances last updated before --older-than, with their steps, events,
+human decisions and DLQ records, to gzip-compressed JSONL files in a directory.
+
+Archived instances get the archived_at label and are skipped by later runs. With --cleanup the
+store cleanup runs once archiving succeeded.
+
+Password can be provided via:
+  - -W flag (prompts for password)
+  - PG_PASSWORD environment variable
+  - If neither is provided, empty password is used
+
+Examples:
+  # Archive instances finished more than 7 days ago
+  floxyctl archive create -d /var/lib/floxy/archive --host localhost --port 5432 --user user --database mydb -W
+
+  # Archive instances finished more than a day ago, then run the cleanup
+  PG_PASSWORD=mypassword floxyctl archive create -d /var/lib/floxy/archive --older-than 24h --cleanup --host localhost --port 5432 --user user --database mydb`,
+		RunE: archiveCreateCommand,
+	}
+
+	addDBFlags(archiveCreateCmd)
+	archiveCreateCmd.Flags().StringP("dir", "d", "", "Directory to write the archive files to (required)")
+	archiveCreateCmd.Flags().String("older-than", "168h", "Minimum time since the instance was last updated")
+	archiveCreateCmd.Flags().Bool("cleanup", false, "Run the store cleanup after archiving")
+
+	if err := archiveCreateCmd.MarkFlagRequired("dir"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error marking dir flag as required: %v\n", err)
+		os.Exit(1)
+	}
+
+	archiveCmd.AddCommand(archiveCreateCmd)
+	archiveCmd.AddCommand(archiveRestoreCmd)
+
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(simulateCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(cancelCmd)
 	rootCmd.AddCommand(abortCmd)
+	rootCmd.AddCommand(skipCmd)
+	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(workersCmd)
+	rootCmd.AddCommand(timelineCmd)
+	rootCmd.AddCommand(archiveCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	return rootCmd
@@ -230,6 +544,94 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 	return RunWorkflow(cmd.Context(), yamlFile, inputFile, config)
 }
 
+func simulateCommand(cmd *cobra.Command, _ []string) error {
+	yamlFile, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file flag: %w", err)
+	}
+
+	inputFile, err := cmd.Flags().GetString("input")
+	if err != nil {
+		return fmt.Errorf("failed to get input flag: %w", err)
+	}
+
+	failAt, err := cmd.Flags().GetStringArray("fail")
+	if err != nil {
+		return fmt.Errorf("failed to get fail flag: %w", err)
+	}
+
+	outputs, err := cmd.Flags().GetStringArray("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output flag: %w", err)
+	}
+
+	decisions, err := cmd.Flags().GetStringArray("decision")
+	if err != nil {
+		return fmt.Errorf("failed to get decision flag: %w", err)
+	}
+
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return fmt.Errorf("failed to get json flag: %w", err)
+	}
+
+	config := SimulateConfig{
+		FailAt:    failAt,
+		Outputs:   outputs,
+		Decisions: decisions,
+		JSON:      asJSON,
+	}
+
+	return SimulateWorkflow(cmd.Context(), yamlFile, inputFile, config)
+}
+
+func lintCommand(cmd *cobra.Command, _ []string) error {
+	yamlFile, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file flag: %w", err)
+	}
+
+	inputFile, err := cmd.Flags().GetString("input")
+	if err != nil {
+		return fmt.Errorf("failed to get input flag: %w", err)
+	}
+
+	ignore, err := cmd.Flags().GetStringArray("ignore")
+	if err != nil {
+		return fmt.Errorf("failed to get ignore flag: %w", err)
+	}
+
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return fmt.Errorf("failed to get json flag: %w", err)
+	}
+
+	// Lint warnings are not usage errors
+	cmd.SilenceUsage = true
+
+	config := LintConfig{
+		InputFile: inputFile,
+		Ignore:    ignore,
+		JSON:      asJSON,
+	}
+
+	return LintWorkflows(yamlFile, config)
+}
+
+func graphCommand(cmd *cobra.Command, _ []string) error {
+	yamlFile, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file flag: %w", err)
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+
+	return RenderWorkflowGraphs(yamlFile, format)
+}
+
 func startCommand(cmd *cobra.Command, _ []string) error {
 	workflowID, err := cmd.Flags().GetString("object")
 	if err != nil {
@@ -315,6 +717,209 @@ func abortCommand(cmd *cobra.Command, _ []string) error {
 	return AbortWorkflow(cmd.Context(), pool, objectID, requestedBy, reason)
 }
 
+func skipCommand(cmd *cobra.Command, _ []string) error {
+	objectID, err := cmd.Flags().GetString("object")
+	if err != nil {
+		return fmt.Errorf("failed to get object flag: %w", err)
+	}
+
+	outputFile, err := cmd.Flags().GetString("input")
+	if err != nil {
+		return fmt.Errorf("failed to get input flag: %w", err)
+	}
+
+	skippedBy, err := cmd.Flags().GetString("skipped-by")
+	if err != nil {
+		return fmt.Errorf("failed to get skipped-by flag: %w", err)
+	}
+
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return fmt.Errorf("failed to get reason flag: %w", err)
+	}
+
+	dbConfig, err := getDBConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	pool, err := ConnectDB(cmd.Context(), dbConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return SkipStep(cmd.Context(), pool, objectID, outputFile, skippedBy, reason)
+}
+
+func bulkCommand(cmd *cobra.Command, _ []string) error {
+	action, err := cmd.Flags().GetString("action")
+	if err != nil {
+		return fmt.Errorf("failed to get action flag: %w", err)
+	}
+
+	ids, err := cmd.Flags().GetString("ids")
+	if err != nil {
+		return fmt.Errorf("failed to get ids flag: %w", err)
+	}
+
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return fmt.Errorf("failed to get filter flag: %w", err)
+	}
+
+	requestedBy, err := cmd.Flags().GetString("requested-by")
+	if err != nil {
+		return fmt.Errorf("failed to get requested-by flag: %w", err)
+	}
+
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return fmt.Errorf("failed to get reason flag: %w", err)
+	}
+
+	rate, err := cmd.Flags().GetFloat64("rate")
+	if err != nil {
+		return fmt.Errorf("failed to get rate flag: %w", err)
+	}
+
+	config := BulkConfig{
+		Action:        action,
+		InstanceIDs:   ids,
+		Filter:        filter,
+		RequestedBy:   requestedBy,
+		Reason:        reason,
+		RatePerSecond: rate,
+	}
+
+	dbConfig, err := getDBConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	pool, err := ConnectDB(cmd.Context(), dbConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return RunBulkOperation(cmd.Context(), pool, config)
+}
+
+func workersCommand(cmd *cobra.Command, _ []string) error {
+	objectID, err := cmd.Flags().GetString("object")
+	if err != nil {
+		return fmt.Errorf("failed to get object flag: %w", err)
+	}
+
+	dbConfig, err := getDBConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	pool, err := ConnectDB(cmd.Context(), dbConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return ListWorkers(cmd.Context(), pool, objectID)
+}
+
+func timelineCommand(cmd *cobra.Command, _ []string) error {
+	objectID, err := cmd.Flags().GetString("object")
+	if err != nil {
+		return fmt.Errorf("failed to get object flag: %w", err)
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+
+	dbConfig, err := getDBConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	pool, err := ConnectDB(cmd.Context(), dbConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return ShowTimeline(cmd.Context(), pool, objectID, format)
+}
+
+func archiveCreateCommand(cmd *cobra.Command, _ []string) error {
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return fmt.Errorf("failed to get dir flag: %w", err)
+	}
+
+	olderThanStr, err := cmd.Flags().GetString("older-than")
+	if err != nil {
+		return fmt.Errorf("failed to get older-than flag: %w", err)
+	}
+
+	olderThan, err := parseDuration(olderThanStr)
+	if err != nil {
+		return fmt.Errorf("invalid older-than: %w", err)
+	}
+
+	cleanup, err := cmd.Flags().GetBool("cleanup")
+	if err != nil {
+		return fmt.Errorf("failed to get cleanup flag: %w", err)
+	}
+
+	dbConfig, err := getDBConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	pool, err := ConnectDB(cmd.Context(), dbConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return CreateArchive(cmd.Context(), pool, dir, olderThan, cleanup)
+}
+
+func archiveRestoreCommand(cmd *cobra.Command, _ []string) error {
+	archivePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file flag: %w", err)
+	}
+
+	objectID, err := cmd.Flags().GetString("object")
+	if err != nil {
+		return fmt.Errorf("failed to get object flag: %w", err)
+	}
+
+	sqlitePath, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite flag: %w", err)
+	}
+
+	if sqlitePath != "" {
+		return RestoreToSQLite(cmd.Context(), sqlitePath, archivePath, objectID)
+	}
+
+	dbConfig, err := getDBConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	pool, err := ConnectDB(cmd.Context(), dbConfig)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return RestoreToDB(cmd.Context(), pool, archivePath, objectID)
+}
+
 func getDBConfig(cmd *cobra.Command) (DBConfig, error) {
 	host, err := cmd.Flags().GetString("host")
 	if err != nil {
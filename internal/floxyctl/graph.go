@@ -0,0 +1,51 @@
+package floxyctl
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// RenderWorkflowGraphs prints every flow of the YAML file as a Mermaid flowchart or a Graphviz digraph.
+func RenderWorkflowGraphs(yamlFile, format string) error {
+	if format != "mermaid" && format != "dot" {
+		return fmt.Errorf("invalid format %q, expected mermaid or dot", format)
+	}
+
+	yamlData, err := os.ReadFile(yamlFile)
+	if err != nil {
+		return fmt.Errorf("failed to read YAML file: %w", err)
+	}
+
+	defs, _, err := floxy.ParseWorkflowYAML(yamlData, 1)
+	if err != nil {
+		return fmt.Errorf("failed to parse workflow YAML: %w", err)
+	}
+
+	if len(defs) == 0 {
+		return fmt.Errorf("no workflows defined in YAML file")
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	visualizer := floxy.NewVisualizer()
+	for i, name := range names {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		if format == "dot" {
+			fmt.Print(visualizer.RenderDOT(defs[name]))
+		} else {
+			fmt.Print(visualizer.RenderMermaid(defs[name]))
+		}
+	}
+
+	return nil
+}
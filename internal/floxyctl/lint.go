@@ -0,0 +1,106 @@
+package floxyctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+type LintConfig struct {
+	InputFile string
+	Ignore    []string
+	JSON      bool
+}
+
+type workflowLintResult struct {
+	Workflow string              `json:"workflow"`
+	Warnings []floxy.LintWarning `json:"warnings"`
+}
+
+// LintWorkflows lints every flow of the YAML file and fails when any of them has warnings.
+func LintWorkflows(yamlFile string, config LintConfig) error {
+	yamlData, err := os.ReadFile(yamlFile)
+	if err != nil {
+		return fmt.Errorf("failed to read YAML file: %w", err)
+	}
+
+	defs, handlersExec, err := floxy.ParseWorkflowYAML(yamlData, 1)
+	if err != nil {
+		return fmt.Errorf("failed to parse workflow YAML: %w", err)
+	}
+
+	if len(defs) == 0 {
+		return fmt.Errorf("no workflows defined in YAML file")
+	}
+
+	opts := floxy.LintOptions{Handlers: make([]string, 0, len(handlersExec))}
+	for handlerName := range handlersExec {
+		opts.Handlers = append(opts.Handlers, handlerName)
+	}
+	for _, rule := range config.Ignore {
+		opts.Ignore = append(opts.Ignore, floxy.LintRule(rule))
+	}
+
+	if config.InputFile != "" {
+		inputData, err := os.ReadFile(config.InputFile)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+
+		if !json.Valid(inputData) {
+			return fmt.Errorf("input file is not valid JSON")
+		}
+
+		opts.Input = inputData
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]workflowLintResult, 0, len(defs))
+	total := 0
+	for _, name := range names {
+		def := defs[name]
+		if err := floxy.ValidateWorkflowDefinition(def); err != nil {
+			return fmt.Errorf("invalid workflow %q: %w", name, err)
+		}
+
+		warnings := floxy.LintWorkflowDefinition(def, opts)
+		if warnings == nil {
+			warnings = []floxy.LintWarning{}
+		}
+
+		results = append(results, workflowLintResult{Workflow: def.ID, Warnings: warnings})
+		total += len(warnings)
+	}
+
+	if config.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return fmt.Errorf("failed to encode lint results: %w", err)
+		}
+	} else {
+		for _, result := range results {
+			for _, warning := range result.Warnings {
+				fmt.Printf("%s: %s\n", result.Workflow, warning)
+			}
+		}
+	}
+
+	if total > 0 {
+		return fmt.Errorf("%d lint warnings", total)
+	}
+
+	if !config.JSON {
+		fmt.Printf("No lint warnings in %d workflows\n", len(results))
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package floxyctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+type SimulateConfig struct {
+	FailAt    []string
+	Outputs   []string // step=json
+	Decisions []string // step=confirmed|rejected
+	JSON      bool
+}
+
+func SimulateWorkflow(ctx context.Context, yamlFile, inputFile string, config SimulateConfig) error {
+	yamlData, err := os.ReadFile(yamlFile)
+	if err != nil {
+		return fmt.Errorf("failed to read YAML file: %w", err)
+	}
+
+	defs, _, err := floxy.ParseWorkflowYAML(yamlData, 1)
+	if err != nil {
+		return fmt.Errorf("failed to parse workflow YAML: %w", err)
+	}
+
+	var workflowDef *floxy.WorkflowDefinition
+	for _, def := range defs {
+		workflowDef = def
+
+		break
+	}
+	if workflowDef == nil {
+		return fmt.Errorf("no workflows defined in YAML file")
+	}
+
+	input, err := readSimulationInput(inputFile)
+	if err != nil {
+		return err
+	}
+
+	opts, err := simulationOptions(config)
+	if err != nil {
+		return err
+	}
+
+	engine := floxy.NewEngine(nil,
+		floxy.WithEngineStore(floxy.NewMemoryStore()),
+		floxy.WithEngineTxManager(floxy.NewMemoryTxManager()),
+	)
+	defer engine.Shutdown()
+
+	result, err := engine.Simulate(ctx, workflowDef, input, opts)
+	if err != nil {
+		return fmt.Errorf("failed to simulate workflow: %w", err)
+	}
+
+	if config.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(result)
+	}
+
+	printSimulationResult(workflowDef, result)
+
+	return nil
+}
+
+func readSimulationInput(inputFile string) (json.RawMessage, error) {
+	if inputFile != "" {
+		inputData, err := os.ReadFile(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input file: %w", err)
+		}
+
+		if !json.Valid(inputData) {
+			return nil, fmt.Errorf("input file is not valid JSON")
+		}
+
+		return inputData, nil
+	}
+
+	stat, _ := os.Stdin.Stat()
+	if (stat.Mode() & os.ModeCharDevice) != 0 {
+		return json.RawMessage("{}"), nil
+	}
+
+	inputData, err := io.ReadAll(os.Stdin)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read from stdin: %w", err)
+	}
+
+	if len(inputData) == 0 {
+		return json.RawMessage("{}"), nil
+	}
+	if !json.Valid(inputData) {
+		return nil, fmt.Errorf("stdin input is not valid JSON")
+	}
+
+	return inputData, nil
+}
+
+func simulationOptions(config SimulateConfig) (floxy.SimulationOptions, error) {
+	opts := floxy.SimulationOptions{
+		FailAt:    config.FailAt,
+		Outputs:   make(map[string]json.RawMessage, len(config.Outputs)),
+		Decisions: make(map[string]floxy.HumanDecision, len(config.Decisions)),
+	}
+
+	for _, value := range config.Outputs {
+		step, output, ok := strings.Cut(value, "=")
+		if !ok || step == "" {
+			return opts, fmt.Errorf("invalid output %q, expected step=json", value)
+		}
+		if !json.Valid([]byte(output)) {
+			return opts, fmt.Errorf("output of step %q is not valid JSON", step)
+		}
+
+		opts.Outputs[step] = json.RawMessage(output)
+	}
+
+	for _, value := range config.Decisions {
+		step, decision, ok := strings.Cut(value, "=")
+		if !ok || step == "" {
+			return opts, fmt.Errorf("invalid decision %q, expected step=confirmed|rejected", value)
+		}
+
+		switch floxy.HumanDecision(decision) {
+		case floxy.HumanDecisionConfirmed, floxy.HumanDecisionRejected:
+			opts.Decisions[step] = floxy.HumanDecision(decision)
+		default:
+			return opts, fmt.Errorf("invalid decision %q for step %q, expected confirmed or rejected", decision, step)
+		}
+	}
+
+	return opts, nil
+}
+
+func printSimulationResult(def *floxy.WorkflowDefinition, result *floxy.SimulationResult) {
+	fmt.Printf("Simulated workflow %s: %s\n", def.ID, result.Status)
+	if result.Duration > 0 {
+		fmt.Printf("Simulated duration: %s\n", result.Duration)
+	}
+	if len(result.Output) > 0 && result.Status == floxy.StatusCompleted {
+		fmt.Printf("Output: %s\n", string(result.Output))
+	}
+	if result.Error != nil {
+		fmt.Printf("Error: %s\n", *result.Error)
+	}
+
+	fmt.Printf("\nTrace:\n")
+	for _, entry := range result.Trace {
+		fmt.Printf("  %s\n", entry)
+	}
+
+	if len(result.Conditions) > 0 {
+		fmt.Printf("\nConditions:\n")
+		for _, condition := range result.Conditions {
+			fmt.Printf("  %s: %t\n", condition.Step, condition.Result)
+		}
+	}
+
+	if len(result.Joins) > 0 {
+		fmt.Printf("\nJoins:\n")
+		for _, join := range result.Joins {
+			fmt.Printf("  %s (%s): completed=[%s] failed=[%s]\n",
+				join.Step, join.Status, strings.Join(join.Completed, ", "), strings.Join(join.Failed, ", "))
+		}
+	}
+
+	if len(result.Compensated) > 0 {
+		fmt.Printf("\nRolled back: %s\n", strings.Join(result.Compensated, ", "))
+	}
+}
@@ -0,0 +1,57 @@
+package floxyctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SkipStep(ctx context.Context, pool *pgxpool.Pool, objectID, outputFile, skippedBy, reason string) error {
+	stepID, err := strconv.ParseInt(objectID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid step ID: %w", err)
+	}
+
+	var output json.RawMessage
+	if outputFile != "" {
+		outputData, err := os.ReadFile(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to read output file: %w", err)
+		}
+
+		if !json.Valid(outputData) {
+			return fmt.Errorf("output file is not valid JSON")
+		}
+
+		output = outputData
+	}
+
+	engine, err := CreateEngineFromDB(ctx, pool)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer engine.Shutdown()
+
+	if skippedBy == "" {
+		skippedBy = os.Getenv("USER")
+		if skippedBy == "" {
+			skippedBy = "floxyctl"
+		}
+	}
+
+	if reason == "" {
+		reason = "Skipped via floxyctl"
+	}
+
+	if err := engine.SkipStep(ctx, stepID, skippedBy, output, reason); err != nil {
+		return fmt.Errorf("failed to skip step: %w", err)
+	}
+
+	fmt.Printf("Workflow step %d skipped\n", stepID)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package floxyctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// ShowTimeline prints the execution timeline of an instance as text, JSON or an HTML Gantt chart.
+func ShowTimeline(ctx context.Context, pool *pgxpool.Pool, objectID, format string) error {
+	instanceID, err := strconv.ParseInt(objectID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid instance ID: %w", err)
+	}
+
+	if format != "text" && format != "json" && format != "html" {
+		return fmt.Errorf("invalid format %q, expected text, json or html", format)
+	}
+
+	if err := floxy.RunMigrations(ctx, pool); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	timeline, err := floxy.LoadTimeline(ctx, floxy.NewStore(pool), instanceID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to build timeline of instance %d: %w", instanceID, err)
+	}
+
+	visualizer := floxy.NewVisualizer()
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(timeline)
+	case "html":
+		fmt.Print(visualizer.RenderTimelineHTML(timeline))
+	default:
+		fmt.Print(visualizer.RenderTimeline(timeline))
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package floxyctl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rom8726/floxy-pro"
+)
+
+// ListWorkers prints the worker registry. With objectID (a worker ID or the attempted_by of a
+// queue item) only the matching worker is printed.
+func ListWorkers(ctx context.Context, pool *pgxpool.Pool, objectID string) error {
+	if err := floxy.RunMigrations(ctx, pool); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	store := floxy.NewStore(pool)
+
+	var workers []floxy.WorkerRecord
+	if objectID != "" {
+		worker, err := store.GetWorker(ctx, floxy.WorkerRecordID(objectID))
+		if err != nil {
+			return fmt.Errorf("failed to get worker %q: %w", objectID, err)
+		}
+		workers = append(workers, *worker)
+	} else {
+		var err error
+		workers, err = store.ListWorkers(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list workers: %w", err)
+		}
+	}
+
+	if len(workers) == 0 {
+		fmt.Println("No workers registered")
+
+		return nil
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tSTATUS\tHOST\tVERSION\tIN FLIGHT\tLAST HEARTBEAT\tTASK QUEUES")
+	for _, worker := range workers {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\t%s ago\t%s\n",
+			worker.ID,
+			worker.Status,
+			worker.Host,
+			worker.Version,
+			worker.InFlight,
+			worker.Concurrency,
+			now.Sub(worker.LastHeartbeatAt).Round(time.Second),
+			strings.Join(worker.TaskQueues, ","),
+		)
+	}
+
+	return w.Flush()
+}
@@ -15,6 +15,19 @@ type Config struct {
 	DBName         string
 	Workers        int
 	WorkerInterval time.Duration
+
+	// Autoscaling is enabled when MaxWorkers is set
+	MinWorkers   int
+	MaxWorkers   int
+	DrainTimeout time.Duration
+
+	HeartbeatInterval  time.Duration
+	WorkerStaleTimeout time.Duration
+
+	// The gRPC server is started when GRPCAddr is set
+	GRPCAddr string
+	// Lets gRPC calls without a namespace see every namespace
+	GRPCAllowUnscoped bool
 }
 
 func LoadConfig() (*Config, error) {
@@ -26,6 +39,16 @@ func LoadConfig() (*Config, error) {
 		DBName:         getEnvOrDefault("FLOXY_DB_NAME", ""),
 		Workers:        getIntEnvOrDefault("FLOXY_WORKERS", 3),
 		WorkerInterval: getDurationEnvOrDefault("FLOXY_WORKER_INTERVAL", 100*time.Millisecond),
+
+		MinWorkers:   getIntEnvOrDefault("FLOXY_MIN_WORKERS", 1),
+		MaxWorkers:   getIntEnvOrDefault("FLOXY_MAX_WORKERS", 0),
+		DrainTimeout: getDurationEnvOrDefault("FLOXY_DRAIN_TIMEOUT", 30*time.Second),
+
+		HeartbeatInterval:  getDurationEnvOrDefault("FLOXY_HEARTBEAT_INTERVAL", 10*time.Second),
+		WorkerStaleTimeout: getDurationEnvOrDefault("FLOXY_WORKER_STALE_TIMEOUT", time.Minute),
+
+		GRPCAddr:          getEnvOrDefault("FLOXY_GRPC_ADDR", ""),
+		GRPCAllowUnscoped: getBoolEnvOrDefault("FLOXY_GRPC_ALLOW_UNSCOPED", false),
 	}
 
 	if config.DBHost == "" {
@@ -60,6 +83,15 @@ func getIntEnvOrDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getBoolEnvOrDefault(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getDurationEnvOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,314 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLeaseDuration       = 30 * time.Second
+	defaultLeaseReaperInterval = 10 * time.Second
+	leaseReaperBatchSize       = 100
+)
+
+// stepRun is a handler invocation prepared in the transaction that claimed the queue item.
+// The handler runs after that transaction is committed, and finishStep records its result.
+type stepRun struct {
+	instance *WorkflowInstance
+	step     *WorkflowStep
+	stepDef  *StepDefinition
+	// onFailure is set when the run executes the compensation handler of the step
+	onFailure *StepDefinition
+}
+
+// runStep executes the handler of a prepared run. It must not be called within a transaction.
+func (engine *Engine) runStep(ctx context.Context, run *stepRun) (output json.RawMessage, canceled bool, err error) {
+	if run.onFailure != nil {
+		if run.stepDef.Timeout != 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = withClockTimeout(ctx, engine.clock, run.stepDef.Timeout)
+			defer cancel()
+		}
+
+		output, err = engine.executeCompensation(ctx, run.step, run.onFailure)
+
+		return output, false, err
+	}
+
+	handlerCtx, cancel := engine.stepContext(ctx, run.instance.ID, run.step.ID, run.stepDef.Timeout)
+	defer cancel()
+
+	output, err = engine.executeTask(handlerCtx, run.instance, run.step, run.stepDef)
+
+	return output, errors.Is(handlerCtx.Err(), context.Canceled), err
+}
+
+// finishStep records the result of a run.
+func (engine *Engine) finishStep(
+	ctx context.Context,
+	run *stepRun,
+	output json.RawMessage,
+	canceled bool,
+	stepErr error,
+) error {
+	if run.onFailure != nil {
+		return engine.completeCompensation(ctx, run.instance, run.step, run.onFailure, stepErr)
+	}
+
+	return engine.completeStep(ctx, run.instance, run.step, run.stepDef, output, true, canceled, stepErr)
+}
+
+// runLeased runs the handler outside any transaction while a heartbeat renews the lease of the
+// queue item, then records the result and removes the item in a second transaction.
+// The result is discarded when the lease was lost meanwhile: the item was released or has been
+// taken over by another worker, which executes the step again with the same idempotency key.
+func (engine *Engine) runLeased(ctx context.Context, item *QueueItem, run *stepRun) error {
+	defer engine.trackInFlight(ctx)()
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	var leaseLost atomic.Bool
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+
+		if !engine.keepLease(runCtx, item) {
+			leaseLost.Store(true)
+			cancelRun()
+		}
+	}()
+
+	output, canceled, stepErr := engine.runStep(runCtx, run)
+	cancelRun()
+	<-heartbeatDone
+
+	if leaseLost.Load() {
+		slog.Warn("[floxy] queue item lease lost, discarding step result",
+			"queue_id", item.ID, "instance_id", run.instance.ID, "step_name", run.step.StepName)
+
+		return nil
+	}
+
+	err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		// Inside the transaction the renewal locks the item, so the lease cannot be taken over until commit
+		if err := engine.renewLease(ctx, item); err != nil {
+			return err
+		}
+
+		if err := engine.finishStep(ctx, run, output, canceled, stepErr); err != nil {
+			return err
+		}
+
+		return engine.store.RemoveFromQueue(ctx, item.ID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrLeaseLost) {
+			slog.Warn("[floxy] queue item lease lost, discarding step result",
+				"queue_id", item.ID, "instance_id", run.instance.ID, "step_name", run.step.StepName)
+
+			return nil
+		}
+
+		_ = engine.store.ReleaseQueueItem(context.WithoutCancel(ctx), item.ID)
+
+		return err
+	}
+
+	return nil
+}
+
+// keepLease renews the lease of the item until ctx is done. It returns false when the lease is lost.
+func (engine *Engine) keepLease(ctx context.Context, item *QueueItem) bool {
+	ticker := engine.clock.NewTicker(engine.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C():
+			err := engine.renewLease(ctx, item)
+			if errors.Is(err, ErrLeaseLost) {
+				return false
+			}
+			if err != nil && ctx.Err() == nil {
+				slog.Warn("[floxy] renew queue item lease failed", "queue_id", item.ID, "error", err)
+			}
+		}
+	}
+}
+
+// leaseQueueItem attaches a new lease to an item just claimed by workerID.
+func (engine *Engine) leaseQueueItem(ctx context.Context, item *QueueItem, workerID string) error {
+	leaseToken := uuid.NewString()
+	leaseUntil := engine.clock.Now().Add(engine.leaseDuration)
+
+	if err := engine.store.LeaseQueueItem(ctx, item.ID, workerID, leaseToken, leaseUntil); err != nil {
+		return fmt.Errorf("lease queue item: %w", err)
+	}
+
+	item.LeaseToken = &leaseToken
+	item.LeaseExpiresAt = &leaseUntil
+
+	return nil
+}
+
+func (engine *Engine) renewLease(ctx context.Context, item *QueueItem) error {
+	if item.LeaseToken == nil {
+		return ErrLeaseLost
+	}
+
+	leaseUntil := engine.clock.Now().Add(engine.leaseDuration)
+	if err := engine.store.RenewQueueItemLease(ctx, item.ID, *item.LeaseToken, leaseUntil); err != nil {
+		return fmt.Errorf("renew queue item lease: %w", err)
+	}
+
+	item.LeaseExpiresAt = &leaseUntil
+
+	return nil
+}
+
+func (engine *Engine) leaseReaperWorker() {
+	ticker := engine.clock.NewTicker(engine.leaseReaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-engine.shutdownCh:
+			return
+		case <-ticker.C():
+			engine.processExpiredLeases(engine.shutdownCtx)
+		}
+	}
+}
+
+// processExpiredLeases reclaims queue items whose worker stopped renewing the lease and recovers
+// each of them in its own transaction. Items claimed without a lease (by engines predating leases)
+// are reclaimed once they are older than the lease duration.
+func (engine *Engine) processExpiredLeases(ctx context.Context) {
+	now := engine.clock.Now()
+	items, err := engine.store.ReclaimExpiredQueueItems(
+		ctx, engine.leaseReaperID, now, now.Add(-engine.leaseDuration), leaseReaperBatchSize)
+	if err != nil {
+		slog.Error("[floxy] reclaim expired queue items failed", "error", err)
+
+		return
+	}
+
+	for i := range items {
+		item := &items[i]
+
+		err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+			return engine.recoverQueueItem(ctx, item)
+		})
+		if err != nil {
+			slog.Warn("[floxy] recover queue item failed", "queue_id", item.ID, "error", err)
+			// Unleased, the item is reclaimed again after the lease duration
+			_ = engine.store.ReleaseQueueItem(context.WithoutCancel(ctx), item.ID)
+		}
+	}
+}
+
+// recoverQueueItem handles an item reclaimed from a worker that stopped renewing its lease.
+// A handler run that was in progress counts as a failed attempt and takes the usual retry or
+// failure path; other items are put back in the queue as they are.
+func (engine *Engine) recoverQueueItem(ctx context.Context, item *QueueItem) error {
+	instance, err := engine.store.GetInstance(ctx, item.InstanceID)
+	if err != nil {
+		return fmt.Errorf("get instance: %w", err)
+	}
+
+	run, err := engine.interruptedRun(ctx, instance, item)
+	if err != nil {
+		return err
+	}
+
+	previousOwner := ""
+	if item.AttemptedBy != nil {
+		previousOwner = *item.AttemptedBy
+	}
+
+	payload := map[string]any{
+		KeyQueueID:  item.ID,
+		KeyWorkerID: previousOwner,
+	}
+	if item.LeaseExpiresAt != nil {
+		payload[KeyLeaseExpiry] = *item.LeaseExpiresAt
+	}
+	if run != nil {
+		payload[KeyStepName] = run.step.StepName
+		payload[KeyRetryCount] = run.step.RetryCount
+	}
+	_ = engine.store.LogEvent(ctx, instance.ID, item.StepID, EventQueueItemRecovered, payload)
+
+	if run == nil {
+		return engine.store.ReleaseQueueItem(ctx, item.ID)
+	}
+
+	stepErr := fmt.Errorf("worker %q stopped renewing the lease of queue item %d", previousOwner, item.ID)
+	if err := engine.finishStep(ctx, run, nil, false, stepErr); err != nil {
+		return err
+	}
+
+	return engine.store.RemoveFromQueue(ctx, item.ID)
+}
+
+// interruptedRun returns the handler run a reclaimed item was executing, or nil when the
+// item had not started a task or compensation handler.
+func (engine *Engine) interruptedRun(ctx context.Context, instance *WorkflowInstance, item *QueueItem) (*stepRun, error) {
+	if item.StepID == nil {
+		return nil, nil
+	}
+
+	steps, err := engine.store.GetStepsByInstance(ctx, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get steps: %w", err)
+	}
+
+	var step *WorkflowStep
+	for i := range steps {
+		if steps[i].ID == *item.StepID {
+			step = &steps[i]
+
+			break
+		}
+	}
+
+	if step == nil {
+		return nil, nil
+	}
+
+	running := step.Status == StepStatusRunning && step.StepType == StepTypeTask
+	if !running && step.Status != StepStatusCompensation {
+		return nil, nil
+	}
+
+	def, err := engine.getDefinition(ctx, instance.WorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow definition: %w", err)
+	}
+
+	stepDef, ok := def.Definition.Steps[step.StepName]
+	if !ok {
+		return nil, nil
+	}
+
+	run := &stepRun{instance: instance, step: step, stepDef: stepDef}
+	if step.Status == StepStatusCompensation {
+		onFailureStep, ok := def.Definition.Steps[stepDef.OnFailure]
+		if !ok {
+			return nil, nil
+		}
+		run.onFailure = onFailureStep
+	}
+
+	return run, nil
+}
@@ -0,0 +1,395 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testQueueLeases checks LeaseQueueItem and RenewQueueItemLease against claims and releases.
+func testQueueLeases(t *testing.T, store Store) {
+	ctx := context.Background()
+	leaseUntil := time.Now().Add(time.Minute)
+
+	stepID := int64(1)
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "", PriorityNormal, 0))
+
+	item, err := store.DequeueStep(ctx, "worker-1", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+
+	assert.ErrorIs(t, store.LeaseQueueItem(ctx, item.ID, "worker-2", "token-1", leaseUntil), ErrLeaseLost)
+	require.NoError(t, store.LeaseQueueItem(ctx, item.ID, "worker-1", "token-1", leaseUntil))
+	assert.ErrorIs(t, store.LeaseQueueItem(ctx, item.ID, "worker-1", "token-2", leaseUntil), ErrLeaseLost)
+
+	require.NoError(t, store.RenewQueueItemLease(ctx, item.ID, "token-1", leaseUntil.Add(time.Minute)))
+	assert.ErrorIs(t, store.RenewQueueItemLease(ctx, item.ID, "token-2", leaseUntil), ErrLeaseLost)
+
+	// Releasing ends the lease, a new claim gets a new one
+	require.NoError(t, store.ReleaseQueueItem(ctx, item.ID))
+	assert.ErrorIs(t, store.RenewQueueItemLease(ctx, item.ID, "token-1", leaseUntil), ErrLeaseLost)
+
+	item, err = store.DequeueStep(ctx, "worker-2", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	require.NoError(t, store.LeaseQueueItem(ctx, item.ID, "worker-2", "token-2", leaseUntil))
+	assert.ErrorIs(t, store.RenewQueueItemLease(ctx, item.ID, "token-1", leaseUntil), ErrLeaseLost)
+
+	require.NoError(t, store.RemoveFromQueue(ctx, item.ID))
+	assert.ErrorIs(t, store.RenewQueueItemLease(ctx, item.ID, "token-2", leaseUntil), ErrLeaseLost)
+}
+
+func TestQueueLeases_MemoryStore(t *testing.T) {
+	testQueueLeases(t, NewMemoryStore())
+}
+
+func TestIntegration_QueueLeases(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testQueueLeases(t, store)
+}
+
+// testReclaimExpiredQueueItems checks which claimed items ReclaimExpiredQueueItems takes over.
+func testReclaimExpiredQueueItems(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := time.Now()
+
+	for stepID := int64(1); stepID <= 4; stepID++ {
+		require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "", PriorityNormal, 0))
+	}
+
+	expired, err := store.DequeueStep(ctx, "worker-1", nil)
+	require.NoError(t, err)
+	require.NoError(t, store.LeaseQueueItem(ctx, expired.ID, "worker-1", "token-1", now.Add(-time.Second)))
+
+	renewed, err := store.DequeueStep(ctx, "worker-1", nil)
+	require.NoError(t, err)
+	require.NoError(t, store.LeaseQueueItem(ctx, renewed.ID, "worker-1", "token-2", now.Add(time.Minute)))
+
+	unleased, err := store.DequeueStep(ctx, "worker-2", nil)
+	require.NoError(t, err)
+
+	// Only the expired lease is reclaimed, unleased claims are recent yet
+	items, err := store.ReclaimExpiredQueueItems(ctx, "reaper", now, now.Add(-time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, expired.ID, items[0].ID)
+	require.NotNil(t, items[0].AttemptedBy)
+	assert.Equal(t, "worker-1", *items[0].AttemptedBy)
+	assert.NotNil(t, items[0].LeaseExpiresAt)
+
+	// The lease of the previous owner is gone and the reaper owns the claim
+	assert.ErrorIs(t, store.RenewQueueItemLease(ctx, expired.ID, "token-1", now.Add(time.Minute)), ErrLeaseLost)
+	require.NoError(t, store.LeaseQueueItem(ctx, expired.ID, "reaper", "token-3", now.Add(time.Minute)))
+
+	items, err = store.ReclaimExpiredQueueItems(ctx, "reaper", now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, unleased.ID, items[0].ID)
+	assert.Equal(t, "worker-2", *items[0].AttemptedBy)
+
+	// The unclaimed item stays in the queue
+	next, err := store.DequeueStep(ctx, "worker-3", nil)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.NotContains(t, []int64{expired.ID, renewed.ID, unleased.ID}, next.ID)
+}
+
+func TestReclaimExpiredQueueItems_MemoryStore(t *testing.T) {
+	testReclaimExpiredQueueItems(t, NewMemoryStore())
+}
+
+func TestIntegration_ReclaimExpiredQueueItems(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testReclaimExpiredQueueItems(t, store)
+}
+
+// trackingTxManager records how many transactions are open.
+type trackingTxManager struct {
+	MemoryTxManager
+	open atomic.Int32
+}
+
+func (m *trackingTxManager) ReadCommitted(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.open.Add(1)
+	defer m.open.Add(-1)
+
+	return fn(ctx)
+}
+
+// renewCountingStore counts lease renewals.
+type renewCountingStore struct {
+	*MemoryStore
+	renewals atomic.Int32
+}
+
+func (s *renewCountingStore) RenewQueueItemLease(
+	ctx context.Context,
+	queueID int64,
+	leaseToken string,
+	leaseUntil time.Time,
+) error {
+	s.renewals.Add(1)
+
+	return s.MemoryStore.RenewQueueItemLease(ctx, queueID, leaseToken, leaseUntil)
+}
+
+// blockingHandler reports every call and waits for the test to let it return.
+type blockingHandler struct {
+	started chan string
+	release chan struct{}
+	txOpen  func() int32
+	openTx  atomic.Int32
+}
+
+func (h *blockingHandler) Name() string { return "blocking" }
+
+func (h *blockingHandler) Execute(_ context.Context, stepCtx StepContext, input json.RawMessage) (json.RawMessage, error) {
+	if h.txOpen != nil {
+		h.openTx.Store(h.txOpen())
+	}
+	h.started <- stepCtx.IdempotencyKey()
+	<-h.release
+
+	return input, nil
+}
+
+func startLeaseTestWorkflow(t *testing.T, engine *Engine) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	workflowDef, err := NewBuilder("lease_test", 1).
+		Step("call", "blocking", WithStepMaxRetries(0)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	return instanceID
+}
+
+func TestExecuteNext_HandlerRunsOutsideTransaction(t *testing.T) {
+	ctx := context.Background()
+	store := &renewCountingStore{MemoryStore: NewMemoryStore()}
+	txManager := &trackingTxManager{}
+
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(txManager),
+		WithLeaseDuration(30*time.Millisecond),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	handler := &blockingHandler{
+		started: make(chan string, 1),
+		release: make(chan struct{}),
+		txOpen:  txManager.open.Load,
+	}
+	engine.RegisterHandler(handler)
+
+	instanceID := startLeaseTestWorkflow(t, engine)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := engine.ExecuteNext(ctx, "worker-1")
+		done <- err
+	}()
+
+	<-handler.started
+	// The heartbeat keeps the lease while the handler outlives it
+	time.Sleep(100 * time.Millisecond)
+	close(handler.release)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, int32(0), handler.openTx.Load())
+	assert.GreaterOrEqual(t, store.renewals.Load(), int32(2))
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+}
+
+func TestExecuteNext_LeaseLostDiscardsResult(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	handler := &blockingHandler{
+		started: make(chan string, 1),
+		release: make(chan struct{}, 2),
+	}
+	engine.RegisterHandler(handler)
+
+	instanceID := startLeaseTestWorkflow(t, engine)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := engine.ExecuteNext(ctx, "worker-1")
+		done <- err
+	}()
+	firstKey := <-handler.started
+
+	// The lease expired and the item was released and taken over meanwhile
+	store.mu.RLock()
+	var queueID int64
+	for id, item := range store.queue {
+		if item.InstanceID == instanceID {
+			queueID = id
+		}
+	}
+	store.mu.RUnlock()
+	require.NoError(t, store.ReleaseQueueItem(ctx, queueID))
+
+	handler.release <- struct{}{}
+	require.NoError(t, <-done)
+
+	// The result of the first run is not recorded
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, StepStatusRunning, steps[0].Status)
+	assert.Zero(t, countEvents(t, ctx, store, instanceID, EventStepCompleted))
+
+	// The step runs again with the same idempotency key and completes once
+	go func() {
+		_, err := engine.ExecuteNext(ctx, "worker-2")
+		done <- err
+	}()
+	assert.Equal(t, firstKey, <-handler.started)
+	handler.release <- struct{}{}
+	require.NoError(t, <-done)
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+	assert.Equal(t, 1, countEvents(t, ctx, store, instanceID, EventStepCompleted))
+}
+
+func newLeaseReaperTestEngine(t *testing.T) (*Engine, *MemoryStore, *countingHandler) {
+	t.Helper()
+
+	store := NewMemoryStore()
+	handler := &countingHandler{}
+	engine := newMemoryTestEngine(t, store, []StepHandler{handler},
+		WithLeaseDuration(20*time.Millisecond),
+		WithLeaseReaperInterval(0),
+	)
+
+	return engine, store, handler
+}
+
+// claimAndCrash claims the next queue item like a worker that dies before the handler returns.
+func claimAndCrash(t *testing.T, ctx context.Context, engine *Engine, startHandler bool) {
+	t.Helper()
+
+	err := engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		item, err := engine.store.DequeueStep(ctx, "crashed-worker", nil)
+		require.NoError(t, err)
+		require.NotNil(t, item)
+
+		if err := engine.leaseQueueItem(ctx, item, "crashed-worker"); err != nil {
+			return err
+		}
+		if !startHandler {
+			return nil
+		}
+
+		run, err := engine.executeQueueItem(ctx, item)
+		require.NoError(t, err)
+		require.NotNil(t, run)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestLeaseReaper_RecoversInterruptedRun(t *testing.T) {
+	ctx := context.Background()
+	engine, store, handler := newLeaseReaperTestEngine(t)
+
+	workflowDef, err := NewBuilder("lease_reaper", 1).
+		Step("call", "counting", WithStepMaxRetries(2)).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	claimAndCrash(t, ctx, engine, true)
+
+	// Nothing to recover while the lease is valid
+	engine.processExpiredLeases(ctx)
+	assert.Zero(t, countEvents(t, ctx, store, instanceID, EventQueueItemRecovered))
+	empty, err := engine.ExecuteNext(ctx, "worker-1")
+	require.NoError(t, err)
+	assert.True(t, empty)
+
+	time.Sleep(40 * time.Millisecond)
+	engine.processExpiredLeases(ctx)
+	assert.Equal(t, 1, countEvents(t, ctx, store, instanceID, EventQueueItemRecovered))
+	assert.Equal(t, 1, countEvents(t, ctx, store, instanceID, EventStepRetry))
+
+	// The interrupted run counts as an attempt
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, StepStatusFailed, steps[0].Status)
+	assert.Equal(t, 1, steps[0].RetryCount)
+
+	drainQueue(t, ctx, engine)
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+	assert.Equal(t, int64(1), handler.calls.Load())
+}
+
+func TestLeaseReaper_ReleasesItemNotStarted(t *testing.T) {
+	ctx := context.Background()
+	engine, store, handler := newLeaseReaperTestEngine(t)
+	workflowID := registerChainWorkflow(t, ctx, engine, 1)
+
+	instanceID, err := engine.Start(ctx, workflowID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	// The dispatcher claimed the item but died before a worker started it
+	claimAndCrash(t, ctx, engine, false)
+
+	time.Sleep(40 * time.Millisecond)
+	engine.processExpiredLeases(ctx)
+	assert.Equal(t, 1, countEvents(t, ctx, store, instanceID, EventQueueItemRecovered))
+	assert.Zero(t, countEvents(t, ctx, store, instanceID, EventStepRetry))
+
+	drainQueue(t, ctx, engine)
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, StepStatusCompleted, steps[0].Status)
+	assert.Zero(t, steps[0].RetryCount)
+	assert.Equal(t, int64(1), handler.calls.Load())
+}
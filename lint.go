@@ -0,0 +1,493 @@
+package floxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// MetadataSideEffects is the step metadata key marking a task step without side effects
+// (false), which then needs no compensation.
+const MetadataSideEffects = "side_effects"
+
+var ErrWorkflowLint = errors.New("workflow lint failed")
+
+type LintRule string
+
+const (
+	LintUnreachableStep       LintRule = "unreachable_step"
+	LintMissingCompensation   LintRule = "missing_compensation"
+	LintForkWithoutJoin       LintRule = "fork_without_join"
+	LintUnsatisfiableJoin     LintRule = "unsatisfiable_join"
+	LintRetriedNonIdempotent  LintRule = "retried_non_idempotent"
+	LintInvalidCondition      LintRule = "invalid_condition"
+	LintUnknownConditionField LintRule = "unknown_condition_field"
+	LintUnregisteredHandler   LintRule = "unregistered_handler"
+)
+
+// LintWarning is a finding of LintWorkflowDefinition. Unlike the errors of ValidateWorkflowDefinition,
+// warnings do not prevent a workflow from running.
+type LintWarning struct {
+	Rule    LintRule `json:"rule"`
+	Step    string   `json:"step,omitempty"`
+	Message string   `json:"message"`
+}
+
+func (w LintWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Rule, w.Message)
+}
+
+type LintOptions struct {
+	// Handlers lists the registered handlers. Nil skips the unregistered handler check.
+	Handlers []string
+	// Input is a sample workflow input, checked against the fields read by conditions.
+	Input json.RawMessage
+	// Outputs holds sample handler outputs by step name, checked against the fields read by conditions.
+	Outputs map[string]json.RawMessage
+	// Ignore lists rules whose warnings are dropped.
+	Ignore []LintRule
+}
+
+// LintWorkflowDefinition checks a valid workflow definition for likely mistakes: unreachable steps,
+// task steps with side effects but no compensation, forks without a join, joins waiting for a step of
+// a condition branch, non-idempotent steps with retries, conditions reading missing fields and steps of
+// unregistered handlers.
+//
+// The fields read by a condition are checked only when its input is known: the sample workflow input,
+// a sample handler output or the output of a join, fork or human step.
+func LintWorkflowDefinition(def *WorkflowDefinition, opts LintOptions) []LintWarning {
+	linter := newWorkflowLinter(def, opts)
+
+	linter.lintReachability()
+	linter.lintForks()
+	linter.lintJoins()
+	linter.lintSteps()
+
+	sort.SliceStable(linter.warnings, func(i, j int) bool {
+		return linter.warnings[i].Step < linter.warnings[j].Step
+	})
+
+	return linter.warnings
+}
+
+// LintWorkflow lints def against the handlers registered on the engine.
+func (engine *Engine) LintWorkflow(def *WorkflowDefinition, opts LintOptions) []LintWarning {
+	if opts.Handlers == nil {
+		engine.mu.RLock()
+		opts.Handlers = make([]string, 0, len(engine.handlers))
+		for name := range engine.handlers {
+			opts.Handlers = append(opts.Handlers, name)
+		}
+		engine.mu.RUnlock()
+	}
+
+	return LintWorkflowDefinition(def, opts)
+}
+
+func lintError(warnings []LintWarning) error {
+	messages := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+
+	return fmt.Errorf("%w: %s", ErrWorkflowLint, strings.Join(messages, "; "))
+}
+
+// lintPredecessor is the step a step is entered from; parallel marks the branch start of a fork.
+type lintPredecessor struct {
+	step     string
+	parallel bool
+}
+
+// lintAnyValue stands for a value of unknown shape, any field of which may exist.
+type lintAnyValue struct{}
+
+type workflowLinter struct {
+	def      *WorkflowDefinition
+	steps    map[string]*StepDefinition
+	names    []string
+	opts     LintOptions
+	preds    map[string]lintPredecessor
+	warnings []LintWarning
+}
+
+func newWorkflowLinter(def *WorkflowDefinition, opts LintOptions) *workflowLinter {
+	steps := def.Definition.Steps
+
+	names := make([]string, 0, len(steps))
+	for name := range steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// A step entered from several steps is attributed to the first one by name
+	preds := make(map[string]lintPredecessor, len(steps))
+	addPred := func(step string, pred lintPredecessor) {
+		if _, ok := preds[step]; !ok && step != "" {
+			preds[step] = pred
+		}
+	}
+	for _, name := range names {
+		stepDef := steps[name]
+		for _, next := range stepDef.Next {
+			addPred(next, lintPredecessor{step: name})
+		}
+		addPred(stepDef.Else, lintPredecessor{step: name})
+		for _, parallelStep := range stepDef.Parallel {
+			addPred(parallelStep, lintPredecessor{step: name, parallel: true})
+		}
+	}
+
+	return &workflowLinter{
+		def:   def,
+		steps: steps,
+		names: names,
+		opts:  opts,
+		preds: preds,
+	}
+}
+
+func (l *workflowLinter) warn(rule LintRule, step, format string, args ...any) {
+	if slices.Contains(l.opts.Ignore, rule) {
+		return
+	}
+
+	l.warnings = append(l.warnings, LintWarning{
+		Rule:    rule,
+		Step:    step,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *workflowLinter) lintReachability() {
+	compensations := make(map[string]bool)
+	for _, stepDef := range l.steps {
+		if stepDef.OnFailure != "" {
+			compensations[stepDef.OnFailure] = true
+		}
+	}
+
+	reached := make(map[string]bool, len(l.steps))
+	stack := []string{l.def.Definition.Start}
+	for len(stack) > 0 {
+		name := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		stepDef, ok := l.steps[name]
+		if !ok || reached[name] {
+			continue
+		}
+		reached[name] = true
+
+		stack = append(stack, stepDef.Next...)
+		stack = append(stack, stepDef.Parallel...)
+		if stepDef.Else != "" {
+			stack = append(stack, stepDef.Else)
+		}
+		if stepDef.OnFailure != "" {
+			stack = append(stack, stepDef.OnFailure)
+		}
+	}
+
+	for _, name := range l.names {
+		stepDef := l.steps[name]
+
+		if !reached[name] {
+			l.warn(LintUnreachableStep, name, "step %q is not reachable from start step %q", name, l.def.Definition.Start)
+
+			continue
+		}
+
+		if stepDef.Type == StepTypeTask && stepDef.OnFailure == "" && !compensations[name] && hasSideEffects(stepDef) {
+			l.warn(LintMissingCompensation, name,
+				"step %q runs handler %q without an OnFailure compensation; set metadata %s: false if it has no side effects",
+				name, stepDef.Handler, MetadataSideEffects)
+		}
+	}
+}
+
+func hasSideEffects(stepDef *StepDefinition) bool {
+	sideEffects, ok := stepDef.Metadata[MetadataSideEffects].(bool)
+
+	return !ok || sideEffects
+}
+
+func (l *workflowLinter) lintForks() {
+	for _, name := range l.names {
+		stepDef := l.steps[name]
+		if stepDef.Type != StepTypeFork && stepDef.Type != StepTypeParallel {
+			continue
+		}
+
+		hasJoin := slices.ContainsFunc(stepDef.Next, func(next string) bool {
+			nextDef, ok := l.steps[next]
+
+			return ok && nextDef.Type == StepTypeJoin
+		})
+		if !hasJoin {
+			l.warn(LintForkWithoutJoin, name, "fork %q has no join step, the workflow does not wait for its branches", name)
+		}
+	}
+}
+
+func (l *workflowLinter) lintJoins() {
+	for _, name := range l.names {
+		stepDef := l.steps[name]
+		if stepDef.Type != StepTypeJoin || stepDef.JoinStrategy == JoinStrategyAny {
+			continue
+		}
+
+		for _, waitFor := range stepDef.WaitFor {
+			if isVirtualStep(waitFor) {
+				continue
+			}
+
+			if condition := l.enclosingCondition(waitFor); condition != "" {
+				l.warn(LintUnsatisfiableJoin, name,
+					"join %q waits for step %q, which runs on one branch of condition %q only; use Join to wait for the condition",
+					name, waitFor, condition)
+			}
+		}
+	}
+}
+
+// enclosingCondition returns the nearest condition whose branch contains the step, searching up to the fork.
+func (l *workflowLinter) enclosingCondition(step string) string {
+	visited := make(map[string]bool)
+	for !visited[step] {
+		visited[step] = true
+
+		pred, ok := l.preds[step]
+		if !ok || pred.parallel {
+			return ""
+		}
+		if predDef, ok := l.steps[pred.step]; ok && predDef.Type == StepTypeCondition {
+			return pred.step
+		}
+
+		step = pred.step
+	}
+
+	return ""
+}
+
+func (l *workflowLinter) lintSteps() {
+	var handlers map[string]bool
+	if l.opts.Handlers != nil {
+		handlers = make(map[string]bool, len(l.opts.Handlers))
+		for _, handler := range l.opts.Handlers {
+			handlers[handler] = true
+		}
+	}
+
+	for _, name := range l.names {
+		stepDef := l.steps[name]
+
+		if stepDef.NoIdempotent && stepDef.MaxRetries > 1 {
+			l.warn(LintRetriedNonIdempotent, name, "non-idempotent step %q is retried up to %d times", name, stepDef.MaxRetries)
+		}
+
+		if handlers != nil && stepDef.Handler != "" && !handlers[stepDef.Handler] {
+			l.warn(LintUnregisteredHandler, name, "handler %q of step %q is not registered", stepDef.Handler, name)
+		}
+
+		if stepDef.Type == StepTypeCondition {
+			l.lintCondition(name, stepDef)
+		}
+	}
+}
+
+func (l *workflowLinter) lintCondition(name string, stepDef *StepDefinition) {
+	fields, err := conditionFields(stepDef.Condition)
+	if err != nil {
+		l.warn(LintInvalidCondition, name, "condition of step %q does not parse: %v", name, err)
+
+		return
+	}
+
+	input, ok := l.inputOf(name, make(map[string]bool))
+	if !ok {
+		return
+	}
+
+	reported := make(map[string]bool)
+	for _, field := range fields {
+		path := "." + strings.Join(field, ".")
+		if reported[path] || hasLintField(input, field) {
+			continue
+		}
+		reported[path] = true
+
+		l.warn(LintUnknownConditionField, name, "condition of step %q reads %s, which is not in its input", name, path)
+	}
+}
+
+// inputOf returns the sample input of a step, if it is known.
+func (l *workflowLinter) inputOf(step string, visited map[string]bool) (any, bool) {
+	if visited[step] {
+		return nil, false
+	}
+	visited[step] = true
+
+	if step == l.def.Definition.Start {
+		return decodeLintSample(l.opts.Input)
+	}
+
+	pred, ok := l.preds[step]
+	if !ok {
+		return nil, false
+	}
+	// Fork branches start with the input of the fork
+	if pred.parallel {
+		return l.inputOf(pred.step, visited)
+	}
+
+	return l.outputOf(pred.step, visited)
+}
+
+// outputOf returns the sample output of a step, if it is known.
+func (l *workflowLinter) outputOf(step string, visited map[string]bool) (any, bool) {
+	stepDef, ok := l.steps[step]
+	if !ok {
+		return nil, false
+	}
+
+	switch stepDef.Type {
+	case StepTypeTask:
+		output, ok := l.opts.Outputs[step]
+		if !ok {
+			return nil, false
+		}
+
+		return decodeLintSample(output)
+	case StepTypeCondition, StepTypeSavePoint:
+		return l.inputOf(step, visited)
+	case StepTypeHuman:
+		input, ok := l.inputOf(step, visited)
+		fields, isMap := input.(map[string]any)
+		if !ok || !isMap {
+			return nil, false
+		}
+
+		output := make(map[string]any, len(fields)+4)
+		for key, value := range fields {
+			output[key] = value
+		}
+		for _, key := range []string{"status", "decided_by", "comment", "decided_at"} {
+			output[key] = lintAnyValue{}
+		}
+
+		return output, true
+	case StepTypeJoin:
+		return map[string]any{
+			KeyCompleted: lintAnyValue{},
+			KeyFailed:    lintAnyValue{},
+			KeyOutputs:   lintAnyValue{},
+			KeyStrategy:  lintAnyValue{},
+			KeyStatus:    lintAnyValue{},
+		}, true
+	case StepTypeFork, StepTypeParallel:
+		return map[string]any{
+			KeyStatus:        lintAnyValue{},
+			KeyParallelSteps: lintAnyValue{},
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func decodeLintSample(data json.RawMessage) (any, bool) {
+	if len(data) == 0 {
+		return nil, false
+	}
+
+	var sample any
+	if err := json.Unmarshal(data, &sample); err != nil {
+		return nil, false
+	}
+
+	return sample, true
+}
+
+func hasLintField(value any, path []string) bool {
+	for _, name := range path {
+		switch v := value.(type) {
+		case lintAnyValue:
+			return true
+		case map[string]any:
+			next, ok := v[name]
+			if !ok {
+				return false
+			}
+			value = next
+		default:
+			// A null sample value may hold an object at run time
+			return v == nil
+		}
+	}
+
+	return true
+}
+
+// conditionFields returns the field paths a condition reads from its input, such as [order amount]
+// for .order.amount. Fields inside range and with blocks are relative to another value and left out.
+func conditionFields(expr string) ([][]string, error) {
+	tpl, err := template.New("condition").Funcs(conditionFuncs).Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if tpl.Tree == nil {
+		return nil, nil
+	}
+
+	var fields [][]string
+	collectConditionFields(tpl.Tree.Root, &fields)
+
+	return fields, nil
+}
+
+func collectConditionFields(node parse.Node, fields *[][]string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectConditionFields(child, fields)
+		}
+	case *parse.ActionNode:
+		collectConditionFields(n.Pipe, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectConditionFields(cmd, fields)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectConditionFields(arg, fields)
+		}
+	case *parse.FieldNode:
+		*fields = append(*fields, n.Ident)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			*fields = append(*fields, n.Ident[1:])
+		}
+	case *parse.ChainNode:
+		collectConditionFields(n.Node, fields)
+	case *parse.IfNode:
+		collectConditionFields(n.Pipe, fields)
+		collectConditionFields(n.List, fields)
+		collectConditionFields(n.ElseList, fields)
+	case *parse.RangeNode:
+		collectConditionFields(n.Pipe, fields)
+	case *parse.WithNode:
+		collectConditionFields(n.Pipe, fields)
+	}
+}
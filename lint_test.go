@@ -0,0 +1,168 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintRules(warnings []LintWarning) map[LintRule][]string {
+	rules := make(map[LintRule][]string)
+	for _, warning := range warnings {
+		rules[warning.Rule] = append(rules[warning.Rule], warning.Step)
+	}
+
+	return rules
+}
+
+func TestLintWorkflowDefinition_CleanWorkflow(t *testing.T) {
+	def, err := NewBuilder("clean", 1).
+		Step("reserve", "reserve").OnFailure("release", "release").
+		Then("lookup", "lookup", WithStepMetadata(map[string]any{MetadataSideEffects: false})).
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("email", "notify").OnFailure("email-undo", "notify")
+		}, func(branch *Builder) {
+			branch.Step("sms", "notify").OnFailure("sms-undo", "notify")
+		}).
+		Join("sync", JoinStrategyAll).
+		Condition("all-done", `{{ eq .status "success" }}`, func(elseBranch *Builder) {
+			elseBranch.Step("alert", "notify", WithStepMetadata(map[string]any{MetadataSideEffects: false}))
+		}).
+		Build()
+	require.NoError(t, err)
+
+	warnings := LintWorkflowDefinition(def, LintOptions{
+		Handlers: []string{"reserve", "release", "lookup", "notify"},
+	})
+	assert.Empty(t, warnings)
+}
+
+func TestLintWorkflowDefinition_Rules(t *testing.T) {
+	def, err := NewBuilder("messy", 1).
+		Step("charge", "charge", WithStepNoIdempotent(), WithStepMaxRetries(3)).
+		Then("classify", "classify", WithStepMetadata(map[string]any{MetadataSideEffects: false})).
+		Condition("is-physical", `{{ and (eq .kind "physical") (gt .order.total 0) }}`, func(elseBranch *Builder) {
+			elseBranch.Step("skip", "noop", WithStepMetadata(map[string]any{MetadataSideEffects: false}))
+		}).
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("ship", "ship").OnFailure("unship", "ship")
+		}, func(branch *Builder) {
+			branch.Step("invoice", "invoice").OnFailure("void", "invoice")
+		}).
+		Build()
+	require.NoError(t, err)
+
+	// NoIdempotent caps MaxRetries at 1 in the builder, set it as a YAML definition would
+	def.Definition.Steps["charge"].MaxRetries = 3
+	def.Definition.Steps["orphan"] = &StepDefinition{Name: "orphan", Type: StepTypeTask, Handler: "orphan"}
+
+	warnings := LintWorkflowDefinition(def, LintOptions{
+		Handlers: []string{"charge", "classify", "noop", "ship"},
+		Outputs:  map[string]json.RawMessage{"classify": json.RawMessage(`{"kind":"physical","order":null}`)},
+	})
+
+	rules := lintRules(warnings)
+	assert.Equal(t, []string{"orphan"}, rules[LintUnreachableStep])
+	assert.Equal(t, []string{"charge"}, rules[LintMissingCompensation])
+	assert.Equal(t, []string{"charge"}, rules[LintRetriedNonIdempotent])
+	assert.Equal(t, []string{"fanout"}, rules[LintForkWithoutJoin])
+	assert.Equal(t, []string{"invoice", "orphan", "void"}, rules[LintUnregisteredHandler])
+	assert.Empty(t, rules[LintUnknownConditionField], "fields of a null sample value are not reported")
+
+	warnings = LintWorkflowDefinition(def, LintOptions{
+		Outputs: map[string]json.RawMessage{"classify": json.RawMessage(`{"type":"physical"}`)},
+		Ignore:  []LintRule{LintUnreachableStep, LintMissingCompensation},
+	})
+
+	rules = lintRules(warnings)
+	assert.Empty(t, rules[LintUnreachableStep])
+	assert.Empty(t, rules[LintUnregisteredHandler], "handlers are not checked without a handler list")
+	assert.Equal(t, []string{"is-physical", "is-physical"}, rules[LintUnknownConditionField])
+}
+
+func TestLintWorkflowDefinition_ConditionAfterJoin(t *testing.T) {
+	def, err := NewBuilder("after-join", 1).
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("left", "noop")
+		}, func(branch *Builder) {
+			branch.Step("right", "noop")
+		}).
+		Join("sync", JoinStrategyAll).
+		Condition("wants-report", `{{ eq .generate_report true }}`, func(elseBranch *Builder) {
+			elseBranch.Step("skip-report", "noop")
+		}).
+		Build()
+	require.NoError(t, err)
+
+	warnings := LintWorkflowDefinition(def, LintOptions{Ignore: []LintRule{LintMissingCompensation}})
+	require.Len(t, warnings, 1)
+	assert.Equal(t, LintUnknownConditionField, warnings[0].Rule)
+	assert.Equal(t, "wants-report", warnings[0].Step)
+	assert.Contains(t, warnings[0].Message, ".generate_report")
+}
+
+func TestLintWorkflowDefinition_JoinWaitingForConditionBranch(t *testing.T) {
+	def, err := NewBuilder("branch-join", 1).
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("check", "noop").
+				Condition("is-big", `{{ gt .amount 100 }}`, func(elseBranch *Builder) {
+					elseBranch.Step("small", "noop")
+				}).
+				Then("big", "noop")
+		}, func(branch *Builder) {
+			branch.Step("other", "noop")
+		}).
+		JoinStep("sync", []string{"big", "other"}, JoinStrategyAll).
+		Build()
+	require.NoError(t, err)
+
+	warnings := LintWorkflowDefinition(def, LintOptions{
+		Input:  json.RawMessage(`{"amount":150}`),
+		Ignore: []LintRule{LintMissingCompensation},
+	})
+	require.Len(t, warnings, 1)
+	assert.Equal(t, LintUnsatisfiableJoin, warnings[0].Rule)
+	assert.Equal(t, "sync", warnings[0].Step)
+	assert.Contains(t, warnings[0].Message, `"is-big"`)
+}
+
+func TestLintWorkflowDefinition_InvalidCondition(t *testing.T) {
+	def, err := NewBuilder("invalid-condition", 1).
+		Step("first", "noop", WithStepMetadata(map[string]any{MetadataSideEffects: false})).
+		Condition("broken", `{{ eq .a `, func(elseBranch *Builder) {
+			elseBranch.Step("other", "noop", WithStepMetadata(map[string]any{MetadataSideEffects: false}))
+		}).
+		Build()
+	require.NoError(t, err)
+
+	warnings := LintWorkflowDefinition(def, LintOptions{})
+	require.Len(t, warnings, 1)
+	assert.Equal(t, LintInvalidCondition, warnings[0].Rule)
+}
+
+func TestEngine_RegisterWorkflowWithLint(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+		WithWorkflowLint(LintMissingCompensation),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	def, err := NewBuilder("linted", 1).
+		Step("first", "counting").
+		Build()
+	require.NoError(t, err)
+
+	err = engine.RegisterWorkflow(ctx, def)
+	require.ErrorIs(t, err, ErrWorkflowLint)
+	assert.ErrorContains(t, err, string(LintUnregisteredHandler))
+
+	engine.RegisterHandler(&countingHandler{})
+	require.NoError(t, engine.RegisterWorkflow(ctx, def))
+}
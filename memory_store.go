@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -27,6 +29,7 @@ type MemoryStore struct {
 	cancelRequests      map[int64]*WorkflowCancelRequest
 	humanDecisions      map[int64]*HumanDecisionRecord
 	deadLetters         map[int64]*DeadLetterRecord
+	workers             map[string]*WorkerRecord
 	nextInstanceID      int64
 	nextStepID          int64
 	nextQueueID         int64
@@ -36,10 +39,15 @@ type MemoryStore struct {
 	nextDeadLetterID    int64
 	agingEnabled        bool
 	agingRate           float64
+	notifications       *notificationHub
+	clock               Clock
 }
 
-func NewMemoryStore() *MemoryStore {
+func NewMemoryStore(opts ...StoreOption) *MemoryStore {
+	options := applyStoreOptions(opts)
+
 	return &MemoryStore{
+		clock:               options.clock,
 		definitions:         make(map[string]*WorkflowDefinition),
 		instances:           make(map[int64]*WorkflowInstance),
 		steps:               make(map[int64]*WorkflowStep),
@@ -51,6 +59,7 @@ func NewMemoryStore() *MemoryStore {
 		cancelRequests:      make(map[int64]*WorkflowCancelRequest),
 		humanDecisions:      make(map[int64]*HumanDecisionRecord),
 		deadLetters:         make(map[int64]*DeadLetterRecord),
+		workers:             make(map[string]*WorkerRecord),
 		nextInstanceID:      1,
 		nextStepID:          1,
 		nextQueueID:         1,
@@ -60,9 +69,14 @@ func NewMemoryStore() *MemoryStore {
 		nextDeadLetterID:    1,
 		agingEnabled:        true,
 		agingRate:           0.5,
+		notifications:       newNotificationHub(),
 	}
 }
 
+func (s *MemoryStore) Subscribe(channel string) (<-chan string, func()) {
+	return s.notifications.Subscribe(channel)
+}
+
 func (s *MemoryStore) SetAgingEnabled(enabled bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -75,21 +89,48 @@ func (s *MemoryStore) SetAgingRate(rate float64) {
 	s.agingRate = rate
 }
 
+// Clock returns the clock set with WithStoreClock.
+func (s *MemoryStore) Clock() Clock {
+	return s.clock
+}
+
 func (s *MemoryStore) SaveWorkflowDefinition(ctx context.Context, def *WorkflowDefinition) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if existing, exists := s.definitions[def.ID]; exists && existing != nil {
+	if def.Namespace == "" {
+		def.Namespace = namespaceOf(ctx)
+	}
+	if !visibleIn(ctx, def.Namespace) {
+		return ErrNamespaceMismatch
+	}
+
+	if existing, exists := s.definitions[def.ID]; exists && existing != nil && existing.Namespace != def.Namespace {
+		return ErrNamespaceMismatch
+	}
+
+	// Names and versions are unique per namespace: saving one again replaces its graph
+	existing := s.definitions[def.ID]
+	for _, other := range s.definitions {
+		if other.Namespace == def.Namespace && other.Name == def.Name && other.Version == def.Version {
+			existing = other
+
+			break
+		}
+	}
+
+	if existing != nil {
 		def.ID = existing.ID
 		def.CreatedAt = existing.CreatedAt
 	} else {
 		if def.ID == "" {
 			def.ID = uuid.NewString()
 		}
-		def.CreatedAt = time.Now()
+		def.CreatedAt = s.clock.Now()
 	}
 
 	s.definitions[def.ID] = def
+	s.notifications.publish(NotifyChannelDefinition, def.ID)
 
 	return nil
 }
@@ -99,7 +140,7 @@ func (s *MemoryStore) GetWorkflowDefinition(ctx context.Context, id string) (*Wo
 	defer s.mu.RUnlock()
 
 	def, exists := s.definitions[id]
-	if !exists {
+	if !exists || !visibleIn(ctx, def.Namespace) {
 		return nil, ErrEntityNotFound
 	}
 
@@ -114,10 +155,19 @@ func (s *MemoryStore) CreateInstance(
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	now := time.Now()
+	namespace := DefaultNamespace
+	if def, exists := s.definitions[workflowID]; exists {
+		namespace = def.Namespace
+	}
+	if !visibleIn(ctx, namespace) {
+		return nil, ErrEntityNotFound
+	}
+
+	now := s.clock.Now()
 	instance := &WorkflowInstance{
 		ID:         s.nextInstanceID,
 		WorkflowID: workflowID,
+		Namespace:  namespace,
 		Status:     StatusPending,
 		Input:      input,
 		CreatedAt:  now,
@@ -148,18 +198,20 @@ func (s *MemoryStore) UpdateInstanceStatus(
 	instance.Status = status
 	instance.Output = output
 	instance.Error = errMsg
-	instance.UpdatedAt = time.Now()
+	instance.UpdatedAt = s.clock.Now()
 
 	if status == StatusCompleted || status == StatusFailed || status == StatusCancelled {
-		now := time.Now()
+		now := s.clock.Now()
 		instance.CompletedAt = &now
 	}
 
 	if instance.StartedAt == nil && status == StatusRunning {
-		now := time.Now()
+		now := s.clock.Now()
 		instance.StartedAt = &now
 	}
 
+	s.notifications.publish(NotifyChannelInstance, strconv.FormatInt(instanceID, 10))
+
 	return nil
 }
 
@@ -168,11 +220,28 @@ func (s *MemoryStore) GetInstance(ctx context.Context, instanceID int64) (*Workf
 	defer s.mu.RUnlock()
 
 	instance, exists := s.instances[instanceID]
-	if !exists {
+	if !exists || !visibleIn(ctx, instance.Namespace) {
 		return nil, ErrEntityNotFound
 	}
 
-	return instance, nil
+	// Callers must not observe later updates made under the lock
+	instanceCopy := *instance
+
+	return &instanceCopy, nil
+}
+
+func (s *MemoryStore) CountRunningInstances(ctx context.Context, namespace string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, instance := range s.instances {
+		if instance.Namespace == namespace && isRunningStatus(instance.Status) {
+			count++
+		}
+	}
+
+	return count, nil
 }
 
 func (s *MemoryStore) CreateStep(ctx context.Context, step *WorkflowStep) error {
@@ -184,7 +253,7 @@ func (s *MemoryStore) CreateStep(ctx context.Context, step *WorkflowStep) error
 	}
 
 	step.ID = s.nextStepID
-	step.CreatedAt = time.Now()
+	step.CreatedAt = s.clock.Now()
 	s.nextStepID++
 
 	s.steps[step.ID] = step
@@ -208,7 +277,7 @@ func (s *MemoryStore) UpdateStep(
 		return ErrEntityNotFound
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	step.Status = status
 	step.Output = output
 	step.Error = errMsg
@@ -228,6 +297,30 @@ func (s *MemoryStore) UpdateStep(
 	return nil
 }
 
+func (s *MemoryStore) SkipPendingStep(ctx context.Context, stepID int64, output json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	step, exists := s.steps[stepID]
+	if !exists {
+		return ErrEntityNotFound
+	}
+
+	switch step.Status {
+	case StepStatusPending, StepStatusFailed, StepStatusWaitingDecision:
+	default:
+		return ErrStepNotSkippable
+	}
+
+	now := s.clock.Now()
+	step.Status = StepStatusSkipped
+	step.Output = output
+	step.Error = nil
+	step.CompletedAt = &now
+
+	return nil
+}
+
 func (s *MemoryStore) GetStepsByInstance(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -245,7 +338,11 @@ func (s *MemoryStore) GetStepsByInstance(ctx context.Context, instanceID int64)
 	}
 
 	sort.Slice(steps, func(i, j int) bool {
-		return steps[i].CreatedAt.Before(steps[j].CreatedAt)
+		if !steps[i].CreatedAt.Equal(steps[j].CreatedAt) {
+			return steps[i].CreatedAt.Before(steps[j].CreatedAt)
+		}
+
+		return steps[i].ID < steps[j].ID
 	})
 
 	return steps, nil
@@ -255,6 +352,7 @@ func (s *MemoryStore) EnqueueStep(
 	ctx context.Context,
 	instanceID int64,
 	stepID *int64,
+	taskQueue string,
 	priority Priority,
 	delay time.Duration,
 ) error {
@@ -265,21 +363,70 @@ func (s *MemoryStore) EnqueueStep(
 		ID:          s.nextQueueID,
 		InstanceID:  instanceID,
 		StepID:      stepID,
-		ScheduledAt: time.Now().Add(delay),
+		ScheduledAt: s.clock.Now().Add(delay),
 		Priority:    int(priority),
+		TaskQueue:   taskQueue,
+		Namespace:   s.instanceNamespaceLocked(instanceID),
 	}
 
 	s.queue[item.ID] = item
 	s.nextQueueID++
 
+	s.notifications.publish(NotifyChannelQueue, queueNotifyPayload(item.ScheduledAt))
+
 	return nil
 }
 
-func (s *MemoryStore) DequeueStep(ctx context.Context, workerID string) (*QueueItem, error) {
+func (s *MemoryStore) DequeueStep(ctx context.Context, workerID string, taskQueues []string) (*QueueItem, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	now := time.Now()
+	return s.dequeueLocked(s.clock.Now(), namespaceFilter(ctx), workerID, taskQueues), nil
+}
+
+func (s *MemoryStore) DequeueSteps(ctx context.Context, workerID string, taskQueues []string, n int) ([]QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	scope := namespaceFilter(ctx)
+	items := make([]QueueItem, 0, n)
+	for len(items) < n {
+		item := s.dequeueLocked(now, scope, workerID, taskQueues)
+		if item == nil {
+			break
+		}
+		items = append(items, *item)
+	}
+
+	return items, nil
+}
+
+func (s *MemoryStore) GetQueueLength(ctx context.Context, taskQueues []string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.clock.Now()
+	count := 0
+	for _, item := range s.queue {
+		if item.AttemptedAt != nil || item.ScheduledAt.After(now) {
+			continue
+		}
+		if taskQueues != nil && !slices.Contains(taskQueues, item.TaskQueue) {
+			continue
+		}
+		if !visibleIn(ctx, item.Namespace) {
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// dequeueLocked claims the due item with the highest effective priority, restricted to the scope
+// namespace when it is not nil. The caller holds s.mu.
+func (s *MemoryStore) dequeueLocked(now time.Time, scope *string, workerID string, taskQueues []string) *QueueItem {
 	var selectedItem *QueueItem
 	maxPriority := -1
 
@@ -290,6 +437,12 @@ func (s *MemoryStore) DequeueStep(ctx context.Context, workerID string) (*QueueI
 		if item.ScheduledAt.After(now) {
 			continue
 		}
+		if taskQueues != nil && !slices.Contains(taskQueues, item.TaskQueue) {
+			continue
+		}
+		if scope != nil && item.Namespace != *scope {
+			continue
+		}
 
 		priority := item.Priority
 		if s.agingEnabled && s.agingRate > 0 {
@@ -300,15 +453,16 @@ func (s *MemoryStore) DequeueStep(ctx context.Context, workerID string) (*QueueI
 			}
 		}
 
+		// Ties are broken by scheduled_at, then by ID, like in the SQL stores
 		if priority > maxPriority ||
-			(priority == maxPriority && selectedItem != nil && item.ScheduledAt.Before(selectedItem.ScheduledAt)) {
+			(priority == maxPriority && selectedItem != nil && queuedBefore(item, selectedItem)) {
 			maxPriority = priority
 			selectedItem = item
 		}
 	}
 
 	if selectedItem == nil {
-		return nil, nil
+		return nil
 	}
 
 	selectedItem.AttemptedAt = &now
@@ -316,7 +470,15 @@ func (s *MemoryStore) DequeueStep(ctx context.Context, workerID string) (*QueueI
 
 	result := *selectedItem
 
-	return &result, nil
+	return &result
+}
+
+func queuedBefore(a, b *QueueItem) bool {
+	if !a.ScheduledAt.Equal(b.ScheduledAt) {
+		return a.ScheduledAt.Before(b.ScheduledAt)
+	}
+
+	return a.ID < b.ID
 }
 
 func (s *MemoryStore) RemoveFromQueue(ctx context.Context, queueID int64) error {
@@ -339,6 +501,10 @@ func (s *MemoryStore) ReleaseQueueItem(ctx context.Context, queueID int64) error
 
 	item.AttemptedAt = nil
 	item.AttemptedBy = nil
+	item.LeaseToken = nil
+	item.LeaseExpiresAt = nil
+
+	s.notifications.publish(NotifyChannelQueue, "")
 
 	return nil
 }
@@ -352,7 +518,7 @@ func (s *MemoryStore) RescheduleAndReleaseQueueItem(ctx context.Context, queueID
 		return ErrEntityNotFound
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	newScheduledAt := now.Add(delay)
 	if newScheduledAt.Before(item.ScheduledAt) {
 		newScheduledAt = item.ScheduledAt
@@ -361,10 +527,84 @@ func (s *MemoryStore) RescheduleAndReleaseQueueItem(ctx context.Context, queueID
 	item.ScheduledAt = newScheduledAt
 	item.AttemptedAt = nil
 	item.AttemptedBy = nil
+	item.LeaseToken = nil
+	item.LeaseExpiresAt = nil
+
+	s.notifications.publish(NotifyChannelQueue, queueNotifyPayload(newScheduledAt))
+
+	return nil
+}
+
+func (s *MemoryStore) LeaseQueueItem(
+	ctx context.Context,
+	queueID int64,
+	workerID, leaseToken string,
+	leaseUntil time.Time,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, exists := s.queue[queueID]
+	if !exists || item.AttemptedBy == nil || *item.AttemptedBy != workerID || item.LeaseToken != nil {
+		return ErrLeaseLost
+	}
+
+	item.LeaseToken = &leaseToken
+	item.LeaseExpiresAt = &leaseUntil
+
+	return nil
+}
+
+func (s *MemoryStore) RenewQueueItemLease(ctx context.Context, queueID int64, leaseToken string, leaseUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, exists := s.queue[queueID]
+	if !exists || item.LeaseToken == nil || *item.LeaseToken != leaseToken {
+		return ErrLeaseLost
+	}
+
+	item.LeaseExpiresAt = &leaseUntil
 
 	return nil
 }
 
+func (s *MemoryStore) ReclaimExpiredQueueItems(
+	ctx context.Context,
+	workerID string,
+	now, claimedBefore time.Time,
+	limit int,
+) ([]QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []QueueItem
+	for _, item := range s.queue {
+		if len(items) >= limit {
+			break
+		}
+		if item.AttemptedAt == nil || !visibleIn(ctx, item.Namespace) {
+			continue
+		}
+
+		expired := item.LeaseExpiresAt != nil && item.LeaseExpiresAt.Before(now)
+		abandoned := item.LeaseToken == nil && item.AttemptedAt.Before(claimedBefore)
+		if !expired && !abandoned {
+			continue
+		}
+
+		items = append(items, *item)
+
+		reclaimedAt := now
+		item.AttemptedAt = &reclaimedAt
+		item.AttemptedBy = &workerID
+		item.LeaseToken = nil
+		item.LeaseExpiresAt = nil
+	}
+
+	return items, nil
+}
+
 func (s *MemoryStore) LogEvent(
 	ctx context.Context,
 	instanceID int64,
@@ -386,7 +626,8 @@ func (s *MemoryStore) LogEvent(
 		StepID:     stepID,
 		EventType:  eventType,
 		Payload:    payloadJSON,
-		CreatedAt:  time.Now(),
+		Namespace:  s.instanceNamespaceLocked(instanceID),
+		CreatedAt:  s.clock.Now(),
 	}
 
 	s.events = append(s.events, event)
@@ -415,7 +656,7 @@ func (s *MemoryStore) CreateJoinState(
 		strategy = JoinStrategyAll
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	state := &JoinState{
 		InstanceID:   instanceID,
 		JoinStepName: joinStepName,
@@ -485,7 +726,7 @@ func (s *MemoryStore) UpdateJoinState(
 	}
 
 	state.IsReady = s.checkJoinReady(state.WaitingFor, state.Completed, state.Failed, state.JoinStrategy)
-	state.UpdatedAt = time.Now()
+	state.UpdatedAt = s.clock.Now()
 
 	return state.IsReady, nil
 }
@@ -522,7 +763,7 @@ func (s *MemoryStore) AddToJoinWaitFor(ctx context.Context, instanceID int64, jo
 
 	state.WaitingFor = append(state.WaitingFor, stepToAdd)
 	state.IsReady = s.checkJoinReady(state.WaitingFor, state.Completed, state.Failed, state.JoinStrategy)
-	state.UpdatedAt = time.Now()
+	state.UpdatedAt = s.clock.Now()
 
 	return nil
 }
@@ -582,7 +823,7 @@ func (s *MemoryStore) ReplaceInJoinWaitFor(ctx context.Context, instanceID int64
 	}
 
 	state.IsReady = s.checkJoinReady(state.WaitingFor, state.Completed, state.Failed, state.JoinStrategy)
-	state.UpdatedAt = time.Now()
+	state.UpdatedAt = s.clock.Now()
 
 	return nil
 }
@@ -622,6 +863,9 @@ func (s *MemoryStore) GetSummaryStats(ctx context.Context) (*SummaryStats, error
 
 	stats := &SummaryStats{}
 	for _, instance := range s.instances {
+		if !visibleIn(ctx, instance.Namespace) {
+			continue
+		}
 		stats.TotalWorkflows++
 		switch instance.Status {
 		case StatusCompleted:
@@ -650,6 +894,9 @@ func (s *MemoryStore) GetActiveInstances(ctx context.Context) ([]ActiveWorkflowI
 		if instance.Status != StatusRunning && instance.Status != StatusPending && instance.Status != StatusDLQ {
 			continue
 		}
+		if !visibleIn(ctx, instance.Namespace) {
+			continue
+		}
 
 		active := ActiveWorkflowInstance{
 			ID:         instance.ID,
@@ -697,7 +944,9 @@ func (s *MemoryStore) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowDef
 
 	definitions := make([]WorkflowDefinition, 0, len(s.definitions))
 	for _, def := range s.definitions {
-		definitions = append(definitions, *def)
+		if visibleIn(ctx, def.Namespace) {
+			definitions = append(definitions, *def)
+		}
 	}
 
 	sort.Slice(definitions, func(i, j int) bool {
@@ -716,7 +965,7 @@ func (s *MemoryStore) GetWorkflowInstances(ctx context.Context, workflowID strin
 
 	instances := make([]WorkflowInstance, 0)
 	for _, instance := range s.instances {
-		if instance.WorkflowID == workflowID {
+		if instance.WorkflowID == workflowID && visibleIn(ctx, instance.Namespace) {
 			instances = append(instances, *instance)
 		}
 	}
@@ -734,7 +983,9 @@ func (s *MemoryStore) GetAllWorkflowInstances(ctx context.Context) ([]WorkflowIn
 
 	instances := make([]WorkflowInstance, 0, len(s.instances))
 	for _, instance := range s.instances {
-		instances = append(instances, *instance)
+		if visibleIn(ctx, instance.Namespace) {
+			instances = append(instances, *instance)
+		}
 	}
 
 	sort.Slice(instances, func(i, j int) bool {
@@ -744,13 +995,13 @@ func (s *MemoryStore) GetAllWorkflowInstances(ctx context.Context) ([]WorkflowIn
 	return instances, nil
 }
 
-func (s *MemoryStore) GetWorkflowInstancesPaginated(_ context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error) {
+func (s *MemoryStore) GetWorkflowInstancesPaginated(ctx context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	instances := make([]WorkflowInstance, 0)
 	for _, instance := range s.instances {
-		if instance.WorkflowID == workflowID {
+		if instance.WorkflowID == workflowID && visibleIn(ctx, instance.Namespace) {
 			instances = append(instances, *instance)
 		}
 	}
@@ -779,7 +1030,9 @@ func (s *MemoryStore) GetAllWorkflowInstancesPaginated(ctx context.Context, offs
 
 	instances := make([]WorkflowInstance, 0, len(s.instances))
 	for _, instance := range s.instances {
-		instances = append(instances, *instance)
+		if visibleIn(ctx, instance.Namespace) {
+			instances = append(instances, *instance)
+		}
 	}
 
 	sort.Slice(instances, func(i, j int) bool {
@@ -800,6 +1053,66 @@ func (s *MemoryStore) GetAllWorkflowInstancesPaginated(ctx context.Context, offs
 	return instances[offset:end], total, nil
 }
 
+func (s *MemoryStore) SetInstanceLabels(_ context.Context, instanceID int64, labels map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, exists := s.instances[instanceID]
+	if !exists {
+		return ErrEntityNotFound
+	}
+
+	// Copy on write: readers may hold the previous map
+	merged := make(map[string]string, len(instance.Labels)+len(labels))
+	for key, value := range instance.Labels {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	instance.Labels = merged
+
+	return nil
+}
+
+func (s *MemoryStore) SearchInstances(ctx context.Context, query InstanceQuery) (*InstanceSearchResult, error) {
+	cursor, err := query.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	instances := make([]WorkflowInstance, 0)
+	for _, instance := range s.instances {
+		if !visibleIn(ctx, instance.Namespace) {
+			continue
+		}
+
+		workflowName := ""
+		if def, ok := s.definitions[instance.WorkflowID]; ok {
+			workflowName = def.Name
+		}
+
+		if !query.matches(instance, workflowName) || !query.afterCursor(instance, cursor) {
+			continue
+		}
+
+		instances = append(instances, *instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return query.less(&instances[i], &instances[j])
+	})
+
+	if len(instances) > query.Limit+1 {
+		instances = instances[:query.Limit+1]
+	}
+
+	return query.pageResult(instances), nil
+}
+
 func (s *MemoryStore) GetWorkflowSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
 	return s.GetStepsByInstance(ctx, instanceID)
 }
@@ -817,19 +1130,70 @@ func (s *MemoryStore) GetWorkflowEvents(ctx context.Context, instanceID int64) (
 	for _, eventID := range eventIDs {
 		for _, event := range s.events {
 			if event.ID == eventID {
-				events = append(events, *event)
+				if visibleIn(ctx, event.Namespace) {
+					events = append(events, *event)
+				}
 				break
 			}
 		}
 	}
 
 	sort.Slice(events, func(i, j int) bool {
-		return events[i].CreatedAt.Before(events[j].CreatedAt)
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+
+		return events[i].ID < events[j].ID
+	})
+
+	return events, nil
+}
+
+func (s *MemoryStore) GetEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter EventFilter,
+	limit int,
+) ([]WorkflowEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// s.events is appended in ID order
+	start := sort.Search(len(s.events), func(i int) bool {
+		return s.events[i].ID > afterID
 	})
 
+	events := make([]WorkflowEvent, 0)
+	for _, event := range s.events[start:] {
+		var workflowID string
+		if instance, ok := s.instances[event.InstanceID]; ok {
+			workflowID = instance.WorkflowID
+		}
+
+		if !filter.matches(event, workflowID) || !visibleIn(ctx, event.Namespace) {
+			continue
+		}
+
+		events = append(events, *event)
+		if len(events) == limit {
+			break
+		}
+	}
+
 	return events, nil
 }
 
+func (s *MemoryStore) GetLastEventID(ctx context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+
+	return s.events[len(s.events)-1].ID, nil
+}
+
 func (s *MemoryStore) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -838,7 +1202,7 @@ func (s *MemoryStore) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, er
 
 	for _, instance := range s.instances {
 		def, exists := s.definitions[instance.WorkflowID]
-		if !exists {
+		if !exists || !visibleIn(ctx, instance.Namespace) {
 			continue
 		}
 
@@ -896,7 +1260,11 @@ func (s *MemoryStore) GetActiveStepsForUpdate(ctx context.Context, instanceID in
 	}
 
 	sort.Slice(steps, func(i, j int) bool {
-		return steps[i].CreatedAt.After(steps[j].CreatedAt)
+		if !steps[i].CreatedAt.Equal(steps[j].CreatedAt) {
+			return steps[i].CreatedAt.After(steps[j].CreatedAt)
+		}
+
+		return steps[i].ID > steps[j].ID
 	})
 
 	return steps, nil
@@ -911,7 +1279,7 @@ func (s *MemoryStore) CreateCancelRequest(ctx context.Context, req *WorkflowCanc
 	}
 
 	req.ID = s.nextCancelRequestID
-	req.CreatedAt = time.Now()
+	req.CreatedAt = s.clock.Now()
 	s.nextCancelRequestID++
 
 	s.cancelRequests[req.InstanceID] = req
@@ -945,7 +1313,7 @@ func (s *MemoryStore) CreateHumanDecision(ctx context.Context, decision *HumanDe
 	defer s.mu.Unlock()
 
 	decision.ID = s.nextHumanDecisionID
-	decision.CreatedAt = time.Now()
+	decision.CreatedAt = s.clock.Now()
 	s.nextHumanDecisionID++
 
 	s.humanDecisions[decision.StepID] = decision
@@ -1015,7 +1383,8 @@ func (s *MemoryStore) CreateDeadLetterRecord(ctx context.Context, rec *DeadLette
 	defer s.mu.Unlock()
 
 	rec.ID = s.nextDeadLetterID
-	rec.CreatedAt = time.Now()
+	rec.Namespace = s.instanceNamespaceLocked(rec.InstanceID)
+	rec.CreatedAt = s.clock.Now()
 	s.nextDeadLetterID++
 
 	s.deadLetters[rec.ID] = rec
@@ -1026,6 +1395,7 @@ func (s *MemoryStore) CreateDeadLetterRecord(ctx context.Context, rec *DeadLette
 func (s *MemoryStore) RequeueDeadLetter(
 	ctx context.Context,
 	dlqID int64,
+	taskQueue string,
 	newInput *json.RawMessage,
 ) error {
 	s.mu.Lock()
@@ -1065,20 +1435,40 @@ func (s *MemoryStore) RequeueDeadLetter(
 		}
 	}
 
+	stepID := rec.StepID
+	s.queue[s.nextQueueID] = &QueueItem{
+		ID:          s.nextQueueID,
+		InstanceID:  rec.InstanceID,
+		StepID:      &stepID,
+		ScheduledAt: s.clock.Now(),
+		TaskQueue:   taskQueue,
+		Namespace:   rec.Namespace,
+	}
+	s.nextQueueID++
+
 	delete(s.deadLetters, dlqID)
 
+	s.notifications.publish(NotifyChannelQueue, "")
+
 	return nil
 }
 
-func (s *MemoryStore) ListDeadLetters(ctx context.Context, offset int, limit int) ([]DeadLetterRecord, int64, error) {
+func (s *MemoryStore) ListDeadLetters(
+	ctx context.Context,
+	filter DeadLetterFilter,
+	offset int,
+	limit int,
+) ([]DeadLetterRecord, int64, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	total := int64(len(s.deadLetters))
 	records := make([]DeadLetterRecord, 0, len(s.deadLetters))
 	for _, rec := range s.deadLetters {
-		records = append(records, *rec)
+		if filter.matches(rec) && visibleIn(ctx, rec.Namespace) {
+			records = append(records, *rec)
+		}
 	}
+	total := int64(len(records))
 
 	sort.Slice(records, func(i, j int) bool {
 		return records[i].CreatedAt.After(records[j].CreatedAt)
@@ -1107,13 +1497,66 @@ func (s *MemoryStore) GetDeadLetterByID(ctx context.Context, id int64) (*DeadLet
 	defer s.mu.RUnlock()
 
 	rec, exists := s.deadLetters[id]
-	if !exists {
+	if !exists || !visibleIn(ctx, rec.Namespace) {
 		return nil, ErrEntityNotFound
 	}
 
 	return rec, nil
 }
 
+func (s *MemoryStore) GetDeadLettersByInstance(ctx context.Context, instanceID int64) ([]DeadLetterRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]DeadLetterRecord, 0)
+	for _, rec := range s.deadLetters {
+		if rec.InstanceID == instanceID && visibleIn(ctx, rec.Namespace) {
+			records = append(records, *rec)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+
+	return records, nil
+}
+
+func (s *MemoryStore) GetDueDeadLetters(ctx context.Context, now time.Time, limit int) ([]DeadLetterRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]DeadLetterRecord, 0)
+	for _, rec := range s.deadLetters {
+		if rec.NextRedriveAt != nil && !rec.NextRedriveAt.After(now) && visibleIn(ctx, rec.Namespace) {
+			records = append(records, *rec)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].NextRedriveAt.Before(*records[j].NextRedriveAt)
+	})
+
+	if len(records) > limit {
+		records = records[:limit]
+	}
+
+	return records, nil
+}
+
+func (s *MemoryStore) DeleteDeadLetter(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.deadLetters[id]; !exists {
+		return ErrEntityNotFound
+	}
+
+	delete(s.deadLetters, id)
+
+	return nil
+}
+
 func (s *MemoryStore) PauseActiveStepsAndClearQueue(ctx context.Context, instanceID int64) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -1137,11 +1580,101 @@ func (s *MemoryStore) PauseActiveStepsAndClearQueue(ctx context.Context, instanc
 	return nil
 }
 
+func (s *MemoryStore) RegisterWorker(ctx context.Context, worker *WorkerRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := *worker
+	rec.Handlers = slices.Clone(worker.Handlers)
+	rec.TaskQueues = slices.Clone(worker.TaskQueues)
+	rec.Status = WorkerStatusAlive
+	s.workers[worker.ID] = &rec
+
+	return nil
+}
+
+func (s *MemoryStore) HeartbeatWorker(ctx context.Context, workerID string, inFlight, concurrency int, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, exists := s.workers[workerID]
+	if !exists {
+		return ErrEntityNotFound
+	}
+
+	rec.InFlight = inFlight
+	rec.Concurrency = concurrency
+	rec.LastHeartbeatAt = at
+	rec.Status = WorkerStatusAlive
+
+	return nil
+}
+
+func (s *MemoryStore) DeregisterWorker(ctx context.Context, workerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, exists := s.workers[workerID]
+	if !exists {
+		return ErrEntityNotFound
+	}
+
+	rec.InFlight = 0
+	rec.Status = WorkerStatusStopped
+
+	return nil
+}
+
+func (s *MemoryStore) MarkStaleWorkersDead(ctx context.Context, heartbeatBefore time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	marked := int64(0)
+	for _, rec := range s.workers {
+		if rec.Status == WorkerStatusAlive && rec.LastHeartbeatAt.Before(heartbeatBefore) {
+			rec.Status = WorkerStatusDead
+			marked++
+		}
+	}
+
+	return marked, nil
+}
+
+func (s *MemoryStore) ListWorkers(ctx context.Context) ([]WorkerRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	workers := make([]WorkerRecord, 0, len(s.workers))
+	for _, rec := range s.workers {
+		workers = append(workers, *rec)
+	}
+
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].ID < workers[j].ID
+	})
+
+	return workers, nil
+}
+
+func (s *MemoryStore) GetWorker(ctx context.Context, workerID string) (*WorkerRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, exists := s.workers[workerID]
+	if !exists {
+		return nil, ErrEntityNotFound
+	}
+
+	worker := *rec
+
+	return &worker, nil
+}
+
 func (s *MemoryStore) CleanupOldWorkflows(context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	cutoffTime := time.Now().AddDate(0, 0, -1)
+	cutoffTime := s.clock.Now().AddDate(0, 0, -1)
 	deleted := int64(0)
 
 	for id, instance := range s.instances {
@@ -1173,6 +1706,47 @@ func (s *MemoryStore) CleanupOldWorkflows(context.Context) error {
 	return nil
 }
 
+// instanceNamespaceLocked returns the namespace of an instance. The caller holds s.mu.
+func (s *MemoryStore) instanceNamespaceLocked(instanceID int64) string {
+	if instance, exists := s.instances[instanceID]; exists && instance.Namespace != "" {
+		return instance.Namespace
+	}
+
+	return DefaultNamespace
+}
+
 func (s *MemoryStore) joinStateKey(instanceID int64, joinStepName string) string {
 	return fmt.Sprintf("%d:%s", instanceID, joinStepName)
 }
+
+// nextScheduledAt returns the earliest scheduled_at of the unclaimed queue items that are not due yet.
+func (s *MemoryStore) nextScheduledAt() (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.clock.Now()
+
+	var next time.Time
+	for _, item := range s.queue {
+		if item.AttemptedAt != nil || !item.ScheduledAt.After(now) {
+			continue
+		}
+		if next.IsZero() || item.ScheduledAt.Before(next) {
+			next = item.ScheduledAt
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// removeQueuedStep drops the queue items of a step.
+func (s *MemoryStore) removeQueuedStep(stepID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, item := range s.queue {
+		if item.StepID != nil && *item.StepID == stepID {
+			delete(s.queue, id)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 )
 
+// MemoryTxManager runs the function directly: it gives no isolation and no rollback, a failing
+// function keeps the writes it made before the error.
 type MemoryTxManager struct{}
 
 func NewMemoryTxManager() *MemoryTxManager {
@@ -0,0 +1,28 @@
+BEGIN;
+
+-- ============================================================
+-- Instance labels (business keys) and indexes for SearchInstances
+-- ============================================================
+
+ALTER TABLE workflows.workflow_instances
+    ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN workflows.workflow_instances.labels IS 'User-defined labels and business keys attached to the instance (flat string map)';
+
+-- Containment lookups: labels @> '{"customer_id":"42"}'
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_labels
+    ON workflows.workflow_instances USING GIN (labels jsonb_path_ops);
+
+-- Keyset pagination: (sort column, id) in both directions
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_created_at_id
+    ON workflows.workflow_instances (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_updated_at_id
+    ON workflows.workflow_instances (updated_at, id);
+
+-- Most common filters combined with the default sort
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_status_created_at
+    ON workflows.workflow_instances (status, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_workflow_id_created_at
+    ON workflows.workflow_instances (workflow_id, created_at, id);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- ============================================================
+-- DLQ redrive state and indexes for DLQ filters
+-- ============================================================
+
+ALTER TABLE workflows.workflow_dlq
+    ADD COLUMN IF NOT EXISTS redrive_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_redrive_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN workflows.workflow_dlq.redrive_count IS 'Number of automatic redrives of the step before this record was created';
+COMMENT ON COLUMN workflows.workflow_dlq.next_redrive_at IS 'When the record is requeued automatically by the redrive policy (NULL = never)';
+
+-- Redrive worker lookup
+CREATE INDEX IF NOT EXISTS idx_workflow_dlq_next_redrive_at
+    ON workflows.workflow_dlq (next_redrive_at)
+    WHERE next_redrive_at IS NOT NULL;
+
+-- ListDeadLetters filters
+CREATE INDEX IF NOT EXISTS idx_workflow_dlq_workflow_id_created_at
+    ON workflows.workflow_dlq (workflow_id, created_at);
+
+COMMIT;
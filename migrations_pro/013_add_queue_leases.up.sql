@@ -0,0 +1,19 @@
+BEGIN;
+
+-- ============================================================
+-- Queue item leases: handlers run outside the claiming transaction
+-- ============================================================
+
+ALTER TABLE workflows.workflow_queue
+    ADD COLUMN IF NOT EXISTS lease_token TEXT,
+    ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN workflows.workflow_queue.lease_token IS 'Token of the current execution lease; results are committed only while it is held';
+COMMENT ON COLUMN workflows.workflow_queue.lease_expires_at IS 'When the execution lease expires unless renewed by the worker heartbeat';
+
+-- Expired lease lookup
+CREATE INDEX IF NOT EXISTS idx_workflow_queue_lease_expires_at
+    ON workflows.workflow_queue (lease_expires_at)
+    WHERE lease_expires_at IS NOT NULL;
+
+COMMIT;
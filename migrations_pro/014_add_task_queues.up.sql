@@ -0,0 +1,17 @@
+BEGIN;
+
+-- ============================================================
+-- Task queues: workers dequeue only items they have handlers for
+-- ============================================================
+
+ALTER TABLE workflows.workflow_queue
+    ADD COLUMN IF NOT EXISTS task_queue TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN workflows.workflow_queue.task_queue IS 'Task queue of the item (step task queue or handler name); empty for the default queue polled by all workers';
+
+-- Dequeue by task queue
+CREATE INDEX IF NOT EXISTS idx_workflow_queue_task_queue_scheduled
+    ON workflows.workflow_queue (task_queue, scheduled_at ASC, priority DESC)
+    WHERE attempted_at IS NULL;
+
+COMMIT;
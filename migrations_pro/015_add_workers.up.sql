@@ -0,0 +1,30 @@
+BEGIN;
+
+-- ============================================================
+-- Worker registry: one row per worker pool, kept fresh by heartbeats
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS workflows.workers (
+    id                TEXT PRIMARY KEY,
+    host              TEXT        NOT NULL DEFAULT '',
+    version           TEXT        NOT NULL DEFAULT '',
+    handlers          TEXT[]      NOT NULL DEFAULT '{}',
+    task_queues       TEXT[]      NOT NULL DEFAULT '{}',
+    concurrency       INT         NOT NULL DEFAULT 0,
+    in_flight         INT         NOT NULL DEFAULT 0,
+    status            TEXT        NOT NULL DEFAULT 'alive'
+        CHECK (status IN ('alive', 'stopped', 'dead')),
+    started_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE workflows.workers IS 'Worker pools registered by the engines; queue items attempted_by resolves to id';
+COMMENT ON COLUMN workflows.workers.task_queues IS 'Task queues the pool polls besides the default queue';
+COMMENT ON COLUMN workflows.workers.in_flight IS 'Handler runs in progress at the last heartbeat';
+COMMENT ON COLUMN workflows.workers.status IS 'alive, stopped (deregistered on shutdown) or dead (heartbeats stopped)';
+
+-- Stale worker lookup
+CREATE INDEX IF NOT EXISTS idx_workers_status_last_heartbeat_at
+    ON workflows.workers (status, last_heartbeat_at);
+
+COMMIT;
@@ -0,0 +1,78 @@
+BEGIN;
+
+-- ============================================================
+-- Namespaces: tenants sharing one schema, see floxy.WithNamespace
+-- ============================================================
+
+ALTER TABLE workflows.workflow_definitions
+    ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE workflows.workflow_instances
+    ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE workflows.workflow_queue
+    ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE workflows.workflow_events
+    ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE workflows.workflow_dlq
+    ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default';
+
+COMMENT ON COLUMN workflows.workflow_definitions.namespace IS 'Namespace owning the definition';
+COMMENT ON COLUMN workflows.workflow_instances.namespace IS 'Namespace of the workflow definition, copied on creation';
+COMMENT ON COLUMN workflows.workflow_queue.namespace IS 'Namespace of the instance, copied on enqueue';
+COMMENT ON COLUMN workflows.workflow_events.namespace IS 'Namespace of the instance, copied on insert';
+COMMENT ON COLUMN workflows.workflow_dlq.namespace IS 'Namespace of the instance, copied on insert';
+
+-- Definition names and versions are unique per namespace, definition IDs stay global.
+-- The index also serves namespace listings.
+ALTER TABLE workflows.workflow_definitions
+    DROP CONSTRAINT IF EXISTS workflow_definitions_name_version_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflow_definitions_namespace_name_version
+    ON workflows.workflow_definitions (namespace, name, version);
+
+-- Running instance limits
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_namespace_status
+    ON workflows.workflow_instances (namespace, status);
+CREATE INDEX IF NOT EXISTS idx_workflow_events_namespace_id
+    ON workflows.workflow_events (namespace, id);
+CREATE INDEX IF NOT EXISTS idx_workflow_dlq_namespace_created
+    ON workflows.workflow_dlq (namespace, created_at DESC);
+
+-- Dequeue by namespace
+CREATE INDEX IF NOT EXISTS idx_workflow_queue_namespace_scheduled
+    ON workflows.workflow_queue (namespace, scheduled_at ASC, priority DESC)
+    WHERE attempted_at IS NULL;
+
+-- Views gain a trailing namespace column
+CREATE OR REPLACE VIEW workflows.active_workflows AS
+SELECT
+    wi.id,
+    wi.workflow_id,
+    wi.status,
+    wi.created_at,
+    wi.updated_at,
+    EXTRACT(epoch FROM now() - wi.created_at) AS duration_seconds,
+    COUNT(ws.id) AS total_steps,
+    COUNT(ws.id) FILTER (WHERE ws.status = 'completed') AS completed_steps,
+    COUNT(ws.id) FILTER (WHERE ws.status = 'failed') AS failed_steps,
+    COUNT(ws.id) FILTER (WHERE ws.status = 'running') AS running_steps,
+    wi.namespace
+FROM workflows.workflow_instances wi
+         LEFT JOIN workflows.workflow_steps ws ON wi.id = ws.instance_id
+WHERE wi.status IN ('pending', 'running', 'dlq')
+GROUP BY wi.id, wi.workflow_id, wi.status, wi.created_at, wi.updated_at, wi.namespace;
+
+CREATE OR REPLACE VIEW workflows.workflow_stats AS
+SELECT
+    wd.name,
+    wd.version,
+    COUNT(wi.id) AS total_instances,
+    COUNT(wi.id) FILTER (WHERE wi.status = 'completed') AS completed,
+    COUNT(wi.id) FILTER (WHERE wi.status = 'failed') AS failed,
+    COUNT(wi.id) FILTER (WHERE wi.status = 'running') AS running,
+    AVG(EXTRACT(epoch FROM wi.completed_at - wi.created_at))
+    FILTER (WHERE wi.status = 'completed') AS avg_duration_seconds,
+    wd.namespace
+FROM workflows.workflow_definitions wd
+         LEFT JOIN workflows.workflow_instances wi ON wd.id = wi.workflow_id
+GROUP BY wd.name, wd.version, wd.namespace;
+
+COMMIT;
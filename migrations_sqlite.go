@@ -14,7 +14,8 @@ import (
 var sqliteMigrationFiles embed.FS
 
 // RunSQLiteMigrations executes embedded SQLite migrations in lexical order.
-// Note: SQLite DDL operations are auto-committed, so we don't use a transaction.
+// Applied migrations are recorded in schema_migrations and skipped on the next run,
+// each migration runs in its own transaction.
 func RunSQLiteMigrations(ctx context.Context, db *sql.DB) error {
 	entries, err := fs.ReadDir(sqliteMigrationFiles, "migrations_sqlite")
 	if err != nil {
@@ -24,6 +25,15 @@ func RunSQLiteMigrations(ctx context.Context, db *sql.DB) error {
 		return nil
 	}
 
+	// Databases created before the migrations were recorded re-run the earlier files,
+	// which only use IF NOT EXISTS statements.
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		name TEXT PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
 	// Sort by filename to ensure deterministic order (e.g., 0001_..., 0002_...)
 	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
 
@@ -31,17 +41,8 @@ func RunSQLiteMigrations(ctx context.Context, db *sql.DB) error {
 		if e.IsDir() {
 			continue
 		}
-		b, err := sqliteMigrationFiles.ReadFile("migrations_sqlite/" + e.Name())
-		if err != nil {
-			return fmt.Errorf("read migration %s: %w", e.Name(), err)
-		}
-		content := string(b)
-		// Very simple split by semicolon; adequate for our DDL files
-		stmts := splitSQLStatements(content)
-		for _, stmt := range stmts {
-			if _, err := db.ExecContext(ctx, stmt); err != nil {
-				return fmt.Errorf("exec migration %s: %w", e.Name(), err)
-			}
+		if err := runSQLiteMigration(ctx, db, e.Name()); err != nil {
+			return err
 		}
 	}
 
@@ -61,6 +62,41 @@ func RunSQLiteMigrations(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
+func runSQLiteMigration(ctx context.Context, db *sql.DB, name string) error {
+	var applied int
+	if err := db.QueryRowContext(
+		ctx, `SELECT COUNT(*) FROM schema_migrations WHERE name = ?`, name,
+	).Scan(&applied); err != nil {
+		return fmt.Errorf("check migration %s: %w", name, err)
+	}
+	if applied > 0 {
+		return nil
+	}
+
+	b, err := sqliteMigrationFiles.ReadFile("migrations_sqlite/" + name)
+	if err != nil {
+		return fmt.Errorf("read migration %s: %w", name, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin migration %s: %w", name, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Very simple split by semicolon; adequate for our DDL files
+	for _, stmt := range splitSQLStatements(string(b)) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("exec migration %s: %w", name, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (name) VALUES (?)`, name); err != nil {
+		return fmt.Errorf("record migration %s: %w", name, err)
+	}
+
+	return tx.Commit()
+}
+
 func splitSQLStatements(sqlText string) []string {
 	parts := strings.Split(sqlText, ";")
 	res := make([]string, 0, len(parts))
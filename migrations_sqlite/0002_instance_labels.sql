@@ -0,0 +1,13 @@
+-- Instance labels (business keys) used by SearchInstances
+CREATE TABLE IF NOT EXISTS workflow_instance_labels (
+    instance_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (instance_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_instance_labels_key_value ON workflow_instance_labels(key, value);
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_created_at ON workflow_instances(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_updated_at ON workflow_instances(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_status ON workflow_instances(status);
+CREATE INDEX IF NOT EXISTS idx_workflow_instances_workflow_id ON workflow_instances(workflow_id);
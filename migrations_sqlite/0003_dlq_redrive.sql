@@ -0,0 +1,10 @@
+-- DLQ redrive state (see RedrivePolicy), one row per scheduled or redriven record
+CREATE TABLE IF NOT EXISTS workflow_dlq_redrive (
+    dlq_id INTEGER PRIMARY KEY,
+    redrive_count INTEGER NOT NULL DEFAULT 0,
+    next_redrive_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_dlq_redrive_next_redrive_at ON workflow_dlq_redrive(next_redrive_at);
+CREATE INDEX IF NOT EXISTS idx_workflow_dlq_instance_id ON workflow_dlq(instance_id);
+CREATE INDEX IF NOT EXISTS idx_workflow_dlq_workflow_id_created_at ON workflow_dlq(workflow_id, created_at);
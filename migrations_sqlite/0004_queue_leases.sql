@@ -0,0 +1,8 @@
+-- Queue item execution leases (see Store.LeaseQueueItem), one row per leased item
+CREATE TABLE IF NOT EXISTS queue_leases (
+    queue_id INTEGER PRIMARY KEY,
+    lease_token TEXT NOT NULL,
+    lease_expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_queue_leases_lease_expires_at ON queue_leases(lease_expires_at);
@@ -0,0 +1,7 @@
+-- Task queue of queue items (see Store.EnqueueStep), items of the default queue have no row
+CREATE TABLE IF NOT EXISTS queue_task_queues (
+    queue_id INTEGER PRIMARY KEY,
+    task_queue TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_queue_task_queues_task_queue ON queue_task_queues(task_queue);
@@ -0,0 +1,15 @@
+-- Worker registry (see Store.RegisterWorker), handlers and task_queues are JSON arrays
+CREATE TABLE IF NOT EXISTS workers (
+    id TEXT PRIMARY KEY,
+    host TEXT NOT NULL DEFAULT '',
+    version TEXT NOT NULL DEFAULT '',
+    handlers TEXT NOT NULL DEFAULT '[]',
+    task_queues TEXT NOT NULL DEFAULT '[]',
+    concurrency INTEGER NOT NULL DEFAULT 0,
+    in_flight INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'alive',
+    started_at TIMESTAMP NOT NULL,
+    last_heartbeat_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_workers_status ON workers(status);
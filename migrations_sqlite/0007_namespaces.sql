@@ -0,0 +1,8 @@
+-- Namespace of workflow definitions (see floxy.WithNamespace), definitions of the default namespace have no row.
+-- Instances, queue items, events and DLQ records belong to the namespace of their workflow definition.
+CREATE TABLE IF NOT EXISTS workflow_namespaces (
+    definition_id TEXT PRIMARY KEY,
+    namespace TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_namespaces_namespace ON workflow_namespaces(namespace);
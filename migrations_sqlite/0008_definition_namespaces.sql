@@ -0,0 +1,21 @@
+-- Definition names and versions are unique per namespace, definition IDs stay global.
+-- SQLite cannot change a table constraint in place, so workflow_definitions is rebuilt
+-- with the namespace as a column and the side table of 0007 is folded into it.
+CREATE TABLE workflow_definitions_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    definition BLOB NOT NULL,
+    namespace TEXT NOT NULL DEFAULT 'default',
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE(namespace, name, version)
+);
+
+INSERT INTO workflow_definitions_new (id, name, version, definition, namespace, created_at)
+SELECT wd.id, wd.name, wd.version, wd.definition, COALESCE(wn.namespace, 'default'), wd.created_at
+FROM workflow_definitions wd
+LEFT JOIN workflow_namespaces wn ON wn.definition_id = wd.id;
+
+DROP TABLE workflow_definitions;
+DROP TABLE workflow_namespaces;
+ALTER TABLE workflow_definitions_new RENAME TO workflow_definitions;
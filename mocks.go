@@ -110,6 +110,63 @@ func (_c *MockIEngine_AbortWorkflow_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// CancelBulkOperation provides a mock function for the type MockIEngine
+func (_mock *MockIEngine) CancelBulkOperation(ctx context.Context, jobID string) error {
+	ret := _mock.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelBulkOperation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIEngine_CancelBulkOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelBulkOperation'
+type MockIEngine_CancelBulkOperation_Call struct {
+	*mock.Call
+}
+
+// CancelBulkOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+func (_e *MockIEngine_Expecter) CancelBulkOperation(ctx interface{}, jobID interface{}) *MockIEngine_CancelBulkOperation_Call {
+	return &MockIEngine_CancelBulkOperation_Call{Call: _e.mock.On("CancelBulkOperation", ctx, jobID)}
+}
+
+func (_c *MockIEngine_CancelBulkOperation_Call) Run(run func(ctx context.Context, jobID string)) *MockIEngine_CancelBulkOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIEngine_CancelBulkOperation_Call) Return(err error) *MockIEngine_CancelBulkOperation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIEngine_CancelBulkOperation_Call) RunAndReturn(run func(ctx context.Context, jobID string) error) *MockIEngine_CancelBulkOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CancelWorkflow provides a mock function for the type MockIEngine
 func (_mock *MockIEngine) CancelWorkflow(ctx context.Context, instanceID int64, requestedBy string, reason string) error {
 	ret := _mock.Called(ctx, instanceID, requestedBy, reason)
@@ -179,6 +236,143 @@ func (_c *MockIEngine_CancelWorkflow_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// DiscardFromDLQ provides a mock function for the type MockIEngine
+func (_mock *MockIEngine) DiscardFromDLQ(ctx context.Context, dlqID int64, discardedBy string, reason string) error {
+	ret := _mock.Called(ctx, dlqID, discardedBy, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiscardFromDLQ")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = returnFunc(ctx, dlqID, discardedBy, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIEngine_DiscardFromDLQ_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiscardFromDLQ'
+type MockIEngine_DiscardFromDLQ_Call struct {
+	*mock.Call
+}
+
+// DiscardFromDLQ is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dlqID int64
+//   - discardedBy string
+//   - reason string
+func (_e *MockIEngine_Expecter) DiscardFromDLQ(ctx interface{}, dlqID interface{}, discardedBy interface{}, reason interface{}) *MockIEngine_DiscardFromDLQ_Call {
+	return &MockIEngine_DiscardFromDLQ_Call{Call: _e.mock.On("DiscardFromDLQ", ctx, dlqID, discardedBy, reason)}
+}
+
+func (_c *MockIEngine_DiscardFromDLQ_Call) Run(run func(ctx context.Context, dlqID int64, discardedBy string, reason string)) *MockIEngine_DiscardFromDLQ_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIEngine_DiscardFromDLQ_Call) Return(err error) *MockIEngine_DiscardFromDLQ_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIEngine_DiscardFromDLQ_Call) RunAndReturn(run func(ctx context.Context, dlqID int64, discardedBy string, reason string) error) *MockIEngine_DiscardFromDLQ_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBulkOperation provides a mock function for the type MockIEngine
+func (_mock *MockIEngine) GetBulkOperation(ctx context.Context, jobID string) (*BulkJob, error) {
+	ret := _mock.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBulkOperation")
+	}
+
+	var r0 *BulkJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*BulkJob, error)); ok {
+		return returnFunc(ctx, jobID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *BulkJob); ok {
+		r0 = returnFunc(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BulkJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIEngine_GetBulkOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBulkOperation'
+type MockIEngine_GetBulkOperation_Call struct {
+	*mock.Call
+}
+
+// GetBulkOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+func (_e *MockIEngine_Expecter) GetBulkOperation(ctx interface{}, jobID interface{}) *MockIEngine_GetBulkOperation_Call {
+	return &MockIEngine_GetBulkOperation_Call{Call: _e.mock.On("GetBulkOperation", ctx, jobID)}
+}
+
+func (_c *MockIEngine_GetBulkOperation_Call) Run(run func(ctx context.Context, jobID string)) *MockIEngine_GetBulkOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIEngine_GetBulkOperation_Call) Return(bulkJob *BulkJob, err error) *MockIEngine_GetBulkOperation_Call {
+	_c.Call.Return(bulkJob, err)
+	return _c
+}
+
+func (_c *MockIEngine_GetBulkOperation_Call) RunAndReturn(run func(ctx context.Context, jobID string) (*BulkJob, error)) *MockIEngine_GetBulkOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MakeHumanDecision provides a mock function for the type MockIEngine
 func (_mock *MockIEngine) MakeHumanDecision(ctx context.Context, stepID int64, decidedBy string, decision HumanDecision, comment *string) error {
 	ret := _mock.Called(ctx, stepID, decidedBy, decision, comment)
@@ -317,6 +511,149 @@ func (_c *MockIEngine_RequeueFromDLQ_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// SkipStep provides a mock function for the type MockIEngine
+func (_mock *MockIEngine) SkipStep(ctx context.Context, stepID int64, skippedBy string, output json.RawMessage, reason string) error {
+	ret := _mock.Called(ctx, stepID, skippedBy, output, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SkipStep")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, json.RawMessage, string) error); ok {
+		r0 = returnFunc(ctx, stepID, skippedBy, output, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIEngine_SkipStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SkipStep'
+type MockIEngine_SkipStep_Call struct {
+	*mock.Call
+}
+
+// SkipStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stepID int64
+//   - skippedBy string
+//   - output json.RawMessage
+//   - reason string
+func (_e *MockIEngine_Expecter) SkipStep(ctx interface{}, stepID interface{}, skippedBy interface{}, output interface{}, reason interface{}) *MockIEngine_SkipStep_Call {
+	return &MockIEngine_SkipStep_Call{Call: _e.mock.On("SkipStep", ctx, stepID, skippedBy, output, reason)}
+}
+
+func (_c *MockIEngine_SkipStep_Call) Run(run func(ctx context.Context, stepID int64, skippedBy string, output json.RawMessage, reason string)) *MockIEngine_SkipStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 json.RawMessage
+		if args[3] != nil {
+			arg3 = args[3].(json.RawMessage)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIEngine_SkipStep_Call) Return(err error) *MockIEngine_SkipStep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIEngine_SkipStep_Call) RunAndReturn(run func(ctx context.Context, stepID int64, skippedBy string, output json.RawMessage, reason string) error) *MockIEngine_SkipStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartBulkOperation provides a mock function for the type MockIEngine
+func (_mock *MockIEngine) StartBulkOperation(ctx context.Context, req BulkRequest) (*BulkJob, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartBulkOperation")
+	}
+
+	var r0 *BulkJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, BulkRequest) (*BulkJob, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, BulkRequest) *BulkJob); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BulkJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, BulkRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIEngine_StartBulkOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartBulkOperation'
+type MockIEngine_StartBulkOperation_Call struct {
+	*mock.Call
+}
+
+// StartBulkOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req BulkRequest
+func (_e *MockIEngine_Expecter) StartBulkOperation(ctx interface{}, req interface{}) *MockIEngine_StartBulkOperation_Call {
+	return &MockIEngine_StartBulkOperation_Call{Call: _e.mock.On("StartBulkOperation", ctx, req)}
+}
+
+func (_c *MockIEngine_StartBulkOperation_Call) Run(run func(ctx context.Context, req BulkRequest)) *MockIEngine_StartBulkOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 BulkRequest
+		if args[1] != nil {
+			arg1 = args[1].(BulkRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIEngine_StartBulkOperation_Call) Return(bulkJob *BulkJob, err error) *MockIEngine_StartBulkOperation_Call {
+	_c.Call.Return(bulkJob, err)
+	return _c
+}
+
+func (_c *MockIEngine_StartBulkOperation_Call) RunAndReturn(run func(ctx context.Context, req BulkRequest) (*BulkJob, error)) *MockIEngine_StartBulkOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMonitor creates a new instance of MockMonitor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMonitor(t interface {
@@ -1619,16 +1956,82 @@ func (_c *MockStore_CleanupOldWorkflows_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
-// CreateCancelRequest provides a mock function for the type MockStore
-func (_mock *MockStore) CreateCancelRequest(ctx context.Context, req *WorkflowCancelRequest) error {
-	ret := _mock.Called(ctx, req)
+// CountRunningInstances provides a mock function for the type MockStore
+func (_mock *MockStore) CountRunningInstances(ctx context.Context, namespace string) (int, error) {
+	ret := _mock.Called(ctx, namespace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateCancelRequest")
+		panic("no return value specified for CountRunningInstances")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *WorkflowCancelRequest) error); ok {
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return returnFunc(ctx, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, namespace)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_CountRunningInstances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRunningInstances'
+type MockStore_CountRunningInstances_Call struct {
+	*mock.Call
+}
+
+// CountRunningInstances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+func (_e *MockStore_Expecter) CountRunningInstances(ctx interface{}, namespace interface{}) *MockStore_CountRunningInstances_Call {
+	return &MockStore_CountRunningInstances_Call{Call: _e.mock.On("CountRunningInstances", ctx, namespace)}
+}
+
+func (_c *MockStore_CountRunningInstances_Call) Run(run func(ctx context.Context, namespace string)) *MockStore_CountRunningInstances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_CountRunningInstances_Call) Return(n int, err error) *MockStore_CountRunningInstances_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_CountRunningInstances_Call) RunAndReturn(run func(ctx context.Context, namespace string) (int, error)) *MockStore_CountRunningInstances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCancelRequest provides a mock function for the type MockStore
+func (_mock *MockStore) CreateCancelRequest(ctx context.Context, req *WorkflowCancelRequest) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCancelRequest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *WorkflowCancelRequest) error); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		r0 = ret.Error(0)
@@ -2053,9 +2456,66 @@ func (_c *MockStore_DeleteCancelRequest_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// DeleteDeadLetter provides a mock function for the type MockStore
+func (_mock *MockStore) DeleteDeadLetter(ctx context.Context, id int64) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_DeleteDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDeadLetter'
+type MockStore_DeleteDeadLetter_Call struct {
+	*mock.Call
+}
+
+// DeleteDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockStore_Expecter) DeleteDeadLetter(ctx interface{}, id interface{}) *MockStore_DeleteDeadLetter_Call {
+	return &MockStore_DeleteDeadLetter_Call{Call: _e.mock.On("DeleteDeadLetter", ctx, id)}
+}
+
+func (_c *MockStore_DeleteDeadLetter_Call) Run(run func(ctx context.Context, id int64)) *MockStore_DeleteDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteDeadLetter_Call) Return(err error) *MockStore_DeleteDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_DeleteDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockStore_DeleteDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DequeueStep provides a mock function for the type MockStore
-func (_mock *MockStore) DequeueStep(ctx context.Context, workerID string) (*QueueItem, error) {
-	ret := _mock.Called(ctx, workerID)
+func (_mock *MockStore) DequeueStep(ctx context.Context, workerID string, taskQueues []string) (*QueueItem, error) {
+	ret := _mock.Called(ctx, workerID, taskQueues)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DequeueStep")
@@ -2063,18 +2523,18 @@ func (_mock *MockStore) DequeueStep(ctx context.Context, workerID string) (*Queu
 
 	var r0 *QueueItem
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*QueueItem, error)); ok {
-		return returnFunc(ctx, workerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (*QueueItem, error)); ok {
+		return returnFunc(ctx, workerID, taskQueues)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *QueueItem); ok {
-		r0 = returnFunc(ctx, workerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) *QueueItem); ok {
+		r0 = returnFunc(ctx, workerID, taskQueues)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*QueueItem)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, workerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, workerID, taskQueues)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2089,11 +2549,12 @@ type MockStore_DequeueStep_Call struct {
 // DequeueStep is a helper method to define mock.On call
 //   - ctx context.Context
 //   - workerID string
-func (_e *MockStore_Expecter) DequeueStep(ctx interface{}, workerID interface{}) *MockStore_DequeueStep_Call {
-	return &MockStore_DequeueStep_Call{Call: _e.mock.On("DequeueStep", ctx, workerID)}
+//   - taskQueues []string
+func (_e *MockStore_Expecter) DequeueStep(ctx interface{}, workerID interface{}, taskQueues interface{}) *MockStore_DequeueStep_Call {
+	return &MockStore_DequeueStep_Call{Call: _e.mock.On("DequeueStep", ctx, workerID, taskQueues)}
 }
 
-func (_c *MockStore_DequeueStep_Call) Run(run func(ctx context.Context, workerID string)) *MockStore_DequeueStep_Call {
+func (_c *MockStore_DequeueStep_Call) Run(run func(ctx context.Context, workerID string, taskQueues []string)) *MockStore_DequeueStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2103,9 +2564,14 @@ func (_c *MockStore_DequeueStep_Call) Run(run func(ctx context.Context, workerID
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -2116,22 +2582,159 @@ func (_c *MockStore_DequeueStep_Call) Return(queueItem *QueueItem, err error) *M
 	return _c
 }
 
-func (_c *MockStore_DequeueStep_Call) RunAndReturn(run func(ctx context.Context, workerID string) (*QueueItem, error)) *MockStore_DequeueStep_Call {
+func (_c *MockStore_DequeueStep_Call) RunAndReturn(run func(ctx context.Context, workerID string, taskQueues []string) (*QueueItem, error)) *MockStore_DequeueStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DequeueSteps provides a mock function for the type MockStore
+func (_mock *MockStore) DequeueSteps(ctx context.Context, workerID string, taskQueues []string, n int) ([]QueueItem, error) {
+	ret := _mock.Called(ctx, workerID, taskQueues, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DequeueSteps")
+	}
+
+	var r0 []QueueItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, int) ([]QueueItem, error)); ok {
+		return returnFunc(ctx, workerID, taskQueues, n)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, int) []QueueItem); ok {
+		r0 = returnFunc(ctx, workerID, taskQueues, n)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]QueueItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, int) error); ok {
+		r1 = returnFunc(ctx, workerID, taskQueues, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_DequeueSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DequeueSteps'
+type MockStore_DequeueSteps_Call struct {
+	*mock.Call
+}
+
+// DequeueSteps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workerID string
+//   - taskQueues []string
+//   - n int
+func (_e *MockStore_Expecter) DequeueSteps(ctx interface{}, workerID interface{}, taskQueues interface{}, n interface{}) *MockStore_DequeueSteps_Call {
+	return &MockStore_DequeueSteps_Call{Call: _e.mock.On("DequeueSteps", ctx, workerID, taskQueues, n)}
+}
+
+func (_c *MockStore_DequeueSteps_Call) Run(run func(ctx context.Context, workerID string, taskQueues []string, n int)) *MockStore_DequeueSteps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_DequeueSteps_Call) Return(queueItems []QueueItem, err error) *MockStore_DequeueSteps_Call {
+	_c.Call.Return(queueItems, err)
+	return _c
+}
+
+func (_c *MockStore_DequeueSteps_Call) RunAndReturn(run func(ctx context.Context, workerID string, taskQueues []string, n int) ([]QueueItem, error)) *MockStore_DequeueSteps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeregisterWorker provides a mock function for the type MockStore
+func (_mock *MockStore) DeregisterWorker(ctx context.Context, workerID string) error {
+	ret := _mock.Called(ctx, workerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeregisterWorker")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, workerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_DeregisterWorker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeregisterWorker'
+type MockStore_DeregisterWorker_Call struct {
+	*mock.Call
+}
+
+// DeregisterWorker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workerID string
+func (_e *MockStore_Expecter) DeregisterWorker(ctx interface{}, workerID interface{}) *MockStore_DeregisterWorker_Call {
+	return &MockStore_DeregisterWorker_Call{Call: _e.mock.On("DeregisterWorker", ctx, workerID)}
+}
+
+func (_c *MockStore_DeregisterWorker_Call) Run(run func(ctx context.Context, workerID string)) *MockStore_DeregisterWorker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_DeregisterWorker_Call) Return(err error) *MockStore_DeregisterWorker_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_DeregisterWorker_Call) RunAndReturn(run func(ctx context.Context, workerID string) error) *MockStore_DeregisterWorker_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // EnqueueStep provides a mock function for the type MockStore
-func (_mock *MockStore) EnqueueStep(ctx context.Context, instanceID int64, stepID *int64, priority Priority, delay time.Duration) error {
-	ret := _mock.Called(ctx, instanceID, stepID, priority, delay)
+func (_mock *MockStore) EnqueueStep(ctx context.Context, instanceID int64, stepID *int64, taskQueue string, priority Priority, delay time.Duration) error {
+	ret := _mock.Called(ctx, instanceID, stepID, taskQueue, priority, delay)
 
 	if len(ret) == 0 {
 		panic("no return value specified for EnqueueStep")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *int64, Priority, time.Duration) error); ok {
-		r0 = returnFunc(ctx, instanceID, stepID, priority, delay)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *int64, string, Priority, time.Duration) error); ok {
+		r0 = returnFunc(ctx, instanceID, stepID, taskQueue, priority, delay)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2147,13 +2750,14 @@ type MockStore_EnqueueStep_Call struct {
 //   - ctx context.Context
 //   - instanceID int64
 //   - stepID *int64
+//   - taskQueue string
 //   - priority Priority
 //   - delay time.Duration
-func (_e *MockStore_Expecter) EnqueueStep(ctx interface{}, instanceID interface{}, stepID interface{}, priority interface{}, delay interface{}) *MockStore_EnqueueStep_Call {
-	return &MockStore_EnqueueStep_Call{Call: _e.mock.On("EnqueueStep", ctx, instanceID, stepID, priority, delay)}
+func (_e *MockStore_Expecter) EnqueueStep(ctx interface{}, instanceID interface{}, stepID interface{}, taskQueue interface{}, priority interface{}, delay interface{}) *MockStore_EnqueueStep_Call {
+	return &MockStore_EnqueueStep_Call{Call: _e.mock.On("EnqueueStep", ctx, instanceID, stepID, taskQueue, priority, delay)}
 }
 
-func (_c *MockStore_EnqueueStep_Call) Run(run func(ctx context.Context, instanceID int64, stepID *int64, priority Priority, delay time.Duration)) *MockStore_EnqueueStep_Call {
+func (_c *MockStore_EnqueueStep_Call) Run(run func(ctx context.Context, instanceID int64, stepID *int64, taskQueue string, priority Priority, delay time.Duration)) *MockStore_EnqueueStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2167,13 +2771,17 @@ func (_c *MockStore_EnqueueStep_Call) Run(run func(ctx context.Context, instance
 		if args[2] != nil {
 			arg2 = args[2].(*int64)
 		}
-		var arg3 Priority
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(Priority)
+			arg3 = args[3].(string)
 		}
-		var arg4 time.Duration
+		var arg4 Priority
 		if args[4] != nil {
-			arg4 = args[4].(time.Duration)
+			arg4 = args[4].(Priority)
+		}
+		var arg5 time.Duration
+		if args[5] != nil {
+			arg5 = args[5].(time.Duration)
 		}
 		run(
 			arg0,
@@ -2181,6 +2789,7 @@ func (_c *MockStore_EnqueueStep_Call) Run(run func(ctx context.Context, instance
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -2191,7 +2800,7 @@ func (_c *MockStore_EnqueueStep_Call) Return(err error) *MockStore_EnqueueStep_C
 	return _c
 }
 
-func (_c *MockStore_EnqueueStep_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, stepID *int64, priority Priority, delay time.Duration) error) *MockStore_EnqueueStep_Call {
+func (_c *MockStore_EnqueueStep_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, stepID *int64, taskQueue string, priority Priority, delay time.Duration) error) *MockStore_EnqueueStep_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2604,47 +3213,47 @@ func (_c *MockStore_GetDeadLetterByID_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
-// GetHumanDecision provides a mock function for the type MockStore
-func (_mock *MockStore) GetHumanDecision(ctx context.Context, stepID int64) (*HumanDecisionRecord, error) {
-	ret := _mock.Called(ctx, stepID)
+// GetDeadLettersByInstance provides a mock function for the type MockStore
+func (_mock *MockStore) GetDeadLettersByInstance(ctx context.Context, instanceID int64) ([]DeadLetterRecord, error) {
+	ret := _mock.Called(ctx, instanceID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetHumanDecision")
+		panic("no return value specified for GetDeadLettersByInstance")
 	}
 
-	var r0 *HumanDecisionRecord
+	var r0 []DeadLetterRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*HumanDecisionRecord, error)); ok {
-		return returnFunc(ctx, stepID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]DeadLetterRecord, error)); ok {
+		return returnFunc(ctx, instanceID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *HumanDecisionRecord); ok {
-		r0 = returnFunc(ctx, stepID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []DeadLetterRecord); ok {
+		r0 = returnFunc(ctx, instanceID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*HumanDecisionRecord)
+			r0 = ret.Get(0).([]DeadLetterRecord)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, stepID)
+		r1 = returnFunc(ctx, instanceID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetHumanDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHumanDecision'
-type MockStore_GetHumanDecision_Call struct {
+// MockStore_GetDeadLettersByInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLettersByInstance'
+type MockStore_GetDeadLettersByInstance_Call struct {
 	*mock.Call
 }
 
-// GetHumanDecision is a helper method to define mock.On call
+// GetDeadLettersByInstance is a helper method to define mock.On call
 //   - ctx context.Context
-//   - stepID int64
-func (_e *MockStore_Expecter) GetHumanDecision(ctx interface{}, stepID interface{}) *MockStore_GetHumanDecision_Call {
-	return &MockStore_GetHumanDecision_Call{Call: _e.mock.On("GetHumanDecision", ctx, stepID)}
+//   - instanceID int64
+func (_e *MockStore_Expecter) GetDeadLettersByInstance(ctx interface{}, instanceID interface{}) *MockStore_GetDeadLettersByInstance_Call {
+	return &MockStore_GetDeadLettersByInstance_Call{Call: _e.mock.On("GetDeadLettersByInstance", ctx, instanceID)}
 }
 
-func (_c *MockStore_GetHumanDecision_Call) Run(run func(ctx context.Context, stepID int64)) *MockStore_GetHumanDecision_Call {
+func (_c *MockStore_GetDeadLettersByInstance_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetDeadLettersByInstance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2662,125 +3271,133 @@ func (_c *MockStore_GetHumanDecision_Call) Run(run func(ctx context.Context, ste
 	return _c
 }
 
-func (_c *MockStore_GetHumanDecision_Call) Return(humanDecisionRecord *HumanDecisionRecord, err error) *MockStore_GetHumanDecision_Call {
-	_c.Call.Return(humanDecisionRecord, err)
+func (_c *MockStore_GetDeadLettersByInstance_Call) Return(deadLetterRecords []DeadLetterRecord, err error) *MockStore_GetDeadLettersByInstance_Call {
+	_c.Call.Return(deadLetterRecords, err)
 	return _c
 }
 
-func (_c *MockStore_GetHumanDecision_Call) RunAndReturn(run func(ctx context.Context, stepID int64) (*HumanDecisionRecord, error)) *MockStore_GetHumanDecision_Call {
+func (_c *MockStore_GetDeadLettersByInstance_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]DeadLetterRecord, error)) *MockStore_GetDeadLettersByInstance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetHumanDecisionStepByInstanceID provides a mock function for the type MockStore
-func (_mock *MockStore) GetHumanDecisionStepByInstanceID(ctx context.Context, instanceID int64) (*WorkflowStep, error) {
-	ret := _mock.Called(ctx, instanceID)
+// GetDueDeadLetters provides a mock function for the type MockStore
+func (_mock *MockStore) GetDueDeadLetters(ctx context.Context, now time.Time, limit int) ([]DeadLetterRecord, error) {
+	ret := _mock.Called(ctx, now, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetHumanDecisionStepByInstanceID")
+		panic("no return value specified for GetDueDeadLetters")
 	}
 
-	var r0 *WorkflowStep
+	var r0 []DeadLetterRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*WorkflowStep, error)); ok {
-		return returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]DeadLetterRecord, error)); ok {
+		return returnFunc(ctx, now, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *WorkflowStep); ok {
-		r0 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []DeadLetterRecord); ok {
+		r0 = returnFunc(ctx, now, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*WorkflowStep)
+			r0 = ret.Get(0).([]DeadLetterRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, now, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetHumanDecisionStepByInstanceID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHumanDecisionStepByInstanceID'
-type MockStore_GetHumanDecisionStepByInstanceID_Call struct {
+// MockStore_GetDueDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueDeadLetters'
+type MockStore_GetDueDeadLetters_Call struct {
 	*mock.Call
 }
 
-// GetHumanDecisionStepByInstanceID is a helper method to define mock.On call
+// GetDueDeadLetters is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-func (_e *MockStore_Expecter) GetHumanDecisionStepByInstanceID(ctx interface{}, instanceID interface{}) *MockStore_GetHumanDecisionStepByInstanceID_Call {
-	return &MockStore_GetHumanDecisionStepByInstanceID_Call{Call: _e.mock.On("GetHumanDecisionStepByInstanceID", ctx, instanceID)}
+//   - now time.Time
+//   - limit int
+func (_e *MockStore_Expecter) GetDueDeadLetters(ctx interface{}, now interface{}, limit interface{}) *MockStore_GetDueDeadLetters_Call {
+	return &MockStore_GetDueDeadLetters_Call{Call: _e.mock.On("GetDueDeadLetters", ctx, now, limit)}
 }
 
-func (_c *MockStore_GetHumanDecisionStepByInstanceID_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetHumanDecisionStepByInstanceID_Call {
+func (_c *MockStore_GetDueDeadLetters_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *MockStore_GetDueDeadLetters_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetHumanDecisionStepByInstanceID_Call) Return(workflowStep *WorkflowStep, err error) *MockStore_GetHumanDecisionStepByInstanceID_Call {
-	_c.Call.Return(workflowStep, err)
+func (_c *MockStore_GetDueDeadLetters_Call) Return(deadLetterRecords []DeadLetterRecord, err error) *MockStore_GetDueDeadLetters_Call {
+	_c.Call.Return(deadLetterRecords, err)
 	return _c
 }
 
-func (_c *MockStore_GetHumanDecisionStepByInstanceID_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) (*WorkflowStep, error)) *MockStore_GetHumanDecisionStepByInstanceID_Call {
+func (_c *MockStore_GetDueDeadLetters_Call) RunAndReturn(run func(ctx context.Context, now time.Time, limit int) ([]DeadLetterRecord, error)) *MockStore_GetDueDeadLetters_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInstance provides a mock function for the type MockStore
-func (_mock *MockStore) GetInstance(ctx context.Context, instanceID int64) (*WorkflowInstance, error) {
-	ret := _mock.Called(ctx, instanceID)
+// GetEventsAfter provides a mock function for the type MockStore
+func (_mock *MockStore) GetEventsAfter(ctx context.Context, afterID int64, filter EventFilter, limit int) ([]WorkflowEvent, error) {
+	ret := _mock.Called(ctx, afterID, filter, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInstance")
+		panic("no return value specified for GetEventsAfter")
 	}
 
-	var r0 *WorkflowInstance
+	var r0 []WorkflowEvent
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*WorkflowInstance, error)); ok {
-		return returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, EventFilter, int) ([]WorkflowEvent, error)); ok {
+		return returnFunc(ctx, afterID, filter, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *WorkflowInstance); ok {
-		r0 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, EventFilter, int) []WorkflowEvent); ok {
+		r0 = returnFunc(ctx, afterID, filter, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*WorkflowInstance)
+			r0 = ret.Get(0).([]WorkflowEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, EventFilter, int) error); ok {
+		r1 = returnFunc(ctx, afterID, filter, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstance'
-type MockStore_GetInstance_Call struct {
+// MockStore_GetEventsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsAfter'
+type MockStore_GetEventsAfter_Call struct {
 	*mock.Call
 }
 
-// GetInstance is a helper method to define mock.On call
+// GetEventsAfter is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-func (_e *MockStore_Expecter) GetInstance(ctx interface{}, instanceID interface{}) *MockStore_GetInstance_Call {
-	return &MockStore_GetInstance_Call{Call: _e.mock.On("GetInstance", ctx, instanceID)}
+//   - afterID int64
+//   - filter EventFilter
+//   - limit int
+func (_e *MockStore_Expecter) GetEventsAfter(ctx interface{}, afterID interface{}, filter interface{}, limit interface{}) *MockStore_GetEventsAfter_Call {
+	return &MockStore_GetEventsAfter_Call{Call: _e.mock.On("GetEventsAfter", ctx, afterID, filter, limit)}
 }
 
-func (_c *MockStore_GetInstance_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetInstance_Call {
+func (_c *MockStore_GetEventsAfter_Call) Run(run func(ctx context.Context, afterID int64, filter EventFilter, limit int)) *MockStore_GetEventsAfter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2790,66 +3407,75 @@ func (_c *MockStore_GetInstance_Call) Run(run func(ctx context.Context, instance
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 EventFilter
+		if args[2] != nil {
+			arg2 = args[2].(EventFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetInstance_Call) Return(workflowInstance *WorkflowInstance, err error) *MockStore_GetInstance_Call {
-	_c.Call.Return(workflowInstance, err)
+func (_c *MockStore_GetEventsAfter_Call) Return(workflowEvents []WorkflowEvent, err error) *MockStore_GetEventsAfter_Call {
+	_c.Call.Return(workflowEvents, err)
 	return _c
 }
 
-func (_c *MockStore_GetInstance_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) (*WorkflowInstance, error)) *MockStore_GetInstance_Call {
+func (_c *MockStore_GetEventsAfter_Call) RunAndReturn(run func(ctx context.Context, afterID int64, filter EventFilter, limit int) ([]WorkflowEvent, error)) *MockStore_GetEventsAfter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetJoinState provides a mock function for the type MockStore
-func (_mock *MockStore) GetJoinState(ctx context.Context, instanceID int64, joinStepName string) (*JoinState, error) {
-	ret := _mock.Called(ctx, instanceID, joinStepName)
+// GetHumanDecision provides a mock function for the type MockStore
+func (_mock *MockStore) GetHumanDecision(ctx context.Context, stepID int64) (*HumanDecisionRecord, error) {
+	ret := _mock.Called(ctx, stepID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetJoinState")
+		panic("no return value specified for GetHumanDecision")
 	}
 
-	var r0 *JoinState
+	var r0 *HumanDecisionRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (*JoinState, error)); ok {
-		return returnFunc(ctx, instanceID, joinStepName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*HumanDecisionRecord, error)); ok {
+		return returnFunc(ctx, stepID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) *JoinState); ok {
-		r0 = returnFunc(ctx, instanceID, joinStepName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *HumanDecisionRecord); ok {
+		r0 = returnFunc(ctx, stepID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*JoinState)
+			r0 = ret.Get(0).(*HumanDecisionRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
-		r1 = returnFunc(ctx, instanceID, joinStepName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, stepID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetJoinState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJoinState'
-type MockStore_GetJoinState_Call struct {
+// MockStore_GetHumanDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHumanDecision'
+type MockStore_GetHumanDecision_Call struct {
 	*mock.Call
 }
 
-// GetJoinState is a helper method to define mock.On call
+// GetHumanDecision is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-//   - joinStepName string
-func (_e *MockStore_Expecter) GetJoinState(ctx interface{}, instanceID interface{}, joinStepName interface{}) *MockStore_GetJoinState_Call {
-	return &MockStore_GetJoinState_Call{Call: _e.mock.On("GetJoinState", ctx, instanceID, joinStepName)}
+//   - stepID int64
+func (_e *MockStore_Expecter) GetHumanDecision(ctx interface{}, stepID interface{}) *MockStore_GetHumanDecision_Call {
+	return &MockStore_GetHumanDecision_Call{Call: _e.mock.On("GetHumanDecision", ctx, stepID)}
 }
 
-func (_c *MockStore_GetJoinState_Call) Run(run func(ctx context.Context, instanceID int64, joinStepName string)) *MockStore_GetJoinState_Call {
+func (_c *MockStore_GetHumanDecision_Call) Run(run func(ctx context.Context, stepID int64)) *MockStore_GetHumanDecision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2859,70 +3485,65 @@ func (_c *MockStore_GetJoinState_Call) Run(run func(ctx context.Context, instanc
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetJoinState_Call) Return(joinState *JoinState, err error) *MockStore_GetJoinState_Call {
-	_c.Call.Return(joinState, err)
+func (_c *MockStore_GetHumanDecision_Call) Return(humanDecisionRecord *HumanDecisionRecord, err error) *MockStore_GetHumanDecision_Call {
+	_c.Call.Return(humanDecisionRecord, err)
 	return _c
 }
 
-func (_c *MockStore_GetJoinState_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, joinStepName string) (*JoinState, error)) *MockStore_GetJoinState_Call {
+func (_c *MockStore_GetHumanDecision_Call) RunAndReturn(run func(ctx context.Context, stepID int64) (*HumanDecisionRecord, error)) *MockStore_GetHumanDecision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStepByID provides a mock function for the type MockStore
-func (_mock *MockStore) GetStepByID(ctx context.Context, stepID int64) (*WorkflowStep, error) {
-	ret := _mock.Called(ctx, stepID)
+// GetHumanDecisionStepByInstanceID provides a mock function for the type MockStore
+func (_mock *MockStore) GetHumanDecisionStepByInstanceID(ctx context.Context, instanceID int64) (*WorkflowStep, error) {
+	ret := _mock.Called(ctx, instanceID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStepByID")
+		panic("no return value specified for GetHumanDecisionStepByInstanceID")
 	}
 
 	var r0 *WorkflowStep
 	var r1 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*WorkflowStep, error)); ok {
-		return returnFunc(ctx, stepID)
+		return returnFunc(ctx, instanceID)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *WorkflowStep); ok {
-		r0 = returnFunc(ctx, stepID)
+		r0 = returnFunc(ctx, instanceID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*WorkflowStep)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, stepID)
+		r1 = returnFunc(ctx, instanceID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetStepByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepByID'
-type MockStore_GetStepByID_Call struct {
+// MockStore_GetHumanDecisionStepByInstanceID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHumanDecisionStepByInstanceID'
+type MockStore_GetHumanDecisionStepByInstanceID_Call struct {
 	*mock.Call
 }
 
-// GetStepByID is a helper method to define mock.On call
+// GetHumanDecisionStepByInstanceID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - stepID int64
-func (_e *MockStore_Expecter) GetStepByID(ctx interface{}, stepID interface{}) *MockStore_GetStepByID_Call {
-	return &MockStore_GetStepByID_Call{Call: _e.mock.On("GetStepByID", ctx, stepID)}
+//   - instanceID int64
+func (_e *MockStore_Expecter) GetHumanDecisionStepByInstanceID(ctx interface{}, instanceID interface{}) *MockStore_GetHumanDecisionStepByInstanceID_Call {
+	return &MockStore_GetHumanDecisionStepByInstanceID_Call{Call: _e.mock.On("GetHumanDecisionStepByInstanceID", ctx, instanceID)}
 }
 
-func (_c *MockStore_GetStepByID_Call) Run(run func(ctx context.Context, stepID int64)) *MockStore_GetStepByID_Call {
+func (_c *MockStore_GetHumanDecisionStepByInstanceID_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetHumanDecisionStepByInstanceID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2940,34 +3561,34 @@ func (_c *MockStore_GetStepByID_Call) Run(run func(ctx context.Context, stepID i
 	return _c
 }
 
-func (_c *MockStore_GetStepByID_Call) Return(workflowStep *WorkflowStep, err error) *MockStore_GetStepByID_Call {
+func (_c *MockStore_GetHumanDecisionStepByInstanceID_Call) Return(workflowStep *WorkflowStep, err error) *MockStore_GetHumanDecisionStepByInstanceID_Call {
 	_c.Call.Return(workflowStep, err)
 	return _c
 }
 
-func (_c *MockStore_GetStepByID_Call) RunAndReturn(run func(ctx context.Context, stepID int64) (*WorkflowStep, error)) *MockStore_GetStepByID_Call {
+func (_c *MockStore_GetHumanDecisionStepByInstanceID_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) (*WorkflowStep, error)) *MockStore_GetHumanDecisionStepByInstanceID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStepsByInstance provides a mock function for the type MockStore
-func (_mock *MockStore) GetStepsByInstance(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
+// GetInstance provides a mock function for the type MockStore
+func (_mock *MockStore) GetInstance(ctx context.Context, instanceID int64) (*WorkflowInstance, error) {
 	ret := _mock.Called(ctx, instanceID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStepsByInstance")
+		panic("no return value specified for GetInstance")
 	}
 
-	var r0 []WorkflowStep
+	var r0 *WorkflowInstance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]WorkflowStep, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*WorkflowInstance, error)); ok {
 		return returnFunc(ctx, instanceID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []WorkflowStep); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *WorkflowInstance); ok {
 		r0 = returnFunc(ctx, instanceID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowStep)
+			r0 = ret.Get(0).(*WorkflowInstance)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
@@ -2978,19 +3599,19 @@ func (_mock *MockStore) GetStepsByInstance(ctx context.Context, instanceID int64
 	return r0, r1
 }
 
-// MockStore_GetStepsByInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepsByInstance'
-type MockStore_GetStepsByInstance_Call struct {
+// MockStore_GetInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstance'
+type MockStore_GetInstance_Call struct {
 	*mock.Call
 }
 
-// GetStepsByInstance is a helper method to define mock.On call
+// GetInstance is a helper method to define mock.On call
 //   - ctx context.Context
 //   - instanceID int64
-func (_e *MockStore_Expecter) GetStepsByInstance(ctx interface{}, instanceID interface{}) *MockStore_GetStepsByInstance_Call {
-	return &MockStore_GetStepsByInstance_Call{Call: _e.mock.On("GetStepsByInstance", ctx, instanceID)}
+func (_e *MockStore_Expecter) GetInstance(ctx interface{}, instanceID interface{}) *MockStore_GetInstance_Call {
+	return &MockStore_GetInstance_Call{Call: _e.mock.On("GetInstance", ctx, instanceID)}
 }
 
-func (_c *MockStore_GetStepsByInstance_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetStepsByInstance_Call {
+func (_c *MockStore_GetInstance_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetInstance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3008,249 +3629,257 @@ func (_c *MockStore_GetStepsByInstance_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockStore_GetStepsByInstance_Call) Return(workflowSteps []WorkflowStep, err error) *MockStore_GetStepsByInstance_Call {
-	_c.Call.Return(workflowSteps, err)
+func (_c *MockStore_GetInstance_Call) Return(workflowInstance *WorkflowInstance, err error) *MockStore_GetInstance_Call {
+	_c.Call.Return(workflowInstance, err)
 	return _c
 }
 
-func (_c *MockStore_GetStepsByInstance_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]WorkflowStep, error)) *MockStore_GetStepsByInstance_Call {
+func (_c *MockStore_GetInstance_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) (*WorkflowInstance, error)) *MockStore_GetInstance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSummaryStats provides a mock function for the type MockStore
-func (_mock *MockStore) GetSummaryStats(ctx context.Context) (*SummaryStats, error) {
-	ret := _mock.Called(ctx)
+// GetJoinState provides a mock function for the type MockStore
+func (_mock *MockStore) GetJoinState(ctx context.Context, instanceID int64, joinStepName string) (*JoinState, error) {
+	ret := _mock.Called(ctx, instanceID, joinStepName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSummaryStats")
+		panic("no return value specified for GetJoinState")
 	}
 
-	var r0 *SummaryStats
+	var r0 *JoinState
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*SummaryStats, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (*JoinState, error)); ok {
+		return returnFunc(ctx, instanceID, joinStepName)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *SummaryStats); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) *JoinState); ok {
+		r0 = returnFunc(ctx, instanceID, joinStepName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*SummaryStats)
+			r0 = ret.Get(0).(*JoinState)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, instanceID, joinStepName)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetSummaryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummaryStats'
-type MockStore_GetSummaryStats_Call struct {
+// MockStore_GetJoinState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJoinState'
+type MockStore_GetJoinState_Call struct {
 	*mock.Call
 }
 
-// GetSummaryStats is a helper method to define mock.On call
+// GetJoinState is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) GetSummaryStats(ctx interface{}) *MockStore_GetSummaryStats_Call {
-	return &MockStore_GetSummaryStats_Call{Call: _e.mock.On("GetSummaryStats", ctx)}
+//   - instanceID int64
+//   - joinStepName string
+func (_e *MockStore_Expecter) GetJoinState(ctx interface{}, instanceID interface{}, joinStepName interface{}) *MockStore_GetJoinState_Call {
+	return &MockStore_GetJoinState_Call{Call: _e.mock.On("GetJoinState", ctx, instanceID, joinStepName)}
 }
 
-func (_c *MockStore_GetSummaryStats_Call) Run(run func(ctx context.Context)) *MockStore_GetSummaryStats_Call {
+func (_c *MockStore_GetJoinState_Call) Run(run func(ctx context.Context, instanceID int64, joinStepName string)) *MockStore_GetJoinState_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetSummaryStats_Call) Return(summaryStats *SummaryStats, err error) *MockStore_GetSummaryStats_Call {
-	_c.Call.Return(summaryStats, err)
+func (_c *MockStore_GetJoinState_Call) Return(joinState *JoinState, err error) *MockStore_GetJoinState_Call {
+	_c.Call.Return(joinState, err)
 	return _c
 }
 
-func (_c *MockStore_GetSummaryStats_Call) RunAndReturn(run func(ctx context.Context) (*SummaryStats, error)) *MockStore_GetSummaryStats_Call {
+func (_c *MockStore_GetJoinState_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, joinStepName string) (*JoinState, error)) *MockStore_GetJoinState_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowDefinition provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowDefinition(ctx context.Context, id string) (*WorkflowDefinition, error) {
-	ret := _mock.Called(ctx, id)
+// GetLastEventID provides a mock function for the type MockStore
+func (_mock *MockStore) GetLastEventID(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowDefinition")
+		panic("no return value specified for GetLastEventID")
 	}
 
-	var r0 *WorkflowDefinition
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*WorkflowDefinition, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *WorkflowDefinition); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*WorkflowDefinition)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetWorkflowDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowDefinition'
-type MockStore_GetWorkflowDefinition_Call struct {
+// MockStore_GetLastEventID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastEventID'
+type MockStore_GetLastEventID_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowDefinition is a helper method to define mock.On call
+// GetLastEventID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockStore_Expecter) GetWorkflowDefinition(ctx interface{}, id interface{}) *MockStore_GetWorkflowDefinition_Call {
-	return &MockStore_GetWorkflowDefinition_Call{Call: _e.mock.On("GetWorkflowDefinition", ctx, id)}
+func (_e *MockStore_Expecter) GetLastEventID(ctx interface{}) *MockStore_GetLastEventID_Call {
+	return &MockStore_GetLastEventID_Call{Call: _e.mock.On("GetLastEventID", ctx)}
 }
 
-func (_c *MockStore_GetWorkflowDefinition_Call) Run(run func(ctx context.Context, id string)) *MockStore_GetWorkflowDefinition_Call {
+func (_c *MockStore_GetLastEventID_Call) Run(run func(ctx context.Context)) *MockStore_GetLastEventID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowDefinition_Call) Return(workflowDefinition *WorkflowDefinition, err error) *MockStore_GetWorkflowDefinition_Call {
-	_c.Call.Return(workflowDefinition, err)
+func (_c *MockStore_GetLastEventID_Call) Return(n int64, err error) *MockStore_GetLastEventID_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowDefinition_Call) RunAndReturn(run func(ctx context.Context, id string) (*WorkflowDefinition, error)) *MockStore_GetWorkflowDefinition_Call {
+func (_c *MockStore_GetLastEventID_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockStore_GetLastEventID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowDefinitions provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowDefinition, error) {
-	ret := _mock.Called(ctx)
+// GetQueueLength provides a mock function for the type MockStore
+func (_mock *MockStore) GetQueueLength(ctx context.Context, taskQueues []string) (int, error) {
+	ret := _mock.Called(ctx, taskQueues)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowDefinitions")
+		panic("no return value specified for GetQueueLength")
 	}
 
-	var r0 []WorkflowDefinition
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]WorkflowDefinition, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int, error)); ok {
+		return returnFunc(ctx, taskQueues)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []WorkflowDefinition); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int); ok {
+		r0 = returnFunc(ctx, taskQueues)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowDefinition)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, taskQueues)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetWorkflowDefinitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowDefinitions'
-type MockStore_GetWorkflowDefinitions_Call struct {
+// MockStore_GetQueueLength_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueueLength'
+type MockStore_GetQueueLength_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowDefinitions is a helper method to define mock.On call
+// GetQueueLength is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) GetWorkflowDefinitions(ctx interface{}) *MockStore_GetWorkflowDefinitions_Call {
-	return &MockStore_GetWorkflowDefinitions_Call{Call: _e.mock.On("GetWorkflowDefinitions", ctx)}
+//   - taskQueues []string
+func (_e *MockStore_Expecter) GetQueueLength(ctx interface{}, taskQueues interface{}) *MockStore_GetQueueLength_Call {
+	return &MockStore_GetQueueLength_Call{Call: _e.mock.On("GetQueueLength", ctx, taskQueues)}
 }
 
-func (_c *MockStore_GetWorkflowDefinitions_Call) Run(run func(ctx context.Context)) *MockStore_GetWorkflowDefinitions_Call {
+func (_c *MockStore_GetQueueLength_Call) Run(run func(ctx context.Context, taskQueues []string)) *MockStore_GetQueueLength_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowDefinitions_Call) Return(workflowDefinitions []WorkflowDefinition, err error) *MockStore_GetWorkflowDefinitions_Call {
-	_c.Call.Return(workflowDefinitions, err)
+func (_c *MockStore_GetQueueLength_Call) Return(n int, err error) *MockStore_GetQueueLength_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowDefinitions_Call) RunAndReturn(run func(ctx context.Context) ([]WorkflowDefinition, error)) *MockStore_GetWorkflowDefinitions_Call {
+func (_c *MockStore_GetQueueLength_Call) RunAndReturn(run func(ctx context.Context, taskQueues []string) (int, error)) *MockStore_GetQueueLength_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowEvents provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowEvents(ctx context.Context, instanceID int64) ([]WorkflowEvent, error) {
-	ret := _mock.Called(ctx, instanceID)
+// GetStepByID provides a mock function for the type MockStore
+func (_mock *MockStore) GetStepByID(ctx context.Context, stepID int64) (*WorkflowStep, error) {
+	ret := _mock.Called(ctx, stepID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowEvents")
+		panic("no return value specified for GetStepByID")
 	}
 
-	var r0 []WorkflowEvent
+	var r0 *WorkflowStep
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]WorkflowEvent, error)); ok {
-		return returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*WorkflowStep, error)); ok {
+		return returnFunc(ctx, stepID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []WorkflowEvent); ok {
-		r0 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *WorkflowStep); ok {
+		r0 = returnFunc(ctx, stepID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowEvent)
+			r0 = ret.Get(0).(*WorkflowStep)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, instanceID)
+		r1 = returnFunc(ctx, stepID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetWorkflowEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowEvents'
-type MockStore_GetWorkflowEvents_Call struct {
+// MockStore_GetStepByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepByID'
+type MockStore_GetStepByID_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowEvents is a helper method to define mock.On call
+// GetStepByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-func (_e *MockStore_Expecter) GetWorkflowEvents(ctx interface{}, instanceID interface{}) *MockStore_GetWorkflowEvents_Call {
-	return &MockStore_GetWorkflowEvents_Call{Call: _e.mock.On("GetWorkflowEvents", ctx, instanceID)}
+//   - stepID int64
+func (_e *MockStore_Expecter) GetStepByID(ctx interface{}, stepID interface{}) *MockStore_GetStepByID_Call {
+	return &MockStore_GetStepByID_Call{Call: _e.mock.On("GetStepByID", ctx, stepID)}
 }
 
-func (_c *MockStore_GetWorkflowEvents_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetWorkflowEvents_Call {
+func (_c *MockStore_GetStepByID_Call) Run(run func(ctx context.Context, stepID int64)) *MockStore_GetStepByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3268,65 +3897,65 @@ func (_c *MockStore_GetWorkflowEvents_Call) Run(run func(ctx context.Context, in
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowEvents_Call) Return(workflowEvents []WorkflowEvent, err error) *MockStore_GetWorkflowEvents_Call {
-	_c.Call.Return(workflowEvents, err)
+func (_c *MockStore_GetStepByID_Call) Return(workflowStep *WorkflowStep, err error) *MockStore_GetStepByID_Call {
+	_c.Call.Return(workflowStep, err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowEvents_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]WorkflowEvent, error)) *MockStore_GetWorkflowEvents_Call {
+func (_c *MockStore_GetStepByID_Call) RunAndReturn(run func(ctx context.Context, stepID int64) (*WorkflowStep, error)) *MockStore_GetStepByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowInstances provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowInstances(ctx context.Context, workflowID string) ([]WorkflowInstance, error) {
-	ret := _mock.Called(ctx, workflowID)
+// GetStepsByInstance provides a mock function for the type MockStore
+func (_mock *MockStore) GetStepsByInstance(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
+	ret := _mock.Called(ctx, instanceID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowInstances")
+		panic("no return value specified for GetStepsByInstance")
 	}
 
-	var r0 []WorkflowInstance
+	var r0 []WorkflowStep
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]WorkflowInstance, error)); ok {
-		return returnFunc(ctx, workflowID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]WorkflowStep, error)); ok {
+		return returnFunc(ctx, instanceID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []WorkflowInstance); ok {
-		r0 = returnFunc(ctx, workflowID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []WorkflowStep); ok {
+		r0 = returnFunc(ctx, instanceID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowInstance)
+			r0 = ret.Get(0).([]WorkflowStep)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, workflowID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, instanceID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetWorkflowInstances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowInstances'
-type MockStore_GetWorkflowInstances_Call struct {
+// MockStore_GetStepsByInstance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStepsByInstance'
+type MockStore_GetStepsByInstance_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowInstances is a helper method to define mock.On call
+// GetStepsByInstance is a helper method to define mock.On call
 //   - ctx context.Context
-//   - workflowID string
-func (_e *MockStore_Expecter) GetWorkflowInstances(ctx interface{}, workflowID interface{}) *MockStore_GetWorkflowInstances_Call {
-	return &MockStore_GetWorkflowInstances_Call{Call: _e.mock.On("GetWorkflowInstances", ctx, workflowID)}
+//   - instanceID int64
+func (_e *MockStore_Expecter) GetStepsByInstance(ctx interface{}, instanceID interface{}) *MockStore_GetStepsByInstance_Call {
+	return &MockStore_GetStepsByInstance_Call{Call: _e.mock.On("GetStepsByInstance", ctx, instanceID)}
 }
 
-func (_c *MockStore_GetWorkflowInstances_Call) Run(run func(ctx context.Context, workflowID string)) *MockStore_GetWorkflowInstances_Call {
+func (_c *MockStore_GetStepsByInstance_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetStepsByInstance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
@@ -3336,205 +3965,736 @@ func (_c *MockStore_GetWorkflowInstances_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowInstances_Call) Return(workflowInstances []WorkflowInstance, err error) *MockStore_GetWorkflowInstances_Call {
-	_c.Call.Return(workflowInstances, err)
+func (_c *MockStore_GetStepsByInstance_Call) Return(workflowSteps []WorkflowStep, err error) *MockStore_GetStepsByInstance_Call {
+	_c.Call.Return(workflowSteps, err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowInstances_Call) RunAndReturn(run func(ctx context.Context, workflowID string) ([]WorkflowInstance, error)) *MockStore_GetWorkflowInstances_Call {
+func (_c *MockStore_GetStepsByInstance_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]WorkflowStep, error)) *MockStore_GetStepsByInstance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowInstancesPaginated provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowInstancesPaginated(ctx context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error) {
-	ret := _mock.Called(ctx, workflowID, offset, limit)
+// GetSummaryStats provides a mock function for the type MockStore
+func (_mock *MockStore) GetSummaryStats(ctx context.Context) (*SummaryStats, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowInstancesPaginated")
+		panic("no return value specified for GetSummaryStats")
 	}
 
-	var r0 []WorkflowInstance
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) ([]WorkflowInstance, int64, error)); ok {
-		return returnFunc(ctx, workflowID, offset, limit)
+	var r0 *SummaryStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*SummaryStats, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) []WorkflowInstance); ok {
-		r0 = returnFunc(ctx, workflowID, offset, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *SummaryStats); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowInstance)
+			r0 = ret.Get(0).(*SummaryStats)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
-		r1 = returnFunc(ctx, workflowID, offset, limit)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = returnFunc(ctx, workflowID, offset, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_GetWorkflowInstancesPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowInstancesPaginated'
-type MockStore_GetWorkflowInstancesPaginated_Call struct {
+// MockStore_GetSummaryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummaryStats'
+type MockStore_GetSummaryStats_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowInstancesPaginated is a helper method to define mock.On call
+// GetSummaryStats is a helper method to define mock.On call
 //   - ctx context.Context
-//   - workflowID string
-//   - offset int
-//   - limit int
-func (_e *MockStore_Expecter) GetWorkflowInstancesPaginated(ctx interface{}, workflowID interface{}, offset interface{}, limit interface{}) *MockStore_GetWorkflowInstancesPaginated_Call {
-	return &MockStore_GetWorkflowInstancesPaginated_Call{Call: _e.mock.On("GetWorkflowInstancesPaginated", ctx, workflowID, offset, limit)}
+func (_e *MockStore_Expecter) GetSummaryStats(ctx interface{}) *MockStore_GetSummaryStats_Call {
+	return &MockStore_GetSummaryStats_Call{Call: _e.mock.On("GetSummaryStats", ctx)}
 }
 
-func (_c *MockStore_GetWorkflowInstancesPaginated_Call) Run(run func(ctx context.Context, workflowID string, offset int, limit int)) *MockStore_GetWorkflowInstancesPaginated_Call {
+func (_c *MockStore_GetSummaryStats_Call) Run(run func(ctx context.Context)) *MockStore_GetSummaryStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
-		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowInstancesPaginated_Call) Return(workflowInstances []WorkflowInstance, n int64, err error) *MockStore_GetWorkflowInstancesPaginated_Call {
-	_c.Call.Return(workflowInstances, n, err)
+func (_c *MockStore_GetSummaryStats_Call) Return(summaryStats *SummaryStats, err error) *MockStore_GetSummaryStats_Call {
+	_c.Call.Return(summaryStats, err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowInstancesPaginated_Call) RunAndReturn(run func(ctx context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error)) *MockStore_GetWorkflowInstancesPaginated_Call {
+func (_c *MockStore_GetSummaryStats_Call) RunAndReturn(run func(ctx context.Context) (*SummaryStats, error)) *MockStore_GetSummaryStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowStats provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, error) {
-	ret := _mock.Called(ctx)
+// GetWorker provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorker(ctx context.Context, workerID string) (*WorkerRecord, error) {
+	ret := _mock.Called(ctx, workerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowStats")
+		panic("no return value specified for GetWorker")
 	}
 
-	var r0 []WorkflowStats
+	var r0 *WorkerRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]WorkflowStats, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*WorkerRecord, error)); ok {
+		return returnFunc(ctx, workerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []WorkflowStats); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *WorkerRecord); ok {
+		r0 = returnFunc(ctx, workerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowStats)
+			r0 = ret.Get(0).(*WorkerRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, workerID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetWorkflowStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowStats'
-type MockStore_GetWorkflowStats_Call struct {
+// MockStore_GetWorker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorker'
+type MockStore_GetWorker_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowStats is a helper method to define mock.On call
+// GetWorker is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) GetWorkflowStats(ctx interface{}) *MockStore_GetWorkflowStats_Call {
-	return &MockStore_GetWorkflowStats_Call{Call: _e.mock.On("GetWorkflowStats", ctx)}
+//   - workerID string
+func (_e *MockStore_Expecter) GetWorker(ctx interface{}, workerID interface{}) *MockStore_GetWorker_Call {
+	return &MockStore_GetWorker_Call{Call: _e.mock.On("GetWorker", ctx, workerID)}
 }
 
-func (_c *MockStore_GetWorkflowStats_Call) Run(run func(ctx context.Context)) *MockStore_GetWorkflowStats_Call {
+func (_c *MockStore_GetWorker_Call) Run(run func(ctx context.Context, workerID string)) *MockStore_GetWorker_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowStats_Call) Return(workflowStatss []WorkflowStats, err error) *MockStore_GetWorkflowStats_Call {
-	_c.Call.Return(workflowStatss, err)
+func (_c *MockStore_GetWorker_Call) Return(workerRecord *WorkerRecord, err error) *MockStore_GetWorker_Call {
+	_c.Call.Return(workerRecord, err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowStats_Call) RunAndReturn(run func(ctx context.Context) ([]WorkflowStats, error)) *MockStore_GetWorkflowStats_Call {
+func (_c *MockStore_GetWorker_Call) RunAndReturn(run func(ctx context.Context, workerID string) (*WorkerRecord, error)) *MockStore_GetWorker_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflowSteps provides a mock function for the type MockStore
-func (_mock *MockStore) GetWorkflowSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
-	ret := _mock.Called(ctx, instanceID)
+// GetWorkflowDefinition provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowDefinition(ctx context.Context, id string) (*WorkflowDefinition, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflowSteps")
+		panic("no return value specified for GetWorkflowDefinition")
 	}
 
-	var r0 []WorkflowStep
+	var r0 *WorkflowDefinition
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]WorkflowStep, error)); ok {
-		return returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*WorkflowDefinition, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []WorkflowStep); ok {
-		r0 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *WorkflowDefinition); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]WorkflowStep)
+			r0 = ret.Get(0).(*WorkflowDefinition)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetWorkflowSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowSteps'
-type MockStore_GetWorkflowSteps_Call struct {
+// MockStore_GetWorkflowDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowDefinition'
+type MockStore_GetWorkflowDefinition_Call struct {
 	*mock.Call
 }
 
-// GetWorkflowSteps is a helper method to define mock.On call
+// GetWorkflowDefinition is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-func (_e *MockStore_Expecter) GetWorkflowSteps(ctx interface{}, instanceID interface{}) *MockStore_GetWorkflowSteps_Call {
-	return &MockStore_GetWorkflowSteps_Call{Call: _e.mock.On("GetWorkflowSteps", ctx, instanceID)}
+//   - id string
+func (_e *MockStore_Expecter) GetWorkflowDefinition(ctx interface{}, id interface{}) *MockStore_GetWorkflowDefinition_Call {
+	return &MockStore_GetWorkflowDefinition_Call{Call: _e.mock.On("GetWorkflowDefinition", ctx, id)}
 }
 
-func (_c *MockStore_GetWorkflowSteps_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetWorkflowSteps_Call {
+func (_c *MockStore_GetWorkflowDefinition_Call) Run(run func(ctx context.Context, id string)) *MockStore_GetWorkflowDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowDefinition_Call) Return(workflowDefinition *WorkflowDefinition, err error) *MockStore_GetWorkflowDefinition_Call {
+	_c.Call.Return(workflowDefinition, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowDefinition_Call) RunAndReturn(run func(ctx context.Context, id string) (*WorkflowDefinition, error)) *MockStore_GetWorkflowDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowDefinitions provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowDefinition, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowDefinitions")
+	}
+
+	var r0 []WorkflowDefinition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]WorkflowDefinition, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []WorkflowDefinition); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkflowDefinition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_GetWorkflowDefinitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowDefinitions'
+type MockStore_GetWorkflowDefinitions_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowDefinitions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) GetWorkflowDefinitions(ctx interface{}) *MockStore_GetWorkflowDefinitions_Call {
+	return &MockStore_GetWorkflowDefinitions_Call{Call: _e.mock.On("GetWorkflowDefinitions", ctx)}
+}
+
+func (_c *MockStore_GetWorkflowDefinitions_Call) Run(run func(ctx context.Context)) *MockStore_GetWorkflowDefinitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowDefinitions_Call) Return(workflowDefinitions []WorkflowDefinition, err error) *MockStore_GetWorkflowDefinitions_Call {
+	_c.Call.Return(workflowDefinitions, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowDefinitions_Call) RunAndReturn(run func(ctx context.Context) ([]WorkflowDefinition, error)) *MockStore_GetWorkflowDefinitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowEvents provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowEvents(ctx context.Context, instanceID int64) ([]WorkflowEvent, error) {
+	ret := _mock.Called(ctx, instanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowEvents")
+	}
+
+	var r0 []WorkflowEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]WorkflowEvent, error)); ok {
+		return returnFunc(ctx, instanceID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []WorkflowEvent); ok {
+		r0 = returnFunc(ctx, instanceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkflowEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, instanceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_GetWorkflowEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowEvents'
+type MockStore_GetWorkflowEvents_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceID int64
+func (_e *MockStore_Expecter) GetWorkflowEvents(ctx interface{}, instanceID interface{}) *MockStore_GetWorkflowEvents_Call {
+	return &MockStore_GetWorkflowEvents_Call{Call: _e.mock.On("GetWorkflowEvents", ctx, instanceID)}
+}
+
+func (_c *MockStore_GetWorkflowEvents_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetWorkflowEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowEvents_Call) Return(workflowEvents []WorkflowEvent, err error) *MockStore_GetWorkflowEvents_Call {
+	_c.Call.Return(workflowEvents, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowEvents_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]WorkflowEvent, error)) *MockStore_GetWorkflowEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowInstances provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowInstances(ctx context.Context, workflowID string) ([]WorkflowInstance, error) {
+	ret := _mock.Called(ctx, workflowID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowInstances")
+	}
+
+	var r0 []WorkflowInstance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]WorkflowInstance, error)); ok {
+		return returnFunc(ctx, workflowID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []WorkflowInstance); ok {
+		r0 = returnFunc(ctx, workflowID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkflowInstance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, workflowID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_GetWorkflowInstances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowInstances'
+type MockStore_GetWorkflowInstances_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowInstances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workflowID string
+func (_e *MockStore_Expecter) GetWorkflowInstances(ctx interface{}, workflowID interface{}) *MockStore_GetWorkflowInstances_Call {
+	return &MockStore_GetWorkflowInstances_Call{Call: _e.mock.On("GetWorkflowInstances", ctx, workflowID)}
+}
+
+func (_c *MockStore_GetWorkflowInstances_Call) Run(run func(ctx context.Context, workflowID string)) *MockStore_GetWorkflowInstances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowInstances_Call) Return(workflowInstances []WorkflowInstance, err error) *MockStore_GetWorkflowInstances_Call {
+	_c.Call.Return(workflowInstances, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowInstances_Call) RunAndReturn(run func(ctx context.Context, workflowID string) ([]WorkflowInstance, error)) *MockStore_GetWorkflowInstances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowInstancesPaginated provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowInstancesPaginated(ctx context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error) {
+	ret := _mock.Called(ctx, workflowID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowInstancesPaginated")
+	}
+
+	var r0 []WorkflowInstance
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) ([]WorkflowInstance, int64, error)); ok {
+		return returnFunc(ctx, workflowID, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) []WorkflowInstance); ok {
+		r0 = returnFunc(ctx, workflowID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkflowInstance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = returnFunc(ctx, workflowID, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = returnFunc(ctx, workflowID, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_GetWorkflowInstancesPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowInstancesPaginated'
+type MockStore_GetWorkflowInstancesPaginated_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowInstancesPaginated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workflowID string
+//   - offset int
+//   - limit int
+func (_e *MockStore_Expecter) GetWorkflowInstancesPaginated(ctx interface{}, workflowID interface{}, offset interface{}, limit interface{}) *MockStore_GetWorkflowInstancesPaginated_Call {
+	return &MockStore_GetWorkflowInstancesPaginated_Call{Call: _e.mock.On("GetWorkflowInstancesPaginated", ctx, workflowID, offset, limit)}
+}
+
+func (_c *MockStore_GetWorkflowInstancesPaginated_Call) Run(run func(ctx context.Context, workflowID string, offset int, limit int)) *MockStore_GetWorkflowInstancesPaginated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowInstancesPaginated_Call) Return(workflowInstances []WorkflowInstance, n int64, err error) *MockStore_GetWorkflowInstancesPaginated_Call {
+	_c.Call.Return(workflowInstances, n, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowInstancesPaginated_Call) RunAndReturn(run func(ctx context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error)) *MockStore_GetWorkflowInstancesPaginated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowStats provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowStats")
+	}
+
+	var r0 []WorkflowStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]WorkflowStats, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []WorkflowStats); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkflowStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_GetWorkflowStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowStats'
+type MockStore_GetWorkflowStats_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) GetWorkflowStats(ctx interface{}) *MockStore_GetWorkflowStats_Call {
+	return &MockStore_GetWorkflowStats_Call{Call: _e.mock.On("GetWorkflowStats", ctx)}
+}
+
+func (_c *MockStore_GetWorkflowStats_Call) Run(run func(ctx context.Context)) *MockStore_GetWorkflowStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowStats_Call) Return(workflowStatss []WorkflowStats, err error) *MockStore_GetWorkflowStats_Call {
+	_c.Call.Return(workflowStatss, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowStats_Call) RunAndReturn(run func(ctx context.Context) ([]WorkflowStats, error)) *MockStore_GetWorkflowStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowSteps provides a mock function for the type MockStore
+func (_mock *MockStore) GetWorkflowSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
+	ret := _mock.Called(ctx, instanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowSteps")
+	}
+
+	var r0 []WorkflowStep
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]WorkflowStep, error)); ok {
+		return returnFunc(ctx, instanceID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []WorkflowStep); ok {
+		r0 = returnFunc(ctx, instanceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkflowStep)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, instanceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_GetWorkflowSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowSteps'
+type MockStore_GetWorkflowSteps_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowSteps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceID int64
+func (_e *MockStore_Expecter) GetWorkflowSteps(ctx interface{}, instanceID interface{}) *MockStore_GetWorkflowSteps_Call {
+	return &MockStore_GetWorkflowSteps_Call{Call: _e.mock.On("GetWorkflowSteps", ctx, instanceID)}
+}
+
+func (_c *MockStore_GetWorkflowSteps_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_GetWorkflowSteps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowSteps_Call) Return(workflowSteps []WorkflowStep, err error) *MockStore_GetWorkflowSteps_Call {
+	_c.Call.Return(workflowSteps, err)
+	return _c
+}
+
+func (_c *MockStore_GetWorkflowSteps_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]WorkflowStep, error)) *MockStore_GetWorkflowSteps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HeartbeatWorker provides a mock function for the type MockStore
+func (_mock *MockStore) HeartbeatWorker(ctx context.Context, workerID string, inFlight int, concurrency int, at time.Time) error {
+	ret := _mock.Called(ctx, workerID, inFlight, concurrency, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeartbeatWorker")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) error); ok {
+		r0 = returnFunc(ctx, workerID, inFlight, concurrency, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_HeartbeatWorker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeartbeatWorker'
+type MockStore_HeartbeatWorker_Call struct {
+	*mock.Call
+}
+
+// HeartbeatWorker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workerID string
+//   - inFlight int
+//   - concurrency int
+//   - at time.Time
+func (_e *MockStore_Expecter) HeartbeatWorker(ctx interface{}, workerID interface{}, inFlight interface{}, concurrency interface{}, at interface{}) *MockStore_HeartbeatWorker_Call {
+	return &MockStore_HeartbeatWorker_Call{Call: _e.mock.On("HeartbeatWorker", ctx, workerID, inFlight, concurrency, at)}
+}
+
+func (_c *MockStore_HeartbeatWorker_Call) Run(run func(ctx context.Context, workerID string, inFlight int, concurrency int, at time.Time)) *MockStore_HeartbeatWorker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_HeartbeatWorker_Call) Return(err error) *MockStore_HeartbeatWorker_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_HeartbeatWorker_Call) RunAndReturn(run func(ctx context.Context, workerID string, inFlight int, concurrency int, at time.Time) error) *MockStore_HeartbeatWorker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaseQueueItem provides a mock function for the type MockStore
+func (_mock *MockStore) LeaseQueueItem(ctx context.Context, queueID int64, workerID string, leaseToken string, leaseUntil time.Time) error {
+	ret := _mock.Called(ctx, queueID, workerID, leaseToken, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaseQueueItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, queueID, workerID, leaseToken, leaseUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_LeaseQueueItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaseQueueItem'
+type MockStore_LeaseQueueItem_Call struct {
+	*mock.Call
+}
+
+// LeaseQueueItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - queueID int64
+//   - workerID string
+//   - leaseToken string
+//   - leaseUntil time.Time
+func (_e *MockStore_Expecter) LeaseQueueItem(ctx interface{}, queueID interface{}, workerID interface{}, leaseToken interface{}, leaseUntil interface{}) *MockStore_LeaseQueueItem_Call {
+	return &MockStore_LeaseQueueItem_Call{Call: _e.mock.On("LeaseQueueItem", ctx, queueID, workerID, leaseToken, leaseUntil)}
+}
+
+func (_c *MockStore_LeaseQueueItem_Call) Run(run func(ctx context.Context, queueID int64, workerID string, leaseToken string, leaseUntil time.Time)) *MockStore_LeaseQueueItem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3544,27 +4704,42 @@ func (_c *MockStore_GetWorkflowSteps_Call) Run(run func(ctx context.Context, ins
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowSteps_Call) Return(workflowSteps []WorkflowStep, err error) *MockStore_GetWorkflowSteps_Call {
-	_c.Call.Return(workflowSteps, err)
+func (_c *MockStore_LeaseQueueItem_Call) Return(err error) *MockStore_LeaseQueueItem_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_GetWorkflowSteps_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) ([]WorkflowStep, error)) *MockStore_GetWorkflowSteps_Call {
+func (_c *MockStore_LeaseQueueItem_Call) RunAndReturn(run func(ctx context.Context, queueID int64, workerID string, leaseToken string, leaseUntil time.Time) error) *MockStore_LeaseQueueItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ListDeadLetters provides a mock function for the type MockStore
-func (_mock *MockStore) ListDeadLetters(ctx context.Context, offset int, limit int) ([]DeadLetterRecord, int64, error) {
-	ret := _mock.Called(ctx, offset, limit)
+func (_mock *MockStore) ListDeadLetters(ctx context.Context, filter DeadLetterFilter, offset int, limit int) ([]DeadLetterRecord, int64, error) {
+	ret := _mock.Called(ctx, filter, offset, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListDeadLetters")
@@ -3573,23 +4748,23 @@ func (_mock *MockStore) ListDeadLetters(ctx context.Context, offset int, limit i
 	var r0 []DeadLetterRecord
 	var r1 int64
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]DeadLetterRecord, int64, error)); ok {
-		return returnFunc(ctx, offset, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, DeadLetterFilter, int, int) ([]DeadLetterRecord, int64, error)); ok {
+		return returnFunc(ctx, filter, offset, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []DeadLetterRecord); ok {
-		r0 = returnFunc(ctx, offset, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, DeadLetterFilter, int, int) []DeadLetterRecord); ok {
+		r0 = returnFunc(ctx, filter, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DeadLetterRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) int64); ok {
-		r1 = returnFunc(ctx, offset, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, DeadLetterFilter, int, int) int64); ok {
+		r1 = returnFunc(ctx, filter, offset, limit)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
-		r2 = returnFunc(ctx, offset, limit)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, DeadLetterFilter, int, int) error); ok {
+		r2 = returnFunc(ctx, filter, offset, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -3603,30 +4778,36 @@ type MockStore_ListDeadLetters_Call struct {
 
 // ListDeadLetters is a helper method to define mock.On call
 //   - ctx context.Context
+//   - filter DeadLetterFilter
 //   - offset int
 //   - limit int
-func (_e *MockStore_Expecter) ListDeadLetters(ctx interface{}, offset interface{}, limit interface{}) *MockStore_ListDeadLetters_Call {
-	return &MockStore_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, offset, limit)}
+func (_e *MockStore_Expecter) ListDeadLetters(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *MockStore_ListDeadLetters_Call {
+	return &MockStore_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, filter, offset, limit)}
 }
 
-func (_c *MockStore_ListDeadLetters_Call) Run(run func(ctx context.Context, offset int, limit int)) *MockStore_ListDeadLetters_Call {
+func (_c *MockStore_ListDeadLetters_Call) Run(run func(ctx context.Context, filter DeadLetterFilter, offset int, limit int)) *MockStore_ListDeadLetters_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 DeadLetterFilter
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(DeadLetterFilter)
 		}
 		var arg2 int
 		if args[2] != nil {
 			arg2 = args[2].(int)
 		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -3637,7 +4818,69 @@ func (_c *MockStore_ListDeadLetters_Call) Return(deadLetterRecords []DeadLetterR
 	return _c
 }
 
-func (_c *MockStore_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, offset int, limit int) ([]DeadLetterRecord, int64, error)) *MockStore_ListDeadLetters_Call {
+func (_c *MockStore_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, filter DeadLetterFilter, offset int, limit int) ([]DeadLetterRecord, int64, error)) *MockStore_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWorkers provides a mock function for the type MockStore
+func (_mock *MockStore) ListWorkers(ctx context.Context) ([]WorkerRecord, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkers")
+	}
+
+	var r0 []WorkerRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]WorkerRecord, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []WorkerRecord); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WorkerRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_ListWorkers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkers'
+type MockStore_ListWorkers_Call struct {
+	*mock.Call
+}
+
+// ListWorkers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) ListWorkers(ctx interface{}) *MockStore_ListWorkers_Call {
+	return &MockStore_ListWorkers_Call{Call: _e.mock.On("ListWorkers", ctx)}
+}
+
+func (_c *MockStore_ListWorkers_Call) Run(run func(ctx context.Context)) *MockStore_ListWorkers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_ListWorkers_Call) Return(workerRecords []WorkerRecord, err error) *MockStore_ListWorkers_Call {
+	_c.Call.Return(workerRecords, err)
+	return _c
+}
+
+func (_c *MockStore_ListWorkers_Call) RunAndReturn(run func(ctx context.Context) ([]WorkerRecord, error)) *MockStore_ListWorkers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3647,54 +4890,263 @@ func (_mock *MockStore) LogEvent(ctx context.Context, instanceID int64, stepID *
 	ret := _mock.Called(ctx, instanceID, stepID, eventType, payload)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LogEvent")
+		panic("no return value specified for LogEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *int64, string, any) error); ok {
+		r0 = returnFunc(ctx, instanceID, stepID, eventType, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_LogEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogEvent'
+type MockStore_LogEvent_Call struct {
+	*mock.Call
+}
+
+// LogEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceID int64
+//   - stepID *int64
+//   - eventType string
+//   - payload any
+func (_e *MockStore_Expecter) LogEvent(ctx interface{}, instanceID interface{}, stepID interface{}, eventType interface{}, payload interface{}) *MockStore_LogEvent_Call {
+	return &MockStore_LogEvent_Call{Call: _e.mock.On("LogEvent", ctx, instanceID, stepID, eventType, payload)}
+}
+
+func (_c *MockStore_LogEvent_Call) Run(run func(ctx context.Context, instanceID int64, stepID *int64, eventType string, payload any)) *MockStore_LogEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 *int64
+		if args[2] != nil {
+			arg2 = args[2].(*int64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 any
+		if args[4] != nil {
+			arg4 = args[4].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_LogEvent_Call) Return(err error) *MockStore_LogEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_LogEvent_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, stepID *int64, eventType string, payload any) error) *MockStore_LogEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkStaleWorkersDead provides a mock function for the type MockStore
+func (_mock *MockStore) MarkStaleWorkersDead(ctx context.Context, heartbeatBefore time.Time) (int64, error) {
+	ret := _mock.Called(ctx, heartbeatBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkStaleWorkersDead")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, heartbeatBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, heartbeatBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, heartbeatBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_MarkStaleWorkersDead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkStaleWorkersDead'
+type MockStore_MarkStaleWorkersDead_Call struct {
+	*mock.Call
+}
+
+// MarkStaleWorkersDead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - heartbeatBefore time.Time
+func (_e *MockStore_Expecter) MarkStaleWorkersDead(ctx interface{}, heartbeatBefore interface{}) *MockStore_MarkStaleWorkersDead_Call {
+	return &MockStore_MarkStaleWorkersDead_Call{Call: _e.mock.On("MarkStaleWorkersDead", ctx, heartbeatBefore)}
+}
+
+func (_c *MockStore_MarkStaleWorkersDead_Call) Run(run func(ctx context.Context, heartbeatBefore time.Time)) *MockStore_MarkStaleWorkersDead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_MarkStaleWorkersDead_Call) Return(n int64, err error) *MockStore_MarkStaleWorkersDead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_MarkStaleWorkersDead_Call) RunAndReturn(run func(ctx context.Context, heartbeatBefore time.Time) (int64, error)) *MockStore_MarkStaleWorkersDead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseActiveStepsAndClearQueue provides a mock function for the type MockStore
+func (_mock *MockStore) PauseActiveStepsAndClearQueue(ctx context.Context, instanceID int64) error {
+	ret := _mock.Called(ctx, instanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseActiveStepsAndClearQueue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, instanceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_PauseActiveStepsAndClearQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseActiveStepsAndClearQueue'
+type MockStore_PauseActiveStepsAndClearQueue_Call struct {
+	*mock.Call
+}
+
+// PauseActiveStepsAndClearQueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceID int64
+func (_e *MockStore_Expecter) PauseActiveStepsAndClearQueue(ctx interface{}, instanceID interface{}) *MockStore_PauseActiveStepsAndClearQueue_Call {
+	return &MockStore_PauseActiveStepsAndClearQueue_Call{Call: _e.mock.On("PauseActiveStepsAndClearQueue", ctx, instanceID)}
+}
+
+func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_PauseActiveStepsAndClearQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) Return(err error) *MockStore_PauseActiveStepsAndClearQueue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) error) *MockStore_PauseActiveStepsAndClearQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReclaimExpiredQueueItems provides a mock function for the type MockStore
+func (_mock *MockStore) ReclaimExpiredQueueItems(ctx context.Context, workerID string, now time.Time, claimedBefore time.Time, limit int) ([]QueueItem, error) {
+	ret := _mock.Called(ctx, workerID, now, claimedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReclaimExpiredQueueItems")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *int64, string, any) error); ok {
-		r0 = returnFunc(ctx, instanceID, stepID, eventType, payload)
+	var r0 []QueueItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, int) ([]QueueItem, error)); ok {
+		return returnFunc(ctx, workerID, now, claimedBefore, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, int) []QueueItem); ok {
+		r0 = returnFunc(ctx, workerID, now, claimedBefore, limit)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]QueueItem)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, workerID, now, claimedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_LogEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogEvent'
-type MockStore_LogEvent_Call struct {
+// MockStore_ReclaimExpiredQueueItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReclaimExpiredQueueItems'
+type MockStore_ReclaimExpiredQueueItems_Call struct {
 	*mock.Call
 }
 
-// LogEvent is a helper method to define mock.On call
+// ReclaimExpiredQueueItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-//   - stepID *int64
-//   - eventType string
-//   - payload any
-func (_e *MockStore_Expecter) LogEvent(ctx interface{}, instanceID interface{}, stepID interface{}, eventType interface{}, payload interface{}) *MockStore_LogEvent_Call {
-	return &MockStore_LogEvent_Call{Call: _e.mock.On("LogEvent", ctx, instanceID, stepID, eventType, payload)}
+//   - workerID string
+//   - now time.Time
+//   - claimedBefore time.Time
+//   - limit int
+func (_e *MockStore_Expecter) ReclaimExpiredQueueItems(ctx interface{}, workerID interface{}, now interface{}, claimedBefore interface{}, limit interface{}) *MockStore_ReclaimExpiredQueueItems_Call {
+	return &MockStore_ReclaimExpiredQueueItems_Call{Call: _e.mock.On("ReclaimExpiredQueueItems", ctx, workerID, now, claimedBefore, limit)}
 }
 
-func (_c *MockStore_LogEvent_Call) Run(run func(ctx context.Context, instanceID int64, stepID *int64, eventType string, payload any)) *MockStore_LogEvent_Call {
+func (_c *MockStore_ReclaimExpiredQueueItems_Call) Run(run func(ctx context.Context, workerID string, now time.Time, claimedBefore time.Time, limit int)) *MockStore_ReclaimExpiredQueueItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(string)
 		}
-		var arg2 *int64
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(*int64)
+			arg2 = args[2].(time.Time)
 		}
-		var arg3 string
+		var arg3 time.Time
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].(time.Time)
 		}
-		var arg4 any
+		var arg4 int
 		if args[4] != nil {
-			arg4 = args[4].(any)
+			arg4 = args[4].(int)
 		}
 		run(
 			arg0,
@@ -3707,54 +5159,54 @@ func (_c *MockStore_LogEvent_Call) Run(run func(ctx context.Context, instanceID
 	return _c
 }
 
-func (_c *MockStore_LogEvent_Call) Return(err error) *MockStore_LogEvent_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_ReclaimExpiredQueueItems_Call) Return(queueItems []QueueItem, err error) *MockStore_ReclaimExpiredQueueItems_Call {
+	_c.Call.Return(queueItems, err)
 	return _c
 }
 
-func (_c *MockStore_LogEvent_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, stepID *int64, eventType string, payload any) error) *MockStore_LogEvent_Call {
+func (_c *MockStore_ReclaimExpiredQueueItems_Call) RunAndReturn(run func(ctx context.Context, workerID string, now time.Time, claimedBefore time.Time, limit int) ([]QueueItem, error)) *MockStore_ReclaimExpiredQueueItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PauseActiveStepsAndClearQueue provides a mock function for the type MockStore
-func (_mock *MockStore) PauseActiveStepsAndClearQueue(ctx context.Context, instanceID int64) error {
-	ret := _mock.Called(ctx, instanceID)
+// RegisterWorker provides a mock function for the type MockStore
+func (_mock *MockStore) RegisterWorker(ctx context.Context, worker *WorkerRecord) error {
+	ret := _mock.Called(ctx, worker)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PauseActiveStepsAndClearQueue")
+		panic("no return value specified for RegisterWorker")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = returnFunc(ctx, instanceID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *WorkerRecord) error); ok {
+		r0 = returnFunc(ctx, worker)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_PauseActiveStepsAndClearQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseActiveStepsAndClearQueue'
-type MockStore_PauseActiveStepsAndClearQueue_Call struct {
+// MockStore_RegisterWorker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterWorker'
+type MockStore_RegisterWorker_Call struct {
 	*mock.Call
 }
 
-// PauseActiveStepsAndClearQueue is a helper method to define mock.On call
+// RegisterWorker is a helper method to define mock.On call
 //   - ctx context.Context
-//   - instanceID int64
-func (_e *MockStore_Expecter) PauseActiveStepsAndClearQueue(ctx interface{}, instanceID interface{}) *MockStore_PauseActiveStepsAndClearQueue_Call {
-	return &MockStore_PauseActiveStepsAndClearQueue_Call{Call: _e.mock.On("PauseActiveStepsAndClearQueue", ctx, instanceID)}
+//   - worker *WorkerRecord
+func (_e *MockStore_Expecter) RegisterWorker(ctx interface{}, worker interface{}) *MockStore_RegisterWorker_Call {
+	return &MockStore_RegisterWorker_Call{Call: _e.mock.On("RegisterWorker", ctx, worker)}
 }
 
-func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) Run(run func(ctx context.Context, instanceID int64)) *MockStore_PauseActiveStepsAndClearQueue_Call {
+func (_c *MockStore_RegisterWorker_Call) Run(run func(ctx context.Context, worker *WorkerRecord)) *MockStore_RegisterWorker_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 *WorkerRecord
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(*WorkerRecord)
 		}
 		run(
 			arg0,
@@ -3764,12 +5216,12 @@ func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) Return(err error) *MockStore_PauseActiveStepsAndClearQueue_Call {
+func (_c *MockStore_RegisterWorker_Call) Return(err error) *MockStore_RegisterWorker_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_PauseActiveStepsAndClearQueue_Call) RunAndReturn(run func(ctx context.Context, instanceID int64) error) *MockStore_PauseActiveStepsAndClearQueue_Call {
+func (_c *MockStore_RegisterWorker_Call) RunAndReturn(run func(ctx context.Context, worker *WorkerRecord) error) *MockStore_RegisterWorker_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3888,6 +5340,75 @@ func (_c *MockStore_RemoveFromQueue_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// RenewQueueItemLease provides a mock function for the type MockStore
+func (_mock *MockStore) RenewQueueItemLease(ctx context.Context, queueID int64, leaseToken string, leaseUntil time.Time) error {
+	ret := _mock.Called(ctx, queueID, leaseToken, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewQueueItemLease")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, queueID, leaseToken, leaseUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_RenewQueueItemLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewQueueItemLease'
+type MockStore_RenewQueueItemLease_Call struct {
+	*mock.Call
+}
+
+// RenewQueueItemLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - queueID int64
+//   - leaseToken string
+//   - leaseUntil time.Time
+func (_e *MockStore_Expecter) RenewQueueItemLease(ctx interface{}, queueID interface{}, leaseToken interface{}, leaseUntil interface{}) *MockStore_RenewQueueItemLease_Call {
+	return &MockStore_RenewQueueItemLease_Call{Call: _e.mock.On("RenewQueueItemLease", ctx, queueID, leaseToken, leaseUntil)}
+}
+
+func (_c *MockStore_RenewQueueItemLease_Call) Run(run func(ctx context.Context, queueID int64, leaseToken string, leaseUntil time.Time)) *MockStore_RenewQueueItemLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_RenewQueueItemLease_Call) Return(err error) *MockStore_RenewQueueItemLease_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_RenewQueueItemLease_Call) RunAndReturn(run func(ctx context.Context, queueID int64, leaseToken string, leaseUntil time.Time) error) *MockStore_RenewQueueItemLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReplaceInJoinWaitFor provides a mock function for the type MockStore
 func (_mock *MockStore) ReplaceInJoinWaitFor(ctx context.Context, instanceID int64, joinStepName string, virtualStep string, realStep string) error {
 	ret := _mock.Called(ctx, instanceID, joinStepName, virtualStep, realStep)
@@ -3964,16 +5485,16 @@ func (_c *MockStore_ReplaceInJoinWaitFor_Call) RunAndReturn(run func(ctx context
 }
 
 // RequeueDeadLetter provides a mock function for the type MockStore
-func (_mock *MockStore) RequeueDeadLetter(ctx context.Context, dlqID int64, newInput *json.RawMessage) error {
-	ret := _mock.Called(ctx, dlqID, newInput)
+func (_mock *MockStore) RequeueDeadLetter(ctx context.Context, dlqID int64, taskQueue string, newInput *json.RawMessage) error {
+	ret := _mock.Called(ctx, dlqID, taskQueue, newInput)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RequeueDeadLetter")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *json.RawMessage) error); ok {
-		r0 = returnFunc(ctx, dlqID, newInput)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, *json.RawMessage) error); ok {
+		r0 = returnFunc(ctx, dlqID, taskQueue, newInput)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3988,12 +5509,13 @@ type MockStore_RequeueDeadLetter_Call struct {
 // RequeueDeadLetter is a helper method to define mock.On call
 //   - ctx context.Context
 //   - dlqID int64
+//   - taskQueue string
 //   - newInput *json.RawMessage
-func (_e *MockStore_Expecter) RequeueDeadLetter(ctx interface{}, dlqID interface{}, newInput interface{}) *MockStore_RequeueDeadLetter_Call {
-	return &MockStore_RequeueDeadLetter_Call{Call: _e.mock.On("RequeueDeadLetter", ctx, dlqID, newInput)}
+func (_e *MockStore_Expecter) RequeueDeadLetter(ctx interface{}, dlqID interface{}, taskQueue interface{}, newInput interface{}) *MockStore_RequeueDeadLetter_Call {
+	return &MockStore_RequeueDeadLetter_Call{Call: _e.mock.On("RequeueDeadLetter", ctx, dlqID, taskQueue, newInput)}
 }
 
-func (_c *MockStore_RequeueDeadLetter_Call) Run(run func(ctx context.Context, dlqID int64, newInput *json.RawMessage)) *MockStore_RequeueDeadLetter_Call {
+func (_c *MockStore_RequeueDeadLetter_Call) Run(run func(ctx context.Context, dlqID int64, taskQueue string, newInput *json.RawMessage)) *MockStore_RequeueDeadLetter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4003,14 +5525,19 @@ func (_c *MockStore_RequeueDeadLetter_Call) Run(run func(ctx context.Context, dl
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 *json.RawMessage
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*json.RawMessage)
+			arg2 = args[2].(string)
+		}
+		var arg3 *json.RawMessage
+		if args[3] != nil {
+			arg3 = args[3].(*json.RawMessage)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -4021,7 +5548,7 @@ func (_c *MockStore_RequeueDeadLetter_Call) Return(err error) *MockStore_Requeue
 	return _c
 }
 
-func (_c *MockStore_RequeueDeadLetter_Call) RunAndReturn(run func(ctx context.Context, dlqID int64, newInput *json.RawMessage) error) *MockStore_RequeueDeadLetter_Call {
+func (_c *MockStore_RequeueDeadLetter_Call) RunAndReturn(run func(ctx context.Context, dlqID int64, taskQueue string, newInput *json.RawMessage) error) *MockStore_RequeueDeadLetter_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4146,6 +5673,74 @@ func (_c *MockStore_SaveWorkflowDefinition_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// SearchInstances provides a mock function for the type MockStore
+func (_mock *MockStore) SearchInstances(ctx context.Context, query InstanceQuery) (*InstanceSearchResult, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchInstances")
+	}
+
+	var r0 *InstanceSearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, InstanceQuery) (*InstanceSearchResult, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, InstanceQuery) *InstanceSearchResult); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*InstanceSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, InstanceQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SearchInstances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchInstances'
+type MockStore_SearchInstances_Call struct {
+	*mock.Call
+}
+
+// SearchInstances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query InstanceQuery
+func (_e *MockStore_Expecter) SearchInstances(ctx interface{}, query interface{}) *MockStore_SearchInstances_Call {
+	return &MockStore_SearchInstances_Call{Call: _e.mock.On("SearchInstances", ctx, query)}
+}
+
+func (_c *MockStore_SearchInstances_Call) Run(run func(ctx context.Context, query InstanceQuery)) *MockStore_SearchInstances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 InstanceQuery
+		if args[1] != nil {
+			arg1 = args[1].(InstanceQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SearchInstances_Call) Return(instanceSearchResult *InstanceSearchResult, err error) *MockStore_SearchInstances_Call {
+	_c.Call.Return(instanceSearchResult, err)
+	return _c
+}
+
+func (_c *MockStore_SearchInstances_Call) RunAndReturn(run func(ctx context.Context, query InstanceQuery) (*InstanceSearchResult, error)) *MockStore_SearchInstances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetAgingEnabled provides a mock function for the type MockStore
 func (_mock *MockStore) SetAgingEnabled(enabled bool) {
 	_mock.Called(enabled)
@@ -4226,6 +5821,132 @@ func (_c *MockStore_SetAgingRate_Call) RunAndReturn(run func(rate float64)) *Moc
 	return _c
 }
 
+// SetInstanceLabels provides a mock function for the type MockStore
+func (_mock *MockStore) SetInstanceLabels(ctx context.Context, instanceID int64, labels map[string]string) error {
+	ret := _mock.Called(ctx, instanceID, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetInstanceLabels")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, map[string]string) error); ok {
+		r0 = returnFunc(ctx, instanceID, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SetInstanceLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInstanceLabels'
+type MockStore_SetInstanceLabels_Call struct {
+	*mock.Call
+}
+
+// SetInstanceLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceID int64
+//   - labels map[string]string
+func (_e *MockStore_Expecter) SetInstanceLabels(ctx interface{}, instanceID interface{}, labels interface{}) *MockStore_SetInstanceLabels_Call {
+	return &MockStore_SetInstanceLabels_Call{Call: _e.mock.On("SetInstanceLabels", ctx, instanceID, labels)}
+}
+
+func (_c *MockStore_SetInstanceLabels_Call) Run(run func(ctx context.Context, instanceID int64, labels map[string]string)) *MockStore_SetInstanceLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 map[string]string
+		if args[2] != nil {
+			arg2 = args[2].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SetInstanceLabels_Call) Return(err error) *MockStore_SetInstanceLabels_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SetInstanceLabels_Call) RunAndReturn(run func(ctx context.Context, instanceID int64, labels map[string]string) error) *MockStore_SetInstanceLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SkipPendingStep provides a mock function for the type MockStore
+func (_mock *MockStore) SkipPendingStep(ctx context.Context, stepID int64, output json.RawMessage) error {
+	ret := _mock.Called(ctx, stepID, output)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SkipPendingStep")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, json.RawMessage) error); ok {
+		r0 = returnFunc(ctx, stepID, output)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SkipPendingStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SkipPendingStep'
+type MockStore_SkipPendingStep_Call struct {
+	*mock.Call
+}
+
+// SkipPendingStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stepID int64
+//   - output json.RawMessage
+func (_e *MockStore_Expecter) SkipPendingStep(ctx interface{}, stepID interface{}, output interface{}) *MockStore_SkipPendingStep_Call {
+	return &MockStore_SkipPendingStep_Call{Call: _e.mock.On("SkipPendingStep", ctx, stepID, output)}
+}
+
+func (_c *MockStore_SkipPendingStep_Call) Run(run func(ctx context.Context, stepID int64, output json.RawMessage)) *MockStore_SkipPendingStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 json.RawMessage
+		if args[2] != nil {
+			arg2 = args[2].(json.RawMessage)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SkipPendingStep_Call) Return(err error) *MockStore_SkipPendingStep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SkipPendingStep_Call) RunAndReturn(run func(ctx context.Context, stepID int64, output json.RawMessage) error) *MockStore_SkipPendingStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateInstanceStatus provides a mock function for the type MockStore
 func (_mock *MockStore) UpdateInstanceStatus(ctx context.Context, instanceID int64, status WorkflowStatus, output json.RawMessage, errMsg *string) error {
 	ret := _mock.Called(ctx, instanceID, status, output, errMsg)
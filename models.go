@@ -45,6 +45,14 @@ const (
 	StepStatusPaused          StepStatus = "paused"
 )
 
+type WorkerStatus string
+
+const (
+	WorkerStatusAlive   WorkerStatus = "alive"
+	WorkerStatusStopped WorkerStatus = "stopped"
+	WorkerStatusDead    WorkerStatus = "dead"
+)
+
 type StepType string
 
 const (
@@ -90,14 +98,24 @@ type WorkflowDefinition struct {
 	ID         string          `json:"id"`
 	Name       string          `json:"name"`
 	Version    int             `json:"version"`
+	Namespace  string          `json:"namespace"` // empty means the namespace of the saving context
 	Definition GraphDefinition `json:"definition"`
 	CreatedAt  time.Time       `json:"created_at"`
 }
 
 type GraphDefinition struct {
-	Steps      map[string]*StepDefinition `json:"steps"`
-	Start      string                     `json:"start"`
-	DLQEnabled bool                       `json:"dlq_enabled"`
+	Steps         map[string]*StepDefinition `json:"steps"`
+	Start         string                     `json:"start"`
+	DLQEnabled    bool                       `json:"dlq_enabled"`
+	RedrivePolicy *RedrivePolicy             `json:"redrive_policy,omitempty"`
+}
+
+// RedrivePolicy automatically requeues DLQ records of a workflow after a backoff.
+// The delay before the N-th redrive of a step is CalculateRetryDelay(Strategy, Backoff, N).
+type RedrivePolicy struct {
+	MaxRedrives int           `json:"max_redrives"`
+	Backoff     time.Duration `json:"backoff"`
+	Strategy    RetryStrategy `json:"strategy,omitempty"`
 }
 
 type StepDefinition struct {
@@ -119,19 +137,22 @@ type StepDefinition struct {
 	RetryDelay    time.Duration  `json:"retry_delay,omitempty"`
 	RetryStrategy RetryStrategy  `json:"retry_strategy,omitempty"` // Strategy for retry delays: fixed, exponential, linear
 	Timeout       time.Duration  `json:"timeout,omitempty"`
+	TaskQueue     string         `json:"task_queue,omitempty"` // queue of the step's items, defaults to the handler name
 }
 
 type WorkflowInstance struct {
-	ID          int64           `json:"id"`
-	WorkflowID  string          `json:"workflow_id"`
-	Status      WorkflowStatus  `json:"status"`
-	Input       json.RawMessage `json:"input"`
-	Output      json.RawMessage `json:"output"`
-	Error       *string         `json:"error"`
-	StartedAt   *time.Time      `json:"started_at"`
-	CompletedAt *time.Time      `json:"completed_at"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID          int64             `json:"id"`
+	WorkflowID  string            `json:"workflow_id"`
+	Namespace   string            `json:"namespace"` // namespace of the workflow definition
+	Status      WorkflowStatus    `json:"status"`
+	Input       json.RawMessage   `json:"input"`
+	Output      json.RawMessage   `json:"output"`
+	Error       *string           `json:"error"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	StartedAt   *time.Time        `json:"started_at"`
+	CompletedAt *time.Time        `json:"completed_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
 }
 
 type WorkflowStep struct {
@@ -160,11 +181,17 @@ type QueueItem struct {
 	AttemptedAt *time.Time `json:"attempted_at"`
 	AttemptedBy *string    `json:"attempted_by"`
 	Priority    int        `json:"priority"`
+	TaskQueue   string     `json:"task_queue,omitempty"`
+	Namespace   string     `json:"namespace,omitempty"`
+
+	LeaseToken     *string    `json:"lease_token,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
 }
 
 type WorkflowEvent struct {
 	ID         int64           `json:"id"`
 	InstanceID int64           `json:"instance_id"`
+	Namespace  string          `json:"namespace,omitempty"`
 	StepID     *int64          `json:"step_id"`
 	EventType  string          `json:"event_type"`
 	Payload    json.RawMessage `json:"payload"`
@@ -236,6 +263,7 @@ type DeadLetterRecord struct {
 	ID         int64           `json:"id"`
 	InstanceID int64           `json:"instance_id"`
 	WorkflowID string          `json:"workflow_id"`
+	Namespace  string          `json:"namespace"`
 	StepID     int64           `json:"step_id"`
 	StepName   string          `json:"step_name"`
 	StepType   string          `json:"step_type"`
@@ -243,4 +271,23 @@ type DeadLetterRecord struct {
 	Error      *string         `json:"error"`
 	Reason     string          `json:"reason"`
 	CreatedAt  time.Time       `json:"created_at"`
+
+	// Redrive state; NextRedriveAt is nil when the record is not scheduled for automatic requeue
+	RedriveCount  int        `json:"redrive_count"`
+	NextRedriveAt *time.Time `json:"next_redrive_at,omitempty"`
+}
+
+// WorkerRecord is the registry entry of a worker pool (see WorkerPool).
+// The attempted_by of the queue items claimed by the pool resolves to it with WorkerRecordID.
+type WorkerRecord struct {
+	ID              string       `json:"id"`
+	Host            string       `json:"host"`
+	Version         string       `json:"version"`
+	Handlers        []string     `json:"handlers"`
+	TaskQueues      []string     `json:"task_queues"`
+	Concurrency     int          `json:"concurrency"`
+	InFlight        int          `json:"in_flight"`
+	Status          WorkerStatus `json:"status"`
+	StartedAt       time.Time    `json:"started_at"`
+	LastHeartbeatAt time.Time    `json:"last_heartbeat_at"`
 }
@@ -2,6 +2,7 @@ package floxy
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -144,21 +145,58 @@ func (m *MonitorImpl) GetQueueLength(ctx context.Context) (int, error) {
 }
 
 type CleanupService struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	archiver *Archiver
+	clock    Clock
+}
+
+type CleanupOption func(c *CleanupService)
+
+// WithCleanupArchiver makes CleanupOldWorkflows archive the instances it is about to delete and
+// skip the deletion when archiving fails.
+func WithCleanupArchiver(archiver *Archiver) CleanupOption {
+	return func(c *CleanupService) {
+		c.archiver = archiver
+	}
+}
+
+// WithCleanupClock sets the clock the archive cutoff of CleanupOldWorkflows is measured on. The
+// deletion itself runs on the database time.
+func WithCleanupClock(clock Clock) CleanupOption {
+	return func(c *CleanupService) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
 }
 
-func NewCleanupService(pool *pgxpool.Pool) *CleanupService {
-	return &CleanupService{pool: pool}
+func NewCleanupService(pool *pgxpool.Pool, opts ...CleanupOption) *CleanupService {
+	c := &CleanupService{pool: pool, clock: SystemClock}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
+// CleanupOldWorkflows deletes the terminal instances completed before olderThan, rounded up to whole
+// days, with the workflows.cleanup_old_workflows SQL function and returns their number.
 func (c *CleanupService) CleanupOldWorkflows(ctx context.Context, olderThan time.Duration) (int64, error) {
-	const query = `SELECT FROM workflows.cleanup_old_workflows($1)`
+	const query = `SELECT deleted_count FROM workflows.cleanup_old_workflows($1)`
+
+	daysToKeep := int((olderThan + 24*time.Hour - 1) / (24 * time.Hour))
+	if c.archiver != nil {
+		// A superset of what the function deletes by completed_at, see ArchiveCreatedBefore
+		cutoffTime := c.clock.Now().Add(-time.Duration(daysToKeep) * 24 * time.Hour)
+		if _, err := c.archiver.ArchiveCreatedBefore(ctx, cutoffTime); err != nil {
+			return 0, fmt.Errorf("archive: %w", err)
+		}
+	}
 
-	cutoffTime := time.Now().Add(-olderThan)
-	result, err := c.pool.Exec(ctx, query, cutoffTime)
-	if err != nil {
+	var deleted int64
+	if err := c.pool.QueryRow(ctx, query, daysToKeep).Scan(&deleted); err != nil {
 		return 0, err
 	}
 
-	return result.RowsAffected(), nil
+	return deleted, nil
 }
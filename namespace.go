@@ -0,0 +1,152 @@
+package floxy
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultNamespace holds the workflows registered without a namespace and all data created
+// before namespaces existed.
+const DefaultNamespace = "default"
+
+type namespaceKey struct{}
+
+// WithNamespace scopes the store calls made with the returned context to a namespace: workflow
+// definitions, instances, queue items, events and DLQ records of other namespaces are invisible to
+// them, and definitions saved with it belong to the namespace. A context without a namespace sees
+// every namespace and saves definitions to DefaultNamespace.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace set with WithNamespace, if any.
+func NamespaceFromContext(ctx context.Context) (string, bool) {
+	namespace, ok := ctx.Value(namespaceKey{}).(string)
+
+	return namespace, ok && namespace != ""
+}
+
+// namespaceOf returns the namespace new workflow definitions of the context belong to.
+func namespaceOf(ctx context.Context) string {
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		return namespace
+	}
+
+	return DefaultNamespace
+}
+
+// namespaceFilter returns the namespace reads of the context are restricted to, nil when unscoped.
+func namespaceFilter(ctx context.Context) *string {
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		return &namespace
+	}
+
+	return nil
+}
+
+// visibleIn reports whether an entity of the given namespace is visible to the context.
+func visibleIn(ctx context.Context, namespace string) bool {
+	scope, ok := NamespaceFromContext(ctx)
+
+	return !ok || scope == namespace
+}
+
+// isRunningStatus reports whether an instance in the status counts against the running instance
+// limit of its namespace.
+func isRunningStatus(status WorkflowStatus) bool {
+	switch status {
+	case StatusPending, StatusRunning, StatusRollingBack, StatusCancelling:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithEngineNamespace binds the engine to a namespace: workflows it registers belong to the
+// namespace, and its Start, worker and management calls see only the namespace's data, whatever
+// namespace their contexts carry.
+func WithEngineNamespace(namespace string) EngineOption {
+	return func(engine *Engine) {
+		engine.namespace = namespace
+	}
+}
+
+// WithNamespaceMaxRunning limits the pending, running, rolling back and cancelling instances of a
+// namespace: Start fails with ErrNamespaceLimitExceeded once the namespace has n of them.
+// The limit applies to the starts of this engine; n <= 0 removes it.
+func WithNamespaceMaxRunning(namespace string, n int) EngineOption {
+	return func(engine *Engine) {
+		if n <= 0 {
+			delete(engine.namespaceLimits, namespace)
+
+			return
+		}
+		engine.namespaceLimits[namespace] = n
+	}
+}
+
+// Namespace returns the namespace the engine is bound to, "" when it is not bound.
+func (engine *Engine) Namespace() string {
+	return engine.namespace
+}
+
+// scope applies the engine namespace to a context of an exported entry point.
+func (engine *Engine) scope(ctx context.Context) context.Context {
+	if engine.namespace == "" {
+		return ctx
+	}
+
+	return WithNamespace(ctx, engine.namespace)
+}
+
+// checkNamespaceLimit fails when the namespace already has its maximum of running instances.
+// It runs in the transaction creating the instance, which the store serializes per namespace.
+func (engine *Engine) checkNamespaceLimit(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	limit, ok := engine.namespaceLimits[namespace]
+	if !ok {
+		return nil
+	}
+
+	running, err := engine.store.CountRunningInstances(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("count running instances: %w", err)
+	}
+
+	if running >= limit {
+		return fmt.Errorf("%w: %s has %d of %d", ErrNamespaceLimitExceeded, namespace, running, limit)
+	}
+
+	return nil
+}
+
+// checkInstanceNamespace returns ErrEntityNotFound when the instance is outside the namespace of a
+// scoped context. The stores do not scope steps, join states and cancel requests by themselves.
+func (engine *Engine) checkInstanceNamespace(ctx context.Context, instanceID int64) error {
+	if _, ok := NamespaceFromContext(ctx); !ok {
+		return nil
+	}
+
+	_, err := engine.store.GetInstance(ctx, instanceID)
+
+	return err
+}
+
+// WithWorkerNamespace restricts the worker to queue items of a namespace. It has no effect with an
+// engine bound by WithEngineNamespace, which claims only the items of its own namespace.
+func WithWorkerNamespace(namespace string) WorkerOption {
+	return func(worker *Worker) {
+		worker.namespace = namespace
+	}
+}
+
+// WithPoolNamespace restricts the pool workers, dispatcher and autoscaler to the queue items of a
+// namespace (see WithWorkerNamespace).
+func WithPoolNamespace(namespace string) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		pool.namespace = namespace
+	}
+}
@@ -0,0 +1,220 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNamespaces checks that definitions, instances and queue items are only visible in their
+// namespace while an unscoped context sees all of them.
+func testNamespaces(t *testing.T, store Store) {
+	ctx := context.Background()
+	teamA := WithNamespace(ctx, "team-a")
+	teamB := WithNamespace(ctx, "team-b")
+
+	emptyGraph := GraphDefinition{Start: "a", Steps: map[string]*StepDefinition{}}
+	require.NoError(t, store.SaveWorkflowDefinition(teamA, &WorkflowDefinition{
+		ID: "billing-v1", Name: "billing", Version: 1, Definition: emptyGraph,
+	}))
+	require.NoError(t, store.SaveWorkflowDefinition(teamB, &WorkflowDefinition{
+		ID: "shipping-v1", Name: "shipping", Version: 1, Definition: emptyGraph,
+	}))
+
+	err := store.SaveWorkflowDefinition(teamB, &WorkflowDefinition{
+		ID: "billing-v1", Name: "billing", Version: 1, Definition: emptyGraph,
+	})
+	assert.ErrorIs(t, err, ErrNamespaceMismatch)
+
+	def, err := store.GetWorkflowDefinition(ctx, "billing-v1")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", def.Namespace)
+	_, err = store.GetWorkflowDefinition(teamB, "billing-v1")
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+
+	_, err = store.CreateInstance(teamB, "billing-v1", json.RawMessage(`{}`))
+	assert.Error(t, err)
+
+	instanceA, err := store.CreateInstance(teamA, "billing-v1", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", instanceA.Namespace)
+	instanceB, err := store.CreateInstance(ctx, "shipping-v1", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, "team-b", instanceB.Namespace)
+
+	_, err = store.GetInstance(teamB, instanceA.ID)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+	got, err := store.GetInstance(ctx, instanceA.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", got.Namespace)
+
+	running, err := store.CountRunningInstances(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, running)
+	require.NoError(t, store.UpdateInstanceStatus(ctx, instanceA.ID, StatusCompleted, nil, nil))
+	running, err = store.CountRunningInstances(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, 0, running)
+
+	require.NoError(t, store.EnqueueStep(ctx, instanceA.ID, nil, DefaultTaskQueue, PriorityNormal, 0))
+	require.NoError(t, store.EnqueueStep(ctx, instanceB.ID, nil, DefaultTaskQueue, PriorityNormal, 0))
+
+	length, err := store.GetQueueLength(teamB, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, length)
+	length, err = store.GetQueueLength(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	item, err := store.DequeueStep(teamB, "worker-b", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, instanceB.ID, item.InstanceID)
+	assert.Equal(t, "team-b", item.Namespace)
+
+	item, err = store.DequeueStep(teamB, "worker-b", nil)
+	require.NoError(t, err)
+	assert.Nil(t, item)
+
+	item, err = store.DequeueStep(ctx, "worker-admin", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, instanceA.ID, item.InstanceID)
+	assert.Equal(t, "team-a", item.Namespace)
+}
+
+func TestNamespaces_MemoryStore(t *testing.T) {
+	testNamespaces(t, NewMemoryStore())
+}
+
+func TestIntegration_Namespaces(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testNamespaces(t, store)
+}
+
+// testNamespaceDefinitionNames checks that definition names and versions are unique per
+// namespace while definition IDs are unique across namespaces.
+func testNamespaceDefinitionNames(t *testing.T, store Store) {
+	ctx := context.Background()
+	teamA := WithNamespace(ctx, "team-a")
+	teamB := WithNamespace(ctx, "team-b")
+
+	emptyGraph := GraphDefinition{Start: "a", Steps: map[string]*StepDefinition{}}
+	require.NoError(t, store.SaveWorkflowDefinition(teamA, &WorkflowDefinition{
+		ID: "team-a-orders-v1", Name: "orders", Version: 1, Definition: emptyGraph,
+	}))
+	require.NoError(t, store.SaveWorkflowDefinition(teamB, &WorkflowDefinition{
+		ID: "team-b-orders-v1", Name: "orders", Version: 1, Definition: emptyGraph,
+	}))
+
+	// The same name and version in the same namespace updates the existing definition
+	updated := &WorkflowDefinition{
+		ID: "orders-v1", Name: "orders", Version: 1,
+		Definition: GraphDefinition{Start: "b", Steps: map[string]*StepDefinition{}},
+	}
+	require.NoError(t, store.SaveWorkflowDefinition(teamB, updated))
+	assert.Equal(t, "team-b-orders-v1", updated.ID)
+
+	def, err := store.GetWorkflowDefinition(teamB, "team-b-orders-v1")
+	require.NoError(t, err)
+	assert.Equal(t, "b", def.Definition.Start)
+	def, err = store.GetWorkflowDefinition(teamA, "team-a-orders-v1")
+	require.NoError(t, err)
+	assert.Equal(t, "a", def.Definition.Start)
+
+	err = store.SaveWorkflowDefinition(teamA, &WorkflowDefinition{
+		ID: "team-b-orders-v1", Name: "orders", Version: 2, Definition: emptyGraph,
+	})
+	assert.ErrorIs(t, err, ErrNamespaceMismatch)
+}
+
+func TestNamespaceDefinitionNames_MemoryStore(t *testing.T) {
+	testNamespaceDefinitionNames(t, NewMemoryStore())
+}
+
+func TestIntegration_NamespaceDefinitionNames(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testNamespaceDefinitionNames(t, store)
+}
+
+func TestEngine_NamespaceMaxRunning(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+		WithNamespaceMaxRunning("team-a", 1),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+	engine.RegisterHandler(&countingHandler{})
+
+	workflowID := registerChainWorkflow(t, WithNamespace(ctx, "team-a"), engine, 1)
+
+	_, err := engine.Start(ctx, workflowID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	_, err = engine.Start(ctx, workflowID, json.RawMessage(`{}`))
+	assert.ErrorIs(t, err, ErrNamespaceLimitExceeded)
+
+	bound := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+		WithEngineNamespace("team-b"),
+	)
+	t.Cleanup(func() { _ = bound.Shutdown() })
+
+	_, err = bound.Start(ctx, workflowID, json.RawMessage(`{}`))
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
+
+func TestWorkerPool_DispatcherStaysInEngineNamespace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	store := NewMemoryStore()
+
+	other := newMemoryTestEngine(t, store, nil, WithEngineNamespace("team-b"))
+	otherDef, err := NewBuilder("team_b_chain", 1).Step("step-0", "counting").Build()
+	require.NoError(t, err)
+	require.NoError(t, other.RegisterWorkflow(ctx, otherDef))
+	otherID, err := other.Start(ctx, otherDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	handler := &countingHandler{}
+	engine := newMemoryTestEngine(t, store, []StepHandler{handler}, WithEngineNamespace("team-a"))
+	workflowID := registerChainWorkflow(t, ctx, engine, 1)
+
+	pool := NewWorkerPool(engine, 2, 10*time.Millisecond, WithDispatcher(4), WithAutoscaling(1, 2))
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	result, err := engine.StartAwait(ctx, workflowID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, result.Status)
+	assert.Equal(t, int64(1), handler.calls.Load())
+
+	steps, err := other.GetSteps(ctx, otherID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, StepStatusPending, steps[0].Status)
+
+	depth, err := store.GetQueueLength(WithNamespace(ctx, "team-b"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, depth)
+}
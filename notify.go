@@ -0,0 +1,155 @@
+package floxy
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// NotifyChannelQueue is signalled when a step is enqueued. The payload is the
+	// scheduled time in Unix milliseconds or empty when the queue should be polled now.
+	NotifyChannelQueue = "floxy_queue"
+	// NotifyChannelInstance is signalled when an instance status changes. The payload is the instance ID.
+	NotifyChannelInstance = "floxy_instance"
+	// NotifyChannelDefinition is signalled when a workflow definition is saved. The payload is the definition ID,
+	// an empty payload invalidates all definitions.
+	NotifyChannelDefinition = "floxy_definition"
+
+	defaultNotifyFallbackInterval = 5 * time.Second
+	notifySubscriberBuffer        = 16
+	pgListenReconnectDelay        = time.Second
+)
+
+// Notifier pushes queue and instance status changes to waiting workers and awaiters,
+// so they do not have to poll the store.
+type Notifier interface {
+	// Subscribe returns a channel receiving payloads of the given notify channel and a function
+	// releasing the subscription. Payloads are dropped for subscribers that fall behind.
+	Subscribe(channel string) (<-chan string, func())
+}
+
+// notificationHub fans notifications out to in-process subscribers.
+type notificationHub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan string]struct{}
+}
+
+func newNotificationHub() *notificationHub {
+	return &notificationHub{subs: make(map[string]map[chan string]struct{})}
+}
+
+func (h *notificationHub) Subscribe(channel string) (<-chan string, func()) {
+	ch := make(chan string, notifySubscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[channel] == nil {
+		h.subs[channel] = make(map[chan string]struct{})
+	}
+	h.subs[channel][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs[channel], ch)
+			h.mu.Unlock()
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *notificationHub) publish(channel, payload string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[channel] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+// queueNotifyPayload rounds up to whole milliseconds, so a waiter never wakes before the step is due.
+func queueNotifyPayload(scheduledAt time.Time) string {
+	return strconv.FormatInt(scheduledAt.Add(time.Millisecond-1).UnixMilli(), 10)
+}
+
+// parseQueueNotifyPayload returns the scheduled time of a queue notification,
+// or the zero time when the queue should be polled immediately.
+func parseQueueNotifyPayload(payload string) time.Time {
+	ms, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(ms)
+}
+
+// pgNotifier listens on a dedicated pool connection and republishes
+// Postgres notifications to in-process subscribers.
+type pgNotifier struct {
+	*notificationHub
+	pool *pgxpool.Pool
+}
+
+func newPGNotifier(pool *pgxpool.Pool) *pgNotifier {
+	return &pgNotifier{
+		notificationHub: newNotificationHub(),
+		pool:            pool,
+	}
+}
+
+// run keeps a LISTEN connection open until ctx is done, reconnecting on errors.
+func (n *pgNotifier) run(ctx context.Context) {
+	for {
+		err := n.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.Warn("[floxy] notification listener disconnected", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pgListenReconnectDelay):
+		}
+	}
+}
+
+func (n *pgNotifier) listen(ctx context.Context) error {
+	poolConn, err := n.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A listening connection must not be reused by the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	for _, channel := range []string{NotifyChannelQueue, NotifyChannelInstance, NotifyChannelDefinition} {
+		if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+			return err
+		}
+	}
+
+	// Notifications may have been missed while disconnected
+	n.publish(NotifyChannelQueue, "")
+	n.publish(NotifyChannelDefinition, "")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		n.publish(notification.Channel, notification.Payload)
+	}
+}
@@ -0,0 +1,91 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationHub(t *testing.T) {
+	hub := newNotificationHub()
+
+	queue, unsubscribeQueue := hub.Subscribe(NotifyChannelQueue)
+	instance, unsubscribeInstance := hub.Subscribe(NotifyChannelInstance)
+	defer unsubscribeInstance()
+
+	hub.publish(NotifyChannelQueue, "1")
+	assert.Equal(t, "1", <-queue)
+	assert.Empty(t, instance)
+
+	// A subscriber that falls behind loses payloads instead of blocking the publisher
+	for i := 0; i < notifySubscriberBuffer*2; i++ {
+		hub.publish(NotifyChannelQueue, "")
+	}
+	assert.Len(t, queue, notifySubscriberBuffer)
+
+	unsubscribeQueue()
+	unsubscribeQueue()
+	hub.publish(NotifyChannelInstance, "42")
+	assert.Equal(t, "42", <-instance)
+}
+
+func TestQueueNotifyPayload(t *testing.T) {
+	at := time.UnixMilli(time.Now().Add(time.Minute).UnixMilli())
+	assert.True(t, at.Equal(parseQueueNotifyPayload(queueNotifyPayload(at))))
+	assert.True(t, parseQueueNotifyPayload("").IsZero())
+}
+
+// testNotifyWakeup runs a workflow with polling intervals far beyond the test timeout,
+// so it only completes if workers and StartAwait are woken up by notifications.
+func testNotifyWakeup(t *testing.T, store Store, txManager TxManager) {
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(txManager),
+		WithEngineAwaitPollInterval(time.Hour),
+		WithNotifyFallbackInterval(time.Hour),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+	require.NotNil(t, engine.notifier)
+
+	engine.RegisterHandler(&SimpleTestHandler{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	workflowDef, err := NewBuilder("notify_wakeup", 1).
+		Step("first", "simple-test").
+		Then("delayed", "simple-test", WithStepDelay(100*time.Millisecond)).
+		Then("last", "simple-test").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	pool := NewWorkerPool(engine, 2, time.Hour)
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	startedAt := time.Now()
+	result, err := engine.StartAwait(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, result.Status)
+	assert.Less(t, time.Since(startedAt), 5*time.Second)
+}
+
+func TestNotifyWakeup_MemoryStore(t *testing.T) {
+	testNotifyWakeup(t, NewMemoryStore(), NewMemoryTxManager())
+}
+
+func TestIntegration_NotifyWakeup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, txManager, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testNotifyWakeup(t, store, txManager)
+}
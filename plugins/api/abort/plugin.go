@@ -29,7 +29,7 @@ func (p *Plugin) Name() string { return "abort" }
 
 func (p *Plugin) Description() string { return "Abort a workflow instance" }
 
-func (p *Plugin) RegisterRoutes(mux *http.ServeMux) {
+func (p *Plugin) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc(
 		"POST /api/instances/{instance_id}/abort",
 		HandleAbortWorkflow(p.engine, p.extractUserFn),
@@ -50,7 +50,7 @@ func HandleAbortWorkflow(
 			return
 		}
 
-		user, err := extractUserFn(r)
+		user, err := api.RequestUser(r, extractUserFn)
 		if err != nil {
 			if errors.Is(err, floxy.ErrEntityNotFound) {
 				api.WriteErrorResponse(w, err, http.StatusNotFound)
@@ -0,0 +1,176 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	floxy "github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+)
+
+var _ api.Plugin = (*Plugin)(nil)
+
+// Plugin serves the bulk operation routes. Jobs are kept in the memory of the engine that started
+// them, so with several replicas a job is only found, and can only be cancelled, on its own replica.
+type Plugin struct {
+	engine        floxy.IEngine
+	extractUserFn ExtractUserFn
+}
+
+func New(engine floxy.IEngine, extractUserFn ExtractUserFn) *Plugin {
+	return &Plugin{
+		engine:        engine,
+		extractUserFn: extractUserFn,
+	}
+}
+
+func (p *Plugin) Name() string { return "bulk" }
+
+func (p *Plugin) Description() string {
+	return "Bulk cancel, abort, requeue and retry of workflow instances"
+}
+
+func (p *Plugin) RegisterRoutes(mux api.Router) {
+	mux.HandleFunc("POST /api/bulk", HandleStartBulkOperation(p.engine, p.extractUserFn))
+	mux.HandleFunc("GET /api/bulk/{job_id}", HandleGetBulkOperation(p.engine))
+	mux.HandleFunc("POST /api/bulk/{job_id}/cancel", HandleCancelBulkOperation(p.engine))
+}
+
+func HandleStartBulkOperation(
+	engine floxy.IEngine,
+	extractUserFn ExtractUserFn,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		user, err := api.RequestUser(r, extractUserFn)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+
+				return
+			}
+
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		var bulkReq BulkRequest
+		if err := json.NewDecoder(r.Body).Decode(&bulkReq); err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		switch bulkReq.Action {
+		case floxy.BulkActionCancel, floxy.BulkActionAbort:
+			if bulkReq.Reason == "" {
+				api.WriteErrorResponse(w, errors.New("reason is required"), http.StatusBadRequest)
+
+				return
+			}
+		case floxy.BulkActionRequeue, floxy.BulkActionRetry:
+		default:
+			err = fmt.Errorf("unsupported action: %q", bulkReq.Action)
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		if err := floxy.ValidateBulkRate(bulkReq.RatePerSecond); err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		if len(bulkReq.InstanceIDs) == 0 && bulkReq.Filter == "" {
+			api.WriteErrorResponse(w, errors.New("instance_ids or filter is required"), http.StatusBadRequest)
+
+			return
+		}
+
+		selector := floxy.BulkSelector{InstanceIDs: bulkReq.InstanceIDs}
+		if bulkReq.Filter != "" {
+			values, err := url.ParseQuery(bulkReq.Filter)
+			if err != nil {
+				api.WriteErrorResponse(w, fmt.Errorf("invalid filter: %w", err), http.StatusBadRequest)
+
+				return
+			}
+
+			query, err := api.ParseInstanceQuery(values)
+			if err != nil {
+				api.WriteErrorResponse(w, fmt.Errorf("invalid filter: %w", err), http.StatusBadRequest)
+
+				return
+			}
+			selector.Query = &query
+		}
+
+		job, err := engine.StartBulkOperation(ctx, floxy.BulkRequest{
+			Action:        bulkReq.Action,
+			Selector:      selector,
+			RequestedBy:   user,
+			Reason:        bulkReq.Reason,
+			RatePerSecond: bulkReq.RatePerSecond,
+		})
+		if err != nil {
+			if errors.Is(err, floxy.ErrEmptyBulkSelector) || errors.Is(err, floxy.ErrInvalidBulkRate) {
+				api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+				return
+			}
+
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(job)
+	}
+}
+
+func HandleGetBulkOperation(engine floxy.IEngine) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job, err := engine.GetBulkOperation(r.Context(), r.PathValue("job_id"))
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+
+				return
+			}
+
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(job)
+	}
+}
+
+func HandleCancelBulkOperation(engine floxy.IEngine) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := engine.CancelBulkOperation(r.Context(), r.PathValue("job_id")); err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+
+				return
+			}
+
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,159 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	floxy "github.com/rom8726/floxy-pro"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testExtractUser(r *http.Request) (string, error) {
+	return "test-user", nil
+}
+
+func newStartRequest(t *testing.T, body any) *http.Request {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/bulk", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req.WithContext(context.Background())
+}
+
+func TestHandleStartBulkOperation_WithFilter(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	job := &floxy.BulkJob{ID: "job-1", Action: floxy.BulkActionCancel, Status: floxy.BulkJobStatusRunning, Total: 2}
+
+	mockEngine.On("StartBulkOperation", mock.Anything, mock.MatchedBy(func(req floxy.BulkRequest) bool {
+		return req.Action == floxy.BulkActionCancel &&
+			req.RequestedBy == "test-user" &&
+			req.Reason == "outage" &&
+			req.RatePerSecond == 10 &&
+			assert.ObjectsAreEqual([]int64{7}, req.Selector.InstanceIDs) &&
+			req.Selector.Query != nil &&
+			assert.ObjectsAreEqual([]floxy.WorkflowStatus{floxy.StatusRunning}, req.Selector.Query.Statuses) &&
+			req.Selector.Query.Labels["customer_id"] == "42"
+	})).Return(job, nil)
+
+	req := newStartRequest(t, BulkRequest{
+		Action:        floxy.BulkActionCancel,
+		InstanceIDs:   []int64{7},
+		Filter:        "status=running&label=customer_id:42",
+		Reason:        "outage",
+		RatePerSecond: 10,
+	})
+	w := httptest.NewRecorder()
+
+	HandleStartBulkOperation(mockEngine, testExtractUser)(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var resp floxy.BulkJob
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "job-1", resp.ID)
+	assert.Equal(t, 2, resp.Total)
+}
+
+func TestHandleStartBulkOperation_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		body BulkRequest
+	}{
+		{"unknown action", BulkRequest{Action: "delete", InstanceIDs: []int64{1}}},
+		{"missing reason", BulkRequest{Action: floxy.BulkActionAbort, InstanceIDs: []int64{1}}},
+		{"missing selector", BulkRequest{Action: floxy.BulkActionRetry}},
+		{"invalid filter", BulkRequest{Action: floxy.BulkActionRetry, Filter: "label=broken"}},
+		{"negative rate", BulkRequest{Action: floxy.BulkActionRetry, InstanceIDs: []int64{1}, RatePerSecond: -1}},
+		{"rate above maximum", BulkRequest{Action: floxy.BulkActionRetry, InstanceIDs: []int64{1}, RatePerSecond: 2e9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEngine := floxy.NewMockIEngine(t)
+
+			w := httptest.NewRecorder()
+			HandleStartBulkOperation(mockEngine, testExtractUser)(w, newStartRequest(t, tt.body))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestHandleStartBulkOperation_EmptySelection(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.On("StartBulkOperation", mock.Anything, mock.Anything).
+		Return(nil, floxy.ErrEmptyBulkSelector)
+
+	w := httptest.NewRecorder()
+	HandleStartBulkOperation(mockEngine, testExtractUser)(w, newStartRequest(t, BulkRequest{
+		Action: floxy.BulkActionRequeue,
+		Filter: "status=dlq",
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleGetBulkOperation(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	job := &floxy.BulkJob{
+		ID:        "job-1",
+		Status:    floxy.BulkJobStatusCompleted,
+		Total:     1,
+		Processed: 1,
+		Failed:    1,
+		Items: []floxy.BulkItemResult{
+			{InstanceID: 5, Status: floxy.BulkItemStatusFailed, Error: "already in terminal state"},
+		},
+	}
+	mockEngine.On("GetBulkOperation", mock.Anything, "job-1").Return(job, nil)
+	mockEngine.On("GetBulkOperation", mock.Anything, "missing").Return(nil, floxy.ErrEntityNotFound)
+
+	req := httptest.NewRequest("GET", "/api/bulk/job-1", nil)
+	req.SetPathValue("job_id", "job-1")
+	w := httptest.NewRecorder()
+
+	HandleGetBulkOperation(mockEngine)(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp floxy.BulkJob
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, int64(5), resp.Items[0].InstanceID)
+
+	req = httptest.NewRequest("GET", "/api/bulk/missing", nil)
+	req.SetPathValue("job_id", "missing")
+	w = httptest.NewRecorder()
+
+	HandleGetBulkOperation(mockEngine)(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleCancelBulkOperation(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.On("CancelBulkOperation", mock.Anything, "job-1").Return(nil)
+
+	req := httptest.NewRequest("POST", "/api/bulk/job-1/cancel", nil)
+	req.SetPathValue("job_id", "job-1")
+	w := httptest.NewRecorder()
+
+	HandleCancelBulkOperation(mockEngine)(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
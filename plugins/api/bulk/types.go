@@ -0,0 +1,19 @@
+package bulk
+
+import (
+	"net/http"
+
+	floxy "github.com/rom8726/floxy-pro"
+)
+
+type ExtractUserFn func(req *http.Request) (string, error)
+
+// BulkRequest selects instances by IDs and/or a filter. Filter uses the same
+// query string syntax as GET /api/instances, e.g. "status=failed&label=customer_id:42".
+type BulkRequest struct {
+	Action        floxy.BulkAction `json:"action"`
+	InstanceIDs   []int64          `json:"instance_ids,omitempty"`
+	Filter        string           `json:"filter,omitempty"`
+	Reason        string           `json:"reason,omitempty"`
+	RatePerSecond float64          `json:"rate_per_second,omitempty"`
+}
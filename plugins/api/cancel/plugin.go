@@ -29,7 +29,7 @@ func (p *Plugin) Name() string { return "cancel" }
 
 func (p *Plugin) Description() string { return "Cancel a workflow instance" }
 
-func (p *Plugin) RegisterRoutes(mux *http.ServeMux) {
+func (p *Plugin) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc(
 		"POST /api/instances/{instance_id}/cancel",
 		HandleCancelWorkflow(p.engine, p.extractUserFn),
@@ -51,7 +51,7 @@ func HandleCancelWorkflow(
 			return
 		}
 
-		user, err := extractUserFn(r)
+		user, err := api.RequestUser(r, extractUserFn)
 		if err != nil {
 			if errors.Is(err, floxy.ErrEntityNotFound) {
 				api.WriteErrorResponse(w, err, http.StatusNotFound)
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	floxy "github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -44,6 +45,26 @@ func TestHandleCancelWorkflow_Success(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, w.Code)
 }
 
+func TestHandleCancelWorkflow_AuthenticatedPrincipal(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.On("CancelWorkflow", mock.Anything, int64(123), "alice", "duplicate").
+		Return(nil)
+
+	jsonBody, _ := json.Marshal(CancelRequest{Reason: "duplicate"})
+	req := httptest.NewRequest("POST", "/api/instances/123/cancel", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(api.WithPrincipal(context.Background(), &api.Principal{Subject: "alice"}))
+	req.SetPathValue("instance_id", "123")
+
+	w := httptest.NewRecorder()
+
+	// The principal of the server auth takes precedence, no ExtractUserFn is needed
+	handler := HandleCancelWorkflow(mockEngine, nil)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
 func TestHandleCancelWorkflow_InvalidInstanceID(t *testing.T) {
 	mockEngine := floxy.NewMockIEngine(t)
 
@@ -1,6 +1,8 @@
 package cleanup
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	floxy "github.com/rom8726/floxy-pro"
@@ -10,31 +12,62 @@ import (
 var _ api.Plugin = (*Plugin)(nil)
 
 type Plugin struct {
-	store floxy.Store
+	store    floxy.Store
+	archiver *floxy.Archiver
 }
 
-func New(store floxy.Store) *Plugin {
-	return &Plugin{
+type Option func(p *Plugin)
+
+// WithArchiver archives finished instances before the cleanup deletes them.
+// The cleanup is skipped when archiving fails.
+func WithArchiver(archiver *floxy.Archiver) Option {
+	return func(p *Plugin) {
+		p.archiver = archiver
+	}
+}
+
+func New(store floxy.Store, opts ...Option) *Plugin {
+	p := &Plugin{
 		store: store,
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
 func (p *Plugin) Name() string { return "cleanup" }
 
 func (p *Plugin) Description() string { return "Clean up old completed workflow instances" }
 
-func (p *Plugin) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("POST /api/cleanup", HandleCleanupWorkflows(p.store))
+func (p *Plugin) RegisterRoutes(mux api.Router) {
+	mux.HandleFunc("POST /api/cleanup", HandleCleanupWorkflows(p.store, p.archiver))
 }
 
 func HandleCleanupWorkflows(
 	store floxy.Store,
+	archiver *floxy.Archiver,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		// Call the cleanup function
-		err := store.CleanupOldWorkflows(ctx)
+		// Retention is cluster-wide, a namespaced caller must not purge other tenants
+		if namespace, ok := floxy.NamespaceFromContext(ctx); ok {
+			api.WriteErrorResponse(w, fmt.Errorf("cleanup is not allowed for namespace %q", namespace), http.StatusForbidden)
+
+			return
+		}
+
+		var resp CleanupResponse
+		var err error
+		if archiver != nil {
+			resp.Archived, err = archiver.ArchiveAndCleanup(ctx)
+		} else {
+			// Call the cleanup function
+			err = store.CleanupOldWorkflows(ctx)
+		}
 		if err != nil {
 			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
 
@@ -43,6 +76,6 @@ func HandleCleanupWorkflows(
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("{}"))
+		_ = json.NewEncoder(w).Encode(resp)
 	}
 }
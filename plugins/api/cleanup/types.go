@@ -5,3 +5,7 @@ import (
 )
 
 type ExtractUserFn func(req *http.Request) (string, error)
+
+type CleanupResponse struct {
+	Archived int `json:"archived"`
+}
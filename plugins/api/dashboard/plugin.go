@@ -0,0 +1,161 @@
+package dashboard
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"sort"
+
+	floxy "github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+)
+
+var _ api.Plugin = (*Plugin)(nil)
+
+// DefaultPath is the path the dashboard is served under.
+const DefaultPath = "/dashboard/"
+
+//go:embed static
+var staticFiles embed.FS
+
+// Plugin serves a single-page dashboard for the api.Server routes. It reads the core routes and
+// calls the routes of the cancel, abort, human-decision and dlq plugins for its actions, so those
+// should be registered on the same server.
+type Plugin struct {
+	store floxy.Store
+	path  string
+}
+
+type Option func(p *Plugin)
+
+// WithPath serves the dashboard under path instead of DefaultPath.
+func WithPath(path string) Option {
+	return func(p *Plugin) {
+		if path == "" {
+			return
+		}
+		if path[len(path)-1] != '/' {
+			path += "/"
+		}
+		p.path = path
+	}
+}
+
+func New(store floxy.Store, opts ...Option) *Plugin {
+	p := &Plugin{
+		store: store,
+		path:  DefaultPath,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *Plugin) Name() string { return "dashboard" }
+
+func (p *Plugin) Description() string {
+	return "Web dashboard for workflows, instances, approvals and DLQ"
+}
+
+func (p *Plugin) RegisterRoutes(mux api.Router) {
+	mux.HandleFunc("GET /api/dashboard/approvals", HandleListApprovals(p.store))
+	mux.Handle("GET "+p.path, HandleStatic(p.path))
+}
+
+// HandleStatic serves the embedded dashboard files under path. Unknown paths get index.html.
+func HandleStatic(path string) http.Handler {
+	files, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(err)
+	}
+	fileServer := http.StripPrefix(path, http.FileServer(http.FS(files)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Path[len(path):]
+		if name == "" || name == "index.html" {
+			serveIndex(w, files)
+
+			return
+		}
+
+		if _, err := fs.Stat(files, name); err != nil {
+			serveIndex(w, files)
+
+			return
+		}
+
+		fileServer.ServeHTTP(w, r)
+	})
+}
+
+func serveIndex(w http.ResponseWriter, files fs.FS) {
+	index, err := fs.ReadFile(files, "index.html")
+	if err != nil {
+		api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(index)
+}
+
+// HandleListApprovals lists the human steps of active instances that wait for a decision.
+func HandleListApprovals(store floxy.Store) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		instances, err := store.GetActiveInstances(ctx)
+		if err != nil {
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		resp := ApprovalsResponse{Items: make([]Approval, 0)}
+		for _, instance := range instances {
+			step, err := store.GetHumanDecisionStepByInstanceID(ctx, instance.ID)
+			if err != nil {
+				if errors.Is(err, floxy.ErrEntityNotFound) {
+					continue
+				}
+
+				api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+				return
+			}
+
+			if step.Status != floxy.StepStatusWaitingDecision {
+				continue
+			}
+
+			waitingAt := step.CreatedAt
+			if step.StartedAt != nil {
+				waitingAt = *step.StartedAt
+			}
+
+			resp.Items = append(resp.Items, Approval{
+				InstanceID: instance.ID,
+				WorkflowID: instance.WorkflowID,
+				StepID:     step.ID,
+				StepName:   step.StepName,
+				Input:      step.Input,
+				WaitingAt:  waitingAt,
+			})
+		}
+
+		sort.Slice(resp.Items, func(i, j int) bool {
+			return resp.Items[i].WaitingAt.Before(resp.Items[j].WaitingAt)
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
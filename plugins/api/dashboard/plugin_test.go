@@ -0,0 +1,115 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	floxy "github.com/rom8726/floxy-pro"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleStatic(t *testing.T) {
+	mux := http.NewServeMux()
+	New(nil).RegisterRoutes(mux)
+
+	tests := []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{path: "/dashboard/", contentType: "text/html", contains: `<main id="view">`},
+		{path: "/dashboard/app.js", contentType: "javascript", contains: "function route()"},
+		{path: "/dashboard/style.css", contentType: "text/css", contains: "#toast"},
+		{path: "/dashboard/instances/5", contentType: "text/html", contains: `<main id="view">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), tt.contentType)
+			assert.Contains(t, w.Body.String(), tt.contains)
+		})
+	}
+}
+
+func TestHandleStatic_WithPath(t *testing.T) {
+	mux := http.NewServeMux()
+	New(nil, WithPath("/ui")).RegisterRoutes(mux)
+
+	req := httptest.NewRequest("GET", "/ui/app.js", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "function route()")
+}
+
+func TestHandleListApprovals(t *testing.T) {
+	mockStore := floxy.NewMockStore(t)
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	startedAt := createdAt.Add(time.Minute)
+
+	mockStore.On("GetActiveInstances", mock.Anything).Return([]floxy.ActiveWorkflowInstance{
+		{ID: 1, WorkflowID: "orders-v1"},
+		{ID: 2, WorkflowID: "orders-v1"},
+		{ID: 3, WorkflowID: "refunds-v1"},
+		{ID: 4, WorkflowID: "refunds-v1"},
+	}, nil)
+	mockStore.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(1)).Return(&floxy.WorkflowStep{
+		ID:        11,
+		StepName:  "approve",
+		Status:    floxy.StepStatusWaitingDecision,
+		Input:     json.RawMessage(`{"amount":100}`),
+		CreatedAt: createdAt,
+		StartedAt: &startedAt,
+	}, nil)
+	mockStore.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(2)).
+		Return(nil, floxy.ErrEntityNotFound)
+	mockStore.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(3)).Return(&floxy.WorkflowStep{
+		ID:        31,
+		StepName:  "review",
+		Status:    floxy.StepStatusWaitingDecision,
+		Input:     json.RawMessage(`{}`),
+		CreatedAt: createdAt,
+	}, nil)
+	mockStore.On("GetHumanDecisionStepByInstanceID", mock.Anything, int64(4)).Return(&floxy.WorkflowStep{
+		ID:       41,
+		StepName: "review",
+		Status:   floxy.StepStatusConfirmed,
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/api/dashboard/approvals", nil)
+	req = req.WithContext(context.Background())
+	w := httptest.NewRecorder()
+
+	handler := HandleListApprovals(mockStore)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp ApprovalsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 2)
+
+	assert.Equal(t, int64(3), resp.Items[0].InstanceID)
+	assert.Equal(t, "review", resp.Items[0].StepName)
+	assert.True(t, resp.Items[0].WaitingAt.Equal(createdAt))
+
+	assert.Equal(t, int64(1), resp.Items[1].InstanceID)
+	assert.Equal(t, "orders-v1", resp.Items[1].WorkflowID)
+	assert.Equal(t, int64(11), resp.Items[1].StepID)
+	assert.JSONEq(t, `{"amount":100}`, string(resp.Items[1].Input))
+	assert.True(t, resp.Items[1].WaitingAt.Equal(startedAt))
+}
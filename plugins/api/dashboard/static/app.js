@@ -0,0 +1,547 @@
+(function () {
+  'use strict';
+
+  const view = document.getElementById('view');
+  const namespaceInput = document.getElementById('namespace');
+  const terminalStatuses = ['completed', 'failed', 'cancelled', 'aborted'];
+  const statusColors = {
+    completed: '#c8e6c9', confirmed: '#c8e6c9', running: '#bbdefb',
+    waiting_decision: '#fff9c4', paused: '#fff9c4', pending: '#eeeeee',
+    failed: '#ffcdd2', rejected: '#ffcdd2', skipped: '#e0e0e0',
+    compensation: '#ffe0b2', rolled_back: '#d1c4e9',
+  };
+
+  let streams = [];
+
+  // DOM helpers. Text is always set as text nodes, never parsed as HTML.
+
+  function h(tag, attrs, ...children) {
+    const el = document.createElement(tag);
+    for (const [key, value] of Object.entries(attrs || {})) {
+      if (value === undefined || value === null || value === false) continue;
+      if (key.startsWith('on')) el.addEventListener(key.slice(2), value);
+      else el.setAttribute(key, value === true ? '' : value);
+    }
+    append(el, children);
+    return el;
+  }
+
+  function append(el, children) {
+    for (const child of children.flat()) {
+      if (child === undefined || child === null || child === false) continue;
+      el.appendChild(child instanceof Node ? child : document.createTextNode(String(child)));
+    }
+  }
+
+  function svg(tag, attrs, ...children) {
+    const el = document.createElementNS('http://www.w3.org/2000/svg', tag);
+    for (const [key, value] of Object.entries(attrs || {})) {
+      if (key.startsWith('on')) el.addEventListener(key.slice(2), value);
+      else el.setAttribute(key, value);
+    }
+    append(el, children);
+    return el;
+  }
+
+  function render(...children) {
+    view.replaceChildren(...children.flat().filter(Boolean));
+  }
+
+  function toast(message, isError) {
+    const el = document.getElementById('toast');
+    el.textContent = message;
+    el.style.background = isError ? '#c62828' : '#323232';
+    el.hidden = false;
+    clearTimeout(toast.timer);
+    toast.timer = setTimeout(() => { el.hidden = true; }, 4000);
+  }
+
+  function badge(status) {
+    return h('span', { class: 'status ' + status }, status);
+  }
+
+  function fmtTime(value) {
+    if (!value) return '';
+    const date = new Date(value);
+    return isNaN(date) ? String(value) : date.toLocaleString();
+  }
+
+  function fmtJSON(value) {
+    if (value === undefined || value === null) return 'null';
+    return JSON.stringify(value, null, 2);
+  }
+
+  // API access. The namespace is sent as a header, or as a query parameter where headers
+  // cannot be set (EventSource, links).
+
+  function namespace() {
+    return namespaceInput.value.trim();
+  }
+
+  function withNamespace(url) {
+    if (!namespace()) return url;
+    return url + (url.includes('?') ? '&' : '?') + 'namespace=' + encodeURIComponent(namespace());
+  }
+
+  async function request(method, path, body) {
+    const headers = { Accept: 'application/json' };
+    if (namespace()) headers['X-Floxy-Namespace'] = namespace();
+    if (body !== undefined) headers['Content-Type'] = 'application/json';
+
+    const resp = await fetch(path, { method, headers, body: body === undefined ? undefined : JSON.stringify(body) });
+    if (!resp.ok) {
+      let message = resp.status + ' ' + resp.statusText;
+      try {
+        const data = await resp.json();
+        if (data && data.message) message = data.message;
+      } catch (e) { /* not JSON */ }
+      throw new Error(message);
+    }
+    if (resp.status === 204) return null;
+    return resp.json();
+  }
+
+  // The SSE id is a resume position: events after it can arrive again after a reconnect
+  // and are skipped by event id.
+  function stream(path, onEvent) {
+    const source = new EventSource(withNamespace(path));
+    const seen = new Set();
+    let position = -1;
+    source.onmessage = (msg) => {
+      const event = JSON.parse(msg.data);
+      const isNew = event.id > position && !seen.has(event.id);
+      if (isNew) seen.add(event.id);
+      if (msg.lastEventId !== '') {
+        position = Number(msg.lastEventId);
+        for (const id of seen) if (id <= position) seen.delete(id);
+      }
+      if (isNew) onEvent(event);
+    };
+    streams.push(source);
+    return source;
+  }
+
+  async function act(label, method, path, body, done) {
+    try {
+      await request(method, path, body);
+      toast(label + ' done');
+      if (done) done();
+    } catch (err) {
+      toast(label + ' failed: ' + err.message, true);
+    }
+  }
+
+  function decide(instanceID, decision, done) {
+    const message = prompt((decision === 'confirm' ? 'Confirm' : 'Reject') + ' instance ' + instanceID + '. Comment (optional):', '');
+    if (message === null) return;
+    act(decision === 'confirm' ? 'Confirmation' : 'Rejection', 'POST',
+      '/api/instances/' + instanceID + '/make-decision/' + decision, { message }, done);
+  }
+
+  function stopInstance(instanceID, action, done) {
+    const reason = prompt((action === 'cancel' ? 'Cancel (with rollback)' : 'Abort (without rollback)') +
+      ' instance ' + instanceID + '. Reason:', '');
+    if (reason === null) return;
+    act(action === 'cancel' ? 'Cancel' : 'Abort', 'POST', '/api/instances/' + instanceID + '/' + action, { reason }, done);
+  }
+
+  function failed(err) {
+    render(h('p', { class: 'error' }, err.message));
+  }
+
+  // Workflow graph: steps are layered by their longest distance from the start step,
+  // compensation steps sit next to the step they compensate.
+
+  function graphEdges(steps) {
+    const edges = [];
+    for (const [name, step] of Object.entries(steps)) {
+      for (const target of step.parallel || []) edges.push({ from: name, to: target, kind: 'branch' });
+      for (const target of step.next || []) {
+        const next = steps[target];
+        if ((step.type === 'fork' || step.type === 'parallel') && next && next.type === 'join' && (next.wait_for || []).length) continue;
+        edges.push({ from: name, to: target, kind: step.type === 'condition' ? 'true' : 'next' });
+      }
+      if (step.else) edges.push({ from: name, to: step.else, kind: 'else' });
+      for (const waitFor of step.wait_for || []) edges.push({ from: waitFor.replace(/^cond#/, ''), to: name, kind: 'wait' });
+      if (step.on_failure) edges.push({ from: name, to: step.on_failure, kind: 'compensation' });
+    }
+    return edges.filter((edge) => steps[edge.from] && steps[edge.to]);
+  }
+
+  function graphLayout(def, edges) {
+    const steps = def.definition.steps || {};
+    const names = Object.keys(steps);
+    const flow = edges.filter((edge) => edge.kind !== 'compensation');
+    const compensations = new Set(edges.filter((edge) => edge.kind === 'compensation').map((edge) => edge.to));
+    const level = {};
+
+    if (steps[def.definition.start]) level[def.definition.start] = 0;
+    for (let i = 0; i < names.length; i++) {
+      let changed = false;
+      for (const edge of flow) {
+        if (level[edge.from] === undefined) continue;
+        if (level[edge.to] === undefined || level[edge.to] < level[edge.from] + 1) {
+          level[edge.to] = level[edge.from] + 1;
+          changed = true;
+        }
+      }
+      if (!changed) break;
+    }
+    for (const edge of edges) {
+      if (edge.kind === 'compensation' && level[edge.to] === undefined && level[edge.from] !== undefined) {
+        level[edge.to] = level[edge.from];
+      }
+    }
+    const maxLevel = Math.max(0, ...Object.values(level));
+    for (const name of names.sort()) {
+      if (level[name] === undefined) level[name] = maxLevel + 1;
+    }
+
+    const rows = {};
+    for (const name of names) {
+      (rows[level[name]] = rows[level[name]] || []).push(name);
+    }
+    for (const row of Object.values(rows)) {
+      row.sort((a, b) => (compensations.has(a) - compensations.has(b)) || a.localeCompare(b));
+    }
+    return rows;
+  }
+
+  function nodeShape(type, x, y, w, ht, fill) {
+    const attrs = { fill, stroke: '#607d8b', 'stroke-width': 1.2 };
+    const cx = x + w / 2;
+    const cy = y + ht / 2;
+    switch (type) {
+      case 'condition':
+        return svg('polygon', Object.assign({ points: [cx, y, x + w, cy, cx, y + ht, x, cy].join(' ') }, attrs));
+      case 'fork':
+      case 'parallel':
+        return svg('polygon', Object.assign({ points: [x + 12, y, x + w - 12, y, x + w, cy, x + w - 12, y + ht, x + 12, y + ht, x, cy].join(' ') }, attrs));
+      case 'join':
+        return svg('ellipse', Object.assign({ cx, cy, rx: w / 2, ry: ht / 2 }, attrs));
+      case 'human':
+        return svg('polygon', Object.assign({ points: [x + 12, y, x + w, y, x + w - 12, y + ht, x, y + ht].join(' ') }, attrs));
+      case 'save_point':
+        return svg('rect', Object.assign({ x, y, width: w, height: ht, rx: 2 }, attrs, { 'stroke-width': 3 }));
+      default:
+        return svg('rect', Object.assign({ x, y, width: w, height: ht, rx: 6 }, attrs));
+    }
+  }
+
+  function renderGraph(def, statuses, onSelect) {
+    const steps = def.definition.steps || {};
+    const edges = graphEdges(steps);
+    const rows = graphLayout(def, edges);
+    const W = 150, HT = 40, GX = 30, GY = 56, PAD = 20;
+    const widest = Math.max(1, ...Object.values(rows).map((row) => row.length));
+    const width = PAD * 2 + widest * W + (widest - 1) * GX;
+    const levels = Object.keys(rows).map(Number);
+    const height = PAD * 2 + (Math.max(0, ...levels) + 1) * (HT + GY) - GY;
+
+    const pos = {};
+    for (const [lvl, row] of Object.entries(rows)) {
+      const rowWidth = row.length * W + (row.length - 1) * GX;
+      row.forEach((name, i) => {
+        pos[name] = { x: (width - rowWidth) / 2 + i * (W + GX), y: PAD + Number(lvl) * (HT + GY) };
+      });
+    }
+
+    const root = svg('svg', { width, height, viewBox: '0 0 ' + width + ' ' + height });
+    root.appendChild(svg('defs', {},
+      svg('marker', { id: 'arrow', viewBox: '0 0 10 10', refX: 10, refY: 5, markerWidth: 7, markerHeight: 7, orient: 'auto-start-reverse' },
+        svg('path', { d: 'M 0 0 L 10 5 L 0 10 z', fill: '#78909c' })),
+      svg('marker', { id: 'arrow-red', viewBox: '0 0 10 10', refX: 10, refY: 5, markerWidth: 7, markerHeight: 7, orient: 'auto-start-reverse' },
+        svg('path', { d: 'M 0 0 L 10 5 L 0 10 z', fill: '#d32f2f' }))));
+
+    for (const edge of edges) {
+      const a = pos[edge.from], b = pos[edge.to];
+      let x1 = a.x + W / 2, y1 = a.y + HT, x2 = b.x + W / 2, y2 = b.y;
+      if (a.y === b.y) {
+        const leftToRight = a.x < b.x;
+        x1 = leftToRight ? a.x + W : a.x; y1 = a.y + HT / 2;
+        x2 = leftToRight ? b.x : b.x + W; y2 = b.y + HT / 2;
+      } else if (b.y < a.y) {
+        y1 = a.y; y2 = b.y + HT;
+      }
+      const red = edge.kind === 'compensation';
+      root.appendChild(svg('line', {
+        x1, y1, x2, y2,
+        stroke: red ? '#d32f2f' : '#90a4ae',
+        'stroke-width': 1.3,
+        'stroke-dasharray': red ? '6,4' : (edge.kind === 'wait' ? '2,3' : 'none'),
+        'marker-end': red ? 'url(#arrow-red)' : 'url(#arrow)',
+      }));
+      const label = { true: 'true', else: 'else', compensation: 'on failure' }[edge.kind];
+      if (label) {
+        root.appendChild(svg('text', { x: (x1 + x2) / 2 + 4, y: (y1 + y2) / 2 - 3, fill: red ? '#d32f2f' : '#546e7a' }, label));
+      }
+    }
+
+    for (const [name, step] of Object.entries(steps)) {
+      const p = pos[name];
+      const status = statuses[name];
+      const group = svg('g', { class: 'node', onclick: () => onSelect && onSelect(name) },
+        svg('title', {}, name + ' [' + step.type + ']' + (status ? ' ' + status : '')),
+        nodeShape(step.type, p.x, p.y, W, HT, statusColors[status] || '#ffffff'),
+        svg('text', { x: p.x + W / 2, y: p.y + HT / 2 - (step.handler ? 2 : -4), 'text-anchor': 'middle' }, truncate(name, 22)),
+        step.handler ? svg('text', { x: p.x + W / 2, y: p.y + HT / 2 + 12, 'text-anchor': 'middle', fill: '#78909c' }, truncate(step.handler, 24)) : null);
+      root.appendChild(group);
+    }
+
+    return h('div', { class: 'graph' }, root);
+  }
+
+  function truncate(text, max) {
+    return text.length > max ? text.slice(0, max - 1) + '…' : text;
+  }
+
+  // Views
+
+  async function instancesView(params) {
+    const query = new URLSearchParams({ sort: 'created_at', order: 'desc', limit: '50' });
+    for (const key of ['status', 'workflow_id', 'label', 'error']) {
+      if (params.get(key)) query.set(key, params.get(key));
+    }
+
+    const form = h('form', { class: 'filters', onsubmit: (e) => {
+      e.preventDefault();
+      const next = new URLSearchParams();
+      for (const input of form.querySelectorAll('input, select')) {
+        if (input.value.trim()) next.set(input.name, input.value.trim());
+      }
+      location.hash = '#/instances' + (next.toString() ? '?' + next : '');
+    } },
+    h('select', { name: 'status' }, h('option', { value: '' }, 'any status'),
+      ['pending', 'running', 'completed', 'failed', 'rolling_back', 'cancelling', 'cancelled', 'aborted', 'dlq']
+        .map((s) => h('option', { value: s, selected: params.get('status') === s }, s))),
+    h('input', { name: 'workflow_id', placeholder: 'workflow id', value: params.get('workflow_id') || '' }),
+    h('input', { name: 'label', placeholder: 'label key:value', value: params.get('label') || '' }),
+    h('input', { name: 'error', placeholder: 'error contains', value: params.get('error') || '' }),
+    h('button', { type: 'submit', class: 'primary' }, 'Filter'));
+
+    const body = h('tbody');
+    const more = h('button', { hidden: true }, 'Load more');
+    render(h('h2', {}, 'Instances'), form,
+      h('table', {}, h('thead', {}, h('tr', {}, ['ID', 'Workflow', 'Status', 'Created', 'Updated', 'Error'].map((t) => h('th', {}, t)))), body),
+      h('div', { class: 'actions' }, more));
+
+    async function load(cursor) {
+      if (cursor) query.set('cursor', cursor);
+      const result = await request('GET', '/api/instances?' + query);
+      for (const instance of result.items || []) {
+        body.appendChild(h('tr', { class: 'clickable', onclick: () => { location.hash = '#/instances/' + instance.id; } },
+          h('td', {}, instance.id), h('td', {}, instance.workflow_id), h('td', {}, badge(instance.status)),
+          h('td', {}, fmtTime(instance.created_at)), h('td', {}, fmtTime(instance.updated_at)),
+          h('td', { class: 'error' }, instance.error ? truncate(instance.error, 80) : '')));
+      }
+      if (!body.children.length) body.appendChild(h('tr', {}, h('td', { colspan: 6, class: 'muted' }, 'No instances')));
+      more.hidden = !result.next_cursor;
+      more.onclick = () => load(result.next_cursor).catch(failed);
+    }
+    await load();
+  }
+
+  async function instanceView(id) {
+    const [instance, steps] = await Promise.all([
+      request('GET', '/api/instances/' + id),
+      request('GET', '/api/instances/' + id + '/steps'),
+    ]);
+    const def = await request('GET', '/api/workflows/' + encodeURIComponent(instance.workflow_id));
+
+    const latest = {};
+    for (const step of steps.slice().sort((a, b) => a.id - b.id)) latest[step.step_name] = step;
+    const statuses = Object.fromEntries(Object.entries(latest).map(([name, step]) => [name, step.status]));
+    const waiting = steps.some((step) => step.status === 'waiting_decision');
+    const active = !terminalStatuses.includes(instance.status);
+    const reload = () => route();
+
+    const detail = h('div');
+    const stepRows = {};
+    function select(name) {
+      const step = latest[name];
+      for (const [rowName, row] of Object.entries(stepRows)) row.classList.toggle('selected', rowName === name);
+      if (!step) {
+        detail.replaceChildren(h('h3', {}, name), h('p', { class: 'muted' }, 'Not executed'));
+        return;
+      }
+      detail.replaceChildren(
+        h('h3', {}, step.step_name + ' #' + step.id),
+        h('dl', { class: 'meta' },
+          h('dt', {}, 'Status'), h('dd', {}, badge(step.status)),
+          h('dt', {}, 'Retries'), h('dd', {}, step.retry_count + ' / ' + step.max_retries),
+          h('dt', {}, 'Started'), h('dd', {}, fmtTime(step.started_at)),
+          h('dt', {}, 'Completed'), h('dd', {}, fmtTime(step.completed_at))),
+        step.error ? h('p', { class: 'error' }, step.error) : null,
+        h('div', {}, 'Input'), h('pre', {}, fmtJSON(step.input)),
+        h('div', {}, 'Output'), h('pre', {}, fmtJSON(step.output)));
+    }
+
+    const stepsBody = h('tbody');
+    for (const step of steps) {
+      const row = h('tr', { class: 'clickable', onclick: () => select(step.step_name) },
+        h('td', {}, step.id), h('td', {}, step.step_name), h('td', {}, step.step_type), h('td', {}, badge(step.status)),
+        h('td', {}, step.retry_count), h('td', {}, fmtTime(step.started_at)), h('td', {}, fmtTime(step.completed_at)));
+      stepRows[step.step_name] = row;
+      stepsBody.appendChild(row);
+    }
+
+    const events = h('div', { class: 'events' });
+    render(
+      h('h2', {}, 'Instance ' + instance.id),
+      h('dl', { class: 'meta' },
+        h('dt', {}, 'Workflow'), h('dd', {}, h('a', { href: '#/workflows/' + encodeURIComponent(instance.workflow_id) }, instance.workflow_id)),
+        h('dt', {}, 'Status'), h('dd', {}, badge(instance.status)),
+        h('dt', {}, 'Created'), h('dd', {}, fmtTime(instance.created_at)),
+        h('dt', {}, 'Updated'), h('dd', {}, fmtTime(instance.updated_at)),
+        instance.error ? [h('dt', {}, 'Error'), h('dd', { class: 'error' }, instance.error)] : null,
+        instance.labels ? [h('dt', {}, 'Labels'), h('dd', {}, Object.entries(instance.labels).map(([k, v]) => k + ':' + v).join(', '))] : null),
+      h('div', { class: 'actions' },
+        h('button', { class: 'primary', disabled: !waiting, onclick: () => decide(instance.id, 'confirm', reload) }, 'Confirm'),
+        h('button', { disabled: !waiting, onclick: () => decide(instance.id, 'reject', reload) }, 'Reject'),
+        h('button', { disabled: !active, onclick: () => stopInstance(instance.id, 'cancel', reload) }, 'Cancel'),
+        h('button', { class: 'danger', disabled: !active, onclick: () => stopInstance(instance.id, 'abort', reload) }, 'Abort'),
+        h('a', { href: withNamespace('/api/instances/' + instance.id + '/timeline?format=html'), target: '_blank' }, h('button', { type: 'button' }, 'Timeline'))),
+      h('h3', {}, 'Graph'),
+      renderGraph(def, statuses, select),
+      detail,
+      h('h3', {}, 'Steps'),
+      h('table', {}, h('thead', {}, h('tr', {}, ['ID', 'Step', 'Type', 'Status', 'Retries', 'Started', 'Completed'].map((t) => h('th', {}, t)))), stepsBody),
+      h('h3', {}, 'Input'), h('pre', {}, fmtJSON(instance.input)),
+      instance.output ? [h('h3', {}, 'Output'), h('pre', {}, fmtJSON(instance.output))] : null,
+      h('h3', {}, 'Events'), events);
+
+    const names = Object.fromEntries(steps.map((step) => [step.id, step.step_name]));
+    stream('/api/instances/' + id + '/events/stream', (event) => {
+      events.appendChild(eventLine(event, names[event.step_id]));
+      events.scrollTop = events.scrollHeight;
+    });
+  }
+
+  function eventLine(event, stepName) {
+    const payload = event.payload ? JSON.stringify(event.payload) : '';
+    return h('div', {},
+      h('span', { class: 'muted' }, fmtTime(event.created_at) + ' '),
+      h('a', { href: '#/instances/' + event.instance_id }, '#' + event.instance_id), ' ',
+      h('strong', {}, event.event_type), ' ',
+      stepName ? stepName + ' ' : '',
+      h('span', { class: 'muted' }, truncate(payload, 200)));
+  }
+
+  async function workflowsView() {
+    const defs = await request('GET', '/api/workflows');
+    render(h('h2', {}, 'Workflows'),
+      h('table', {},
+        h('thead', {}, h('tr', {}, ['ID', 'Name', 'Version', 'Steps', 'Created', ''].map((t) => h('th', {}, t)))),
+        h('tbody', {}, (defs || []).map((def) => h('tr', { class: 'clickable', onclick: () => { location.hash = '#/workflows/' + encodeURIComponent(def.id); } },
+          h('td', {}, def.id), h('td', {}, def.name), h('td', {}, def.version),
+          h('td', {}, Object.keys(def.definition.steps || {}).length), h('td', {}, fmtTime(def.created_at)),
+          h('td', {}, h('a', { href: '#/instances?workflow_id=' + encodeURIComponent(def.id), onclick: (e) => e.stopPropagation() }, 'instances')))))));
+  }
+
+  async function workflowView(id) {
+    const def = await request('GET', '/api/workflows/' + encodeURIComponent(id));
+    const detail = h('div');
+    render(h('h2', {}, def.name + ' v' + def.version),
+      h('div', { class: 'actions' }, h('a', { href: '#/instances?workflow_id=' + encodeURIComponent(def.id) }, 'Instances of ' + def.id)),
+      renderGraph(def, {}, (name) => detail.replaceChildren(h('h3', {}, name), h('pre', {}, fmtJSON(def.definition.steps[name])))),
+      detail);
+  }
+
+  async function approvalsView() {
+    const result = await request('GET', '/api/dashboard/approvals');
+    const items = result.items || [];
+    render(h('h2', {}, 'Pending approvals'),
+      h('table', {},
+        h('thead', {}, h('tr', {}, ['Instance', 'Workflow', 'Step', 'Waiting since', 'Input', ''].map((t) => h('th', {}, t)))),
+        h('tbody', {}, items.length ? items.map((item) => h('tr', {},
+          h('td', {}, h('a', { href: '#/instances/' + item.instance_id }, item.instance_id)),
+          h('td', {}, item.workflow_id), h('td', {}, item.step_name), h('td', {}, fmtTime(item.waiting_at)),
+          h('td', {}, h('code', {}, truncate(JSON.stringify(item.input), 120))),
+          h('td', {}, h('div', { class: 'actions' },
+            h('button', { class: 'primary', onclick: () => decide(item.instance_id, 'confirm', route) }, 'Confirm'),
+            h('button', { onclick: () => decide(item.instance_id, 'reject', route) }, 'Reject')))))
+          : h('tr', {}, h('td', { colspan: 6, class: 'muted' }, 'Nothing waits for a decision')))));
+  }
+
+  async function dlqView(params) {
+    const page = Number(params.get('page') || 1);
+    const result = await request('GET', '/api/dlq?page=' + page + '&page_size=50');
+    const items = result.items || [];
+    const pages = Math.max(1, Math.ceil(result.total / result.page_size));
+    render(h('h2', {}, 'Dead letter queue (' + result.total + ')'),
+      h('table', {},
+        h('thead', {}, h('tr', {}, ['ID', 'Instance', 'Workflow', 'Step', 'Error', 'Redrives', 'Created', ''].map((t) => h('th', {}, t)))),
+        h('tbody', {}, items.length ? items.map((item) => h('tr', {},
+          h('td', {}, item.id),
+          h('td', {}, h('a', { href: '#/instances/' + item.instance_id }, item.instance_id)),
+          h('td', {}, item.workflow_id), h('td', {}, item.step_name),
+          h('td', { class: 'error' }, item.error ? truncate(item.error, 100) : item.reason),
+          h('td', {}, item.redrive_count), h('td', {}, fmtTime(item.created_at)),
+          h('td', {}, h('button', { class: 'primary', onclick: () => act('Requeue', 'POST', '/api/dlq/' + item.id + '/requeue', {}, route) }, 'Requeue'))))
+          : h('tr', {}, h('td', { colspan: 8, class: 'muted' }, 'The DLQ is empty')))),
+      h('div', { class: 'actions' },
+        h('button', { disabled: page <= 1, onclick: () => { location.hash = '#/dlq?page=' + (page - 1); } }, 'Previous'),
+        h('span', { class: 'muted' }, 'Page ' + page + ' of ' + pages),
+        h('button', { disabled: page >= pages, onclick: () => { location.hash = '#/dlq?page=' + (page + 1); } }, 'Next')));
+  }
+
+  function eventsView(params) {
+    const events = h('div', { class: 'events' });
+    const workflowID = params.get('workflow_id') || '';
+    const form = h('form', { class: 'filters', onsubmit: (e) => {
+      e.preventDefault();
+      const value = form.querySelector('input').value.trim();
+      location.hash = '#/events' + (value ? '?workflow_id=' + encodeURIComponent(value) : '');
+    } }, h('input', { placeholder: 'workflow id', value: workflowID }), h('button', { type: 'submit', class: 'primary' }, 'Tail'));
+
+    render(h('h2', {}, 'Events'), form, h('p', { class: 'muted' }, 'New events of all instances appear here as they are logged.'), events);
+    stream('/api/events/stream' + (workflowID ? '?workflow_id=' + encodeURIComponent(workflowID) : ''), (event) => {
+      events.appendChild(eventLine(event));
+      while (events.children.length > 500) events.removeChild(events.firstChild);
+      events.scrollTop = events.scrollHeight;
+    });
+  }
+
+  // Routing over the URL hash: #/section[/id][?params]
+
+  function route() {
+    for (const source of streams) source.close();
+    streams = [];
+
+    const [path, search] = (location.hash.slice(1) || '/instances').split('?');
+    const parts = path.split('/').filter(Boolean);
+    const params = new URLSearchParams(search || '');
+    const section = parts[0] || 'instances';
+
+    for (const link of document.querySelectorAll('[data-nav]')) {
+      link.classList.toggle('active', link.dataset.nav === section);
+    }
+
+    let result;
+    switch (section) {
+      case 'workflows':
+        result = parts[1] ? workflowView(decodeURIComponent(parts[1])) : workflowsView();
+        break;
+      case 'approvals':
+        result = approvalsView();
+        break;
+      case 'dlq':
+        result = dlqView(params);
+        break;
+      case 'events':
+        result = eventsView(params);
+        break;
+      default:
+        result = parts[1] ? instanceView(parts[1]) : instancesView(params);
+    }
+    Promise.resolve(result).catch(failed);
+  }
+
+  namespaceInput.value = localStorage.getItem('floxy.namespace') || '';
+  namespaceInput.addEventListener('change', () => {
+    localStorage.setItem('floxy.namespace', namespace());
+    route();
+  });
+  window.addEventListener('hashchange', route);
+  route();
+})();
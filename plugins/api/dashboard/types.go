@@ -0,0 +1,20 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Approval is a human step of an active instance waiting for its decision.
+type Approval struct {
+	InstanceID int64           `json:"instance_id"`
+	WorkflowID string          `json:"workflow_id"`
+	StepID     int64           `json:"step_id"`
+	StepName   string          `json:"step_name"`
+	Input      json.RawMessage `json:"input"`
+	WaitingAt  time.Time       `json:"waiting_at"`
+}
+
+type ApprovalsResponse struct {
+	Items []Approval `json:"items"`
+}
@@ -3,8 +3,10 @@ package dlq
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -15,21 +17,24 @@ import (
 var _ api.Plugin = (*Plugin)(nil)
 
 type Plugin struct {
-	engine floxy.IEngine
-	store  floxy.Store
+	engine        floxy.IEngine
+	store         floxy.Store
+	extractUserFn ExtractUserFn
 }
 
-func New(engine floxy.IEngine, store floxy.Store) *Plugin {
-	return &Plugin{engine: engine, store: store}
+func New(engine floxy.IEngine, store floxy.Store, extractUserFn ExtractUserFn) *Plugin {
+	return &Plugin{engine: engine, store: store, extractUserFn: extractUserFn}
 }
 
 func (p *Plugin) Name() string        { return "dlq" }
 func (p *Plugin) Description() string { return "Dead Letter Queue operations" }
 
-func (p *Plugin) RegisterRoutes(mux *http.ServeMux) {
+func (p *Plugin) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("GET /api/dlq", HandleList(p.store))
 	mux.HandleFunc("GET /api/dlq/{id}", HandleGet(p.store))
 	mux.HandleFunc("POST /api/dlq/{id}/requeue", HandleRequeue(p.engine))
+	mux.HandleFunc("POST /api/dlq/{id}/discard", HandleDiscard(p.engine, p.extractUserFn))
+	mux.HandleFunc("POST /api/dlq/requeue", HandleBulkRequeue(p.engine, p.extractUserFn))
 }
 
 func HandleList(store floxy.Store) func(w http.ResponseWriter, r *http.Request) {
@@ -50,8 +55,14 @@ func HandleList(store floxy.Store) func(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
+		filter, err := parseDeadLetterFilter(r.URL.Query())
+		if err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
 		offset := (page - 1) * pageSize
-		items, total, err := store.ListDeadLetters(ctx, offset, pageSize)
+		items, total, err := store.ListDeadLetters(ctx, filter, offset, pageSize)
 		if err != nil {
 			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
 			return
@@ -63,19 +74,8 @@ func HandleList(store floxy.Store) func(w http.ResponseWriter, r *http.Request)
 			PageSize: pageSize,
 			Total:    total,
 		}
-		for _, it := range items {
-			resp.Items = append(resp.Items, DeadLetterResponse{
-				ID:         it.ID,
-				InstanceID: it.InstanceID,
-				WorkflowID: it.WorkflowID,
-				StepID:     it.StepID,
-				StepName:   it.StepName,
-				StepType:   it.StepType,
-				Input:      it.Input,
-				Error:      it.Error,
-				Reason:     it.Reason,
-				CreatedAt:  it.CreatedAt.UTC().Format(time.RFC3339Nano),
-			})
+		for i := range items {
+			resp.Items = append(resp.Items, newDeadLetterResponse(&items[i]))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -105,18 +105,7 @@ func HandleGet(store floxy.Store) func(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		resp := DeadLetterResponse{
-			ID:         rec.ID,
-			InstanceID: rec.InstanceID,
-			WorkflowID: rec.WorkflowID,
-			StepID:     rec.StepID,
-			StepName:   rec.StepName,
-			StepType:   rec.StepType,
-			Input:      rec.Input,
-			Error:      rec.Error,
-			Reason:     rec.Reason,
-			CreatedAt:  rec.CreatedAt.UTC().Format(time.RFC3339Nano),
-		}
+		resp := newDeadLetterResponse(rec)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -153,3 +142,155 @@ func HandleRequeue(engine floxy.IEngine) func(w http.ResponseWriter, r *http.Req
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+func HandleDiscard(engine floxy.IEngine, extractUserFn ExtractUserFn) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		idStr := r.PathValue("id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		user, err := api.RequestUser(r, extractUserFn)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+				return
+			}
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		var req DiscardRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		if req.Reason == "" {
+			api.WriteErrorResponse(w, errors.New("reason is required"), http.StatusBadRequest)
+			return
+		}
+
+		if err := engine.DiscardFromDLQ(ctx, id, user, req.Reason); err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+				return
+			}
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleBulkRequeue starts a bulk requeue job for the instances owning the matched DLQ records.
+func HandleBulkRequeue(engine floxy.IEngine, extractUserFn ExtractUserFn) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		user, err := api.RequestUser(r, extractUserFn)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+				return
+			}
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		var req BulkRequeueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		if err := floxy.ValidateBulkRate(req.RatePerSecond); err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		job, err := engine.StartBulkOperation(ctx, floxy.BulkRequest{
+			Action: floxy.BulkActionRequeue,
+			Selector: floxy.BulkSelector{
+				DeadLetters: &floxy.DeadLetterFilter{
+					WorkflowID:    req.WorkflowID,
+					StepName:      req.StepName,
+					CreatedFrom:   req.CreatedFrom,
+					CreatedTo:     req.CreatedTo,
+					ErrorContains: req.Error,
+				},
+			},
+			RequestedBy:   user,
+			RatePerSecond: req.RatePerSecond,
+		})
+		if err != nil {
+			if errors.Is(err, floxy.ErrEmptyBulkSelector) || errors.Is(err, floxy.ErrInvalidBulkRate) {
+				api.WriteErrorResponse(w, err, http.StatusBadRequest)
+				return
+			}
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(job)
+	}
+}
+
+// parseDeadLetterFilter reads workflow_id, step_name, created_from, created_to (RFC3339) and error.
+func parseDeadLetterFilter(values url.Values) (floxy.DeadLetterFilter, error) {
+	filter := floxy.DeadLetterFilter{
+		WorkflowID:    values.Get("workflow_id"),
+		StepName:      values.Get("step_name"),
+		ErrorContains: values.Get("error"),
+	}
+
+	for _, param := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+	} {
+		v := values.Get(param.name)
+		if v == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return floxy.DeadLetterFilter{}, fmt.Errorf("invalid %s: %w", param.name, err)
+		}
+		*param.dst = &t
+	}
+
+	return filter, nil
+}
+
+func newDeadLetterResponse(rec *floxy.DeadLetterRecord) DeadLetterResponse {
+	resp := DeadLetterResponse{
+		ID:           rec.ID,
+		InstanceID:   rec.InstanceID,
+		WorkflowID:   rec.WorkflowID,
+		StepID:       rec.StepID,
+		StepName:     rec.StepName,
+		StepType:     rec.StepType,
+		Input:        rec.Input,
+		Error:        rec.Error,
+		Reason:       rec.Reason,
+		CreatedAt:    rec.CreatedAt.UTC().Format(time.RFC3339Nano),
+		RedriveCount: rec.RedriveCount,
+	}
+	if rec.NextRedriveAt != nil {
+		nextRedriveAt := rec.NextRedriveAt.UTC().Format(time.RFC3339Nano)
+		resp.NextRedriveAt = &nextRedriveAt
+	}
+
+	return resp
+}
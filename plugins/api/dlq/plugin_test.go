@@ -36,7 +36,7 @@ func TestHandleList_Success_DefaultPagination(t *testing.T) {
 	}
 
 	mockStore.
-		On("ListDeadLetters", mock.Anything, 0, 20).
+		On("ListDeadLetters", mock.Anything, floxy.DeadLetterFilter{}, 0, 20).
 		Return(records, int64(1), nil)
 
 	req := httptest.NewRequest("GET", "/api/dlq", nil)
@@ -68,7 +68,7 @@ func TestHandleList_CustomPagination(t *testing.T) {
 
 	// page=2, page_size=10 -> offset 10, limit 10
 	mockStore.
-		On("ListDeadLetters", mock.Anything, 10, 10).
+		On("ListDeadLetters", mock.Anything, floxy.DeadLetterFilter{}, 10, 10).
 		Return([]floxy.DeadLetterRecord{}, int64(0), nil)
 
 	req := httptest.NewRequest("GET", "/api/dlq?page=2&page_size=10", nil)
@@ -85,7 +85,7 @@ func TestHandleList_Error(t *testing.T) {
 	mockStore := floxy.NewMockStore(t)
 
 	mockStore.
-		On("ListDeadLetters", mock.Anything, 0, 20).
+		On("ListDeadLetters", mock.Anything, floxy.DeadLetterFilter{}, 0, 20).
 		Return(nil, int64(0), errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/dlq", nil)
@@ -293,4 +293,132 @@ func TestHandleRequeue_InternalError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestHandleList_Filters(t *testing.T) {
+	mockStore := floxy.NewMockStore(t)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockStore.
+		On("ListDeadLetters", mock.Anything, mock.MatchedBy(func(f floxy.DeadLetterFilter) bool {
+			return f.WorkflowID == "payments-v1" && f.StepName == "charge" &&
+				f.ErrorContains == "timeout" && f.CreatedFrom != nil && f.CreatedFrom.Equal(from) && f.CreatedTo == nil
+		}), 0, 20).
+		Return([]floxy.DeadLetterRecord{}, int64(0), nil)
+
+	req := httptest.NewRequest("GET",
+		"/api/dlq?workflow_id=payments-v1&step_name=charge&error=timeout&created_from=2026-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	HandleList(mockStore)(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandleList_InvalidTime(t *testing.T) {
+	mockStore := floxy.NewMockStore(t)
+
+	req := httptest.NewRequest("GET", "/api/dlq?created_to=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	HandleList(mockStore)(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func extractTestUser(*http.Request) (string, error) { return "operator", nil }
+
+func TestHandleDiscard_Success(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.
+		On("DiscardFromDLQ", mock.Anything, int64(42), "operator", "refunded").
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/api/dlq/42/discard", bytes.NewBufferString(`{"reason":"refunded"}`))
+	req.SetPathValue("id", "42")
+	w := httptest.NewRecorder()
+
+	HandleDiscard(mockEngine, extractTestUser)(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandleDiscard_MissingReason(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	req := httptest.NewRequest("POST", "/api/dlq/42/discard", bytes.NewBufferString(`{}`))
+	req.SetPathValue("id", "42")
+	w := httptest.NewRecorder()
+
+	HandleDiscard(mockEngine, extractTestUser)(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleDiscard_NotFound(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.
+		On("DiscardFromDLQ", mock.Anything, int64(404), "operator", "refunded").
+		Return(floxy.ErrEntityNotFound)
+
+	req := httptest.NewRequest("POST", "/api/dlq/404/discard", bytes.NewBufferString(`{"reason":"refunded"}`))
+	req.SetPathValue("id", "404")
+	w := httptest.NewRecorder()
+
+	HandleDiscard(mockEngine, extractTestUser)(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleBulkRequeue_Success(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.
+		On("StartBulkOperation", mock.Anything, mock.MatchedBy(func(req floxy.BulkRequest) bool {
+			return req.Action == floxy.BulkActionRequeue && req.RequestedBy == "operator" &&
+				req.Selector.DeadLetters != nil && req.Selector.DeadLetters.WorkflowID == "payments-v1" &&
+				req.Selector.DeadLetters.ErrorContains == "timeout" && req.RatePerSecond == 5
+		})).
+		Return(&floxy.BulkJob{ID: "job-1", Action: floxy.BulkActionRequeue, Status: floxy.BulkJobStatusRunning, Total: 3}, nil)
+
+	body := `{"workflow_id":"payments-v1","error":"timeout","rate_per_second":5}`
+	req := httptest.NewRequest("POST", "/api/dlq/requeue", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	HandleBulkRequeue(mockEngine, extractTestUser)(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var job floxy.BulkJob
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+	assert.Equal(t, "job-1", job.ID)
+	assert.Equal(t, 3, job.Total)
+}
+
+func TestHandleBulkRequeue_NothingMatched(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	mockEngine.
+		On("StartBulkOperation", mock.Anything, mock.Anything).
+		Return(nil, floxy.ErrEmptyBulkSelector)
+
+	req := httptest.NewRequest("POST", "/api/dlq/requeue", nil)
+	w := httptest.NewRecorder()
+
+	HandleBulkRequeue(mockEngine, extractTestUser)(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleBulkRequeue_InvalidRate(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	req := httptest.NewRequest("POST", "/api/dlq/requeue", bytes.NewBufferString(`{"rate_per_second":1e12}`))
+	w := httptest.NewRecorder()
+
+	HandleBulkRequeue(mockEngine, extractTestUser)(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func ptr[T any](v T) *T { return &v }
@@ -2,8 +2,12 @@ package dlq
 
 import (
 	"encoding/json"
+	"net/http"
+	"time"
 )
 
+type ExtractUserFn func(req *http.Request) (string, error)
+
 type ListRequest struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
@@ -27,8 +31,26 @@ type DeadLetterResponse struct {
 	Error      *string         `json:"error"`
 	Reason     string          `json:"reason"`
 	CreatedAt  string          `json:"created_at"`
+
+	RedriveCount  int     `json:"redrive_count"`
+	NextRedriveAt *string `json:"next_redrive_at,omitempty"`
 }
 
 type RequeueRequest struct {
 	NewInput *json.RawMessage `json:"new_input"`
 }
+
+type DiscardRequest struct {
+	Reason string `json:"reason"`
+}
+
+// BulkRequeueRequest selects DLQ records to requeue; empty fields do not filter.
+// All records of the matched instances are requeued.
+type BulkRequeueRequest struct {
+	WorkflowID    string     `json:"workflow_id,omitempty"`
+	StepName      string     `json:"step_name,omitempty"`
+	CreatedFrom   *time.Time `json:"created_from,omitempty"`
+	CreatedTo     *time.Time `json:"created_to,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	RatePerSecond float64    `json:"rate_per_second,omitempty"`
+}
@@ -30,7 +30,7 @@ func (p *Plugin) Name() string { return "human-decision" }
 
 func (p *Plugin) Description() string { return "Make a human decision" }
 
-func (p *Plugin) RegisterRoutes(mux *http.ServeMux) {
+func (p *Plugin) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc(
 		"POST /api/instances/{instance_id}/make-decision/confirm",
 		HandleHumanDecision(p.engine, p.store, p.extractUserFn, floxy.HumanDecisionConfirmed),
@@ -59,7 +59,7 @@ func HandleHumanDecision(
 			return
 		}
 
-		user, err := extractUserFn(r)
+		user, err := api.RequestUser(r, extractUserFn)
 		if err != nil {
 			if errors.Is(err, floxy.ErrEntityNotFound) {
 				api.WriteErrorResponse(w, err, http.StatusNotFound)
@@ -72,6 +72,21 @@ func HandleHumanDecision(
 			return
 		}
 
+		// Steps are not namespaced, a scoped caller must see the instance first
+		if _, scoped := floxy.NamespaceFromContext(ctx); scoped {
+			if _, err := store.GetInstance(ctx, instanceID); err != nil {
+				if errors.Is(err, floxy.ErrEntityNotFound) {
+					api.WriteErrorResponse(w, err, http.StatusNotFound)
+
+					return
+				}
+
+				api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+				return
+			}
+		}
+
 		step, err := store.GetHumanDecisionStepByInstanceID(ctx, instanceID)
 		if err != nil {
 			if errors.Is(err, floxy.ErrEntityNotFound) {
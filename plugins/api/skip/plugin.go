@@ -0,0 +1,103 @@
+package skip
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	floxy "github.com/rom8726/floxy-pro"
+	"github.com/rom8726/floxy-pro/api"
+)
+
+var _ api.Plugin = (*Plugin)(nil)
+
+type Plugin struct {
+	engine        floxy.IEngine
+	extractUserFn ExtractUserFn
+}
+
+func New(engine floxy.IEngine, extractUserFn ExtractUserFn) *Plugin {
+	return &Plugin{
+		engine:        engine,
+		extractUserFn: extractUserFn,
+	}
+}
+
+func (p *Plugin) Name() string { return "skip" }
+
+func (p *Plugin) Description() string { return "Skip a stuck workflow step" }
+
+func (p *Plugin) RegisterRoutes(mux api.Router) {
+	mux.HandleFunc(
+		"POST /api/steps/{step_id}/skip",
+		HandleSkipStep(p.engine, p.extractUserFn),
+	)
+}
+
+func HandleSkipStep(
+	engine floxy.IEngine,
+	extractUserFn ExtractUserFn,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		stepIDStr := r.PathValue("step_id")
+		stepID, err := strconv.ParseInt(stepIDStr, 10, 64)
+		if err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		user, err := api.RequestUser(r, extractUserFn)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+
+				return
+			}
+
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		var skipReq SkipRequest
+		if err := json.NewDecoder(r.Body).Decode(&skipReq); err != nil {
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		// Validate required fields
+		if skipReq.Reason == "" {
+			err = errors.New("reason is required")
+			api.WriteErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		err = engine.SkipStep(ctx, stepID, user, skipReq.Output, skipReq.Reason)
+		if err != nil {
+			if errors.Is(err, floxy.ErrEntityNotFound) {
+				api.WriteErrorResponse(w, err, http.StatusNotFound)
+
+				return
+			}
+
+			// Step or workflow is not in a state that allows skipping
+			if errors.Is(err, floxy.ErrStepNotSkippable) {
+				api.WriteErrorResponse(w, err, http.StatusConflict)
+
+				return
+			}
+
+			api.WriteErrorResponse(w, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
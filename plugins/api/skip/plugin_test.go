@@ -0,0 +1,241 @@
+package skip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	floxy "github.com/rom8726/floxy-pro"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleSkipStep_Success(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	stepID := int64(42)
+	user := "test-user"
+	reason := "Dependency fixed manually"
+	output := json.RawMessage(`{"order_id":"A-1"}`)
+
+	mockEngine.On("SkipStep", mock.Anything, stepID, user, output, reason).
+		Return(nil)
+
+	requestBody := SkipRequest{Output: output, Reason: reason}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return user, nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandleSkipStep_WithoutOutput(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	stepID := int64(42)
+	user := "test-user"
+	reason := "Dependency fixed manually"
+
+	mockEngine.On("SkipStep", mock.Anything, stepID, user, json.RawMessage(nil), reason).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBufferString(`{"reason":"Dependency fixed manually"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return user, nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandleSkipStep_InvalidStepID(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	req := httptest.NewRequest("POST", "/api/steps/invalid/skip", nil)
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "invalid")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return "test-user", nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleSkipStep_ExtractUser_NotFound(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", nil)
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return "", floxy.ErrEntityNotFound
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleSkipStep_InvalidJSON(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBufferString("invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return "test-user", nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleSkipStep_MissingReason(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	requestBody := SkipRequest{Reason: ""}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return "test-user", nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleSkipStep_StepNotFound(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	stepID := int64(42)
+	user := "test-user"
+	reason := "Test skip"
+
+	mockEngine.On("SkipStep", mock.Anything, stepID, user, mock.Anything, reason).
+		Return(floxy.ErrEntityNotFound)
+
+	requestBody := SkipRequest{Reason: reason}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return user, nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleSkipStep_StepCannotBeSkipped(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	stepID := int64(42)
+	user := "test-user"
+	reason := "Test skip"
+
+	mockEngine.On("SkipStep", mock.Anything, stepID, user, mock.Anything, reason).
+		Return(fmt.Errorf("step 42 cannot be skipped (current status: completed): %w", floxy.ErrStepNotSkippable))
+
+	requestBody := SkipRequest{Reason: reason}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return user, nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestHandleSkipStep_EngineError(t *testing.T) {
+	mockEngine := floxy.NewMockIEngine(t)
+
+	stepID := int64(42)
+	user := "test-user"
+	reason := "Test skip"
+
+	mockEngine.On("SkipStep", mock.Anything, stepID, user, mock.Anything, reason).
+		Return(errors.New("engine error"))
+
+	requestBody := SkipRequest{Reason: reason}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/steps/42/skip", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.Background())
+	req.SetPathValue("step_id", "42")
+
+	w := httptest.NewRecorder()
+
+	extractUserFn := func(r *http.Request) (string, error) {
+		return user, nil
+	}
+
+	handler := HandleSkipStep(mockEngine, extractUserFn)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
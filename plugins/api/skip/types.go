@@ -0,0 +1,13 @@
+package skip
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type ExtractUserFn func(req *http.Request) (string, error)
+
+type SkipRequest struct {
+	Output json.RawMessage `json:"output,omitempty"`
+	Reason string          `json:"reason"`
+}
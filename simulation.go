@@ -0,0 +1,363 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSimulationMaxSteps = 10000
+	simulationWorkerID        = "simulation"
+)
+
+var ErrSimulationNotSettled = errors.New("simulation did not settle")
+
+// SimulationOptions configures the stub handlers of Engine.Simulate.
+type SimulationOptions struct {
+	// Outputs holds scripted handler outputs by step name. Steps without an output echo their input.
+	Outputs map[string]json.RawMessage
+	// FailAt lists steps whose handler fails on every attempt, so that retries and rollback run.
+	FailAt []string
+	// Decisions holds the decisions of human steps by step name. Human steps are confirmed by default.
+	Decisions map[string]HumanDecision
+	// MaxSteps bounds the executed queue items. Default: 10000.
+	MaxSteps int
+}
+
+// TraceEntry is one event of an executed path. Detail holds the condition result, the retry count,
+// the join status, "waiting" for human steps, "rollback" for steps enqueued for compensation or
+// "compensated"; error messages are left out to keep traces stable.
+type TraceEntry struct {
+	Event  string `json:"event"`
+	Step   string `json:"step,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (e TraceEntry) String() string {
+	return strings.TrimSpace(strings.Join([]string{e.Event, e.Step, e.Detail}, " "))
+}
+
+// SimulatedCondition is the result of a condition step.
+type SimulatedCondition struct {
+	Step   string `json:"step"`
+	Result bool   `json:"result"`
+}
+
+// SimulatedJoin is the outcome of a join step.
+type SimulatedJoin struct {
+	Step      string     `json:"step"`
+	Status    StepStatus `json:"status"`
+	Completed []string   `json:"completed"`
+	Failed    []string   `json:"failed"`
+}
+
+// SimulationResult is the predicted execution of a workflow.
+type SimulationResult struct {
+	Status      WorkflowStatus       `json:"status"`
+	Output      json.RawMessage      `json:"output,omitempty"`
+	Error       *string              `json:"error,omitempty"`
+	Executed    []string             `json:"executed"`
+	Compensated []string             `json:"compensated"`
+	Conditions  []SimulatedCondition `json:"conditions"`
+	Joins       []SimulatedJoin      `json:"joins"`
+	Trace       []TraceEntry         `json:"trace"`
+	// Duration is the simulated time the workflow took, including step delays and retry backoff
+	Duration time.Duration `json:"duration"`
+}
+
+// tracedEvents are the events making up an executed path; join bookkeeping events are left out.
+var tracedEvents = map[string]bool{
+	EventWorkflowStarted:   true,
+	EventWorkflowCompleted: true,
+	EventWorkflowFailed:    true,
+	EventWorkflowCancelled: true,
+	EventWorkflowAborted:   true,
+	EventStepStarted:       true,
+	EventStepCompleted:     true,
+	EventStepRetry:         true,
+	EventStepFailed:        true,
+	EventStepSkipped:       true,
+	EventForkStarted:       true,
+	EventJoinCompleted:     true,
+	EventConditionCheck:    true,
+	EventDLQCreated:        true,
+}
+
+// BuildTrace turns the events of an instance into its executed path.
+func BuildTrace(events []WorkflowEvent, steps []WorkflowStep) []TraceEntry {
+	stepNames := make(map[int64]string, len(steps))
+	for _, step := range steps {
+		stepNames[step.ID] = step.StepName
+	}
+
+	trace := make([]TraceEntry, 0, len(events))
+	for _, event := range events {
+		if !tracedEvents[event.EventType] {
+			continue
+		}
+
+		var payload map[string]any
+		_ = json.Unmarshal(event.Payload, &payload)
+
+		entry := TraceEntry{Event: event.EventType}
+		if event.StepID != nil {
+			entry.Step = stepNames[*event.StepID]
+		}
+		if name, ok := payload[KeyStepName].(string); ok && entry.Step == "" {
+			entry.Step = name
+		}
+
+		switch {
+		case event.EventType == EventConditionCheck:
+			if result, ok := payload[KeyResult]; ok {
+				entry.Detail = fmt.Sprint(result)
+			} else {
+				entry.Detail = "error"
+			}
+		case event.EventType == EventJoinCompleted:
+			entry.Detail = fmt.Sprint(payload[KeyStatus])
+		case payload[KeyReason] == "waiting_for_human_decision":
+			entry.Detail = "waiting"
+		case payload[KeyReason] == "compensation", payload[KeyReason] == "enqueued_for_rollback_after_failure":
+			entry.Detail = "rollback"
+		case payload[KeyReason] == "compensation_success":
+			entry.Detail = "compensated"
+		case payload[KeyReason] == "compensation_retry":
+			entry.Detail = fmt.Sprintf("compensation retry=%v", payload[KeyRetryCount])
+		case event.EventType == EventStepRetry:
+			entry.Detail = fmt.Sprintf("retry=%v", payload[KeyRetryCount])
+		}
+
+		trace = append(trace, entry)
+	}
+
+	return trace
+}
+
+// simulationHandler is the stub of every handler during a simulation.
+type simulationHandler struct {
+	name string
+	opts *SimulationOptions
+}
+
+func (h *simulationHandler) Name() string {
+	return h.name
+}
+
+func (h *simulationHandler) Execute(_ context.Context, stepCtx StepContext, input json.RawMessage) (json.RawMessage, error) {
+	if reason, _ := stepCtx.GetVariableAsString("reason"); reason == "compensation" {
+		return input, nil
+	}
+
+	if slices.Contains(h.opts.FailAt, stepCtx.StepName()) {
+		return nil, fmt.Errorf("simulated failure of step %s", stepCtx.StepName())
+	}
+
+	if output, ok := h.opts.Outputs[stepCtx.StepName()]; ok {
+		return output, nil
+	}
+
+	return input, nil
+}
+
+// Simulate predicts the execution of def for input without side effects: the real engine logic runs
+// against an ephemeral memory store on a manual clock, with stub handlers in place of the registered ones.
+// Delays and retry backoff take no real time and human steps are decided as set in opts.
+func (engine *Engine) Simulate(
+	ctx context.Context,
+	def *WorkflowDefinition,
+	input json.RawMessage,
+	opts SimulationOptions,
+) (*SimulationResult, error) {
+	if err := engine.validateDefinition(def); err != nil {
+		return nil, fmt.Errorf("invalid workflow definition: %w", err)
+	}
+
+	if opts.MaxSteps <= 0 {
+		opts.MaxSteps = defaultSimulationMaxSteps
+	}
+	if len(input) == 0 {
+		input = json.RawMessage("{}")
+	}
+
+	start := engine.clock.Now()
+	clock := NewManualClock(start)
+	store := NewMemoryStore(WithStoreClock(clock))
+
+	sim := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+		WithEngineClock(clock),
+		WithLeaseReaperInterval(0),
+		WithDLQRedriveInterval(0),
+		WithDefinitionCacheTTL(0),
+	)
+	defer func() { _ = sim.Shutdown() }()
+
+	for _, stepDef := range def.Definition.Steps {
+		if stepDef.Handler != "" {
+			sim.RegisterHandler(&simulationHandler{name: stepDef.Handler, opts: &opts})
+		}
+	}
+
+	// The simulation runs unscoped, whatever namespace the caller is bound to
+	ctx = WithNamespace(context.WithoutCancel(ctx), "")
+
+	simDef := *def
+	if err := sim.RegisterWorkflow(ctx, &simDef); err != nil {
+		return nil, err
+	}
+
+	instanceID, err := sim.Start(ctx, simDef.ID, input)
+	if err != nil {
+		return nil, fmt.Errorf("start: %w", err)
+	}
+
+	if err := sim.runSimulation(ctx, store, clock, instanceID, &opts); err != nil {
+		return nil, err
+	}
+
+	return sim.simulationResult(ctx, instanceID, clock.Now().Sub(start))
+}
+
+func (engine *Engine) runSimulation(
+	ctx context.Context,
+	store *MemoryStore,
+	clock *ManualClock,
+	instanceID int64,
+	opts *SimulationOptions,
+) error {
+	for i := 0; i < opts.MaxSteps; i++ {
+		empty, err := engine.ExecuteNext(ctx, simulationWorkerID)
+		if err != nil {
+			return fmt.Errorf("execute step: %w", err)
+		}
+		if !empty {
+			continue
+		}
+
+		decided, err := engine.decideSimulatedHumanSteps(ctx, store, instanceID, opts)
+		if err != nil {
+			return err
+		}
+		if decided {
+			continue
+		}
+
+		next, ok := store.nextScheduledAt()
+		if !ok {
+			return nil
+		}
+		clock.Set(next)
+	}
+
+	return fmt.Errorf("%w within %d steps", ErrSimulationNotSettled, opts.MaxSteps)
+}
+
+// decideSimulatedHumanSteps decides the human steps waiting for a decision. Their polling queue
+// items are dropped, the decision continues the workflow.
+func (engine *Engine) decideSimulatedHumanSteps(
+	ctx context.Context,
+	store *MemoryStore,
+	instanceID int64,
+	opts *SimulationOptions,
+) (bool, error) {
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get steps: %w", err)
+	}
+
+	decided := false
+	for _, step := range steps {
+		if step.Status != StepStatusWaitingDecision {
+			continue
+		}
+
+		decision, ok := opts.Decisions[step.StepName]
+		if !ok {
+			decision = HumanDecisionConfirmed
+		}
+
+		store.removeQueuedStep(step.ID)
+		if err := engine.MakeHumanDecision(ctx, step.ID, simulationWorkerID, decision, nil); err != nil {
+			return false, fmt.Errorf("decide step %s: %w", step.StepName, err)
+		}
+		decided = true
+	}
+
+	return decided, nil
+}
+
+func (engine *Engine) simulationResult(
+	ctx context.Context,
+	instanceID int64,
+	duration time.Duration,
+) (*SimulationResult, error) {
+	instance, err := engine.store.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+
+	steps, err := engine.store.GetStepsByInstance(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get steps: %w", err)
+	}
+
+	events, err := engine.store.GetWorkflowEvents(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+
+	result := &SimulationResult{
+		Status:      instance.Status,
+		Output:      instance.Output,
+		Error:       instance.Error,
+		Executed:    []string{},
+		Compensated: []string{},
+		Conditions:  []SimulatedCondition{},
+		Joins:       []SimulatedJoin{},
+		Trace:       BuildTrace(events, steps),
+		Duration:    duration,
+	}
+
+	for _, entry := range result.Trace {
+		switch {
+		case entry.Event == EventStepStarted && entry.Detail == "":
+			result.Executed = append(result.Executed, entry.Step)
+		case entry.Event == EventStepCompleted && entry.Detail == "compensated":
+			result.Compensated = append(result.Compensated, entry.Step)
+		case entry.Event == EventConditionCheck && entry.Detail != "error":
+			result.Conditions = append(result.Conditions, SimulatedCondition{Step: entry.Step, Result: entry.Detail == "true"})
+		}
+	}
+
+	for _, step := range steps {
+		if step.StepType != StepTypeJoin {
+			continue
+		}
+
+		join := SimulatedJoin{Step: step.StepName, Status: step.Status, Completed: []string{}, Failed: []string{}}
+		if step.Status == StepStatusCompleted {
+			// The join state also tracks steps after the join, its output holds the joined branches only
+			var output struct {
+				Completed []string `json:"completed"`
+				Failed    []string `json:"failed"`
+			}
+			if err := json.Unmarshal(step.Output, &output); err == nil {
+				join.Completed = append(join.Completed, output.Completed...)
+				join.Failed = append(join.Failed, output.Failed...)
+			}
+		} else if state, err := engine.store.GetJoinState(ctx, instanceID, step.StepName); err == nil {
+			join.Completed = append(join.Completed, state.Completed...)
+			join.Failed = append(join.Failed, state.Failed...)
+		}
+		result.Joins = append(result.Joins, join)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSimulationEngine(t *testing.T) (*Engine, *MemoryStore) {
+	t.Helper()
+
+	store := NewMemoryStore()
+
+	return newMemoryTestEngine(t, store, nil), store
+}
+
+func TestEngine_SimulateConditionAndJoin(t *testing.T) {
+	ctx := context.Background()
+	engine, store := newSimulationEngine(t)
+
+	def, err := NewBuilder("simulated-order", 1).
+		Step("classify", "classify").
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("notify", "notify").
+				Condition("is-physical", `{{ eq .kind "physical" }}`, func(elseBranch *Builder) {
+					elseBranch.Step("skip-shipping", "skip")
+				}).
+				Then("ship", "ship", WithStepDelay(time.Hour))
+		}, func(branch *Builder) {
+			branch.Step("invoice", "invoice")
+		}).
+		Join("sync", JoinStrategyAll).
+		Then("finish", "finish").
+		Build()
+	require.NoError(t, err)
+
+	result, err := engine.Simulate(ctx, def, json.RawMessage(`{"order":1}`), SimulationOptions{
+		Outputs: map[string]json.RawMessage{"classify": json.RawMessage(`{"kind":"physical"}`)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusCompleted, result.Status)
+	assert.Contains(t, result.Executed, "ship")
+	assert.NotContains(t, result.Executed, "skip-shipping")
+	assert.Equal(t, []SimulatedCondition{{Step: "is-physical", Result: true}}, result.Conditions)
+	require.Len(t, result.Joins, 1)
+	assert.Equal(t, StepStatusCompleted, result.Joins[0].Status)
+	assert.ElementsMatch(t, []string{"ship", "invoice"}, result.Joins[0].Completed)
+	assert.Empty(t, result.Compensated)
+	assert.GreaterOrEqual(t, result.Duration, time.Hour)
+	assert.Equal(t, EventWorkflowCompleted, result.Trace[len(result.Trace)-1].Event)
+
+	// Nothing reached the store of the engine
+	instances, err := store.GetAllWorkflowInstances(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+	definitions, err := store.GetWorkflowDefinitions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, definitions)
+}
+
+func TestEngine_SimulateFailureRollsBack(t *testing.T) {
+	engine, _ := newSimulationEngine(t)
+
+	def, err := NewBuilder("simulated-saga", 1).
+		Step("reserve", "reserve").OnFailure("release", "release").
+		Then("charge", "charge").OnFailure("refund", "refund").
+		Then("ship", "ship", WithStepMaxRetries(2), WithStepRetryDelay(time.Minute)).
+		Build()
+	require.NoError(t, err)
+
+	result, err := engine.Simulate(context.Background(), def, nil, SimulationOptions{FailAt: []string{"ship"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusFailed, result.Status)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, []string{"reserve", "charge", "ship", "ship", "ship"}, result.Executed)
+	assert.Equal(t, []string{"charge", "reserve"}, result.Compensated)
+	assert.Contains(t, result.Trace, TraceEntry{Event: EventStepRetry, Step: "ship", Detail: "retry=1"})
+}
+
+func TestEngine_SimulateHumanDecisions(t *testing.T) {
+	engine, _ := newSimulationEngine(t)
+
+	def, err := NewBuilder("simulated-approval", 1).
+		Step("prepare", "prepare").
+		WaitHumanConfirm("approve").
+		Then("publish", "publish").
+		Build()
+	require.NoError(t, err)
+
+	confirmed, err := engine.Simulate(context.Background(), def, nil, SimulationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, confirmed.Status)
+	assert.Equal(t, []string{"prepare", "approve", "publish"}, confirmed.Executed)
+
+	rejected, err := engine.Simulate(context.Background(), def, nil, SimulationOptions{
+		Decisions: map[string]HumanDecision{"approve": HumanDecisionRejected},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusAborted, rejected.Status)
+	assert.NotContains(t, rejected.Executed, "publish")
+}
+
+func TestEngine_SimulateInvalidDefinition(t *testing.T) {
+	engine, _ := newSimulationEngine(t)
+
+	_, err := engine.Simulate(context.Background(), &WorkflowDefinition{ID: "broken"}, nil, SimulationOptions{})
+	assert.Error(t, err)
+}
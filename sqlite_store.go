@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,27 +33,30 @@ type SQLiteStore struct {
 	agingEnabled bool
 	agingRate    float64
 	mu           sync.Mutex // serialize critical sections for SQLite
+
+	notifications *notificationHub
+	clock         Clock
 }
 
 // NewSQLiteStore creates a persistent SQLite database stored in a file and initializes schema.
 // The filepath parameter specifies the path to the SQLite database file.
 // If the file doesn't exist, it will be created automatically.
-func NewSQLiteStore(filepath string) (*SQLiteStore, error) {
+func NewSQLiteStore(filepath string, opts ...StoreOption) (*SQLiteStore, error) {
 	if filepath == "" {
 		return nil, fmt.Errorf("filepath cannot be empty")
 	}
-	return newSQLiteStore(filepath, false)
+	return newSQLiteStore(filepath, false, applyStoreOptions(opts))
 }
 
 // NewSQLiteInMemoryStore creates an in-memory SQLite database and initializes schema.
 // This is useful for testing. For production use, prefer NewSQLiteStore with a file path.
-func NewSQLiteInMemoryStore() (*SQLiteStore, error) {
-	return newSQLiteStore(":memory:", true)
+func NewSQLiteInMemoryStore(opts ...StoreOption) (*SQLiteStore, error) {
+	return newSQLiteStore(":memory:", true, applyStoreOptions(opts))
 }
 
 // newSQLiteStore is an internal helper function that creates a SQLite store.
 // isInMemory indicates whether this is an in-memory database.
-func newSQLiteStore(dsn string, isInMemory bool) (*SQLiteStore, error) {
+func newSQLiteStore(dsn string, isInMemory bool, options storeOptions) (*SQLiteStore, error) {
 	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite: %w", err)
@@ -72,7 +77,13 @@ func newSQLiteStore(dsn string, isInMemory bool) (*SQLiteStore, error) {
 		db.SetMaxIdleConns(5)
 	}
 
-	store := &SQLiteStore{db: db, agingEnabled: true, agingRate: 0.5}
+	store := &SQLiteStore{
+		db:            db,
+		agingEnabled:  true,
+		agingRate:     0.5,
+		notifications: newNotificationHub(),
+		clock:         options.clock,
+	}
 	if err := RunSQLiteMigrations(context.Background(), db); err != nil {
 		_ = db.Close()
 		return nil, err
@@ -89,6 +100,11 @@ func (s *SQLiteStore) SetAgingRate(rate float64) {
 	s.agingRate = clampAgingRate(rate)
 }
 
+// Clock returns the clock set with WithStoreClock.
+func (s *SQLiteStore) Clock() Clock {
+	return s.clock
+}
+
 // clampAgingRate ensures the aging rate is within valid bounds.
 // Returns 0.0 for NaN/Inf, and clamps to [MinAgingRate, MaxAgingRate].
 func clampAgingRate(rate float64) float64 {
@@ -117,32 +133,106 @@ func (s *SQLiteStore) Close() error {
 }
 
 // Definitions
+
+// SaveWorkflowDefinition keeps names and versions unique per namespace and IDs unique across
+// namespaces. Saving an existing name and version keeps the ID of the stored definition.
 func (s *SQLiteStore) SaveWorkflowDefinition(ctx context.Context, def *WorkflowDefinition) error {
+	if def.Namespace == "" {
+		def.Namespace = namespaceOf(ctx)
+	}
+	if !visibleIn(ctx, def.Namespace) {
+		return ErrNamespaceMismatch
+	}
 	definitionJSON, err := json.Marshal(def.Definition)
 	if err != nil {
 		return err
 	}
-	const query = `INSERT INTO workflow_definitions (id, name, version, definition, created_at)
-		VALUES(?, ?, ?, ?, ?)
-		ON CONFLICT(name, version) DO UPDATE SET definition=excluded.definition`
-	if _, err := s.db.ExecContext(
-		ctx, query, def.ID, def.Name, def.Version, definitionJSON, time.Now(),
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var owner string
+	err = tx.QueryRowContext(ctx, `SELECT namespace FROM workflow_definitions WHERE id=?`, def.ID).Scan(&owner)
+	switch {
+	case err == nil && owner != def.Namespace:
+		return ErrNamespaceMismatch
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	var existingID string
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT id FROM workflow_definitions WHERE namespace=? AND name=? AND version=?`,
+		def.Namespace, def.Name, def.Version,
+	).Scan(&existingID)
+	switch {
+	case err == nil:
+		def.ID = existingID
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	const query = `INSERT INTO workflow_definitions (id, name, version, definition, namespace, created_at)
+		VALUES(?, ?, ?, ?, ?, ?)
+		ON CONFLICT(namespace, name, version) DO UPDATE SET definition=excluded.definition`
+	if _, err := tx.ExecContext(
+		ctx, query, def.ID, def.Name, def.Version, definitionJSON, def.Namespace, s.clock.Now(),
 	); err != nil {
 		return err
 	}
-	// We keep provided ID/CreatedAt; in SQLite we don't fetch RETURNING here.
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	tx = nil
+	s.notifications.publish(NotifyChannelDefinition, def.ID)
+	// We keep provided CreatedAt; in SQLite we don't fetch RETURNING here.
 	return nil
 }
 
+// sqliteNamespace is the namespace of the workflow definition referenced by the workflowID expression.
+// The expression must not be an unqualified id column, which the subquery would capture.
+func sqliteNamespace(workflowID string) string {
+	return fmt.Sprintf(
+		"COALESCE((SELECT wdn.namespace FROM workflow_definitions wdn WHERE wdn.id = %s), '%s')",
+		workflowID, DefaultNamespace,
+	)
+}
+
+// sqliteInstanceNamespace is the namespace of the instance referenced by the instanceID expression.
+func sqliteInstanceNamespace(instanceID string) string {
+	return sqliteNamespace(fmt.Sprintf("(SELECT workflow_id FROM workflow_instances WHERE id = %s)", instanceID))
+}
+
+// sqliteNamespaceFilter restricts a query to the namespace of the context, namespaceExpr being
+// the row's namespace. Unscoped contexts match any namespace.
+func sqliteNamespaceFilter(ctx context.Context, namespaceExpr string) (string, []any) {
+	namespace, ok := NamespaceFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	return fmt.Sprintf(" AND %s = ?", namespaceExpr), []any{namespace}
+}
+
 func (s *SQLiteStore) GetWorkflowDefinition(ctx context.Context, id string) (*WorkflowDefinition, error) {
-	const query = `SELECT id, name, version, definition, created_at
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, "namespace")
+	query := `SELECT id, name, version, definition, namespace, created_at
 		FROM workflow_definitions
-		WHERE id=?`
-	row := s.db.QueryRowContext(ctx, query, id)
+		WHERE id=?` + nsFilter
+	row := s.db.QueryRowContext(ctx, query, append([]any{id}, nsArgs...)...)
 	var def WorkflowDefinition
 	var defJSON []byte
 	if err := row.Scan(
-		&def.ID, &def.Name, &def.Version, &defJSON, &def.CreatedAt,
+		&def.ID, &def.Name, &def.Version, &defJSON, &def.Namespace, &def.CreatedAt,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrEntityNotFound
@@ -157,7 +247,17 @@ func (s *SQLiteStore) GetWorkflowDefinition(ctx context.Context, id string) (*Wo
 
 // Instances
 func (s *SQLiteStore) CreateInstance(ctx context.Context, workflowID string, input json.RawMessage) (*WorkflowInstance, error) {
-	now := time.Now()
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		var owner string
+		err := s.db.QueryRowContext(ctx, `SELECT `+sqliteNamespace("?"), workflowID).Scan(&owner)
+		if err != nil {
+			return nil, err
+		}
+		if owner != namespace {
+			return nil, ErrEntityNotFound
+		}
+	}
+	now := s.clock.Now()
 	const query = `INSERT INTO workflow_instances (workflow_id, status, input, created_at, updated_at)
 		VALUES(?, ?, ?, ?, ?)`
 	res, err := s.db.ExecContext(ctx, query, workflowID, StatusPending, input, now, now)
@@ -169,7 +269,7 @@ func (s *SQLiteStore) CreateInstance(ctx context.Context, workflowID string, inp
 }
 
 func (s *SQLiteStore) UpdateInstanceStatus(ctx context.Context, instanceID int64, status WorkflowStatus, output json.RawMessage, errMsg *string) error {
-	now := time.Now()
+	now := s.clock.Now()
 	// Update completed_at when status is completed, failed, or cancelled
 	// Update started_at when status is running and started_at is NULL
 	const query = `UPDATE workflow_instances 
@@ -180,19 +280,31 @@ func (s *SQLiteStore) UpdateInstanceStatus(ctx context.Context, instanceID int64
 	_, err := s.db.ExecContext(
 		ctx, query, status, output, errMsg, now, status, now, status, now, instanceID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	s.notifications.publish(NotifyChannelInstance, strconv.FormatInt(instanceID, 10))
+	return nil
+}
+
+// Subscribe delivers notifications of this process only; other processes sharing the file are not signalled.
+func (s *SQLiteStore) Subscribe(channel string) (<-chan string, func()) {
+	return s.notifications.Subscribe(channel)
 }
 
 func (s *SQLiteStore) GetInstance(ctx context.Context, instanceID int64) (*WorkflowInstance, error) {
-	const query = `SELECT id, workflow_id, status, input, output, error,
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("wi.workflow_id"))
+	query := `SELECT id, workflow_id, ` + sqliteNamespace("wi.workflow_id") + `, status, input, output, error,
+			(SELECT json_group_object(key, value) FROM workflow_instance_labels WHERE instance_id = wi.id),
 			started_at, completed_at, created_at, updated_at
-		FROM workflow_instances
-		WHERE id=?`
-	row := s.db.QueryRowContext(ctx, query, instanceID)
+		FROM workflow_instances wi
+		WHERE id=?` + nsFilter
+	row := s.db.QueryRowContext(ctx, query, append([]any{instanceID}, nsArgs...)...)
 	var inst WorkflowInstance
 	var inputBytes, outputBytes []byte
+	var labels string
 	if err := row.Scan(
-		&inst.ID, &inst.WorkflowID, &inst.Status, &inputBytes, &outputBytes, &inst.Error,
+		&inst.ID, &inst.WorkflowID, &inst.Namespace, &inst.Status, &inputBytes, &outputBytes, &inst.Error, &labels,
 		&inst.StartedAt, &inst.CompletedAt, &inst.CreatedAt, &inst.UpdatedAt,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -200,6 +312,9 @@ func (s *SQLiteStore) GetInstance(ctx context.Context, instanceID int64) (*Workf
 		}
 		return nil, err
 	}
+	if err := decodeSQLiteLabels(labels, &inst); err != nil {
+		return nil, err
+	}
 	inst.Input = json.RawMessage(inputBytes)
 	if outputBytes != nil {
 		inst.Output = json.RawMessage(outputBytes)
@@ -209,9 +324,20 @@ func (s *SQLiteStore) GetInstance(ctx context.Context, instanceID int64) (*Workf
 	return &inst, nil
 }
 
+func (s *SQLiteStore) CountRunningInstances(ctx context.Context, namespace string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM workflow_instances
+			WHERE status IN ('pending', 'running', 'rolling_back', 'cancelling') AND `+sqliteNamespace("workflow_id")+` = ?`,
+		namespace,
+	).Scan(&count)
+	return count, err
+}
+
 // Steps
 func (s *SQLiteStore) CreateStep(ctx context.Context, step *WorkflowStep) error {
-	now := time.Now()
+	now := s.clock.Now()
 	const query = `INSERT INTO workflow_steps (
 		instance_id, step_name, step_type, status, input, output, error, retry_count,
 		max_retries, compensation_retry_count, idempotency_key, started_at, completed_at, created_at)
@@ -231,7 +357,7 @@ func (s *SQLiteStore) CreateStep(ctx context.Context, step *WorkflowStep) error
 }
 
 func (s *SQLiteStore) UpdateStep(ctx context.Context, stepID int64, status StepStatus, output json.RawMessage, errMsg *string) error {
-	now := time.Now()
+	now := s.clock.Now()
 	// Update completed_at when status is completed, failed, skipped, or rolled_back
 	// Update started_at when status is running and started_at is NULL
 	// Increment retry_count when status is failed
@@ -245,6 +371,23 @@ func (s *SQLiteStore) UpdateStep(ctx context.Context, stepID int64, status StepS
 	return err
 }
 
+func (s *SQLiteStore) SkipPendingStep(ctx context.Context, stepID int64, output json.RawMessage) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE workflow_steps
+			SET status='skipped', output=?, error=NULL, completed_at=?
+			WHERE id=? AND status IN ('pending', 'failed', 'waiting_decision')`,
+		output, s.clock.Now(), stepID,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrStepNotSkippable
+	}
+	return nil
+}
+
 func (s *SQLiteStore) GetStepsByInstance(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
 	const query = `SELECT id, instance_id, step_name, step_type, status, input, output, error,
 			retry_count, max_retries, compensation_retry_count, idempotency_key,
@@ -279,14 +422,103 @@ func (s *SQLiteStore) GetStepsByInstance(ctx context.Context, instanceID int64)
 }
 
 // Queue
-func (s *SQLiteStore) EnqueueStep(ctx context.Context, instanceID int64, stepID *int64, priority Priority, delay time.Duration) error {
-	sched := time.Now().Add(delay)
-	const query = `INSERT INTO queue (instance_id, step_id, scheduled_at, priority)
-		VALUES(?, ?, ?, ?)`
-	_, err := s.db.ExecContext(ctx, query, instanceID, stepID, sched, int(priority))
+func (s *SQLiteStore) EnqueueStep(
+	ctx context.Context,
+	instanceID int64,
+	stepID *int64,
+	taskQueue string,
+	priority Priority,
+	delay time.Duration,
+) error {
+	sched := s.clock.Now().Add(delay)
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	if err := s.insertQueueItem(ctx, tx, instanceID, stepID, taskQueue, sched, priority); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	tx = nil
+	s.notifications.publish(NotifyChannelQueue, queueNotifyPayload(sched))
+	return nil
+}
+
+// insertQueueItem adds a queue row and, outside the default queue, its task queue.
+func (s *SQLiteStore) insertQueueItem(
+	ctx context.Context,
+	tx *sql.Tx,
+	instanceID int64,
+	stepID *int64,
+	taskQueue string,
+	scheduledAt time.Time,
+	priority Priority,
+) error {
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO queue (instance_id, step_id, scheduled_at, priority)
+			VALUES(?, ?, ?, ?)`,
+		instanceID, stepID, scheduledAt, int(priority),
+	)
+	if err != nil {
+		return err
+	}
+	if taskQueue == "" {
+		return nil
+	}
+	queueID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO queue_task_queues (queue_id, task_queue) VALUES(?, ?)`,
+		queueID, taskQueue,
+	)
 	return err
 }
 
+// sqliteTaskQueue is the task queue of the queue row with the given alias.
+func sqliteTaskQueue(alias string) string {
+	return fmt.Sprintf("COALESCE((SELECT task_queue FROM queue_task_queues WHERE queue_id = %s.id), '')", alias)
+}
+
+// sqliteTaskQueueFilter restricts a queue query to taskQueues; nil matches any queue.
+func sqliteTaskQueueFilter(alias string, taskQueues []string) (string, []any) {
+	if taskQueues == nil {
+		return "", nil
+	}
+	if len(taskQueues) == 0 {
+		return " AND 0", nil
+	}
+	args := make([]any, len(taskQueues))
+	for i, queue := range taskQueues {
+		args[i] = queue
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(taskQueues)), ",")
+	return fmt.Sprintf(" AND %s IN (%s)", sqliteTaskQueue(alias), placeholders), args
+}
+
+func (s *SQLiteStore) GetQueueLength(ctx context.Context, taskQueues []string) (int, error) {
+	queueFilter, queueArgs := sqliteTaskQueueFilter("queue", taskQueues)
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteInstanceNamespace("queue.instance_id"))
+	args := append(append([]any{s.clock.Now()}, queueArgs...), nsArgs...)
+	var count int
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM queue WHERE scheduled_at <= ? AND attempted_by IS NULL`+queueFilter+nsFilter,
+		args...,
+	).Scan(&count)
+	return count, err
+}
+
 func (s *SQLiteStore) UpdateStepCompensationRetry(ctx context.Context, stepID int64, retryCount int, status StepStatus) error {
 	const query = `UPDATE workflow_steps
 		SET compensation_retry_count=?, status=?
@@ -295,7 +527,25 @@ func (s *SQLiteStore) UpdateStepCompensationRetry(ctx context.Context, stepID in
 	return err
 }
 
-func (s *SQLiteStore) DequeueStep(ctx context.Context, workerID string) (*QueueItem, error) {
+// queueOrderExpr returns the effective priority expression used to order the queue.
+// scheduled_at holds Go's time.String() form which strftime cannot parse, so only its
+// "YYYY-MM-DD HH:MM:SS" prefix is used and the wait is measured against now in the same zone.
+func (s *SQLiteStore) queueOrderExpr(now time.Time) string {
+	if !s.agingEnabled {
+		return "priority"
+	}
+
+	// Clamp aging rate to ensure valid SQL expression (defense in depth)
+	rate := clampAgingRate(s.agingRate)
+	// effective_priority = min(100, priority + floor(wait_seconds * rate))
+	return fmt.Sprintf(
+		"MIN(100, priority + CAST(((strftime('%%s','%s') - strftime('%%s', substr(scheduled_at, 1, 19))) * %.6f) AS INTEGER))",
+		now.Format(time.DateTime),
+		rate,
+	)
+}
+
+func (s *SQLiteStore) DequeueStep(ctx context.Context, workerID string, taskQueues []string) (*QueueItem, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	// Simple transactional dequeue.
@@ -309,33 +559,27 @@ func (s *SQLiteStore) DequeueStep(ctx context.Context, workerID string) (*QueueI
 		}
 	}()
 
-	orderExpr := "priority"
-	if s.agingEnabled {
-		// Clamp aging rate to ensure valid SQL expression (defense in depth)
-		rate := clampAgingRate(s.agingRate)
-		// effective_priority = min(100, priority + floor(wait_seconds * rate))
-		orderExpr = fmt.Sprintf(
-			"MIN(100, priority + CAST(((strftime('%%s','now') - strftime('%%s', scheduled_at)) * %.6f) AS INTEGER))",
-			rate,
-		)
-	}
+	orderExpr := s.queueOrderExpr(s.clock.Now())
+	queueFilter, queueArgs := sqliteTaskQueueFilter("queue", taskQueues)
+	namespaceExpr := sqliteInstanceNamespace("queue.instance_id")
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, namespaceExpr)
 
 	row := tx.QueryRowContext(
 		ctx,
 		fmt.Sprintf(`
-			SELECT id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority
+			SELECT id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority, %s, %s
 			FROM queue
-			WHERE scheduled_at <= ? AND (attempted_by IS NULL)
+			WHERE scheduled_at <= ? AND (attempted_by IS NULL)%s%s
 			ORDER BY %s DESC, scheduled_at ASC, id ASC
 			LIMIT 1`,
-			orderExpr,
+			sqliteTaskQueue("queue"), namespaceExpr, queueFilter, nsFilter, orderExpr,
 		),
-		time.Now(),
+		append(append([]any{s.clock.Now()}, queueArgs...), nsArgs...)...,
 	)
 	var qi QueueItem
 	if err := row.Scan(
 		&qi.ID, &qi.InstanceID, &qi.StepID, &qi.ScheduledAt,
-		&qi.AttemptedAt, &qi.AttemptedBy, &qi.Priority,
+		&qi.AttemptedAt, &qi.AttemptedBy, &qi.Priority, &qi.TaskQueue, &qi.Namespace,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -343,7 +587,7 @@ func (s *SQLiteStore) DequeueStep(ctx context.Context, workerID string) (*QueueI
 		return nil, err
 	}
 	// mark as attempted by worker
-	now := time.Now()
+	now := s.clock.Now()
 	res, err := tx.ExecContext(
 		ctx,
 		`UPDATE queue
@@ -368,14 +612,230 @@ func (s *SQLiteStore) DequeueStep(ctx context.Context, workerID string) (*QueueI
 	return &qi, nil
 }
 
+func (s *SQLiteStore) DequeueSteps(
+	ctx context.Context,
+	workerID string,
+	taskQueues []string,
+	n int,
+) ([]QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	orderExpr := s.queueOrderExpr(s.clock.Now())
+	queueFilter, queueArgs := sqliteTaskQueueFilter("queue", taskQueues)
+	namespaceExpr := sqliteInstanceNamespace("queue.instance_id")
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, namespaceExpr)
+	args := append(append([]any{s.clock.Now()}, queueArgs...), nsArgs...)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		fmt.Sprintf(`
+			SELECT id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority, %s, %s
+			FROM queue
+			WHERE scheduled_at <= ? AND (attempted_by IS NULL)%s%s
+			ORDER BY %s DESC, scheduled_at ASC, id ASC
+			LIMIT ?`,
+			sqliteTaskQueue("queue"), namespaceExpr, queueFilter, nsFilter, orderExpr,
+		),
+		append(args, n)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var items []QueueItem
+	for rows.Next() {
+		var qi QueueItem
+		if err := rows.Scan(
+			&qi.ID, &qi.InstanceID, &qi.StepID, &qi.ScheduledAt,
+			&qi.AttemptedAt, &qi.AttemptedBy, &qi.Priority, &qi.TaskQueue, &qi.Namespace,
+		); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		items = append(items, qi)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	for i := range items {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE queue
+				SET attempted_at=?, attempted_by=?
+				WHERE id=?`,
+			now, workerID, items[i].ID,
+		); err != nil {
+			return nil, err
+		}
+		items[i].AttemptedAt = &now
+		items[i].AttemptedBy = &workerID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	tx = nil
+	return items, nil
+}
+
 func (s *SQLiteStore) RemoveFromQueue(ctx context.Context, queueID int64) error {
+	if err := s.deleteQueueLease(ctx, queueID); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM queue_task_queues WHERE queue_id=?`, queueID); err != nil {
+		return err
+	}
 	_, err := s.db.ExecContext(
 		ctx, `DELETE FROM queue WHERE id=?`, queueID,
 	)
 	return err
 }
 
+func (s *SQLiteStore) LeaseQueueItem(
+	ctx context.Context,
+	queueID int64,
+	workerID, leaseToken string,
+	leaseUntil time.Time,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO queue_leases (queue_id, lease_token, lease_expires_at)
+			SELECT id, ?, ? FROM queue WHERE id=? AND attempted_by=?
+			ON CONFLICT (queue_id) DO NOTHING`,
+		leaseToken, leaseUntil.UTC(), queueID, workerID,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *SQLiteStore) RenewQueueItemLease(ctx context.Context, queueID int64, leaseToken string, leaseUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE queue_leases
+			SET lease_expires_at=?
+			WHERE queue_id=? AND lease_token=?`,
+		leaseUntil.UTC(), queueID, leaseToken,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *SQLiteStore) ReclaimExpiredQueueItems(
+	ctx context.Context,
+	workerID string,
+	now, claimedBefore time.Time,
+	limit int,
+) ([]QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Claimed items are few (one per busy worker), so the times are compared here
+	// rather than on the stored strings
+	namespaceExpr := sqliteInstanceNamespace("q.instance_id")
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, namespaceExpr)
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT q.id, q.instance_id, q.step_id, q.scheduled_at, q.attempted_at, q.attempted_by, q.priority,
+				`+sqliteTaskQueue("q")+`, `+namespaceExpr+`, l.lease_token, l.lease_expires_at
+			FROM queue q
+			LEFT JOIN queue_leases l ON l.queue_id = q.id
+			WHERE q.attempted_by IS NOT NULL`+nsFilter+`
+			ORDER BY q.id`,
+		nsArgs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var items []QueueItem
+	for rows.Next() {
+		var qi QueueItem
+		if err := rows.Scan(
+			&qi.ID, &qi.InstanceID, &qi.StepID, &qi.ScheduledAt,
+			&qi.AttemptedAt, &qi.AttemptedBy, &qi.Priority,
+			&qi.TaskQueue, &qi.Namespace, &qi.LeaseToken, &qi.LeaseExpiresAt,
+		); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		expired := qi.LeaseExpiresAt != nil && qi.LeaseExpiresAt.Before(now)
+		abandoned := qi.LeaseToken == nil && qi.AttemptedAt != nil && qi.AttemptedAt.Before(claimedBefore)
+		if expired || abandoned {
+			items = append(items, qi)
+		}
+		if len(items) >= limit {
+			break
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM queue_leases WHERE queue_id=?`, items[i].ID); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE queue
+				SET attempted_at=?, attempted_by=?
+				WHERE id=?`,
+			now, workerID, items[i].ID,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	tx = nil
+	return items, nil
+}
+
+// deleteQueueLease ends the lease of a queue item that is released or removed.
+func (s *SQLiteStore) deleteQueueLease(ctx context.Context, queueID int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM queue_leases WHERE queue_id=?`, queueID)
+	return err
+}
+
 func (s *SQLiteStore) ReleaseQueueItem(ctx context.Context, queueID int64) error {
+	if err := s.deleteQueueLease(ctx, queueID); err != nil {
+		return err
+	}
 	_, err := s.db.ExecContext(
 		ctx,
 		`UPDATE queue
@@ -383,11 +843,18 @@ func (s *SQLiteStore) ReleaseQueueItem(ctx context.Context, queueID int64) error
 			WHERE id=?`,
 		queueID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	s.notifications.publish(NotifyChannelQueue, "")
+	return nil
 }
 
 func (s *SQLiteStore) RescheduleAndReleaseQueueItem(ctx context.Context, queueID int64, delay time.Duration) error {
-	sched := time.Now().Add(delay)
+	if err := s.deleteQueueLease(ctx, queueID); err != nil {
+		return err
+	}
+	sched := s.clock.Now().Add(delay)
 	_, err := s.db.ExecContext(
 		ctx,
 		`UPDATE queue
@@ -395,7 +862,11 @@ func (s *SQLiteStore) RescheduleAndReleaseQueueItem(ctx context.Context, queueID
 			WHERE id=?`,
 		sched, queueID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	s.notifications.publish(NotifyChannelQueue, queueNotifyPayload(sched))
+	return nil
 }
 
 func (s *SQLiteStore) LogEvent(ctx context.Context, instanceID int64, stepID *int64, eventType string, payload any) error {
@@ -407,19 +878,21 @@ func (s *SQLiteStore) LogEvent(ctx context.Context, instanceID int64, stepID *in
 		ctx,
 		`INSERT INTO workflow_events (instance_id, step_id, event_type, payload, created_at)
 			VALUES(?, ?, ?, ?, ?)`,
-		instanceID, stepID, eventType, payloadJSON, time.Now(),
+		instanceID, stepID, eventType, payloadJSON, s.clock.Now(),
 	)
 	return err
 }
 
 func (s *SQLiteStore) GetWorkflowEvents(ctx context.Context, instanceID int64) ([]WorkflowEvent, error) {
+	namespaceExpr := sqliteInstanceNamespace("workflow_events.instance_id")
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, namespaceExpr)
 	rows, err := s.db.QueryContext(
 		ctx,
-		`SELECT id, instance_id, step_id, event_type, payload, created_at
+		`SELECT id, instance_id, step_id, event_type, payload, `+namespaceExpr+`, created_at
 			FROM workflow_events
-			WHERE instance_id=?
+			WHERE instance_id=?`+nsFilter+`
 			ORDER BY id`,
-		instanceID,
+		append([]any{instanceID}, nsArgs...)...,
 	)
 	if err != nil {
 		return nil, err
@@ -428,7 +901,7 @@ func (s *SQLiteStore) GetWorkflowEvents(ctx context.Context, instanceID int64) (
 	var res []WorkflowEvent
 	for rows.Next() {
 		var ev WorkflowEvent
-		if err := rows.Scan(&ev.ID, &ev.InstanceID, &ev.StepID, &ev.EventType, &ev.Payload, &ev.CreatedAt); err != nil {
+		if err := rows.Scan(&ev.ID, &ev.InstanceID, &ev.StepID, &ev.EventType, &ev.Payload, &ev.Namespace, &ev.CreatedAt); err != nil {
 			return nil, err
 		}
 		res = append(res, ev)
@@ -436,9 +909,68 @@ func (s *SQLiteStore) GetWorkflowEvents(ctx context.Context, instanceID int64) (
 	return res, nil
 }
 
+func (s *SQLiteStore) GetEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter EventFilter,
+	limit int,
+) ([]WorkflowEvent, error) {
+	conds := []string{"e.id > ?"}
+	args := []any{afterID}
+	if filter.InstanceID != 0 {
+		conds = append(conds, "e.instance_id = ?")
+		args = append(args, filter.InstanceID)
+	}
+	if filter.WorkflowID != "" {
+		conds = append(conds, "i.workflow_id = ?")
+		args = append(args, filter.WorkflowID)
+	}
+	if len(filter.EventTypes) > 0 {
+		conds = append(conds, "e.event_type IN (?"+strings.Repeat(",?", len(filter.EventTypes)-1)+")")
+		for _, eventType := range filter.EventTypes {
+			args = append(args, eventType)
+		}
+	}
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		conds = append(conds, sqliteNamespace("i.workflow_id")+" = ?")
+		args = append(args, namespace)
+	}
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT e.id, e.instance_id, e.step_id, e.event_type, e.payload, `+sqliteNamespace("i.workflow_id")+`, e.created_at
+			FROM workflow_events e
+			JOIN workflow_instances i ON i.id = e.instance_id
+			WHERE `+strings.Join(conds, " AND ")+`
+			ORDER BY e.id
+			LIMIT ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []WorkflowEvent
+	for rows.Next() {
+		var ev WorkflowEvent
+		if err := rows.Scan(&ev.ID, &ev.InstanceID, &ev.StepID, &ev.EventType, &ev.Payload, &ev.Namespace, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, ev)
+	}
+	return res, rows.Err()
+}
+
+func (s *SQLiteStore) GetLastEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM workflow_events`).Scan(&id)
+	return id, err
+}
+
 func (s *SQLiteStore) CreateJoinState(ctx context.Context, instanceID int64, joinStepName string, waitingFor []string, strategy JoinStrategy) error {
 	wf, _ := json.Marshal(waitingFor)
-	now := time.Now()
+	now := s.clock.Now()
 	_, err := s.db.ExecContext(
 		ctx,
 		`INSERT INTO join_states (
@@ -500,7 +1032,7 @@ func (s *SQLiteStore) UpdateJoinState(ctx context.Context, instanceID int64, joi
 		`UPDATE join_states
 			SET completed=?, failed=?, is_ready=?, updated_at=?
 			WHERE instance_id=? AND join_step_name=?`,
-		string(compJSON), string(failJSON), boolToInt(isReady), time.Now(),
+		string(compJSON), string(failJSON), boolToInt(isReady), s.clock.Now(),
 		instanceID, joinStepName,
 	)
 	return isReady, err
@@ -559,7 +1091,7 @@ func (s *SQLiteStore) AddToJoinWaitFor(ctx context.Context, instanceID int64, jo
 	waitingFor = append(waitingFor, stepToAdd)
 	isReady := checkJoinReady(waitingFor, completed, failed, strategy)
 	wfJSON, _ := json.Marshal(waitingFor)
-	_, err := s.db.ExecContext(ctx, `UPDATE join_states SET waiting_for=?, is_ready=?, updated_at=? WHERE instance_id=? AND join_step_name=?`, string(wfJSON), boolToInt(isReady), time.Now(), instanceID, joinStepName)
+	_, err := s.db.ExecContext(ctx, `UPDATE join_states SET waiting_for=?, is_ready=?, updated_at=? WHERE instance_id=? AND join_step_name=?`, string(wfJSON), boolToInt(isReady), s.clock.Now(), instanceID, joinStepName)
 	return err
 }
 
@@ -590,18 +1122,20 @@ func (s *SQLiteStore) ReplaceInJoinWaitFor(ctx context.Context, instanceID int64
 	}
 	isReady := checkJoinReady(waitingFor, completed, failed, strategy)
 	wfJSON, _ := json.Marshal(waitingFor)
-	_, err := s.db.ExecContext(ctx, `UPDATE join_states SET waiting_for=?, is_ready=?, updated_at=? WHERE instance_id=? AND join_step_name=?`, string(wfJSON), boolToInt(isReady), time.Now(), instanceID, joinStepName)
+	_, err := s.db.ExecContext(ctx, `UPDATE join_states SET waiting_for=?, is_ready=?, updated_at=? WHERE instance_id=? AND join_step_name=?`, string(wfJSON), boolToInt(isReady), s.clock.Now(), instanceID, joinStepName)
 	return err
 }
 
 func (s *SQLiteStore) GetSummaryStats(ctx context.Context) (*SummaryStats, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("workflow_id"))
 	row := s.db.QueryRowContext(ctx, `SELECT 
 		COUNT(*) as total,
-		SUM(CASE WHEN status='completed' THEN 1 ELSE 0 END),
-		SUM(CASE WHEN status='failed' THEN 1 ELSE 0 END),
-		SUM(CASE WHEN status='running' THEN 1 ELSE 0 END),
-		SUM(CASE WHEN status='pending' THEN 1 ELSE 0 END)
-		FROM workflow_instances`)
+		COALESCE(SUM(CASE WHEN status='completed' THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN status='failed' THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN status='running' THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN status='pending' THEN 1 ELSE 0 END), 0)
+		FROM workflow_instances
+		WHERE 1=1`+nsFilter, nsArgs...)
 	var stats SummaryStats
 	if err := row.Scan(&stats.TotalWorkflows, &stats.CompletedWorkflows, &stats.FailedWorkflows,
 		&stats.RunningWorkflows, &stats.PendingWorkflows); err != nil {
@@ -612,6 +1146,7 @@ func (s *SQLiteStore) GetSummaryStats(ctx context.Context) (*SummaryStats, error
 	return &stats, nil
 }
 func (s *SQLiteStore) GetActiveInstances(ctx context.Context) ([]ActiveWorkflowInstance, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("wi.workflow_id"))
 	rows, err := s.db.QueryContext(
 		ctx,
 		`SELECT
@@ -623,8 +1158,9 @@ func (s *SQLiteStore) GetActiveInstances(ctx context.Context) ([]ActiveWorkflowI
 			(SELECT COUNT(*) FROM workflow_steps WHERE instance_id=wi.id AND status='rolled_back') as rolled_back_steps
 		FROM workflow_instances wi
 		LEFT JOIN workflow_definitions wd ON wi.workflow_id = wd.id
-		WHERE wi.status IN ('running','pending','dlq')
+		WHERE wi.status IN ('running','pending','dlq')`+nsFilter+`
 		ORDER BY wi.created_at DESC`,
+		nsArgs...,
 	)
 	if err != nil {
 		return nil, err
@@ -649,11 +1185,14 @@ func (s *SQLiteStore) GetActiveInstances(ctx context.Context) ([]ActiveWorkflowI
 	return res, nil
 }
 func (s *SQLiteStore) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowDefinition, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, "namespace")
 	rows, err := s.db.QueryContext(
 		ctx,
-		`SELECT id, name, version, definition, created_at
+		`SELECT id, name, version, definition, namespace, created_at
 			FROM workflow_definitions
+			WHERE 1=1`+nsFilter+`
 			ORDER BY created_at DESC`,
+		nsArgs...,
 	)
 	if err != nil {
 		return nil, err
@@ -663,7 +1202,7 @@ func (s *SQLiteStore) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowDef
 	for rows.Next() {
 		var d WorkflowDefinition
 		var defJSON []byte
-		if err := rows.Scan(&d.ID, &d.Name, &d.Version, &defJSON, &d.CreatedAt); err != nil {
+		if err := rows.Scan(&d.ID, &d.Name, &d.Version, &defJSON, &d.Namespace, &d.CreatedAt); err != nil {
 			return nil, err
 		}
 		_ = json.Unmarshal(defJSON, &d.Definition)
@@ -672,14 +1211,15 @@ func (s *SQLiteStore) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowDef
 	return res, nil
 }
 func (s *SQLiteStore) GetWorkflowInstances(ctx context.Context, workflowID string) ([]WorkflowInstance, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("workflow_id"))
 	rows, err := s.db.QueryContext(
 		ctx,
-		`SELECT id, workflow_id, status, input, output, error,
+		`SELECT id, workflow_id, `+sqliteNamespace("workflow_id")+`, status, input, output, error,
 			started_at, completed_at, created_at, updated_at
 			FROM workflow_instances
-			WHERE workflow_id=?
+			WHERE workflow_id=?`+nsFilter+`
 			ORDER BY id`,
-		workflowID,
+		append([]any{workflowID}, nsArgs...)...,
 	)
 	if err != nil {
 		return nil, err
@@ -689,7 +1229,7 @@ func (s *SQLiteStore) GetWorkflowInstances(ctx context.Context, workflowID strin
 	for rows.Next() {
 		var inst WorkflowInstance
 		var inb, outb []byte
-		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Status, &inb, &outb, &inst.Error, &inst.StartedAt,
+		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Namespace, &inst.Status, &inb, &outb, &inst.Error, &inst.StartedAt,
 			&inst.CompletedAt, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
 			return nil, err
 		}
@@ -702,12 +1242,15 @@ func (s *SQLiteStore) GetWorkflowInstances(ctx context.Context, workflowID strin
 	return res, nil
 }
 func (s *SQLiteStore) GetAllWorkflowInstances(ctx context.Context) ([]WorkflowInstance, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("workflow_id"))
 	rows, err := s.db.QueryContext(
 		ctx,
-		`SELECT id, workflow_id, status, input, output, error,
+		`SELECT id, workflow_id, `+sqliteNamespace("workflow_id")+`, status, input, output, error,
 			started_at, completed_at, created_at, updated_at
 			FROM workflow_instances
+			WHERE 1=1`+nsFilter+`
 			ORDER BY id`,
+		nsArgs...,
 	)
 	if err != nil {
 		return nil, err
@@ -717,7 +1260,7 @@ func (s *SQLiteStore) GetAllWorkflowInstances(ctx context.Context) ([]WorkflowIn
 	for rows.Next() {
 		var inst WorkflowInstance
 		var inb, outb []byte
-		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Status, &inb, &outb, &inst.Error, &inst.StartedAt,
+		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Namespace, &inst.Status, &inb, &outb, &inst.Error, &inst.StartedAt,
 			&inst.CompletedAt, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
 			return nil, err
 		}
@@ -731,7 +1274,9 @@ func (s *SQLiteStore) GetAllWorkflowInstances(ctx context.Context) ([]WorkflowIn
 }
 
 func (s *SQLiteStore) GetWorkflowInstancesPaginated(ctx context.Context, workflowID string, offset int, limit int) ([]WorkflowInstance, int64, error) {
-	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workflow_instances WHERE workflow_id=?`, workflowID)
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("workflow_id"))
+	args := append([]any{workflowID}, nsArgs...)
+	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workflow_instances WHERE workflow_id=?`+nsFilter, args...)
 	var total int64
 	if err := row.Scan(&total); err != nil {
 		return nil, 0, err
@@ -739,13 +1284,13 @@ func (s *SQLiteStore) GetWorkflowInstancesPaginated(ctx context.Context, workflo
 
 	rows, err := s.db.QueryContext(
 		ctx,
-		`SELECT id, workflow_id, status, input, output, error,
+		`SELECT id, workflow_id, `+sqliteNamespace("workflow_id")+`, status, input, output, error,
 			started_at, completed_at, created_at, updated_at
 			FROM workflow_instances
-			WHERE workflow_id=?
+			WHERE workflow_id=?`+nsFilter+`
 			ORDER BY created_at DESC
 			LIMIT ? OFFSET ?`,
-		workflowID, limit, offset,
+		append(args, limit, offset)...,
 	)
 	if err != nil {
 		return nil, 0, err
@@ -756,7 +1301,7 @@ func (s *SQLiteStore) GetWorkflowInstancesPaginated(ctx context.Context, workflo
 	for rows.Next() {
 		var inst WorkflowInstance
 		var inb, outb []byte
-		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Status, &inb, &outb, &inst.Error, &inst.StartedAt,
+		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Namespace, &inst.Status, &inb, &outb, &inst.Error, &inst.StartedAt,
 			&inst.CompletedAt, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
 			return nil, 0, err
 		}
@@ -770,7 +1315,8 @@ func (s *SQLiteStore) GetWorkflowInstancesPaginated(ctx context.Context, workflo
 }
 
 func (s *SQLiteStore) GetAllWorkflowInstancesPaginated(ctx context.Context, offset int, limit int) ([]WorkflowInstance, int64, error) {
-	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workflow_instances`)
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("workflow_id"))
+	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workflow_instances WHERE 1=1`+nsFilter, nsArgs...)
 	var total int64
 	if err := row.Scan(&total); err != nil {
 		return nil, 0, err
@@ -778,12 +1324,13 @@ func (s *SQLiteStore) GetAllWorkflowInstancesPaginated(ctx context.Context, offs
 
 	rows, err := s.db.QueryContext(
 		ctx,
-		`SELECT id, workflow_id, status, input, output, error,
+		`SELECT id, workflow_id, `+sqliteNamespace("workflow_id")+`, status, input, output, error,
 			started_at, completed_at, created_at, updated_at
 			FROM workflow_instances
+			WHERE 1=1`+nsFilter+`
 			ORDER BY created_at DESC
 			LIMIT ? OFFSET ?`,
-		limit, offset,
+		append(nsArgs, limit, offset)...,
 	)
 	if err != nil {
 		return nil, 0, err
@@ -794,7 +1341,7 @@ func (s *SQLiteStore) GetAllWorkflowInstancesPaginated(ctx context.Context, offs
 	for rows.Next() {
 		var inst WorkflowInstance
 		var inb, outb []byte
-		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Status, &inb, &outb, &inst.Error,
+		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Namespace, &inst.Status, &inb, &outb, &inst.Error,
 			&inst.StartedAt, &inst.CompletedAt, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
 			return nil, 0, err
 		}
@@ -807,6 +1354,174 @@ func (s *SQLiteStore) GetAllWorkflowInstancesPaginated(ctx context.Context, offs
 	return res, total, nil
 }
 
+func (s *SQLiteStore) SetInstanceLabels(ctx context.Context, instanceID int64, labels map[string]string) error {
+	var exists int
+	row := s.db.QueryRowContext(ctx, `SELECT 1 FROM workflow_instances WHERE id=?`, instanceID)
+	if err := row.Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEntityNotFound
+		}
+		return err
+	}
+
+	for key, value := range labels {
+		_, err := s.db.ExecContext(ctx, `INSERT INTO workflow_instance_labels (instance_id, key, value)
+			VALUES (?, ?, ?)
+			ON CONFLICT(instance_id, key) DO UPDATE SET value=excluded.value`,
+			instanceID, key, value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sqliteSortableTime strips the monotonic clock suffix ("m=+0.001") that the driver
+// writes for time.Now() values, so that equal timestamps compare as equal.
+func sqliteSortableTime(column string) string {
+	return fmt.Sprintf("substr(%[1]s, 1, instr(%[1]s || ' m=', ' m=') - 1)", column)
+}
+
+func (s *SQLiteStore) SearchInstances(ctx context.Context, query InstanceQuery) (*InstanceSearchResult, error) {
+	cursor, err := query.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		conds []string
+		args  []any
+	)
+
+	if len(query.Statuses) > 0 {
+		placeholders := make([]string, 0, len(query.Statuses))
+		for _, status := range query.Statuses {
+			placeholders = append(placeholders, "?")
+			args = append(args, string(status))
+		}
+		conds = append(conds, "wi.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if query.WorkflowID != "" {
+		conds = append(conds, "wi.workflow_id = ?")
+		args = append(args, query.WorkflowID)
+	}
+	if query.WorkflowName != "" {
+		conds = append(conds, "wi.workflow_id IN (SELECT id FROM workflow_definitions WHERE name = ?)")
+		args = append(args, query.WorkflowName)
+	}
+	if query.CreatedFrom != nil {
+		conds = append(conds, "wi.created_at >= ?")
+		args = append(args, *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		conds = append(conds, "wi.created_at < ?")
+		args = append(args, *query.CreatedTo)
+	}
+	if query.UpdatedFrom != nil {
+		conds = append(conds, "wi.updated_at >= ?")
+		args = append(args, *query.UpdatedFrom)
+	}
+	if query.UpdatedTo != nil {
+		conds = append(conds, "wi.updated_at < ?")
+		args = append(args, *query.UpdatedTo)
+	}
+	for key, value := range query.Labels {
+		conds = append(conds, "EXISTS (SELECT 1 FROM workflow_instance_labels l WHERE l.instance_id = wi.id AND l.key = ? AND l.value = ?)")
+		args = append(args, key, value)
+	}
+	for _, key := range query.WithoutLabels {
+		conds = append(conds, "NOT EXISTS (SELECT 1 FROM workflow_instance_labels l WHERE l.instance_id = wi.id AND l.key = ?)")
+		args = append(args, key)
+	}
+	if query.ErrorContains != "" {
+		conds = append(conds, "instr(lower(wi.error), lower(?)) > 0")
+		args = append(args, query.ErrorContains)
+	}
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		conds = append(conds, sqliteNamespace("wi.workflow_id")+" = ?")
+		args = append(args, namespace)
+	}
+
+	cmp, dir := "<", "DESC"
+	if query.SortOrder == SortOrderAsc {
+		cmp, dir = ">", "ASC"
+	}
+
+	sortExpr := sqliteSortableTime("wi." + string(query.SortBy))
+	orderBy := fmt.Sprintf("%s %s, wi.id %s", sortExpr, dir, dir)
+	if query.SortBy == InstanceSortByID {
+		orderBy = fmt.Sprintf("wi.id %s", dir)
+	}
+
+	if cursor != nil {
+		if query.SortBy == InstanceSortByID {
+			conds = append(conds, "wi.id "+cmp+" ?")
+			args = append(args, cursor.ID)
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s, wi.id) %s (?, ?)", sortExpr, cmp))
+			args = append(args, cursor.At, cursor.ID)
+		}
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, query.Limit+1)
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`SELECT wi.id, wi.workflow_id, %s, wi.status, wi.input, wi.output, wi.error,
+			(SELECT json_group_object(key, value) FROM workflow_instance_labels WHERE instance_id = wi.id),
+			wi.started_at, wi.completed_at, wi.created_at, wi.updated_at
+		FROM workflow_instances wi
+		%s
+		ORDER BY %s
+		LIMIT ?`, sqliteNamespace("wi.workflow_id"), where, orderBy),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]WorkflowInstance, 0, query.Limit+1)
+	for rows.Next() {
+		var inst WorkflowInstance
+		var inb, outb []byte
+		var labels string
+		if err := rows.Scan(&inst.ID, &inst.WorkflowID, &inst.Namespace, &inst.Status, &inb, &outb, &inst.Error, &labels,
+			&inst.StartedAt, &inst.CompletedAt, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
+			return nil, err
+		}
+		inst.Input = inb
+		if outb != nil {
+			inst.Output = outb
+		}
+		if err := decodeSQLiteLabels(labels, &inst); err != nil {
+			return nil, err
+		}
+		res = append(res, inst)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return query.pageResult(res), nil
+}
+
+func decodeSQLiteLabels(raw string, inst *WorkflowInstance) error {
+	if raw == "" || raw == "{}" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &inst.Labels); err != nil {
+		return fmt.Errorf("decode labels: %w", err)
+	}
+
+	return nil
+}
+
 func (s *SQLiteStore) GetWorkflowSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
 	return s.GetStepsByInstance(ctx, instanceID)
 }
@@ -847,7 +1562,7 @@ func (s *SQLiteStore) CreateCancelRequest(ctx context.Context, req *WorkflowCanc
 		`INSERT OR REPLACE INTO cancel_requests (
 			instance_id, requested_by, cancel_type, reason, created_at
 		) VALUES(?, ?, ?, ?, ?)`,
-		req.InstanceID, req.RequestedBy, req.CancelType, req.Reason, time.Now(),
+		req.InstanceID, req.RequestedBy, req.CancelType, req.Reason, s.clock.Now(),
 	)
 	return err
 }
@@ -876,7 +1591,7 @@ func (s *SQLiteStore) DeleteCancelRequest(ctx context.Context, instanceID int64)
 }
 
 func (s *SQLiteStore) CreateHumanDecision(ctx context.Context, decision *HumanDecisionRecord) error {
-	now := time.Now()
+	now := s.clock.Now()
 	res, err := s.db.ExecContext(
 		ctx,
 		`INSERT INTO human_decisions (
@@ -981,19 +1696,51 @@ func (s *SQLiteStore) GetHumanDecisionStepByInstanceID(ctx context.Context, inst
 }
 
 func (s *SQLiteStore) CreateDeadLetterRecord(ctx context.Context, rec *DeadLetterRecord) error {
-	_, err := s.db.ExecContext(
+	now := s.clock.Now()
+	res, err := s.db.ExecContext(
 		ctx,
 		`INSERT INTO workflow_dlq (
 			instance_id, workflow_id, step_id, step_name, step_type,
 			input, error, reason, created_at
 		) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		rec.InstanceID, rec.WorkflowID, rec.StepID, rec.StepName, rec.StepType,
-		rec.Input, rec.Error, rec.Reason, time.Now(),
+		rec.Input, rec.Error, rec.Reason, now,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	rec.ID = id
+	rec.CreatedAt = now
+	if err := s.db.QueryRowContext(
+		ctx, `SELECT `+sqliteInstanceNamespace("?"), rec.InstanceID,
+	).Scan(&rec.Namespace); err != nil {
+		return err
+	}
+	if rec.RedriveCount == 0 && rec.NextRedriveAt == nil {
+		return nil
+	}
+	var nextRedriveAt any
+	if rec.NextRedriveAt != nil {
+		nextRedriveAt = rec.NextRedriveAt.UTC()
+	}
+	_, err = s.db.ExecContext(
+		ctx,
+		`INSERT INTO workflow_dlq_redrive (dlq_id, redrive_count, next_redrive_at) VALUES(?, ?, ?)`,
+		rec.ID, rec.RedriveCount, nextRedriveAt,
 	)
 	return err
 }
 
-func (s *SQLiteStore) RequeueDeadLetter(ctx context.Context, dlqID int64, newInput *json.RawMessage) error {
+func (s *SQLiteStore) RequeueDeadLetter(
+	ctx context.Context,
+	dlqID int64,
+	taskQueue string,
+	newInput *json.RawMessage,
+) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
@@ -1014,6 +1761,9 @@ func (s *SQLiteStore) RequeueDeadLetter(ctx context.Context, dlqID int64, newInp
 			WHERE id=?`,
 		dlqID,
 	).Scan(&instanceID, &stepID, &input); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEntityNotFound
+		}
 		return err
 	}
 	var setInput any
@@ -1032,12 +1782,7 @@ func (s *SQLiteStore) RequeueDeadLetter(ctx context.Context, dlqID int64, newInp
 	); err != nil {
 		return err
 	}
-	if _, err := tx.ExecContext(
-		ctx,
-		`INSERT INTO queue (instance_id, step_id, scheduled_at, priority)
-			VALUES(?, ?, ?, ?)`,
-		instanceID, stepID, time.Now(), int(PriorityNormal),
-	); err != nil {
+	if err := s.insertQueueItem(ctx, tx, instanceID, &stepID, taskQueue, s.clock.Now(), PriorityNormal); err != nil {
 		return err
 	}
 	if _, err := tx.ExecContext(
@@ -1052,54 +1797,108 @@ func (s *SQLiteStore) RequeueDeadLetter(ctx context.Context, dlqID int64, newInp
 	if _, err := tx.ExecContext(ctx, `DELETE FROM workflow_dlq WHERE id=?`, dlqID); err != nil {
 		return err
 	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM workflow_dlq_redrive WHERE dlq_id=?`, dlqID); err != nil {
+		return err
+	}
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 	tx = nil
+	s.notifications.publish(NotifyChannelQueue, "")
 	return nil
 }
 
-func (s *SQLiteStore) ListDeadLetters(ctx context.Context, offset int, limit int) ([]DeadLetterRecord, int64, error) {
-	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workflow_dlq`)
+var sqliteDeadLetterSelect = `SELECT d.id, d.instance_id, d.workflow_id, d.step_id, d.step_name, d.step_type,
+		d.input, d.error, d.reason, d.created_at, COALESCE(r.redrive_count, 0), r.next_redrive_at,
+		` + sqliteNamespace("d.workflow_id") + `
+	FROM workflow_dlq d
+	LEFT JOIN workflow_dlq_redrive r ON r.dlq_id = d.id`
+
+func scanSQLiteDeadLetter(scanner interface{ Scan(dest ...any) error }) (DeadLetterRecord, error) {
+	var r DeadLetterRecord
+	err := scanner.Scan(&r.ID, &r.InstanceID, &r.WorkflowID, &r.StepID, &r.StepName, &r.StepType,
+		&r.Input, &r.Error, &r.Reason, &r.CreatedAt, &r.RedriveCount, &r.NextRedriveAt, &r.Namespace)
+	return r, err
+}
+
+func (s *SQLiteStore) queryDeadLetters(ctx context.Context, query string, args ...any) ([]DeadLetterRecord, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]DeadLetterRecord, 0)
+	for rows.Next() {
+		r, err := scanSQLiteDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+	return res, rows.Err()
+}
+
+func (s *SQLiteStore) ListDeadLetters(
+	ctx context.Context,
+	filter DeadLetterFilter,
+	offset int,
+	limit int,
+) ([]DeadLetterRecord, int64, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	if filter.WorkflowID != "" {
+		conds = append(conds, "d.workflow_id = ?")
+		args = append(args, filter.WorkflowID)
+	}
+	if filter.StepName != "" {
+		conds = append(conds, "d.step_name = ?")
+		args = append(args, filter.StepName)
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, sqliteSortableTime("d.created_at")+" >= ?")
+		args = append(args, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, sqliteSortableTime("d.created_at")+" < ?")
+		args = append(args, *filter.CreatedTo)
+	}
+	if filter.ErrorContains != "" {
+		conds = append(conds, "instr(lower(d.error), lower(?)) > 0")
+		args = append(args, filter.ErrorContains)
+	}
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		conds = append(conds, sqliteNamespace("d.workflow_id")+" = ?")
+		args = append(args, namespace)
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
 	var total int64
-	if err := row.Scan(&total); err != nil {
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workflow_dlq d `+where, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
-	rows, err := s.db.QueryContext(
+	res, err := s.queryDeadLetters(
 		ctx,
-		`SELECT id, instance_id, workflow_id, step_id, step_name, step_type,
-			input, error, reason, created_at
-			FROM workflow_dlq
-			ORDER BY created_at DESC
-			LIMIT ? OFFSET ?`,
-		limit, offset,
+		sqliteDeadLetterSelect+` `+where+` ORDER BY `+sqliteSortableTime("d.created_at")+` DESC, d.id DESC LIMIT ? OFFSET ?`,
+		append(args, limit, offset)...,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
-	defer rows.Close()
-	var res []DeadLetterRecord
-	for rows.Next() {
-		var r DeadLetterRecord
-		if err := rows.Scan(&r.ID, &r.InstanceID, &r.WorkflowID, &r.StepID, &r.StepName, &r.StepType, &r.Input, &r.Error, &r.Reason, &r.CreatedAt); err != nil {
-			return nil, 0, err
-		}
-		res = append(res, r)
-	}
 	return res, total, nil
 }
 
 func (s *SQLiteStore) GetDeadLetterByID(ctx context.Context, id int64) (*DeadLetterRecord, error) {
-	row := s.db.QueryRowContext(
-		ctx,
-		`SELECT id, instance_id, workflow_id, step_id, step_name, step_type,
-			input, error, reason, created_at
-			FROM workflow_dlq
-			WHERE id=?`,
-		id,
-	)
-	var r DeadLetterRecord
-	if err := row.Scan(&r.ID, &r.InstanceID, &r.WorkflowID, &r.StepID, &r.StepName, &r.StepType, &r.Input, &r.Error, &r.Reason, &r.CreatedAt); err != nil {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("d.workflow_id"))
+	r, err := scanSQLiteDeadLetter(s.db.QueryRowContext(
+		ctx, sqliteDeadLetterSelect+` WHERE d.id=?`+nsFilter, append([]any{id}, nsArgs...)...,
+	))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrEntityNotFound
 		}
@@ -1108,6 +1907,40 @@ func (s *SQLiteStore) GetDeadLetterByID(ctx context.Context, id int64) (*DeadLet
 	return &r, nil
 }
 
+func (s *SQLiteStore) GetDeadLettersByInstance(ctx context.Context, instanceID int64) ([]DeadLetterRecord, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("d.workflow_id"))
+	return s.queryDeadLetters(
+		ctx,
+		sqliteDeadLetterSelect+` WHERE d.instance_id=?`+nsFilter+` ORDER BY d.id`,
+		append([]any{instanceID}, nsArgs...)...,
+	)
+}
+
+func (s *SQLiteStore) GetDueDeadLetters(ctx context.Context, now time.Time, limit int) ([]DeadLetterRecord, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("d.workflow_id"))
+	return s.queryDeadLetters(
+		ctx,
+		sqliteDeadLetterSelect+` WHERE r.next_redrive_at <= ?`+nsFilter+` ORDER BY r.next_redrive_at LIMIT ?`,
+		append(append([]any{now.UTC()}, nsArgs...), limit)...,
+	)
+}
+
+func (s *SQLiteStore) DeleteDeadLetter(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM workflow_dlq WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrEntityNotFound
+	}
+	_, err = s.db.ExecContext(ctx, `DELETE FROM workflow_dlq_redrive WHERE dlq_id=?`, id)
+	return err
+}
+
 func (s *SQLiteStore) PauseActiveStepsAndClearQueue(ctx context.Context, instanceID int64) error {
 	_, err := s.db.ExecContext(
 		ctx,
@@ -1119,17 +1952,183 @@ func (s *SQLiteStore) PauseActiveStepsAndClearQueue(ctx context.Context, instanc
 	if err != nil {
 		return err
 	}
+	for _, table := range []string{"queue_task_queues", "queue_leases"} {
+		if _, err := s.db.ExecContext(
+			ctx,
+			`DELETE FROM `+table+` WHERE queue_id IN (SELECT id FROM queue WHERE instance_id=?)`,
+			instanceID,
+		); err != nil {
+			return err
+		}
+	}
 	_, err = s.db.ExecContext(ctx, `DELETE FROM queue WHERE instance_id=?`, instanceID)
 	return err
 }
 
+func (s *SQLiteStore) RegisterWorker(ctx context.Context, worker *WorkerRecord) error {
+	handlers, err := json.Marshal(nonNilStrings(worker.Handlers))
+	if err != nil {
+		return err
+	}
+	taskQueues, err := json.Marshal(nonNilStrings(worker.TaskQueues))
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(
+		ctx,
+		`INSERT INTO workers(id, host, version, handlers, task_queues, concurrency, in_flight, status,
+				started_at, last_heartbeat_at)
+			VALUES(?, ?, ?, ?, ?, ?, ?, 'alive', ?, ?)
+			ON CONFLICT(id) DO UPDATE SET host=excluded.host, version=excluded.version,
+				handlers=excluded.handlers, task_queues=excluded.task_queues, concurrency=excluded.concurrency,
+				in_flight=excluded.in_flight, status='alive', started_at=excluded.started_at,
+				last_heartbeat_at=excluded.last_heartbeat_at`,
+		worker.ID, worker.Host, worker.Version, string(handlers), string(taskQueues),
+		worker.Concurrency, worker.InFlight, worker.StartedAt.UTC(), worker.LastHeartbeatAt.UTC(),
+	)
+	return err
+}
+
+func (s *SQLiteStore) HeartbeatWorker(ctx context.Context, workerID string, inFlight, concurrency int, at time.Time) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE workers SET in_flight=?, concurrency=?, last_heartbeat_at=?, status='alive' WHERE id=?`,
+		inFlight, concurrency, at.UTC(), workerID,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrEntityNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteStore) DeregisterWorker(ctx context.Context, workerID string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE workers SET in_flight=0, status='stopped' WHERE id=?`, workerID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrEntityNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteStore) MarkStaleWorkersDead(ctx context.Context, heartbeatBefore time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// As for the queue leases, the times are compared here rather than on the stored strings
+	rows, err := tx.QueryContext(ctx, `SELECT id, last_heartbeat_at FROM workers WHERE status='alive'`)
+	if err != nil {
+		return 0, err
+	}
+	var stale []string
+	for rows.Next() {
+		var (
+			id              string
+			lastHeartbeatAt time.Time
+		)
+		if err := rows.Scan(&id, &lastHeartbeatAt); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		if lastHeartbeatAt.Before(heartbeatBefore) {
+			stale = append(stale, id)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+
+	for _, id := range stale {
+		if _, err := tx.ExecContext(ctx, `UPDATE workers SET status='dead' WHERE id=?`, id); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	tx = nil
+	return int64(len(stale)), nil
+}
+
+const sqliteWorkerSelect = `SELECT id, host, version, handlers, task_queues, concurrency, in_flight, status,
+		started_at, last_heartbeat_at
+	FROM workers`
+
+func scanSQLiteWorker(scanner interface{ Scan(dest ...any) error }) (WorkerRecord, error) {
+	var (
+		w          WorkerRecord
+		handlers   string
+		taskQueues string
+	)
+	err := scanner.Scan(&w.ID, &w.Host, &w.Version, &handlers, &taskQueues, &w.Concurrency, &w.InFlight,
+		&w.Status, &w.StartedAt, &w.LastHeartbeatAt)
+	if err != nil {
+		return w, err
+	}
+	if err := json.Unmarshal([]byte(handlers), &w.Handlers); err != nil {
+		return w, err
+	}
+	err = json.Unmarshal([]byte(taskQueues), &w.TaskQueues)
+	return w, err
+}
+
+func (s *SQLiteStore) ListWorkers(ctx context.Context) ([]WorkerRecord, error) {
+	rows, err := s.db.QueryContext(ctx, sqliteWorkerSelect+` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]WorkerRecord, 0)
+	for rows.Next() {
+		w, err := scanSQLiteWorker(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, w)
+	}
+	return res, rows.Err()
+}
+
+func (s *SQLiteStore) GetWorker(ctx context.Context, workerID string) (*WorkerRecord, error) {
+	w, err := scanSQLiteWorker(s.db.QueryRowContext(ctx, sqliteWorkerSelect+` WHERE id=?`, workerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEntityNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func (s *SQLiteStore) CleanupOldWorkflows(ctx context.Context) error {
 	const daysToKeep = 30
 
-	cutoff := time.Now().AddDate(0, 0, -daysToKeep)
+	cutoff := s.clock.Now().AddDate(0, 0, -daysToKeep)
 	// delete related rows first
 	_, _ = s.db.ExecContext(ctx, `DELETE FROM workflow_events WHERE instance_id IN (SELECT id FROM workflow_instances WHERE updated_at < ?)`, cutoff)
 	_, _ = s.db.ExecContext(ctx, `DELETE FROM workflow_steps WHERE instance_id IN (SELECT id FROM workflow_instances WHERE updated_at < ?)`, cutoff)
+	_, _ = s.db.ExecContext(ctx, `DELETE FROM workflow_instance_labels WHERE instance_id IN (SELECT id FROM workflow_instances WHERE updated_at < ?)`, cutoff)
 	_, err := s.db.ExecContext(ctx, `DELETE FROM workflow_instances WHERE updated_at < ?`, cutoff)
 	if err != nil {
 		return err
@@ -1139,6 +2138,7 @@ func (s *SQLiteStore) CleanupOldWorkflows(ctx context.Context) error {
 }
 
 func (s *SQLiteStore) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, error) {
+	nsFilter, nsArgs := sqliteNamespaceFilter(ctx, sqliteNamespace("wd.id"))
 	rows, err := s.db.QueryContext(
 		ctx,
 		`SELECT name, version,
@@ -1146,7 +2146,9 @@ func (s *SQLiteStore) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, er
 			(SELECT COUNT(*) FROM workflow_instances WHERE workflow_id=wd.id AND status='completed') as completed,
 			(SELECT COUNT(*) FROM workflow_instances WHERE workflow_id=wd.id AND status='failed') as failed,
 			(SELECT COUNT(*) FROM workflow_instances WHERE workflow_id=wd.id AND status='running') as running
-			FROM workflow_definitions wd`,
+			FROM workflow_definitions wd
+			WHERE 1=1`+nsFilter,
+		nsArgs...,
 	)
 	if err != nil {
 		return nil, err
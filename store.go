@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +18,9 @@ import (
 
 var _ Store = (*StoreImpl)(nil)
 
+// PartitionRetention is the partition retention set by the partitioning migrations.
+const PartitionRetention = 90 * 24 * time.Hour
+
 // workflowDefCacheEntry represents a cached workflow definition with expiration time
 type workflowDefCacheEntry struct {
 	def       *WorkflowDefinition
@@ -30,14 +35,22 @@ type StoreImpl struct {
 	// Workflow definition cache
 	defCache    sync.Map // map[string]*workflowDefCacheEntry
 	defCacheTTL time.Duration
+
+	// Partitions are archived here before cleanup drops them
+	archiveSink ArchiveSink
+
+	clock Clock
 }
 
-func NewStore(pool *pgxpool.Pool) *StoreImpl {
+func NewStore(pool *pgxpool.Pool, opts ...StoreOption) *StoreImpl {
+	options := applyStoreOptions(opts)
+
 	return &StoreImpl{
 		db:           pool,
 		agingEnabled: true,
 		agingRate:    0.5,
 		defCacheTTL:  time.Hour, // Default: 1 hour
+		clock:        options.clock,
 	}
 }
 
@@ -49,6 +62,11 @@ func (store *StoreImpl) SetAgingRate(rate float64) {
 	store.agingRate = rate
 }
 
+// Clock returns the clock set with WithStoreClock.
+func (store *StoreImpl) Clock() Clock {
+	return store.clock
+}
+
 // SetDefinitionCacheTTL sets the TTL for workflow definition cache
 // Set to 0 to disable caching
 func (store *StoreImpl) SetDefinitionCacheTTL(ttl time.Duration) {
@@ -59,6 +77,13 @@ func (store *StoreImpl) SetDefinitionCacheTTL(ttl time.Duration) {
 	}
 }
 
+// SetArchiveSink makes CleanupOldWorkflows archive the instances of the partitions it is about to drop,
+// whatever their status, and skip the drop when archiving fails. Without a sink, the default, or with nil
+// the partitions are dropped unarchived.
+func (store *StoreImpl) SetArchiveSink(sink ArchiveSink) {
+	store.archiveSink = sink
+}
+
 // ClearDefinitionCache clears all cached workflow definitions
 func (store *StoreImpl) ClearDefinitionCache() {
 	store.defCache.Range(func(key, value interface{}) bool {
@@ -79,28 +104,46 @@ func (store *StoreImpl) SaveWorkflowDefinition(ctx context.Context, def *Workflo
 
 	executor := store.getExecutor(ctx)
 
+	// Names and versions are unique per namespace, IDs across namespaces
 	const query = `
-INSERT INTO workflows.workflow_definitions (id, name, version, definition, created_at)
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (name, version) DO UPDATE
+INSERT INTO workflows.workflow_definitions (id, name, version, definition, namespace, created_at)
+SELECT $1, $2, $3, $4, $5, $6
+WHERE NOT EXISTS (
+    SELECT 1 FROM workflows.workflow_definitions WHERE id = $1 AND namespace <> $5
+)
+ON CONFLICT (namespace, name, version) DO UPDATE
 SET definition = EXCLUDED.definition
 RETURNING id, created_at`
 
+	if def.Namespace == "" {
+		def.Namespace = namespaceOf(ctx)
+	}
+	if !visibleIn(ctx, def.Namespace) {
+		return ErrNamespaceMismatch
+	}
+
 	definitionJSON, err := json.Marshal(def.Definition)
 	if err != nil {
 		return fmt.Errorf("marshal definition: %w", err)
 	}
 
 	err = executor.QueryRow(ctx, query,
-		def.ID, def.Name, def.Version, definitionJSON, time.Now(),
+		def.ID, def.Name, def.Version, definitionJSON, def.Namespace, store.clock.Now(),
 	).Scan(&def.ID, &def.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The ID belongs to a definition of another namespace
+		return ErrNamespaceMismatch
+	}
 
-	if err == nil {
-		// Invalidate cache for this workflow definition
-		store.InvalidateDefinitionCache(def.ID)
+	if err != nil {
+		return err
 	}
 
-	return err
+	// Invalidate cache for this workflow definition
+	store.InvalidateDefinitionCache(def.ID)
+
+	// Other nodes drop their cached copy once the transaction commits
+	return store.notify(ctx, NotifyChannelDefinition, def.ID)
 }
 
 func (store *StoreImpl) GetWorkflowDefinition(ctx context.Context, id string) (*WorkflowDefinition, error) {
@@ -109,7 +152,11 @@ func (store *StoreImpl) GetWorkflowDefinition(ctx context.Context, id string) (*
 		if cached, ok := store.defCache.Load(id); ok {
 			entry := cached.(*workflowDefCacheEntry)
 			// Check if cache entry is still valid
-			if time.Now().Before(entry.expiresAt) {
+			if store.clock.Now().Before(entry.expiresAt) {
+				if !visibleIn(ctx, entry.def.Namespace) {
+					return nil, ErrEntityNotFound
+				}
+
 				// Return a copy to prevent external modifications
 				return entry.def, nil
 			}
@@ -122,7 +169,7 @@ func (store *StoreImpl) GetWorkflowDefinition(ctx context.Context, id string) (*
 	executor := store.getExecutor(ctx)
 
 	const query = `
-SELECT id, name, version, definition, created_at
+SELECT id, name, version, definition, namespace, created_at
 FROM workflows.workflow_definitions
 WHERE id = $1`
 
@@ -130,7 +177,7 @@ WHERE id = $1`
 	var definitionJSON []byte
 
 	err := executor.QueryRow(ctx, query, id).Scan(
-		&def.ID, &def.Name, &def.Version, &definitionJSON, &def.CreatedAt,
+		&def.ID, &def.Name, &def.Version, &definitionJSON, &def.Namespace, &def.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -148,11 +195,15 @@ WHERE id = $1`
 	if store.defCacheTTL > 0 {
 		entry := &workflowDefCacheEntry{
 			def:       &def,
-			expiresAt: time.Now().Add(store.defCacheTTL),
+			expiresAt: store.clock.Now().Add(store.defCacheTTL),
 		}
 		store.defCache.Store(id, entry)
 	}
 
+	if !visibleIn(ctx, def.Namespace) {
+		return nil, ErrEntityNotFound
+	}
+
 	return &def, nil
 }
 
@@ -164,17 +215,19 @@ func (store *StoreImpl) CreateInstance(
 	executor := store.getExecutor(ctx)
 
 	const query = `
-INSERT INTO workflows.workflow_instances (workflow_id, status, input, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $4)
-RETURNING id, workflow_id, status, input, created_at, updated_at`
+INSERT INTO workflows.workflow_instances (workflow_id, namespace, status, input, created_at, updated_at)
+SELECT $1, COALESCE(
+	(SELECT namespace FROM workflows.workflow_definitions WHERE id = $1), 'default'
+), $2, $3, $4, $4
+RETURNING id, workflow_id, namespace, status, input, created_at, updated_at`
 
-	now := time.Now()
+	now := store.clock.Now()
 	instance := &WorkflowInstance{}
 
 	err := executor.QueryRow(ctx, query,
 		workflowID, StatusPending, input, now,
 	).Scan(
-		&instance.ID, &instance.WorkflowID, &instance.Status,
+		&instance.ID, &instance.WorkflowID, &instance.Namespace, &instance.Status,
 		&instance.Input, &instance.CreatedAt, &instance.UpdatedAt,
 	)
 
@@ -197,24 +250,27 @@ SET status = $2, output = $3, error = $4, updated_at = $5,
 	started_at = CASE WHEN started_at IS NULL AND $2 = 'running' THEN $5 ELSE started_at END
 WHERE id = $1`
 
-	_, err := executor.Exec(ctx, query, instanceID, status, output, errMsg, time.Now())
+	_, err := executor.Exec(ctx, query, instanceID, status, output, errMsg, store.clock.Now())
+	if err != nil {
+		return err
+	}
 
-	return err
+	return store.notify(ctx, NotifyChannelInstance, strconv.FormatInt(instanceID, 10))
 }
 
 func (store *StoreImpl) GetInstance(ctx context.Context, instanceID int64) (*WorkflowInstance, error) {
 	executor := store.getExecutor(ctx)
 
 	const query = `
-SELECT id, workflow_id, status, input, output, error,
+SELECT id, workflow_id, namespace, status, input, output, error, labels,
 	   started_at, completed_at, created_at, updated_at
 FROM workflows.workflow_instances
-WHERE id = $1`
+WHERE id = $1 AND ($2::text IS NULL OR namespace = $2)`
 
 	instance := &WorkflowInstance{}
-	err := executor.QueryRow(ctx, query, instanceID).Scan(
-		&instance.ID, &instance.WorkflowID, &instance.Status,
-		&instance.Input, &instance.Output, &instance.Error,
+	err := executor.QueryRow(ctx, query, instanceID, namespaceFilter(ctx)).Scan(
+		&instance.ID, &instance.WorkflowID, &instance.Namespace, &instance.Status,
+		&instance.Input, &instance.Output, &instance.Error, &instance.Labels,
 		&instance.StartedAt, &instance.CompletedAt,
 		&instance.CreatedAt, &instance.UpdatedAt,
 	)
@@ -229,6 +285,26 @@ WHERE id = $1`
 	return instance, nil
 }
 
+func (store *StoreImpl) CountRunningInstances(ctx context.Context, namespace string) (int, error) {
+	executor := store.getExecutor(ctx)
+
+	// Concurrent starts in the namespace wait here until the counting transaction ends
+	const lockQuery = `SELECT pg_advisory_xact_lock(hashtext('floxy_namespace:' || $1))`
+	if _, err := executor.Exec(ctx, lockQuery, namespace); err != nil {
+		return 0, fmt.Errorf("acquire namespace lock: %w", err)
+	}
+
+	const query = `
+SELECT COUNT(*)
+FROM workflows.workflow_instances
+WHERE namespace = $1 AND status IN ('pending', 'running', 'rolling_back', 'cancelling')`
+
+	var count int
+	err := executor.QueryRow(ctx, query, namespace).Scan(&count)
+
+	return count, err
+}
+
 func (store *StoreImpl) CreateStep(ctx context.Context, step *WorkflowStep) error {
 	if step == nil {
 		return errors.New("workflow step is nil")
@@ -248,7 +324,7 @@ RETURNING id, created_at`
 
 	return executor.QueryRow(ctx, query,
 		step.InstanceID, step.StepName, step.StepType,
-		step.Status, step.Input, step.MaxRetries, time.Now(), step.IdempotencyKey,
+		step.Status, step.Input, step.MaxRetries, store.clock.Now(), step.IdempotencyKey,
 	).Scan(&step.ID, &step.CreatedAt)
 }
 
@@ -269,11 +345,30 @@ SET status = $2, output = $3, error = $4,
 	retry_count = CASE WHEN $2 = 'failed' THEN retry_count + 1 ELSE retry_count END
 WHERE id = $1`
 
-	_, err := executor.Exec(ctx, query, stepID, status, output, errMsg, time.Now())
+	_, err := executor.Exec(ctx, query, stepID, status, output, errMsg, store.clock.Now())
 
 	return err
 }
 
+func (store *StoreImpl) SkipPendingStep(ctx context.Context, stepID int64, output json.RawMessage) error {
+	executor := store.getExecutor(ctx)
+
+	const query = `
+UPDATE workflows.workflow_steps
+SET status = 'skipped', output = $2, error = NULL, completed_at = $3
+WHERE id = $1 AND status IN ('pending', 'failed', 'waiting_decision')`
+
+	tag, err := executor.Exec(ctx, query, stepID, output, store.clock.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStepNotSkippable
+	}
+
+	return nil
+}
+
 func (store *StoreImpl) GetStepsByInstance(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
 	executor := store.getExecutor(ctx)
 
@@ -282,7 +377,7 @@ SELECT id, instance_id, step_name, step_type, status, input, output, error,
 	retry_count, max_retries, idempotency_key, started_at, completed_at, created_at
 FROM workflows.workflow_steps
 WHERE instance_id = $1
-ORDER BY created_at`
+ORDER BY created_at, id`
 
 	rows, err := executor.Query(ctx, query, instanceID)
 	if err != nil {
@@ -313,25 +408,33 @@ func (store *StoreImpl) EnqueueStep(
 	ctx context.Context,
 	instanceID int64,
 	stepID *int64,
+	taskQueue string,
 	priority Priority,
 	delay time.Duration,
 ) error {
 	executor := store.getExecutor(ctx)
 
+	// NOTIFY is delivered on commit, so listeners never see an uncommitted queue item
 	const query = `
-INSERT INTO workflows.workflow_queue (instance_id, step_id, scheduled_at, priority)
-VALUES ($1, $2, $3, $4)`
+WITH ins AS (
+	INSERT INTO workflows.workflow_queue (instance_id, step_id, scheduled_at, priority, task_queue, namespace)
+	SELECT $1, $2, $3, $4, $7, COALESCE(
+		(SELECT namespace FROM workflows.workflow_instances WHERE id = $1), 'default'
+	)
+)
+SELECT pg_notify($5, $6)`
 
-	scheduledAt := time.Now().Add(delay)
-	_, err := executor.Exec(ctx, query, instanceID, stepID, scheduledAt, priority)
+	scheduledAt := store.clock.Now().Add(delay)
+	_, err := executor.Exec(ctx, query, instanceID, stepID, scheduledAt, priority,
+		NotifyChannelQueue, queueNotifyPayload(scheduledAt), taskQueue)
 
 	return err
 }
 
-func (store *StoreImpl) DequeueStep(ctx context.Context, workerID string) (*QueueItem, error) {
+func (store *StoreImpl) DequeueStep(ctx context.Context, workerID string, taskQueues []string) (*QueueItem, error) {
 	executor := store.getExecutor(ctx)
 
-	now := time.Now()
+	now := store.clock.Now()
 
 	var query string
 	var args []any
@@ -342,11 +445,14 @@ WITH next_item AS (
 	SELECT id
 	FROM workflows.workflow_queue
 	WHERE scheduled_at <= $1 AND attempted_at IS NULL
+		AND ($4::text[] IS NULL OR task_queue = ANY($4))
+		AND ($5::text IS NULL OR namespace = $5)
 	ORDER BY
 		LEAST(100,
 			priority + FLOOR(EXTRACT(EPOCH FROM ($1 - scheduled_at)) * $2)
 		) DESC,
-		scheduled_at ASC
+		scheduled_at ASC,
+		id ASC
 	LIMIT 1
 	FOR UPDATE SKIP LOCKED
 )
@@ -354,15 +460,18 @@ UPDATE workflows.workflow_queue
 SET attempted_at = $1, attempted_by = $3
 FROM next_item
 WHERE workflows.workflow_queue.id = next_item.id
-RETURNING workflows.workflow_queue.id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority`
-		args = []any{now, store.agingRate, workerID}
+RETURNING workflows.workflow_queue.id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority,
+	task_queue, namespace`
+		args = []any{now, store.agingRate, workerID, taskQueues, namespaceFilter(ctx)}
 	} else {
 		query = `
 WITH next_item AS (
 	SELECT id
 	FROM workflows.workflow_queue
 	WHERE scheduled_at <= $1 AND attempted_at IS NULL
-	ORDER BY priority DESC, scheduled_at ASC
+		AND ($3::text[] IS NULL OR task_queue = ANY($3))
+		AND ($4::text IS NULL OR namespace = $4)
+	ORDER BY priority DESC, scheduled_at ASC, id ASC
 	LIMIT 1
 	FOR UPDATE SKIP LOCKED
 )
@@ -370,14 +479,16 @@ UPDATE workflows.workflow_queue
 SET attempted_at = $1, attempted_by = $2
 FROM next_item
 WHERE workflows.workflow_queue.id = next_item.id
-RETURNING workflows.workflow_queue.id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority`
-		args = []any{now, workerID}
+RETURNING workflows.workflow_queue.id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority,
+	task_queue, namespace`
+		args = []any{now, workerID, taskQueues, namespaceFilter(ctx)}
 	}
 
 	item := &QueueItem{}
 	err := executor.QueryRow(ctx, query, args...).Scan(
 		&item.ID, &item.InstanceID, &item.StepID,
 		&item.ScheduledAt, &item.AttemptedAt, &item.AttemptedBy, &item.Priority,
+		&item.TaskQueue, &item.Namespace,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -387,6 +498,86 @@ RETURNING workflows.workflow_queue.id, instance_id, step_id, scheduled_at, attem
 	return item, err
 }
 
+func (store *StoreImpl) GetQueueLength(ctx context.Context, taskQueues []string) (int, error) {
+	executor := store.getExecutor(ctx)
+
+	const query = `
+SELECT COUNT(*)
+FROM workflows.workflow_queue
+WHERE scheduled_at <= $1 AND attempted_at IS NULL
+	AND ($2::text[] IS NULL OR task_queue = ANY($2))
+	AND ($3::text IS NULL OR namespace = $3)`
+
+	var count int
+	err := executor.QueryRow(ctx, query, store.clock.Now(), taskQueues, namespaceFilter(ctx)).Scan(&count)
+
+	return count, err
+}
+
+// DequeueSteps claims up to n due queue items in one statement, ordered like DequeueStep.
+func (store *StoreImpl) DequeueSteps(
+	ctx context.Context,
+	workerID string,
+	taskQueues []string,
+	n int,
+) ([]QueueItem, error) {
+	executor := store.getExecutor(ctx)
+
+	now := store.clock.Now()
+
+	orderExpr := "priority"
+	args := []any{now, workerID, n, taskQueues, namespaceFilter(ctx)}
+	if store.agingEnabled && store.agingRate > 0 {
+		// Priority aging: increase effective priority as items wait
+		orderExpr = "LEAST(100, priority + FLOOR(EXTRACT(EPOCH FROM ($1 - scheduled_at)) * $6))"
+		args = append(args, store.agingRate)
+	}
+
+	query := fmt.Sprintf(`
+WITH next_items AS (
+	SELECT id, %s AS effective_priority
+	FROM workflows.workflow_queue
+	WHERE scheduled_at <= $1 AND attempted_at IS NULL
+		AND ($4::text[] IS NULL OR task_queue = ANY($4))
+		AND ($5::text IS NULL OR namespace = $5)
+	ORDER BY effective_priority DESC, scheduled_at ASC, id ASC
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED
+), claimed AS (
+	UPDATE workflows.workflow_queue q
+	SET attempted_at = $1, attempted_by = $2
+	FROM next_items
+	WHERE q.id = next_items.id
+	RETURNING q.id, q.instance_id, q.step_id, q.scheduled_at, q.attempted_at, q.attempted_by, q.priority,
+		q.task_queue, q.namespace, next_items.effective_priority
+)
+SELECT id, instance_id, step_id, scheduled_at, attempted_at, attempted_by, priority, task_queue, namespace
+FROM claimed
+ORDER BY effective_priority DESC, scheduled_at ASC, id ASC`, orderExpr)
+
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]QueueItem, 0, n)
+	for rows.Next() {
+		var item QueueItem
+		err := rows.Scan(
+			&item.ID, &item.InstanceID, &item.StepID,
+			&item.ScheduledAt, &item.AttemptedAt, &item.AttemptedBy, &item.Priority,
+			&item.TaskQueue, &item.Namespace,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
 func (store *StoreImpl) RemoveFromQueue(ctx context.Context, queueID int64) error {
 	executor := store.getExecutor(ctx)
 
@@ -399,10 +590,16 @@ func (store *StoreImpl) RemoveFromQueue(ctx context.Context, queueID int64) erro
 func (store *StoreImpl) ReleaseQueueItem(ctx context.Context, queueID int64) error {
 	executor := store.getExecutor(ctx)
 
-	const query = `UPDATE workflows.workflow_queue SET attempted_at = NULL, attempted_by = NULL WHERE id = $1`
+	const query = `
+UPDATE workflows.workflow_queue
+SET attempted_at = NULL, attempted_by = NULL, lease_token = NULL, lease_expires_at = NULL
+WHERE id = $1`
 	_, err := executor.Exec(ctx, query, queueID)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return store.notify(ctx, NotifyChannelQueue, "")
 }
 
 func (store *StoreImpl) RescheduleAndReleaseQueueItem(ctx context.Context, queueID int64, delay time.Duration) error {
@@ -412,11 +609,125 @@ func (store *StoreImpl) RescheduleAndReleaseQueueItem(ctx context.Context, queue
 UPDATE workflows.workflow_queue
 SET scheduled_at = GREATEST(scheduled_at, $2),
     attempted_at = NULL,
-    attempted_by = NULL
+    attempted_by = NULL,
+    lease_token = NULL,
+    lease_expires_at = NULL
 WHERE id = $1`
 
-	scheduledAt := time.Now().Add(delay)
+	scheduledAt := store.clock.Now().Add(delay)
 	_, err := executor.Exec(ctx, query, queueID, scheduledAt)
+	if err != nil {
+		return err
+	}
+
+	return store.notify(ctx, NotifyChannelQueue, queueNotifyPayload(scheduledAt))
+}
+
+func (store *StoreImpl) LeaseQueueItem(
+	ctx context.Context,
+	queueID int64,
+	workerID, leaseToken string,
+	leaseUntil time.Time,
+) error {
+	executor := store.getExecutor(ctx)
+
+	const query = `
+UPDATE workflows.workflow_queue
+SET lease_token = $3, lease_expires_at = $4
+WHERE id = $1 AND attempted_by = $2 AND lease_token IS NULL`
+
+	tag, err := executor.Exec(ctx, query, queueID, workerID, leaseToken, leaseUntil)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+func (store *StoreImpl) RenewQueueItemLease(
+	ctx context.Context,
+	queueID int64,
+	leaseToken string,
+	leaseUntil time.Time,
+) error {
+	executor := store.getExecutor(ctx)
+
+	// Inside a transaction the row lock keeps the lease until commit
+	const query = `
+UPDATE workflows.workflow_queue
+SET lease_expires_at = $3
+WHERE id = $1 AND lease_token = $2`
+
+	tag, err := executor.Exec(ctx, query, queueID, leaseToken, leaseUntil)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+func (store *StoreImpl) ReclaimExpiredQueueItems(
+	ctx context.Context,
+	workerID string,
+	now, claimedBefore time.Time,
+	limit int,
+) ([]QueueItem, error) {
+	executor := store.getExecutor(ctx)
+
+	// Items being finished hold the row lock and are skipped
+	const query = `
+WITH expired AS (
+	SELECT id, attempted_at, attempted_by, lease_token, lease_expires_at
+	FROM workflows.workflow_queue
+	WHERE attempted_at IS NOT NULL
+	  AND (lease_expires_at < $2 OR (lease_token IS NULL AND attempted_at < $3))
+	  AND ($5::text IS NULL OR namespace = $5)
+	ORDER BY id
+	LIMIT $4
+	FOR UPDATE SKIP LOCKED
+)
+UPDATE workflows.workflow_queue q
+SET attempted_at = $2, attempted_by = $1, lease_token = NULL, lease_expires_at = NULL
+FROM expired
+WHERE q.id = expired.id
+RETURNING q.id, q.instance_id, q.step_id, q.scheduled_at, expired.attempted_at, expired.attempted_by, q.priority,
+	q.task_queue, q.namespace, expired.lease_token, expired.lease_expires_at`
+
+	rows, err := executor.Query(ctx, query, workerID, now, claimedBefore, limit, namespaceFilter(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []QueueItem
+	for rows.Next() {
+		var item QueueItem
+		err := rows.Scan(
+			&item.ID, &item.InstanceID, &item.StepID,
+			&item.ScheduledAt, &item.AttemptedAt, &item.AttemptedBy, &item.Priority,
+			&item.TaskQueue, &item.Namespace, &item.LeaseToken, &item.LeaseExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// notify sends a NOTIFY through the current executor; inside a transaction it is delivered on commit.
+func (store *StoreImpl) notify(ctx context.Context, channel, payload string) error {
+	executor := store.getExecutor(ctx)
+
+	_, err := executor.Exec(ctx, `SELECT pg_notify($1, $2)`, channel, payload)
+
 	return err
 }
 
@@ -430,15 +741,17 @@ func (store *StoreImpl) LogEvent(
 	executor := store.getExecutor(ctx)
 
 	const query = `
-INSERT INTO workflows.workflow_events (instance_id, step_id, event_type, payload, created_at)
-VALUES ($1, $2, $3, $4, $5)`
+INSERT INTO workflows.workflow_events (instance_id, step_id, event_type, payload, namespace, created_at)
+SELECT $1, $2, $3, $4, COALESCE(
+	(SELECT namespace FROM workflows.workflow_instances WHERE id = $1), 'default'
+), $5`
 
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
 
-	_, err = executor.Exec(ctx, query, instanceID, stepID, eventType, payloadJSON, time.Now())
+	_, err = executor.Exec(ctx, query, instanceID, stepID, eventType, payloadJSON, store.clock.Now())
 
 	return err
 }
@@ -496,7 +809,7 @@ VALUES ($1, $2, $3, $4, $5, $5)`
 		strategy = "all"
 	}
 
-	_, err = executor.Exec(ctx, insertQuery, instanceID, joinStepName, waitingForJSON, strategy, time.Now())
+	_, err = executor.Exec(ctx, insertQuery, instanceID, joinStepName, waitingForJSON, strategy, store.clock.Now())
 	if err != nil {
 		return fmt.Errorf("failed to insert join state: %w", err)
 	}
@@ -583,7 +896,7 @@ WHERE instance_id = $5 AND join_step_name = $6`
 	failedJSON, _ = json.Marshal(failed)
 
 	_, err = executor.Exec(ctx, updateQuery,
-		completedJSON, failedJSON, isReady, time.Now(), instanceID, joinStepName,
+		completedJSON, failedJSON, isReady, store.clock.Now(), instanceID, joinStepName,
 	)
 	if err != nil {
 		return false, err
@@ -682,7 +995,7 @@ WHERE instance_id = $4 AND join_step_name = $5`
 	waitingForJSON, _ = json.Marshal(waitingFor)
 
 	_, err = executor.Exec(ctx, updateQuery,
-		waitingForJSON, isReady, time.Now(), instanceID, joinStepName,
+		waitingForJSON, isReady, store.clock.Now(), instanceID, joinStepName,
 	)
 
 	return err
@@ -787,7 +1100,7 @@ WHERE instance_id = $6 AND join_step_name = $7`
 	failedJSON, _ = json.Marshal(failed)
 
 	_, err = executor.Exec(ctx, updateQuery,
-		waitingForJSON, completedJSON, failedJSON, isReady, time.Now(), instanceID, joinStepName,
+		waitingForJSON, completedJSON, failedJSON, isReady, store.clock.Now(), instanceID, joinStepName,
 	)
 
 	return err
@@ -820,10 +1133,13 @@ SELECT
 	COUNT(*) FILTER (WHERE status = 'failed') as failed_workflows,
 	COUNT(*) FILTER (WHERE status = 'running') as running_workflows,
 	COUNT(*) FILTER (WHERE status = 'pending') as pending_workflows
-FROM workflows.workflow_instances`
+FROM workflows.workflow_instances
+WHERE $1::text IS NULL OR namespace = $1`
+
+	scope := namespaceFilter(ctx)
 
 	var stats SummaryStats
-	err := executor.QueryRow(ctx, query).Scan(
+	err := executor.QueryRow(ctx, query, scope).Scan(
 		&stats.TotalWorkflows,
 		&stats.CompletedWorkflows,
 		&stats.FailedWorkflows,
@@ -834,8 +1150,11 @@ FROM workflows.workflow_instances`
 		return nil, err
 	}
 
-	const activeWorkflowsQuery = `SELECT COUNT(*) as active_workflows FROM workflows.active_workflows`
-	err = executor.QueryRow(ctx, activeWorkflowsQuery).Scan(&stats.ActiveWorkflows)
+	const activeWorkflowsQuery = `
+SELECT COUNT(*) as active_workflows
+FROM workflows.active_workflows
+WHERE $1::text IS NULL OR namespace = $1`
+	err = executor.QueryRow(ctx, activeWorkflowsQuery, scope).Scan(&stats.ActiveWorkflows)
 	if err != nil {
 		return nil, err
 	}
@@ -861,9 +1180,10 @@ SELECT
 FROM workflows.workflow_instances wi
 JOIN workflows.workflow_definitions w ON wi.workflow_id = w.id
 WHERE wi.status IN ('running', 'pending', 'dlq')
+	AND ($1::text IS NULL OR wi.namespace = $1)
 ORDER BY wi.created_at DESC`
 
-	rows, err := executor.Query(ctx, query)
+	rows, err := executor.Query(ctx, query, namespaceFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -901,11 +1221,12 @@ func (store *StoreImpl) GetWorkflowDefinitions(ctx context.Context) ([]WorkflowD
 	executor := store.getExecutor(ctx)
 
 	const query = `
-SELECT id, name, version, definition, created_at
+SELECT id, name, version, definition, namespace, created_at
 FROM workflows.workflow_definitions
+WHERE $1::text IS NULL OR namespace = $1
 ORDER BY name, version DESC`
 
-	rows, err := executor.Query(ctx, query)
+	rows, err := executor.Query(ctx, query, namespaceFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -920,6 +1241,7 @@ ORDER BY name, version DESC`
 			&def.Name,
 			&def.Version,
 			&definitionBytes,
+			&def.Namespace,
 			&def.CreatedAt,
 		)
 		if err != nil {
@@ -939,13 +1261,13 @@ func (store *StoreImpl) GetWorkflowInstances(ctx context.Context, workflowID str
 	executor := store.getExecutor(ctx)
 
 	const query = `
-SELECT id, workflow_id, status, input, output, error, 
+SELECT id, workflow_id, namespace, status, input, output, error, 
 		started_at, completed_at, created_at, updated_at
 FROM workflows.workflow_instances
-WHERE workflow_id = $1
+WHERE workflow_id = $1 AND ($2::text IS NULL OR namespace = $2)
 ORDER BY created_at DESC`
 
-	rows, err := executor.Query(ctx, query, workflowID)
+	rows, err := executor.Query(ctx, query, workflowID, namespaceFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -957,6 +1279,7 @@ ORDER BY created_at DESC`
 		err := rows.Scan(
 			&instance.ID,
 			&instance.WorkflowID,
+			&instance.Namespace,
 			&instance.Status,
 			&instance.Input,
 			&instance.Output,
@@ -979,12 +1302,13 @@ func (store *StoreImpl) GetAllWorkflowInstances(ctx context.Context) ([]Workflow
 	executor := store.getExecutor(ctx)
 
 	const query = `
-SELECT id, workflow_id, status, input, output, error, 
+SELECT id, workflow_id, namespace, status, input, output, error, 
 		started_at, completed_at, created_at, updated_at
 FROM workflows.workflow_instances
+WHERE $1::text IS NULL OR namespace = $1
 ORDER BY created_at DESC`
 
-	rows, err := executor.Query(ctx, query)
+	rows, err := executor.Query(ctx, query, namespaceFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -996,6 +1320,7 @@ ORDER BY created_at DESC`
 		err := rows.Scan(
 			&instance.ID,
 			&instance.WorkflowID,
+			&instance.Namespace,
 			&instance.Status,
 			&instance.Input,
 			&instance.Output,
@@ -1020,22 +1345,24 @@ func (store *StoreImpl) GetWorkflowInstancesPaginated(ctx context.Context, workf
 	const countQuery = `
 SELECT COUNT(*)
 FROM workflows.workflow_instances
-WHERE workflow_id = $1`
+WHERE workflow_id = $1 AND ($2::text IS NULL OR namespace = $2)`
+
+	scope := namespaceFilter(ctx)
 
 	var total int64
-	if err := executor.QueryRow(ctx, countQuery, workflowID).Scan(&total); err != nil {
+	if err := executor.QueryRow(ctx, countQuery, workflowID, scope).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	const query = `
-SELECT id, workflow_id, status, input, output, error, 
+SELECT id, workflow_id, namespace, status, input, output, error, 
 		started_at, completed_at, created_at, updated_at
 FROM workflows.workflow_instances
-WHERE workflow_id = $1
+WHERE workflow_id = $1 AND ($4::text IS NULL OR namespace = $4)
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3`
 
-	rows, err := executor.Query(ctx, query, workflowID, limit, offset)
+	rows, err := executor.Query(ctx, query, workflowID, limit, offset, scope)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1047,6 +1374,7 @@ LIMIT $2 OFFSET $3`
 		err := rows.Scan(
 			&instance.ID,
 			&instance.WorkflowID,
+			&instance.Namespace,
 			&instance.Status,
 			&instance.Input,
 			&instance.Output,
@@ -1068,21 +1396,27 @@ LIMIT $2 OFFSET $3`
 func (store *StoreImpl) GetAllWorkflowInstancesPaginated(ctx context.Context, offset int, limit int) ([]WorkflowInstance, int64, error) {
 	executor := store.getExecutor(ctx)
 
-	const countQuery = `SELECT COUNT(*) FROM workflows.workflow_instances`
+	const countQuery = `
+SELECT COUNT(*)
+FROM workflows.workflow_instances
+WHERE $1::text IS NULL OR namespace = $1`
+
+	scope := namespaceFilter(ctx)
 
 	var total int64
-	if err := executor.QueryRow(ctx, countQuery).Scan(&total); err != nil {
+	if err := executor.QueryRow(ctx, countQuery, scope).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	const query = `
-SELECT id, workflow_id, status, input, output, error, 
+SELECT id, workflow_id, namespace, status, input, output, error, 
 		started_at, completed_at, created_at, updated_at
 FROM workflows.workflow_instances
+WHERE $3::text IS NULL OR namespace = $3
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2`
 
-	rows, err := executor.Query(ctx, query, limit, offset)
+	rows, err := executor.Query(ctx, query, limit, offset, scope)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1094,6 +1428,7 @@ LIMIT $1 OFFSET $2`
 		err := rows.Scan(
 			&instance.ID,
 			&instance.WorkflowID,
+			&instance.Namespace,
 			&instance.Status,
 			&instance.Input,
 			&instance.Output,
@@ -1112,6 +1447,157 @@ LIMIT $1 OFFSET $2`
 	return instances, total, rows.Err()
 }
 
+func (store *StoreImpl) SetInstanceLabels(ctx context.Context, instanceID int64, labels map[string]string) error {
+	executor := store.getExecutor(ctx)
+
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return fmt.Errorf("marshal labels: %w", err)
+	}
+
+	const query = `
+UPDATE workflows.workflow_instances
+SET labels = labels || $2::jsonb
+WHERE id = $1`
+
+	tag, err := executor.Exec(ctx, query, instanceID, labelsJSON)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (store *StoreImpl) SearchInstances(ctx context.Context, query InstanceQuery) (*InstanceSearchResult, error) {
+	cursor, err := query.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	executor := store.getExecutor(ctx)
+
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(query.Statuses) > 0 {
+		statuses := make([]string, 0, len(query.Statuses))
+		for _, status := range query.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		conds = append(conds, "status = ANY("+arg(statuses)+")")
+	}
+	if query.WorkflowID != "" {
+		conds = append(conds, "workflow_id = "+arg(query.WorkflowID))
+	}
+	if query.WorkflowName != "" {
+		conds = append(conds, "workflow_id IN (SELECT id FROM workflows.workflow_definitions WHERE name = "+arg(query.WorkflowName)+")")
+	}
+	if query.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+arg(*query.CreatedFrom))
+	}
+	if query.CreatedTo != nil {
+		conds = append(conds, "created_at < "+arg(*query.CreatedTo))
+	}
+	if query.UpdatedFrom != nil {
+		conds = append(conds, "updated_at >= "+arg(*query.UpdatedFrom))
+	}
+	if query.UpdatedTo != nil {
+		conds = append(conds, "updated_at < "+arg(*query.UpdatedTo))
+	}
+	if len(query.Labels) > 0 {
+		labelsJSON, err := json.Marshal(query.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("marshal labels: %w", err)
+		}
+		conds = append(conds, "labels @> "+arg(string(labelsJSON))+"::jsonb")
+	}
+	for _, key := range query.WithoutLabels {
+		conds = append(conds, "NOT labels ? "+arg(key))
+	}
+	if query.ErrorContains != "" {
+		conds = append(conds, "strpos(lower(error), lower("+arg(query.ErrorContains)+")) > 0")
+	}
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		conds = append(conds, "namespace = "+arg(namespace))
+	}
+
+	cmp, dir := "<", "DESC"
+	if query.SortOrder == SortOrderAsc {
+		cmp, dir = ">", "ASC"
+	}
+
+	orderBy := fmt.Sprintf("id %s", dir)
+	if query.SortBy != InstanceSortByID {
+		orderBy = fmt.Sprintf("%s %s, id %s", query.SortBy, dir, dir)
+	}
+
+	if cursor != nil {
+		if query.SortBy == InstanceSortByID {
+			conds = append(conds, "id "+cmp+" "+arg(cursor.ID))
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s, id) %s (%s, %s)", query.SortBy, cmp, arg(cursor.At), arg(cursor.ID)))
+		}
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	sqlQuery := fmt.Sprintf(`
+SELECT id, workflow_id, namespace, status, input, output, error, labels,
+		started_at, completed_at, created_at, updated_at
+FROM workflows.workflow_instances
+%s
+ORDER BY %s
+LIMIT %s`, where, orderBy, arg(query.Limit+1))
+
+	rows, err := executor.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instances := make([]WorkflowInstance, 0, query.Limit+1)
+	for rows.Next() {
+		var instance WorkflowInstance
+		err := rows.Scan(
+			&instance.ID,
+			&instance.WorkflowID,
+			&instance.Namespace,
+			&instance.Status,
+			&instance.Input,
+			&instance.Output,
+			&instance.Error,
+			&instance.Labels,
+			&instance.StartedAt,
+			&instance.CompletedAt,
+			&instance.CreatedAt,
+			&instance.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return query.pageResult(instances), nil
+}
+
 func (store *StoreImpl) GetWorkflowSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error) {
 	executor := store.getExecutor(ctx)
 
@@ -1121,7 +1607,7 @@ SELECT id, instance_id, step_name, step_type, status, input, output, error,
 		started_at, completed_at, created_at
 FROM workflows.workflow_steps
 WHERE instance_id = $1
-ORDER BY created_at`
+ORDER BY created_at, id`
 
 	rows, err := executor.Query(ctx, query, instanceID)
 	if err != nil {
@@ -1208,7 +1694,7 @@ ON CONFLICT (instance_id) DO NOTHING
 RETURNING id, created_at`
 
 	return executor.QueryRow(ctx, query,
-		req.InstanceID, req.RequestedBy, req.CancelType, req.Reason, time.Now(),
+		req.InstanceID, req.RequestedBy, req.CancelType, req.Reason, store.clock.Now(),
 	).Scan(&req.ID, &req.CreatedAt)
 }
 
@@ -1248,12 +1734,12 @@ func (store *StoreImpl) GetWorkflowEvents(ctx context.Context, instanceID int64)
 	executor := store.getExecutor(ctx)
 
 	const query = `
-SELECT id, instance_id, step_id, event_type, payload, created_at
+SELECT id, instance_id, step_id, event_type, payload, namespace, created_at
 FROM workflows.workflow_events
-WHERE instance_id = $1
-ORDER BY created_at`
+WHERE instance_id = $1 AND ($2::text IS NULL OR namespace = $2)
+ORDER BY created_at, id`
 
-	rows, err := executor.Query(ctx, query, instanceID)
+	rows, err := executor.Query(ctx, query, instanceID, namespaceFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -1268,6 +1754,7 @@ ORDER BY created_at`
 			&event.StepID,
 			&event.EventType,
 			&event.Payload,
+			&event.Namespace,
 			&event.CreatedAt,
 		)
 		if err != nil {
@@ -1279,6 +1766,83 @@ ORDER BY created_at`
 	return events, rows.Err()
 }
 
+func (store *StoreImpl) GetEventsAfter(
+	ctx context.Context,
+	afterID int64,
+	filter EventFilter,
+	limit int,
+) ([]WorkflowEvent, error) {
+	executor := store.getExecutor(ctx)
+
+	args := []any{afterID}
+	arg := func(v any) string {
+		args = append(args, v)
+
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conds := []string{"e.id > $1"}
+	if filter.InstanceID != 0 {
+		conds = append(conds, "e.instance_id = "+arg(filter.InstanceID))
+	}
+	if filter.WorkflowID != "" {
+		conds = append(conds, "i.workflow_id = "+arg(filter.WorkflowID))
+	}
+	if len(filter.EventTypes) > 0 {
+		conds = append(conds, "e.event_type = ANY("+arg(filter.EventTypes)+")")
+	}
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		conds = append(conds, "e.namespace = "+arg(namespace))
+	}
+
+	query := fmt.Sprintf(`
+SELECT e.id, e.instance_id, e.step_id, e.event_type, e.payload, e.namespace, e.created_at
+FROM workflows.workflow_events e
+JOIN workflows.workflow_instances i ON i.id = e.instance_id
+WHERE %s
+ORDER BY e.id
+LIMIT %s`, strings.Join(conds, " AND "), arg(limit))
+
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]WorkflowEvent, 0)
+	for rows.Next() {
+		var event WorkflowEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.InstanceID,
+			&event.StepID,
+			&event.EventType,
+			&event.Payload,
+			&event.Namespace,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (store *StoreImpl) GetLastEventID(ctx context.Context) (int64, error) {
+	executor := store.getExecutor(ctx)
+
+	const query = `SELECT COALESCE(MAX(id), 0) FROM workflows.workflow_events`
+
+	var id int64
+	if err := executor.QueryRow(ctx, query).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
 func (store *StoreImpl) GetWorkflowStats(ctx context.Context) ([]WorkflowStats, error) {
 	executor := store.getExecutor(ctx)
 
@@ -1291,9 +1855,10 @@ SELECT
 	failed,
 	running,
 	avg_duration_seconds
-FROM workflows.workflow_stats`
+FROM workflows.workflow_stats
+WHERE $1::text IS NULL OR namespace = $1`
 
-	rows, err := executor.Query(ctx, query)
+	rows, err := executor.Query(ctx, query, namespaceFilter(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -1341,7 +1906,7 @@ RETURNING id, created_at`
 
 	return executor.QueryRow(ctx, query,
 		decision.InstanceID, decision.StepID, decision.DecidedBy,
-		decision.Decision, decision.Comment, decision.DecidedAt, time.Now(),
+		decision.Decision, decision.Comment, decision.DecidedAt, store.clock.Now(),
 	).Scan(&decision.ID, &decision.CreatedAt)
 }
 
@@ -1437,7 +2002,162 @@ LIMIT 1`
 	return &step, nil
 }
 
+func (store *StoreImpl) RegisterWorker(ctx context.Context, worker *WorkerRecord) error {
+	executor := store.getExecutor(ctx)
+
+	const query = `
+INSERT INTO workflows.workers (id, host, version, handlers, task_queues, concurrency, in_flight, status,
+	started_at, last_heartbeat_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'alive', $8, $9)
+ON CONFLICT (id) DO UPDATE
+SET host = EXCLUDED.host, version = EXCLUDED.version, handlers = EXCLUDED.handlers,
+	task_queues = EXCLUDED.task_queues, concurrency = EXCLUDED.concurrency, in_flight = EXCLUDED.in_flight,
+	status = 'alive', started_at = EXCLUDED.started_at, last_heartbeat_at = EXCLUDED.last_heartbeat_at`
+
+	handlers := worker.Handlers
+	if handlers == nil {
+		handlers = []string{}
+	}
+	taskQueues := worker.TaskQueues
+	if taskQueues == nil {
+		taskQueues = []string{}
+	}
+
+	_, err := executor.Exec(ctx, query, worker.ID, worker.Host, worker.Version, handlers, taskQueues,
+		worker.Concurrency, worker.InFlight, worker.StartedAt, worker.LastHeartbeatAt)
+
+	return err
+}
+
+func (store *StoreImpl) HeartbeatWorker(
+	ctx context.Context,
+	workerID string,
+	inFlight, concurrency int,
+	at time.Time,
+) error {
+	executor := store.getExecutor(ctx)
+
+	const query = `
+UPDATE workflows.workers
+SET in_flight = $2, concurrency = $3, last_heartbeat_at = $4, status = 'alive'
+WHERE id = $1`
+
+	tag, err := executor.Exec(ctx, query, workerID, inFlight, concurrency, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (store *StoreImpl) DeregisterWorker(ctx context.Context, workerID string) error {
+	executor := store.getExecutor(ctx)
+
+	const query = `UPDATE workflows.workers SET in_flight = 0, status = 'stopped' WHERE id = $1`
+
+	tag, err := executor.Exec(ctx, query, workerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (store *StoreImpl) MarkStaleWorkersDead(ctx context.Context, heartbeatBefore time.Time) (int64, error) {
+	executor := store.getExecutor(ctx)
+
+	const query = `
+UPDATE workflows.workers
+SET status = 'dead'
+WHERE status = 'alive' AND last_heartbeat_at < $1`
+
+	tag, err := executor.Exec(ctx, query, heartbeatBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+const workerColumns = `id, host, version, handlers, task_queues, concurrency, in_flight, status,
+	started_at, last_heartbeat_at`
+
+func scanWorker(row pgx.Row) (WorkerRecord, error) {
+	worker := WorkerRecord{}
+	err := row.Scan(
+		&worker.ID,
+		&worker.Host,
+		&worker.Version,
+		&worker.Handlers,
+		&worker.TaskQueues,
+		&worker.Concurrency,
+		&worker.InFlight,
+		&worker.Status,
+		&worker.StartedAt,
+		&worker.LastHeartbeatAt,
+	)
+
+	return worker, err
+}
+
+func (store *StoreImpl) ListWorkers(ctx context.Context) ([]WorkerRecord, error) {
+	executor := store.getExecutor(ctx)
+
+	query := `SELECT ` + workerColumns + `
+FROM workflows.workers
+ORDER BY id`
+
+	rows, err := executor.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workers := make([]WorkerRecord, 0)
+	for rows.Next() {
+		worker, err := scanWorker(rows)
+		if err != nil {
+			return nil, err
+		}
+		workers = append(workers, worker)
+	}
+
+	return workers, rows.Err()
+}
+
+func (store *StoreImpl) GetWorker(ctx context.Context, workerID string) (*WorkerRecord, error) {
+	executor := store.getExecutor(ctx)
+
+	query := `SELECT ` + workerColumns + `
+FROM workflows.workers
+WHERE id = $1`
+
+	worker, err := scanWorker(executor.QueryRow(ctx, query, workerID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	return &worker, nil
+}
+
 func (store *StoreImpl) CleanupOldWorkflows(ctx context.Context) error {
+	if store.archiveSink != nil {
+		archiver := NewArchiver(store, store.archiveSink, WithArchiveClock(store.clock))
+		cutoff := store.clock.Now().Add(-PartitionRetention)
+		if _, err := archiver.archivePartitions(ctx, cutoff); err != nil {
+			return fmt.Errorf("failed to archive old partitions: %w", err)
+		}
+	}
+
 	executor := store.getExecutor(ctx)
 
 	const query = `CALL workflows.cleanup_all();`
@@ -1462,10 +2182,15 @@ func (store *StoreImpl) CreateDeadLetterRecord(
 
 	const query = `
 INSERT INTO workflows.workflow_dlq (
-	instance_id, workflow_id, step_id, step_name, step_type, input, error, reason
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	instance_id, workflow_id, step_id, step_name, step_type, input, error, reason,
+	redrive_count, next_redrive_at, namespace
+)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(
+	(SELECT namespace FROM workflows.workflow_instances WHERE id = $1), 'default'
+)
+RETURNING id, namespace, created_at`
 
-	_, err := executor.Exec(ctx, query,
+	return executor.QueryRow(ctx, query,
 		rec.InstanceID,
 		rec.WorkflowID,
 		rec.StepID,
@@ -1474,13 +2199,15 @@ INSERT INTO workflows.workflow_dlq (
 		rec.Input,
 		rec.Error,
 		rec.Reason,
-	)
-	return err
+		rec.RedriveCount,
+		rec.NextRedriveAt,
+	).Scan(&rec.ID, &rec.Namespace, &rec.CreatedAt)
 }
 
 func (store *StoreImpl) RequeueDeadLetter(
 	ctx context.Context,
 	dlqID int64,
+	taskQueue string,
 	newInput *json.RawMessage,
 ) error {
 	executor := store.getExecutor(ctx)
@@ -1504,8 +2231,10 @@ WITH dlq AS (
     WHERE ws.id = dlq.step_id
     RETURNING ws.id AS step_id, ws.instance_id AS instance_id
 ), enq AS (
-    INSERT INTO workflows.workflow_queue (instance_id, step_id)
-    SELECT instance_id, step_id FROM upd_step
+    INSERT INTO workflows.workflow_queue (instance_id, step_id, task_queue, namespace)
+    SELECT upd_step.instance_id, upd_step.step_id, $3, wi.namespace
+    FROM upd_step
+    JOIN workflows.workflow_instances wi ON wi.id = upd_step.instance_id
     RETURNING 1
 ), upd_inst AS (
     UPDATE workflows.workflow_instances wi
@@ -1529,52 +2258,122 @@ DELETE FROM workflows.workflow_dlq d USING dlq WHERE d.id = dlq.id;`
 		input = nil
 	}
 
-	_, err := executor.Exec(ctx, query, dlqID, input)
+	tag, err := executor.Exec(ctx, query, dlqID, input, taskQueue)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if tag.RowsAffected() == 0 {
+		return ErrEntityNotFound
+	}
+
+	return store.notify(ctx, NotifyChannelQueue, "")
 }
 
-func (store *StoreImpl) ListDeadLetters(ctx context.Context, offset int, limit int) ([]DeadLetterRecord, int64, error) {
-	executor := store.getExecutor(ctx)
+const deadLetterColumns = `id, instance_id, workflow_id, step_id, step_name, step_type, input, error, reason, created_at,
+	redrive_count, next_redrive_at, namespace`
 
-	const countQuery = `SELECT COUNT(*) FROM workflows.workflow_dlq`
-	var total int64
-	if err := executor.QueryRow(ctx, countQuery).Scan(&total); err != nil {
-		return nil, 0, err
-	}
+func scanDeadLetter(row pgx.Row) (DeadLetterRecord, error) {
+	rec := DeadLetterRecord{}
+	err := row.Scan(
+		&rec.ID,
+		&rec.InstanceID,
+		&rec.WorkflowID,
+		&rec.StepID,
+		&rec.StepName,
+		&rec.StepType,
+		&rec.Input,
+		&rec.Error,
+		&rec.Reason,
+		&rec.CreatedAt,
+		&rec.RedriveCount,
+		&rec.NextRedriveAt,
+		&rec.Namespace,
+	)
 
-	const selectQuery = `
-SELECT id, instance_id, workflow_id, step_id, step_name, step_type, input, error, reason, created_at
-FROM workflows.workflow_dlq
-ORDER BY created_at DESC
-OFFSET $1 LIMIT $2`
+	return rec, err
+}
+
+func (store *StoreImpl) queryDeadLetters(ctx context.Context, query string, args ...any) ([]DeadLetterRecord, error) {
+	executor := store.getExecutor(ctx)
 
-	rows, err := executor.Query(ctx, selectQuery, offset, limit)
+	rows, err := executor.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
 	records := make([]DeadLetterRecord, 0)
 	for rows.Next() {
-		rec := DeadLetterRecord{}
-		if err := rows.Scan(
-			&rec.ID,
-			&rec.InstanceID,
-			&rec.WorkflowID,
-			&rec.StepID,
-			&rec.StepName,
-			&rec.StepType,
-			&rec.Input,
-			&rec.Error,
-			&rec.Reason,
-			&rec.CreatedAt,
-		); err != nil {
-			return nil, 0, err
+		rec, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
 		}
 		records = append(records, rec)
 	}
 	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (store *StoreImpl) ListDeadLetters(
+	ctx context.Context,
+	filter DeadLetterFilter,
+	offset int,
+	limit int,
+) ([]DeadLetterRecord, int64, error) {
+	executor := store.getExecutor(ctx)
+
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.WorkflowID != "" {
+		conds = append(conds, "workflow_id = "+arg(filter.WorkflowID))
+	}
+	if filter.StepName != "" {
+		conds = append(conds, "step_name = "+arg(filter.StepName))
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.ErrorContains != "" {
+		conds = append(conds, "strpos(lower(error), lower("+arg(filter.ErrorContains)+")) > 0")
+	}
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		conds = append(conds, "namespace = "+arg(namespace))
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	var total int64
+	if err := executor.QueryRow(ctx, "SELECT COUNT(*) FROM workflows.workflow_dlq "+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	selectQuery := fmt.Sprintf(`
+SELECT %s
+FROM workflows.workflow_dlq
+%s
+ORDER BY created_at DESC
+OFFSET %s LIMIT %s`, deadLetterColumns, where, arg(offset), arg(limit))
+
+	records, err := store.queryDeadLetters(ctx, selectQuery, args...)
+	if err != nil {
 		return nil, 0, err
 	}
 
@@ -1584,24 +2383,12 @@ OFFSET $1 LIMIT $2`
 func (store *StoreImpl) GetDeadLetterByID(ctx context.Context, id int64) (*DeadLetterRecord, error) {
 	executor := store.getExecutor(ctx)
 
-	const query = `
-SELECT id, instance_id, workflow_id, step_id, step_name, step_type, input, error, reason, created_at
+	query := `SELECT ` + deadLetterColumns + `
 FROM workflows.workflow_dlq
-WHERE id = $1`
+WHERE id = $1 AND ($2::text IS NULL OR namespace = $2)`
 
-	rec := DeadLetterRecord{}
-	if err := executor.QueryRow(ctx, query, id).Scan(
-		&rec.ID,
-		&rec.InstanceID,
-		&rec.WorkflowID,
-		&rec.StepID,
-		&rec.StepName,
-		&rec.StepType,
-		&rec.Input,
-		&rec.Error,
-		&rec.Reason,
-		&rec.CreatedAt,
-	); err != nil {
+	rec, err := scanDeadLetter(executor.QueryRow(ctx, query, id, namespaceFilter(ctx)))
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrEntityNotFound
 		}
@@ -1611,6 +2398,42 @@ WHERE id = $1`
 	return &rec, nil
 }
 
+func (store *StoreImpl) GetDeadLettersByInstance(ctx context.Context, instanceID int64) ([]DeadLetterRecord, error) {
+	query := `SELECT ` + deadLetterColumns + `
+FROM workflows.workflow_dlq
+WHERE instance_id = $1 AND ($2::text IS NULL OR namespace = $2)
+ORDER BY id`
+
+	return store.queryDeadLetters(ctx, query, instanceID, namespaceFilter(ctx))
+}
+
+func (store *StoreImpl) GetDueDeadLetters(ctx context.Context, now time.Time, limit int) ([]DeadLetterRecord, error) {
+	query := `SELECT ` + deadLetterColumns + `
+FROM workflows.workflow_dlq
+WHERE next_redrive_at <= $1 AND ($3::text IS NULL OR namespace = $3)
+ORDER BY next_redrive_at
+LIMIT $2`
+
+	return store.queryDeadLetters(ctx, query, now, limit, namespaceFilter(ctx))
+}
+
+func (store *StoreImpl) DeleteDeadLetter(ctx context.Context, id int64) error {
+	executor := store.getExecutor(ctx)
+
+	const query = `DELETE FROM workflows.workflow_dlq WHERE id = $1`
+
+	tag, err := executor.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrEntityNotFound
+	}
+
+	return nil
+}
+
 func (store *StoreImpl) PauseActiveStepsAndClearQueue(ctx context.Context, instanceID int64) error {
 	executor := store.getExecutor(ctx)
 
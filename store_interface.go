@@ -24,6 +24,10 @@ type Store interface {
 		errMsg *string,
 	) error
 	GetInstance(ctx context.Context, instanceID int64) (*WorkflowInstance, error)
+	// CountRunningInstances returns the number of pending, running, rolling back and cancelling
+	// instances of a namespace. The PostgreSQL store holds a namespace lock until the transaction ends,
+	// so concurrent limit checks of the namespace do not both pass.
+	CountRunningInstances(ctx context.Context, namespace string) (int, error)
 	CreateStep(ctx context.Context, step *WorkflowStep) error
 	UpdateStep(
 		ctx context.Context,
@@ -32,11 +36,16 @@ type Store interface {
 		output json.RawMessage,
 		errMsg *string,
 	) error
+	// SkipPendingStep marks a pending, failed or waiting for decision step as skipped with the given output.
+	// It returns ErrStepNotSkippable when the step has any other status.
+	SkipPendingStep(ctx context.Context, stepID int64, output json.RawMessage) error
 	GetStepsByInstance(ctx context.Context, instanceID int64) ([]WorkflowStep, error)
+	// EnqueueStep adds a queue item for the step to taskQueue; "" is the default queue.
 	EnqueueStep(
 		ctx context.Context,
 		instanceID int64,
 		stepID *int64,
+		taskQueue string,
 		priority Priority,
 		delay time.Duration,
 	) error
@@ -46,10 +55,32 @@ type Store interface {
 		retryCount int,
 		status StepStatus,
 	) error
-	DequeueStep(ctx context.Context, workerID string) (*QueueItem, error)
+	// DequeueStep claims the due queue item with the highest effective priority among the given
+	// task queues. A nil taskQueues matches items of any queue.
+	DequeueStep(ctx context.Context, workerID string, taskQueues []string) (*QueueItem, error)
+	// DequeueSteps claims up to n due queue items at once, highest effective priority first.
+	DequeueSteps(ctx context.Context, workerID string, taskQueues []string, n int) ([]QueueItem, error)
 	RemoveFromQueue(ctx context.Context, queueID int64) error
 	ReleaseQueueItem(ctx context.Context, queueID int64) error
 	RescheduleAndReleaseQueueItem(ctx context.Context, queueID int64, delay time.Duration) error
+	// LeaseQueueItem attaches a lease to an item just claimed by workerID. It returns ErrLeaseLost
+	// when the item is no longer claimed by workerID or already leased.
+	LeaseQueueItem(ctx context.Context, queueID int64, workerID, leaseToken string, leaseUntil time.Time) error
+	// RenewQueueItemLease extends the lease when it is still held with leaseToken, ErrLeaseLost otherwise.
+	// Releasing or removing the item ends the lease.
+	RenewQueueItemLease(ctx context.Context, queueID int64, leaseToken string, leaseUntil time.Time) error
+	// ReclaimExpiredQueueItems claims up to limit items for workerID whose owner stopped renewing them:
+	// items whose lease expired before now and items claimed without a lease before claimedBefore.
+	// Their leases are ended. The items are returned as they were before, with the previous owner in AttemptedBy.
+	ReclaimExpiredQueueItems(
+		ctx context.Context,
+		workerID string,
+		now, claimedBefore time.Time,
+		limit int,
+	) ([]QueueItem, error)
+	// GetQueueLength returns the number of due, unclaimed queue items of the given task queues
+	// (nil matches any queue).
+	GetQueueLength(ctx context.Context, taskQueues []string) (int, error)
 	LogEvent(
 		ctx context.Context,
 		instanceID int64,
@@ -82,6 +113,10 @@ type Store interface {
 	GetAllWorkflowInstancesPaginated(ctx context.Context, offset int, limit int) ([]WorkflowInstance, int64, error)
 	GetWorkflowSteps(ctx context.Context, instanceID int64) ([]WorkflowStep, error)
 	GetWorkflowEvents(ctx context.Context, instanceID int64) ([]WorkflowEvent, error)
+	// GetEventsAfter returns events with ID greater than afterID in ID order.
+	GetEventsAfter(ctx context.Context, afterID int64, filter EventFilter, limit int) ([]WorkflowEvent, error)
+	// GetLastEventID returns the ID of the most recent event or 0 if there are none.
+	GetLastEventID(ctx context.Context) (int64, error)
 	GetWorkflowStats(ctx context.Context) ([]WorkflowStats, error)
 	GetActiveStepsForUpdate(ctx context.Context, instanceID int64) ([]WorkflowStep, error)
 	CreateCancelRequest(ctx context.Context, req *WorkflowCancelRequest) error
@@ -97,15 +132,43 @@ type Store interface {
 
 	// DLQ methods
 	CreateDeadLetterRecord(ctx context.Context, rec *DeadLetterRecord) error
+	// RequeueDeadLetter resets the record's step to pending and enqueues it to taskQueue.
 	RequeueDeadLetter(
 		ctx context.Context,
 		dlqID int64,
+		taskQueue string,
 		newInput *json.RawMessage,
 	) error
-	ListDeadLetters(ctx context.Context, offset int, limit int) ([]DeadLetterRecord, int64, error)
+	ListDeadLetters(
+		ctx context.Context,
+		filter DeadLetterFilter,
+		offset int,
+		limit int,
+	) ([]DeadLetterRecord, int64, error)
 	GetDeadLetterByID(ctx context.Context, id int64) (*DeadLetterRecord, error)
+	GetDeadLettersByInstance(ctx context.Context, instanceID int64) ([]DeadLetterRecord, error)
+	GetDueDeadLetters(ctx context.Context, now time.Time, limit int) ([]DeadLetterRecord, error)
+	DeleteDeadLetter(ctx context.Context, id int64) error
 	PauseActiveStepsAndClearQueue(ctx context.Context, instanceID int64) error
 
+	// Search methods
+	SetInstanceLabels(ctx context.Context, instanceID int64, labels map[string]string) error
+	SearchInstances(ctx context.Context, query InstanceQuery) (*InstanceSearchResult, error)
+
+	// Worker registry methods
+	// RegisterWorker creates or replaces the registry entry of a worker pool.
+	RegisterWorker(ctx context.Context, worker *WorkerRecord) error
+	// HeartbeatWorker records the in-flight count and size of a registered worker and marks it alive.
+	// It returns ErrEntityNotFound when the worker is not registered.
+	HeartbeatWorker(ctx context.Context, workerID string, inFlight, concurrency int, at time.Time) error
+	// DeregisterWorker marks a worker as stopped.
+	DeregisterWorker(ctx context.Context, workerID string) error
+	// MarkStaleWorkersDead marks alive workers without a heartbeat since heartbeatBefore as dead
+	// and returns their number.
+	MarkStaleWorkersDead(ctx context.Context, heartbeatBefore time.Time) (int64, error)
+	ListWorkers(ctx context.Context) ([]WorkerRecord, error)
+	GetWorker(ctx context.Context, workerID string) (*WorkerRecord, error)
+
 	// Cleanup methods
 	CleanupOldWorkflows(ctx context.Context) error
 }
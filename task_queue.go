@@ -0,0 +1,64 @@
+package floxy
+
+import (
+	"slices"
+	"sort"
+)
+
+// DefaultTaskQueue holds the items of steps without a handler (forks, joins, conditions,
+// human decisions...) and items enqueued before task queues existed. Every worker polls it.
+const DefaultTaskQueue = ""
+
+// taskQueueOf returns the task queue of the queue items of a step: its TaskQueue, or the
+// handler name for task steps. Other steps go to the default queue.
+func taskQueueOf(stepDef *StepDefinition) string {
+	if stepDef.TaskQueue != "" {
+		return stepDef.TaskQueue
+	}
+
+	if stepDef.Type == StepTypeTask {
+		return stepDef.Handler
+	}
+
+	return DefaultTaskQueue
+}
+
+// compensationTaskQueueOf returns the task queue of the compensation items of a step,
+// i.e. the queue of its OnFailure step.
+func compensationTaskQueueOf(def *WorkflowDefinition, stepDef *StepDefinition) string {
+	onFailureStep, ok := def.Definition.Steps[stepDef.OnFailure]
+	if !ok {
+		return DefaultTaskQueue
+	}
+
+	return taskQueueOf(onFailureStep)
+}
+
+// TaskQueues returns the task queues the engine's workers subscribe to by default:
+// the names of the handlers registered with RegisterHandler.
+func (engine *Engine) TaskQueues() []string {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+
+	queues := make([]string, 0, len(engine.handlers))
+	for name := range engine.handlers {
+		queues = append(queues, name)
+	}
+	sort.Strings(queues)
+
+	return queues
+}
+
+// pollQueues returns the task queues to dequeue from for a worker subscribed to taskQueues
+// (nil means the engine defaults). The default queue is always included.
+func (engine *Engine) pollQueues(taskQueues []string) []string {
+	if taskQueues == nil {
+		taskQueues = engine.TaskQueues()
+	}
+
+	if slices.Contains(taskQueues, DefaultTaskQueue) {
+		return taskQueues
+	}
+
+	return append([]string{DefaultTaskQueue}, taskQueues...)
+}
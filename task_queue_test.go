@@ -0,0 +1,158 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDequeueTaskQueues checks that DequeueStep and DequeueSteps only claim items of the given task queues.
+func testDequeueTaskQueues(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	stepIDs := []int64{1, 2, 3, 4}
+	queues := []string{DefaultTaskQueue, "billing", "shipping", "billing"}
+	for i := range stepIDs {
+		require.NoError(t, store.EnqueueStep(ctx, 1, &stepIDs[i], queues[i], PriorityNormal, 0))
+	}
+
+	items, err := store.DequeueSteps(ctx, "worker-1", []string{"shipping"}, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(3), *items[0].StepID)
+	assert.Equal(t, "shipping", items[0].TaskQueue)
+
+	item, err := store.DequeueStep(ctx, "worker-2", []string{"shipping"})
+	require.NoError(t, err)
+	assert.Nil(t, item)
+
+	var claimed []string
+	for {
+		item, err := store.DequeueStep(ctx, "worker-2", []string{DefaultTaskQueue, "billing"})
+		require.NoError(t, err)
+		if item == nil {
+			break
+		}
+		claimed = append(claimed, item.TaskQueue)
+	}
+	assert.ElementsMatch(t, []string{DefaultTaskQueue, "billing", "billing"}, claimed)
+
+	// nil matches any queue
+	stepID := int64(5)
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "reports", PriorityNormal, 0))
+	item, err = store.DequeueStep(ctx, "worker-3", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, "reports", item.TaskQueue)
+}
+
+func TestDequeueTaskQueues_MemoryStore(t *testing.T) {
+	testDequeueTaskQueues(t, NewMemoryStore())
+}
+
+func TestIntegration_DequeueTaskQueues(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testDequeueTaskQueues(t, store)
+}
+
+func TestTaskQueueOf(t *testing.T) {
+	assert.Equal(t, "charge", taskQueueOf(NewTask("pay", "charge")))
+	assert.Equal(t, "payments", taskQueueOf(NewTask("pay", "charge", WithStepTaskQueue("payments"))))
+	assert.Equal(t, DefaultTaskQueue, taskQueueOf(&StepDefinition{Name: "join", Type: StepTypeJoin}))
+}
+
+type namedHandler struct {
+	name  string
+	calls int
+}
+
+func (h *namedHandler) Name() string { return h.name }
+
+func (h *namedHandler) Execute(_ context.Context, _ StepContext, input json.RawMessage) (json.RawMessage, error) {
+	h.calls++
+
+	return input, nil
+}
+
+func newTaskQueueTestEngine(t *testing.T, store Store, handlers ...StepHandler) *Engine {
+	t.Helper()
+
+	return newMemoryTestEngine(t, store, handlers, WithMissingHandlerCooldown(time.Hour))
+}
+
+func TestExecuteNext_RoutesByHandler(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	orders := &namedHandler{name: "orders"}
+	shipping := &namedHandler{name: "shipping"}
+	ordersEngine := newTaskQueueTestEngine(t, store, orders)
+	shippingEngine := newTaskQueueTestEngine(t, store, shipping)
+	assert.Equal(t, []string{"orders"}, ordersEngine.TaskQueues())
+
+	workflowDef, err := NewBuilder("routing", 1).
+		Step("create", "orders").
+		Then("ship", "shipping").
+		Then("confirm", "orders").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, ordersEngine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := ordersEngine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	// Each engine only sees the items it has handlers for, so nothing is skipped
+	for i := 0; i < 3; i++ {
+		drainQueue(t, ctx, ordersEngine)
+		drainQueue(t, ctx, shippingEngine)
+	}
+
+	status, err := ordersEngine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+	assert.Equal(t, 2, orders.calls)
+	assert.Equal(t, 1, shipping.calls)
+	assert.Zero(t, countEvents(t, ctx, store, instanceID, EventStepSkippedMissingHandler))
+}
+
+func TestWorker_TaskQueues(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	handler := &namedHandler{name: "charge"}
+	engine := newTaskQueueTestEngine(t, store, handler)
+
+	workflowDef, err := NewBuilder("named_queue", 1).
+		Step("pay", "charge", WithStepTaskQueue("payments")).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	instanceID, err := engine.Start(ctx, workflowDef.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	// Workers subscribe to the handler names by default
+	empty, err := NewWorker(engine, time.Second).processNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, empty)
+
+	worker := NewWorker(engine, time.Second, WithWorkerTaskQueues("payments"))
+	empty, err = worker.processNext(ctx)
+	require.NoError(t, err)
+	assert.False(t, empty)
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+	assert.Equal(t, 1, handler.calls)
+}
@@ -0,0 +1,276 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type TimelineSpanKind string
+
+const (
+	// TimelineQueued is the wait between the creation of a step and its first execution.
+	TimelineQueued TimelineSpanKind = "queued"
+	// TimelineRunning is one execution attempt of a step.
+	TimelineRunning TimelineSpanKind = "running"
+	// TimelineRetryWait is the wait between a failed attempt and the next one.
+	TimelineRetryWait TimelineSpanKind = "retry_wait"
+	// TimelineWaitingDecision is the wait of a human step for its decision.
+	TimelineWaitingDecision TimelineSpanKind = "waiting_decision"
+	// TimelineJoinWait is the wait of a join from its first finished branch until it is ready.
+	TimelineJoinWait TimelineSpanKind = "join_wait"
+	// TimelineCompensation is the rollback of a step, from its enqueueing to its end.
+	TimelineCompensation TimelineSpanKind = "compensation"
+)
+
+// TimelineSpanKinds lists the span kinds in the order they are rendered.
+var TimelineSpanKinds = []TimelineSpanKind{
+	TimelineQueued,
+	TimelineRunning,
+	TimelineRetryWait,
+	TimelineWaitingDecision,
+	TimelineJoinWait,
+	TimelineCompensation,
+}
+
+type TimelineSpan struct {
+	Kind     TimelineSpanKind `json:"kind"`
+	Attempt  int              `json:"attempt,omitempty"` // running spans only, from 1
+	Start    time.Time        `json:"start"`
+	End      time.Time        `json:"end"`
+	Duration time.Duration    `json:"duration"`
+	Open     bool             `json:"open,omitempty"` // still in progress, End is the time of the snapshot
+}
+
+type TimelineStep struct {
+	StepID   int64          `json:"step_id,omitempty"` // zero for a join that is not ready yet
+	StepName string         `json:"step_name"`
+	StepType StepType       `json:"step_type"`
+	Status   StepStatus     `json:"status,omitempty"`
+	Spans    []TimelineSpan `json:"spans"`
+}
+
+// Timeline is the execution timeline of an instance: one row per step record with its spans,
+// and the total time per span kind summed over all steps.
+type Timeline struct {
+	InstanceID int64                              `json:"instance_id"`
+	WorkflowID string                             `json:"workflow_id"`
+	Status     WorkflowStatus                     `json:"status"`
+	Start      time.Time                          `json:"start"`
+	End        time.Time                          `json:"end"`
+	Duration   time.Duration                      `json:"duration"`
+	Steps      []TimelineStep                     `json:"steps"`
+	Totals     map[TimelineSpanKind]time.Duration `json:"totals"`
+}
+
+// GetTimeline builds the execution timeline of an instance from its steps and events.
+func (engine *Engine) GetTimeline(ctx context.Context, instanceID int64) (*Timeline, error) {
+	return LoadTimeline(engine.scope(ctx), engine.store, instanceID, engine.clock.Now())
+}
+
+// LoadTimeline reads the instance, steps and events from the store and builds the timeline.
+func LoadTimeline(ctx context.Context, store Store, instanceID int64, now time.Time) (*Timeline, error) {
+	instance, err := store.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+
+	steps, err := store.GetStepsByInstance(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get steps: %w", err)
+	}
+
+	events, err := store.GetWorkflowEvents(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+
+	return BuildTimeline(instance, steps, events, now), nil
+}
+
+// BuildTimeline computes the timeline of an instance. Spans that are still open end at now,
+// or at the completion of the instance if it has finished.
+func BuildTimeline(instance *WorkflowInstance, steps []WorkflowStep, events []WorkflowEvent, now time.Time) *Timeline {
+	timeline := &Timeline{
+		InstanceID: instance.ID,
+		WorkflowID: instance.WorkflowID,
+		Status:     instance.Status,
+		Start:      instance.CreatedAt,
+		End:        now,
+		Totals:     make(map[TimelineSpanKind]time.Duration),
+	}
+	finished := instance.CompletedAt != nil
+	if finished {
+		timeline.End = *instance.CompletedAt
+	}
+
+	builders := make(map[int64]*timelineStepBuilder, len(steps))
+	for _, step := range steps {
+		builders[step.ID] = &timelineStepBuilder{
+			row: TimelineStep{
+				StepID:   step.ID,
+				StepName: step.StepName,
+				StepType: step.StepType,
+				Status:   step.Status,
+			},
+			current: &TimelineSpan{Kind: TimelineQueued, Start: step.CreatedAt},
+		}
+	}
+
+	sorted := make([]WorkflowEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].ID < sorted[j].ID
+		}
+
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	joinWaits := make(map[string]*TimelineSpan)
+	var joinOrder []string
+
+	for _, event := range sorted {
+		var payload map[string]any
+		_ = json.Unmarshal(event.Payload, &payload)
+		reason, _ := payload[KeyReason].(string)
+
+		switch event.EventType {
+		case EventJoinUpdated:
+			joinStep, _ := payload[KeyJoinStep].(string)
+			if _, ok := joinWaits[joinStep]; !ok && joinStep != "" {
+				joinWaits[joinStep] = &TimelineSpan{Kind: TimelineJoinWait, Start: event.CreatedAt}
+				joinOrder = append(joinOrder, joinStep)
+			}
+
+			continue
+		case EventJoinReady:
+			joinStep, _ := payload[KeyJoinStep].(string)
+			if span, ok := joinWaits[joinStep]; ok && span.End.IsZero() {
+				span.End = event.CreatedAt
+			}
+
+			continue
+		}
+
+		if event.StepID == nil {
+			continue
+		}
+		builder, ok := builders[*event.StepID]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case reason == "compensation" || reason == "enqueued_for_rollback_after_failure":
+			if builder.current == nil || builder.current.Kind != TimelineCompensation {
+				builder.close(event.CreatedAt)
+				builder.current = &TimelineSpan{Kind: TimelineCompensation, Start: event.CreatedAt}
+			}
+		case reason == "compensation_retry":
+			// The compensation goes on with another attempt
+		case builder.waitingDecision() && event.EventType == EventStepStarted:
+			// A human step polls for its decision while it waits
+		case event.EventType == EventStepStarted && reason == "waiting_for_human_decision":
+			builder.close(event.CreatedAt)
+			builder.current = &TimelineSpan{Kind: TimelineWaitingDecision, Start: event.CreatedAt}
+		case event.EventType == EventStepStarted && builder.row.StepType == StepTypeHuman:
+			builder.close(event.CreatedAt)
+		case event.EventType == EventStepStarted:
+			builder.close(event.CreatedAt)
+			builder.attempts++
+			builder.current = &TimelineSpan{Kind: TimelineRunning, Attempt: builder.attempts, Start: event.CreatedAt}
+		case event.EventType == EventStepRetry:
+			builder.close(event.CreatedAt)
+			builder.current = &TimelineSpan{Kind: TimelineRetryWait, Start: event.CreatedAt}
+		case event.EventType == EventStepCompleted,
+			event.EventType == EventStepFailed,
+			event.EventType == EventStepSkipped,
+			event.EventType == EventStepSkippedMissingHandler:
+			builder.close(event.CreatedAt)
+		}
+	}
+
+	rows := make([]TimelineStep, 0, len(builders)+len(joinWaits))
+	for _, step := range steps {
+		builder := builders[step.ID]
+		if builder.current != nil {
+			builder.current.Open = !finished
+			builder.close(timeline.End)
+		}
+		if len(builder.row.Spans) > 0 {
+			rows = append(rows, builder.row)
+		}
+	}
+
+	for _, joinStep := range joinOrder {
+		span := joinWaits[joinStep]
+		if span.End.IsZero() {
+			span.End = timeline.End
+			span.Open = !finished
+		}
+		span.Duration = span.End.Sub(span.Start)
+
+		index := -1
+		for i := range rows {
+			if rows[i].StepName == joinStep && rows[i].StepType == StepTypeJoin {
+				index = i
+
+				break
+			}
+		}
+		if index < 0 {
+			rows = append(rows, TimelineStep{StepName: joinStep, StepType: StepTypeJoin})
+			index = len(rows) - 1
+		}
+		rows[index].Spans = append([]TimelineSpan{*span}, rows[index].Spans...)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].Spans[0].Start.Equal(rows[j].Spans[0].Start) {
+			return rows[i].Spans[0].Start.Before(rows[j].Spans[0].Start)
+		}
+
+		return rows[i].StepID < rows[j].StepID
+	})
+
+	for _, row := range rows {
+		for _, span := range row.Spans {
+			timeline.Totals[span.Kind] += span.Duration
+			if span.End.After(timeline.End) {
+				timeline.End = span.End
+			}
+		}
+	}
+	timeline.Steps = rows
+	timeline.Duration = timeline.End.Sub(timeline.Start)
+
+	return timeline
+}
+
+type timelineStepBuilder struct {
+	row      TimelineStep
+	current  *TimelineSpan
+	attempts int
+}
+
+func (builder *timelineStepBuilder) waitingDecision() bool {
+	return builder.current != nil && builder.current.Kind == TimelineWaitingDecision
+}
+
+func (builder *timelineStepBuilder) close(end time.Time) {
+	if builder.current == nil {
+		return
+	}
+
+	span := *builder.current
+	builder.current = nil
+	if end.Before(span.Start) {
+		end = span.Start
+	}
+	span.End = end
+	span.Duration = end.Sub(span.Start)
+	builder.row.Spans = append(builder.row.Spans, span)
+}
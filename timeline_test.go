@@ -0,0 +1,190 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clockHandler advances the clock by the duration of the step and fails the listed attempts.
+type clockHandler struct {
+	clock     *ManualClock
+	durations map[string]time.Duration
+	failures  map[string]int
+}
+
+func (h *clockHandler) Name() string {
+	return "clock"
+}
+
+func (h *clockHandler) Execute(_ context.Context, stepCtx StepContext, input json.RawMessage) (json.RawMessage, error) {
+	h.clock.Advance(h.durations[stepCtx.StepName()])
+	if h.failures[stepCtx.StepName()] > 0 {
+		h.failures[stepCtx.StepName()]--
+
+		return nil, errors.New("temporary failure")
+	}
+
+	return input, nil
+}
+
+func timelineSpans(t *testing.T, timeline *Timeline, stepName string) []TimelineSpan {
+	t.Helper()
+
+	for _, step := range timeline.Steps {
+		if step.StepName == stepName {
+			return step.Spans
+		}
+	}
+	t.Fatalf("no timeline row for step %s", stepName)
+
+	return nil
+}
+
+func TestEngine_GetTimeline(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	store := NewMemoryStore(WithStoreClock(clock))
+
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+		WithEngineClock(clock),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	engine.RegisterHandler(&clockHandler{
+		clock: clock,
+		durations: map[string]time.Duration{
+			"flaky":  10 * time.Second,
+			"fast":   5 * time.Second,
+			"slower": 30 * time.Second,
+		},
+		failures: map[string]int{"flaky": 1},
+	})
+
+	wf, err := NewBuilder("timeline", 1).
+		Step("flaky", "clock", WithStepDelay(time.Minute), WithStepMaxRetries(2)).
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("fast", "clock")
+		}, func(branch *Builder) {
+			branch.Step("slower", "clock")
+		}).
+		Join("sync", JoinStrategyAll).
+		WaitHumanConfirm("approve").
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, wf))
+
+	instanceID, err := engine.Start(ctx, wf.ID, json.RawMessage(`{}`))
+	require.NoError(t, err)
+
+	runAll := func() {
+		for {
+			empty, err := engine.ExecuteNext(ctx, "worker")
+			require.NoError(t, err)
+			if empty {
+				return
+			}
+		}
+	}
+
+	runAll()
+	clock.Advance(time.Minute)
+	runAll()
+	clock.Advance(time.Minute)
+	runAll()
+	clock.Advance(time.Minute)
+	runAll()
+	clock.Advance(time.Minute)
+	runAll()
+
+	timeline, err := engine.GetTimeline(ctx, instanceID)
+	require.NoError(t, err)
+
+	flaky := timelineSpans(t, timeline, "flaky")
+	require.Len(t, flaky, 4)
+	assert.Equal(t, TimelineQueued, flaky[0].Kind)
+	assert.Equal(t, time.Minute, flaky[0].Duration)
+	assert.Equal(t, TimelineRunning, flaky[1].Kind)
+	assert.Equal(t, 1, flaky[1].Attempt)
+	assert.Equal(t, 10*time.Second, flaky[1].Duration)
+	assert.Equal(t, TimelineRetryWait, flaky[2].Kind)
+	assert.Equal(t, time.Minute, flaky[2].Duration, "the retry delay starts when the first attempt ends")
+	assert.Equal(t, TimelineRunning, flaky[3].Kind)
+	assert.Equal(t, 2, flaky[3].Attempt)
+
+	sync := timelineSpans(t, timeline, "sync")
+	assert.Equal(t, TimelineJoinWait, sync[0].Kind)
+	assert.Equal(t, 30*time.Second, sync[0].Duration, "the join waits for the slower branch")
+
+	approve := timelineSpans(t, timeline, "approve")
+	require.Len(t, approve, 2)
+	waiting := approve[1]
+	assert.Equal(t, TimelineWaitingDecision, waiting.Kind)
+	assert.True(t, waiting.Open)
+
+	clock.Advance(5 * time.Minute)
+	steps, err := engine.GetSteps(ctx, instanceID)
+	require.NoError(t, err)
+	for _, step := range steps {
+		if step.StepName == "approve" {
+			require.NoError(t, engine.MakeHumanDecision(ctx, step.ID, "alice", HumanDecisionConfirmed, nil))
+		}
+	}
+	runAll()
+
+	timeline, err = engine.GetTimeline(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, timeline.Status)
+
+	approve = timelineSpans(t, timeline, "approve")
+	require.Len(t, approve, 2)
+	assert.Equal(t, TimelineQueued, approve[0].Kind)
+	waiting = approve[1]
+	assert.Equal(t, TimelineWaitingDecision, waiting.Kind)
+	assert.False(t, waiting.Open)
+	assert.Equal(t, 6*time.Minute, waiting.Duration, "polls of the human step do not split the wait")
+	assert.Equal(t, 6*time.Minute, timeline.Totals[TimelineWaitingDecision])
+	assert.Equal(t, time.Minute, timeline.Totals[TimelineRetryWait])
+	assert.Equal(t, start, timeline.Start)
+	assert.Equal(t, clock.Now(), timeline.End)
+	assert.Equal(t, "flaky", timeline.Steps[0].StepName)
+}
+
+func TestBuildTimeline_Compensation(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+	stepID := int64(1)
+	completedAt := at(100)
+
+	instance := &WorkflowInstance{ID: 7, WorkflowID: "wf-v1", Status: StatusFailed, CreatedAt: start, CompletedAt: &completedAt}
+	steps := []WorkflowStep{{ID: stepID, StepName: "charge", StepType: StepTypeTask, Status: StepStatusRolledBack, CreatedAt: at(0)}}
+	event := func(id int64, seconds int, eventType string, payload string) WorkflowEvent {
+		return WorkflowEvent{ID: id, InstanceID: 7, StepID: &stepID, EventType: eventType, Payload: json.RawMessage(payload), CreatedAt: at(seconds)}
+	}
+	events := []WorkflowEvent{
+		event(1, 2, EventStepStarted, `{}`),
+		event(2, 10, EventStepCompleted, `{}`),
+		event(3, 20, EventStepStarted, `{"reason":"enqueued_for_rollback_after_failure"}`),
+		event(4, 30, EventStepFailed, `{"reason":"compensation_retry","retry_count":1}`),
+		event(5, 30, EventStepStarted, `{"reason":"compensation","retry_count":1}`),
+		event(6, 45, EventStepCompleted, `{"reason":"compensation_success"}`),
+	}
+
+	timeline := BuildTimeline(instance, steps, events, at(1000))
+	spans := timelineSpans(t, timeline, "charge")
+	require.Len(t, spans, 3)
+	assert.Equal(t, TimelineQueued, spans[0].Kind)
+	assert.Equal(t, TimelineRunning, spans[1].Kind)
+	assert.Equal(t, TimelineCompensation, spans[2].Kind)
+	assert.Equal(t, at(20), spans[2].Start)
+	assert.Equal(t, 25*time.Second, spans[2].Duration)
+	assert.Equal(t, completedAt, timeline.End, "a finished instance ends at its completion")
+}
@@ -0,0 +1,363 @@
+package floxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type graphEdgeKind int
+
+const (
+	graphEdgeNext graphEdgeKind = iota
+	graphEdgeTrue
+	graphEdgeElse
+	graphEdgeBranch
+	graphEdgeWait
+	graphEdgeCompensation
+)
+
+type graphEdge struct {
+	from, to string
+	kind     graphEdgeKind
+}
+
+// stepStatusColors are the node fill colors of the instance graphs.
+var stepStatusColors = map[StepStatus]string{
+	StepStatusCompleted:       "#c8e6c9",
+	StepStatusConfirmed:       "#c8e6c9",
+	StepStatusRunning:         "#bbdefb",
+	StepStatusWaitingDecision: "#fff9c4",
+	StepStatusPaused:          "#fff9c4",
+	StepStatusPending:         "#eeeeee",
+	StepStatusFailed:          "#ffcdd2",
+	StepStatusRejected:        "#ffcdd2",
+	StepStatusSkipped:         "#e0e0e0",
+	StepStatusCompensation:    "#ffe0b2",
+	StepStatusRolledBack:      "#d1c4e9",
+}
+
+const compensationEdgeColor = "#d32f2f"
+
+// RenderMermaid renders the workflow as a Mermaid flowchart, with a shape per step type,
+// true/else edges of conditions, dotted join edges and red compensation edges.
+func (v *Visualizer) RenderMermaid(def *WorkflowDefinition) string {
+	return v.renderMermaid(def, nil)
+}
+
+// RenderInstanceMermaid renders the workflow of the instance as a Mermaid flowchart with
+// the steps colored by status.
+func (v *Visualizer) RenderInstanceMermaid(def *WorkflowDefinition, instance *WorkflowInstance, steps []WorkflowStep) string {
+	return v.renderMermaid(def, latestStepStatuses(steps), fmt.Sprintf("instance %d: %s", instance.ID, instance.Status))
+}
+
+// RenderDOT renders the workflow as a Graphviz DOT digraph, with a shape per step type,
+// true/else edges of conditions, dotted join edges and red compensation edges.
+func (v *Visualizer) RenderDOT(def *WorkflowDefinition) string {
+	return v.renderDOT(def, nil)
+}
+
+// RenderInstanceDOT renders the workflow of the instance as a Graphviz DOT digraph with
+// the steps colored by status.
+func (v *Visualizer) RenderInstanceDOT(def *WorkflowDefinition, instance *WorkflowInstance, steps []WorkflowStep) string {
+	return v.renderDOT(def, latestStepStatuses(steps), fmt.Sprintf("instance %d: %s", instance.ID, instance.Status))
+}
+
+func (v *Visualizer) renderMermaid(def *WorkflowDefinition, statuses map[string]StepStatus, title ...string) string {
+	nodes, edges := graphOf(def)
+
+	ids := make(map[string]string, len(nodes))
+	for i, name := range nodes {
+		ids[name] = fmt.Sprintf("s%d", i)
+	}
+
+	var b strings.Builder
+	if len(title) > 0 {
+		fmt.Fprintf(&b, "---\ntitle: %s\n---\n", title[0])
+	}
+	b.WriteString("flowchart TD\n")
+
+	for _, name := range nodes {
+		label := mermaidEscape(graphLabel(name, def.Definition.Steps[name]), "<br/>")
+		fmt.Fprintf(&b, "    %s%s\n", ids[name], mermaidShape(def.Definition.Steps[name], label))
+	}
+
+	var compensationLinks []string
+	for i, edge := range edges {
+		from, to := ids[edge.from], ids[edge.to]
+		switch edge.kind {
+		case graphEdgeTrue:
+			fmt.Fprintf(&b, "    %s -->|true| %s\n", from, to)
+		case graphEdgeElse:
+			fmt.Fprintf(&b, "    %s -->|else| %s\n", from, to)
+		case graphEdgeWait:
+			fmt.Fprintf(&b, "    %s -.-> %s\n", from, to)
+		case graphEdgeCompensation:
+			fmt.Fprintf(&b, "    %s -.->|on failure| %s\n", from, to)
+			compensationLinks = append(compensationLinks, fmt.Sprint(i))
+		default:
+			fmt.Fprintf(&b, "    %s --> %s\n", from, to)
+		}
+	}
+
+	if len(compensationLinks) > 0 {
+		fmt.Fprintf(&b, "    linkStyle %s stroke:%s,color:%s\n",
+			strings.Join(compensationLinks, ","), compensationEdgeColor, compensationEdgeColor)
+	}
+
+	if statuses != nil {
+		classes := make(map[StepStatus][]string)
+		for _, name := range nodes {
+			if status, ok := statuses[name]; ok {
+				classes[status] = append(classes[status], ids[name])
+			}
+		}
+
+		for _, status := range sortedStatuses(classes) {
+			color, ok := stepStatusColors[status]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "    classDef %s fill:%s\n", status, color)
+			fmt.Fprintf(&b, "    class %s %s\n", strings.Join(classes[status], ","), status)
+		}
+	}
+
+	return b.String()
+}
+
+func mermaidShape(step *StepDefinition, label string) string {
+	switch step.Type {
+	case StepTypeCondition:
+		return `{"` + label + `"}`
+	case StepTypeFork, StepTypeParallel:
+		return `{{"` + label + `"}}`
+	case StepTypeJoin:
+		return `(("` + label + `"))`
+	case StepTypeSavePoint:
+		return `[("` + label + `")]`
+	case StepTypeHuman:
+		return `[/"` + label + `"\]`
+	case StepTypeTask:
+		return `["` + label + `"]`
+	default:
+		return `("` + label + `")`
+	}
+}
+
+func mermaidEscape(lines []string, separator string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	for i, line := range lines {
+		lines[i] = replacer.Replace(line)
+	}
+
+	return strings.Join(lines, separator)
+}
+
+func (v *Visualizer) renderDOT(def *WorkflowDefinition, statuses map[string]StepStatus, title ...string) string {
+	nodes, edges := graphOf(def)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(def.ID))
+	if len(title) > 0 {
+		fmt.Fprintf(&b, "    label=%s;\n    labelloc=t;\n", dotQuote(title[0]))
+	}
+	b.WriteString("    rankdir=TB;\n")
+	b.WriteString("    node [fontname=\"Helvetica\"];\n")
+	b.WriteString("    edge [fontname=\"Helvetica\"];\n")
+
+	for _, name := range nodes {
+		step := def.Definition.Steps[name]
+		attrs := []string{
+			"label=" + dotQuote(strings.Join(graphLabel(name, step), "\n")),
+			"shape=" + dotShape(step),
+		}
+		if status, ok := statuses[name]; ok {
+			if color, ok := stepStatusColors[status]; ok {
+				attrs = append(attrs, "style=filled", "fillcolor="+dotQuote(color))
+			}
+		}
+
+		fmt.Fprintf(&b, "    %s [%s];\n", dotQuote(name), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range edges {
+		var attrs []string
+		switch edge.kind {
+		case graphEdgeTrue:
+			attrs = []string{`label="true"`}
+		case graphEdgeElse:
+			attrs = []string{`label="else"`}
+		case graphEdgeWait:
+			attrs = []string{"style=dotted"}
+		case graphEdgeCompensation:
+			attrs = []string{
+				`label="on failure"`,
+				"style=dashed",
+				"color=" + dotQuote(compensationEdgeColor),
+				"fontcolor=" + dotQuote(compensationEdgeColor),
+			}
+		}
+
+		fmt.Fprintf(&b, "    %s -> %s", dotQuote(edge.from), dotQuote(edge.to))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func dotShape(step *StepDefinition) string {
+	switch step.Type {
+	case StepTypeCondition:
+		return "diamond"
+	case StepTypeFork, StepTypeParallel:
+		return "hexagon"
+	case StepTypeJoin:
+		return "circle"
+	case StepTypeSavePoint:
+		return "cylinder"
+	case StepTypeHuman:
+		return "trapezium"
+	case StepTypeTask:
+		return "box"
+	default:
+		return "ellipse"
+	}
+}
+
+func dotQuote(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// graphLabel returns the label lines of a step: its name and its handler or condition.
+func graphLabel(name string, step *StepDefinition) []string {
+	lines := []string{name}
+	switch {
+	case step.Type == StepTypeCondition && step.Condition != "":
+		lines = append(lines, step.Condition)
+	case step.Handler != "":
+		lines = append(lines, step.Handler)
+	}
+
+	return lines
+}
+
+// graphOf returns the steps in the order they are reached from the start step, unreachable
+// steps last by name, and the edges between them.
+func graphOf(def *WorkflowDefinition) ([]string, []graphEdge) {
+	steps := def.Definition.Steps
+
+	var (
+		nodes   []string
+		edges   []graphEdge
+		visited = make(map[string]bool, len(steps))
+	)
+
+	var visit func(name string)
+	visit = func(name string) {
+		step, ok := steps[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+		nodes = append(nodes, name)
+
+		var (
+			targets []graphEdge
+			joins   []string
+		)
+		for _, waitFor := range step.WaitFor {
+			// A condition in a branch stands for the last steps of whichever of its branches runs
+			waitFor = strings.TrimPrefix(waitFor, "cond#")
+			targets = append(targets, graphEdge{from: waitFor, to: name, kind: graphEdgeWait})
+		}
+		for _, parallelStep := range step.Parallel {
+			targets = append(targets, graphEdge{from: name, to: parallelStep, kind: graphEdgeBranch})
+		}
+		for _, next := range step.Next {
+			kind := graphEdgeNext
+			if step.Type == StepTypeCondition {
+				kind = graphEdgeTrue
+			}
+			// Forks reach their join through the branches
+			if nextDef, ok := steps[next]; ok && nextDef.Type == StepTypeJoin && len(nextDef.WaitFor) > 0 &&
+				(step.Type == StepTypeFork || step.Type == StepTypeParallel) {
+				joins = append(joins, next)
+
+				continue
+			}
+			targets = append(targets, graphEdge{from: name, to: next, kind: kind})
+		}
+		if step.Else != "" {
+			targets = append(targets, graphEdge{from: name, to: step.Else, kind: graphEdgeElse})
+		}
+		if step.OnFailure != "" {
+			targets = append(targets, graphEdge{from: name, to: step.OnFailure, kind: graphEdgeCompensation})
+		}
+
+		for _, edge := range targets {
+			if _, ok := steps[edge.from]; !ok {
+				continue
+			}
+			if _, ok := steps[edge.to]; !ok {
+				continue
+			}
+			edges = append(edges, edge)
+		}
+		for _, edge := range targets {
+			if edge.kind != graphEdgeWait {
+				visit(edge.to)
+			}
+		}
+		for _, join := range joins {
+			visit(join)
+		}
+	}
+
+	visit(def.Definition.Start)
+
+	rest := make([]string, 0, len(steps)-len(nodes))
+	for name := range steps {
+		if !visited[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		visit(name)
+	}
+
+	return nodes, edges
+}
+
+// latestStepStatuses returns the status of the latest step record of each step name.
+func latestStepStatuses(steps []WorkflowStep) map[string]StepStatus {
+	statuses := make(map[string]StepStatus, len(steps))
+	latest := make(map[string]int64, len(steps))
+	for _, step := range steps {
+		if id, ok := latest[step.StepName]; ok && id > step.ID {
+			continue
+		}
+		latest[step.StepName] = step.ID
+		statuses[step.StepName] = step.Status
+	}
+
+	return statuses
+}
+
+func sortedStatuses(classes map[StepStatus][]string) []StepStatus {
+	statuses := make([]StepStatus, 0, len(classes))
+	for status := range classes {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+	return statuses
+}
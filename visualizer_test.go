@@ -1,6 +1,7 @@
 package floxy
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -230,3 +231,179 @@ func TestVisualizer_GetStatusSymbol(t *testing.T) {
 		assert.Equal(t, test.expected, result, "StepStatus: %s", test.status)
 	}
 }
+
+func graphExportWorkflow(t *testing.T) *WorkflowDefinition {
+	t.Helper()
+
+	def, err := NewBuilder("order", 1).
+		Step("reserve", "reserve").OnFailure("release", "release").
+		SavePoint("checkpoint").
+		Fork("fanout", func(branch *Builder) {
+			branch.Step("check", "check").
+				Condition("is-big", `{{ gt .amount 100 }}`, func(elseBranch *Builder) {
+					elseBranch.Step("small", "noop")
+				}).
+				Then("big", "noop")
+		}, func(branch *Builder) {
+			branch.Step("notify", "notify")
+		}).
+		Join("sync", JoinStrategyAll).
+		WaitHumanConfirm("approve").
+		Then("ship", "ship").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return def
+}
+
+func TestVisualizer_RenderMermaid(t *testing.T) {
+	visualizer := NewVisualizer()
+	result := visualizer.RenderMermaid(graphExportWorkflow(t))
+
+	expected := `flowchart TD
+    s0["reserve<br/>reserve"]
+    s1[("checkpoint")]
+    s2{{"fanout"}}
+    s3["check<br/>check"]
+    s4{"is-big<br/>{{ gt .amount 100 }}"}
+    s5["big<br/>noop"]
+    s6["small<br/>noop"]
+    s7["notify<br/>notify"]
+    s8(("sync"))
+    s9[/"approve"\]
+    s10["ship<br/>ship"]
+    s11["release<br/>release"]
+    s0 --> s1
+    s0 -.->|on failure| s11
+    s1 --> s2
+    s2 --> s3
+    s2 --> s7
+    s3 --> s4
+    s4 -->|true| s5
+    s4 -->|else| s6
+    s4 -.-> s8
+    s7 -.-> s8
+    s8 --> s9
+    s9 --> s10
+    linkStyle 1 stroke:#d32f2f,color:#d32f2f
+`
+	assert.Equal(t, expected, result)
+}
+
+func TestVisualizer_RenderDOT(t *testing.T) {
+	visualizer := NewVisualizer()
+	result := visualizer.RenderDOT(graphExportWorkflow(t))
+
+	assert.Contains(t, result, `digraph "order-v1" {`)
+	assert.Contains(t, result, `"reserve" [label="reserve\nreserve", shape=box];`)
+	assert.Contains(t, result, `"checkpoint" [label="checkpoint", shape=cylinder];`)
+	assert.Contains(t, result, `"fanout" [label="fanout", shape=hexagon];`)
+	assert.Contains(t, result, `"is-big" [label="is-big\n{{ gt .amount 100 }}", shape=diamond];`)
+	assert.Contains(t, result, `"sync" [label="sync", shape=circle];`)
+	assert.Contains(t, result, `"approve" [label="approve", shape=trapezium];`)
+	assert.Contains(t, result, `"is-big" -> "big" [label="true"];`)
+	assert.Contains(t, result, `"is-big" -> "small" [label="else"];`)
+	assert.Contains(t, result, `"notify" -> "sync" [style=dotted];`)
+	assert.Contains(t, result, `"reserve" -> "release" [label="on failure", style=dashed, color="#d32f2f", fontcolor="#d32f2f"];`)
+	assert.NotContains(t, result, `"fanout" -> "sync"`)
+	assert.True(t, strings.HasSuffix(result, "}\n"))
+}
+
+func TestVisualizer_RenderInstanceGraph(t *testing.T) {
+	visualizer := NewVisualizer()
+	def := graphExportWorkflow(t)
+	instance := &WorkflowInstance{ID: 42, WorkflowID: def.ID, Status: StatusRunning}
+	steps := []WorkflowStep{
+		{ID: 1, StepName: "reserve", Status: StepStatusFailed},
+		{ID: 2, StepName: "reserve", Status: StepStatusCompleted},
+		{ID: 3, StepName: "checkpoint", Status: StepStatusCompleted},
+		{ID: 4, StepName: "approve", Status: StepStatusWaitingDecision},
+	}
+
+	mermaid := visualizer.RenderInstanceMermaid(def, instance, steps)
+	assert.True(t, strings.HasPrefix(mermaid, "---\ntitle: instance 42: running\n---\nflowchart TD\n"))
+	assert.Contains(t, mermaid, "    classDef completed fill:#c8e6c9\n    class s0,s1 completed\n")
+	assert.Contains(t, mermaid, "    classDef waiting_decision fill:#fff9c4\n    class s9 waiting_decision\n")
+	assert.NotContains(t, mermaid, "classDef failed")
+
+	dot := visualizer.RenderInstanceDOT(def, instance, steps)
+	assert.Contains(t, dot, `label="instance 42: running";`)
+	assert.Contains(t, dot, `"reserve" [label="reserve\nreserve", shape=box, style=filled, fillcolor="#c8e6c9"];`)
+	assert.Contains(t, dot, `"approve" [label="approve", shape=trapezium, style=filled, fillcolor="#fff9c4"];`)
+	assert.Contains(t, dot, `"ship" [label="ship\nship", shape=box];`)
+}
+
+func TestVisualizer_RenderGraphEscaping(t *testing.T) {
+	visualizer := NewVisualizer()
+	def, err := NewBuilder("quotes", 1).
+		Step("start", "noop").
+		Condition("check", `{{ eq .name "a<b" }}`, func(elseBranch *Builder) {
+			elseBranch.Step("other", "noop")
+		}).
+		Build()
+	assert.NoError(t, err)
+
+	assert.Contains(t, visualizer.RenderMermaid(def), `{"check<br/>{{ eq .name #quot;a#lt;b#quot; }}"}`)
+	assert.Contains(t, visualizer.RenderDOT(def), `[label="check\n{{ eq .name \"a<b\" }}", shape=diamond]`)
+}
+
+func renderedTimeline() *Timeline {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	return &Timeline{
+		InstanceID: 9,
+		WorkflowID: "orders-v1",
+		Status:     StatusRunning,
+		Start:      start,
+		End:        at(60),
+		Duration:   time.Minute,
+		Steps: []TimelineStep{
+			{StepID: 1, StepName: "charge", StepType: StepTypeTask, Spans: []TimelineSpan{
+				{Kind: TimelineQueued, Start: at(0), End: at(6), Duration: 6 * time.Second},
+				{Kind: TimelineRunning, Attempt: 1, Start: at(6), End: at(12), Duration: 6 * time.Second},
+				{Kind: TimelineRetryWait, Start: at(12), End: at(30), Duration: 18 * time.Second},
+				{Kind: TimelineRunning, Attempt: 2, Start: at(30), End: at(36), Duration: 6 * time.Second},
+			}},
+			{StepID: 2, StepName: "<approve>", StepType: StepTypeHuman, Spans: []TimelineSpan{
+				{Kind: TimelineWaitingDecision, Start: at(36), End: at(60), Duration: 24 * time.Second, Open: true},
+			}},
+		},
+		Totals: map[TimelineSpanKind]time.Duration{
+			TimelineQueued:          6 * time.Second,
+			TimelineRunning:         12 * time.Second,
+			TimelineRetryWait:       18 * time.Second,
+			TimelineWaitingDecision: 24 * time.Second,
+		},
+	}
+}
+
+func TestVisualizer_RenderTimeline(t *testing.T) {
+	visualizer := NewVisualizer()
+	result := visualizer.RenderTimeline(renderedTimeline())
+
+	assert.Contains(t, result, "Instance 9 (orders-v1): running, 1m0s\n")
+	assert.Contains(t, result, "charge [task]            |......######rrrrrrrrrrrrrrrrrr######                        |\n")
+	assert.Contains(t, result, "<approve> [human]        |                                    hhhhhhhhhhhhhhhhhhhhhhhh|\n")
+	assert.Contains(t, result, "    running #2           +30s        6s\n")
+	assert.Contains(t, result, "    waiting_decision     +36s        24s (in progress)\n")
+	assert.Contains(t, result, "    r retry_wait         18s\n")
+	assert.NotContains(t, result, "join_wait")
+}
+
+func TestVisualizer_RenderTimelineHTML(t *testing.T) {
+	visualizer := NewVisualizer()
+	result := visualizer.RenderTimelineHTML(renderedTimeline())
+
+	assert.True(t, strings.HasPrefix(result, "<!DOCTYPE html>"))
+	assert.Contains(t, result, "<title>Instance 9 (orders-v1)</title>")
+	assert.Contains(t, result, `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Contains(t, result, `<rect x="308.0" y="30" width="88.0" height="16" fill="#42a5f5"><title>charge: running #1 6s (+6s)</title></rect>`)
+	assert.Contains(t, result, `stroke-dasharray="3,2"><title>&lt;approve&gt;: waiting_decision 24s (+36s)</title>`)
+	assert.Contains(t, result, ">+1m0s</text>")
+	assert.NotContains(t, result, "<approve>")
+	assert.NotContains(t, result, "<script")
+	assert.NotContains(t, strings.ReplaceAll(result, "http://www.w3.org/2000/svg", ""), "http", "no external resources")
+}
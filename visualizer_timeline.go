@@ -0,0 +1,189 @@
+package floxy
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// timelineSpanStyles are the bar color and text symbol of each span kind.
+var timelineSpanStyles = map[TimelineSpanKind]struct {
+	color  string
+	symbol byte
+}{
+	TimelineQueued:          {color: "#b0bec5", symbol: '.'},
+	TimelineRunning:         {color: "#42a5f5", symbol: '#'},
+	TimelineRetryWait:       {color: "#ffb74d", symbol: 'r'},
+	TimelineWaitingDecision: {color: "#fdd835", symbol: 'h'},
+	TimelineJoinWait:        {color: "#ba68c8", symbol: 'j'},
+	TimelineCompensation:    {color: "#e57373", symbol: 'c'},
+}
+
+const (
+	timelineTextWidth  = 60
+	timelineLabelWidth = 220
+	timelineChartWidth = 880
+	timelineRowHeight  = 26
+	timelineBarHeight  = 16
+	timelineAxisHeight = 30
+	timelineTicks      = 5
+)
+
+// RenderTimeline renders the timeline as text: a bar per step with one character per span kind,
+// the spans with their offset from the instance start, and the totals per span kind.
+func (v *Visualizer) RenderTimeline(timeline *Timeline) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Instance %d (%s): %s, %s\n\n",
+		timeline.InstanceID, timeline.WorkflowID, timeline.Status, formatTimelineDuration(timeline.Duration))
+
+	for _, step := range timeline.Steps {
+		bar := []byte(strings.Repeat(" ", timelineTextWidth))
+		for _, span := range step.Spans {
+			from, to := timelineTextColumns(timeline, span)
+			for i := from; i < to; i++ {
+				bar[i] = timelineSpanStyles[span.Kind].symbol
+			}
+		}
+
+		fmt.Fprintf(&b, "%-24s |%s|\n", fmt.Sprintf("%s [%s]", step.StepName, step.StepType), bar)
+		for _, span := range step.Spans {
+			suffix := ""
+			if span.Open {
+				suffix = " (in progress)"
+			}
+			fmt.Fprintf(&b, "    %-20s +%-10s %s%s\n",
+				timelineSpanName(span), formatTimelineDuration(span.Start.Sub(timeline.Start)),
+				formatTimelineDuration(span.Duration), suffix)
+		}
+	}
+
+	b.WriteString("\nTotals:\n")
+	for _, kind := range TimelineSpanKinds {
+		if total, ok := timeline.Totals[kind]; ok {
+			fmt.Fprintf(&b, "    %c %-18s %s\n", timelineSpanStyles[kind].symbol, kind, formatTimelineDuration(total))
+		}
+	}
+
+	return b.String()
+}
+
+func timelineTextColumns(timeline *Timeline, span TimelineSpan) (int, int) {
+	if timeline.Duration <= 0 {
+		return 0, 1
+	}
+
+	column := func(t time.Time) int {
+		return int(float64(t.Sub(timeline.Start)) / float64(timeline.Duration) * timelineTextWidth)
+	}
+
+	from, to := column(span.Start), column(span.End)
+	from = min(max(from, 0), timelineTextWidth-1)
+	to = min(max(to, from+1), timelineTextWidth)
+
+	return from, to
+}
+
+func timelineSpanName(span TimelineSpan) string {
+	if span.Attempt > 0 {
+		return fmt.Sprintf("%s #%d", span.Kind, span.Attempt)
+	}
+
+	return string(span.Kind)
+}
+
+func formatTimelineDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.String()
+	}
+}
+
+// RenderTimelineHTML renders the timeline as a self-contained HTML page with an SVG Gantt chart:
+// a row per step, a bar per span colored by kind, hover titles with the span details and a legend
+// with the totals per span kind.
+func (v *Visualizer) RenderTimelineHTML(timeline *Timeline) string {
+	title := fmt.Sprintf("Instance %d (%s)", timeline.InstanceID, timeline.WorkflowID)
+	height := timelineAxisHeight + len(timeline.Steps)*timelineRowHeight + 10
+	width := timelineLabelWidth + timelineChartWidth + 20
+
+	x := func(t time.Time) float64 {
+		if timeline.Duration <= 0 {
+			return timelineLabelWidth
+		}
+
+		return timelineLabelWidth + float64(t.Sub(timeline.Start))/float64(timeline.Duration)*timelineChartWidth
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString(`<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 24px; color: #212121; }
+h1 { font-size: 18px; margin: 0 0 4px; }
+p { margin: 0 0 16px; color: #616161; }
+svg text { font-size: 12px; }
+.legend { margin-top: 12px; font-size: 13px; }
+.legend span { display: inline-block; margin-right: 18px; }
+.legend i { display: inline-block; width: 12px; height: 12px; margin-right: 6px; vertical-align: middle; }
+</style>
+</head>
+<body>
+`)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+	fmt.Fprintf(&b, "<p>%s &middot; %s &middot; started %s</p>\n",
+		html.EscapeString(string(timeline.Status)),
+		html.EscapeString(formatTimelineDuration(timeline.Duration)),
+		html.EscapeString(timeline.Start.Format(time.RFC3339)))
+
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+
+	for i := 0; i <= timelineTicks; i++ {
+		offset := timeline.Duration * time.Duration(i) / timelineTicks
+		tickX := timelineLabelWidth + float64(timelineChartWidth*i)/timelineTicks
+		anchor := "middle"
+		if i == timelineTicks {
+			anchor = "end"
+		}
+		fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\" stroke=\"#e0e0e0\"/>\n",
+			tickX, timelineAxisHeight-6, tickX, height)
+		fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%d\" text-anchor=\"%s\" fill=\"#757575\">+%s</text>\n",
+			tickX, timelineAxisHeight-10, anchor, html.EscapeString(formatTimelineDuration(offset)))
+	}
+
+	for row, step := range timeline.Steps {
+		y := timelineAxisHeight + row*timelineRowHeight
+		fmt.Fprintf(&b, "<text x=\"0\" y=\"%d\">%s <tspan fill=\"#9e9e9e\">%s</tspan></text>\n",
+			y+timelineBarHeight-3, html.EscapeString(step.StepName), html.EscapeString(string(step.StepType)))
+
+		for _, span := range step.Spans {
+			spanX := x(span.Start)
+			spanWidth := max(x(span.End)-spanX, 1)
+			stroke := ""
+			if span.Open {
+				stroke = ` stroke="#424242" stroke-dasharray="3,2"`
+			}
+			fmt.Fprintf(&b, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" fill=\"%s\"%s>",
+				spanX, y, spanWidth, timelineBarHeight, timelineSpanStyles[span.Kind].color, stroke)
+			fmt.Fprintf(&b, "<title>%s: %s %s (+%s)</title></rect>\n",
+				html.EscapeString(step.StepName), html.EscapeString(timelineSpanName(span)),
+				html.EscapeString(formatTimelineDuration(span.Duration)),
+				html.EscapeString(formatTimelineDuration(span.Start.Sub(timeline.Start))))
+		}
+	}
+
+	b.WriteString("</svg>\n<div class=\"legend\">\n")
+	for _, kind := range TimelineSpanKinds {
+		fmt.Fprintf(&b, "<span><i style=\"background: %s\"></i>%s %s</span>\n",
+			timelineSpanStyles[kind].color, html.EscapeString(string(kind)),
+			html.EscapeString(formatTimelineDuration(timeline.Totals[kind])))
+	}
+	b.WriteString("</div>\n</body>\n</html>\n")
+
+	return b.String()
+}
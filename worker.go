@@ -2,98 +2,456 @@ package floxy
 
 import (
 	"context"
+	"errors"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var errWorkerStopped = errors.New("worker stopped")
+
 type Worker struct {
 	engine   *Engine
 	workerID string
 	interval time.Duration
 	stopCh   chan struct{}
 	stopOnce sync.Once
+
+	// nil means the engine's TaskQueues
+	taskQueues []string
+
+	// "" claims items of any namespace (or of the engine's namespace when bound)
+	namespace string
+
+	// Poll outcomes of pool workers, used by the autoscaler
+	stats *poolStats
 }
 
-func NewWorker(engine *Engine, interval time.Duration) *Worker {
-	return &Worker{
+type WorkerOption func(worker *Worker)
+
+// WithWorkerTaskQueues subscribes the worker to the given task queues instead of the
+// queues named after the handlers registered with the engine. The default queue is always polled.
+func WithWorkerTaskQueues(queues ...string) WorkerOption {
+	return func(worker *Worker) {
+		worker.taskQueues = queues
+	}
+}
+
+func NewWorker(engine *Engine, interval time.Duration, opts ...WorkerOption) *Worker {
+	worker := &Worker{
 		engine:   engine,
 		workerID: uuid.New().String(),
 		interval: interval,
 		stopCh:   make(chan struct{}),
 	}
+
+	for _, opt := range opts {
+		opt(worker)
+	}
+
+	return worker
 }
 
+// Start runs the worker loop until ctx is done or Stop is called.
+// When the engine has a notifier, the worker sleeps until a step is enqueued
+// (or becomes due) and drains the queue; polling every interval is only a fallback.
 func (w *Worker) Start(ctx context.Context) {
-	ticker := time.NewTicker(w.interval)
-	defer ticker.Stop()
-
 	log.Printf("Workflow worker %s started", w.workerID)
 
+	if w.namespace != "" {
+		ctx = WithNamespace(ctx, w.namespace)
+	}
+
+	waiter := newQueueWaiter(w.engine, w.interval)
+	defer waiter.close()
+
+	// Steps enqueued before the subscription are not notified
+	if waiter.pushMode() {
+		w.process(ctx, true)
+	}
+
+	for {
+		if err := waiter.wait(ctx, w.stopCh); err != nil {
+			if errors.Is(err, errWorkerStopped) {
+				log.Printf("Workflow worker %s stopping: stop signal received", w.workerID)
+			} else {
+				log.Printf("Workflow worker %s stopping: context cancelled", w.workerID)
+			}
+
+			return
+		}
+
+		w.process(ctx, waiter.pushMode())
+	}
+}
+
+// process executes the next step. With drain set it keeps going until the queue is empty.
+func (w *Worker) process(ctx context.Context, drain bool) {
 	for {
+		empty, err := w.processNext(ctx)
+		if err != nil {
+			log.Printf("Workflow worker %s error: %v", w.workerID, err)
+
+			return
+		}
+
+		if empty || !drain || ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-w.stopCh:
+			return
+		default:
+		}
+	}
+}
+
+func (w *Worker) Stop() {
+	w.stopOnce.Do(func() { close(w.stopCh) })
+}
+
+func (w *Worker) processNext(ctx context.Context) (bool, error) {
+	empty, err := w.engine.executeNext(ctx, w.workerID, w.engine.pollQueues(w.taskQueues))
+	if w.stats != nil {
+		w.stats.record(err == nil && !empty)
+	}
+
+	return empty, err
+}
+
+// consume executes the items handed out by the pool dispatcher until stopped. The worker counts
+// itself idle while it waits; the dispatcher uncounts it when it hands over an item.
+func (w *Worker) consume(ctx context.Context, items <-chan QueueItem, idle *idleWorkers) {
+	for {
+		idle.add(1)
+
 		select {
 		case <-ctx.Done():
-			log.Printf("Workflow worker %s stopping: context cancelled", w.workerID)
+			idle.add(-1)
 
 			return
 		case <-w.stopCh:
-			log.Printf("Workflow worker %s stopping: stop signal received", w.workerID)
+			idle.add(-1)
 
 			return
-		case <-ticker.C:
-			if _, err := w.processNext(ctx); err != nil {
+		case item := <-items:
+			if err := w.engine.executeClaimedItem(ctx, &item); err != nil {
 				log.Printf("Workflow worker %s error: %v", w.workerID, err)
 			}
 		}
 	}
 }
 
-func (w *Worker) Stop() {
-	w.stopOnce.Do(func() { close(w.stopCh) })
+// idleWorkers counts the pool workers waiting for an item in dispatcher mode.
+type idleWorkers struct {
+	n atomic.Int64
+	// changed is signalled whenever n changes
+	changed chan struct{}
 }
 
-func (w *Worker) processNext(ctx context.Context) (bool, error) {
-	return w.engine.ExecuteNext(ctx, w.workerID)
+func newIdleWorkers() *idleWorkers {
+	return &idleWorkers{changed: make(chan struct{}, 1)}
+}
+
+func (i *idleWorkers) add(delta int64) {
+	i.n.Add(delta)
+
+	select {
+	case i.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (i *idleWorkers) count() int {
+	return int(i.n.Load())
+}
+
+// queueWaiter blocks until the queue may have due items: on the poll ticker or,
+// when the engine has a notifier, on enqueue notifications and when announced delayed steps become due.
+type queueWaiter struct {
+	clock       Clock
+	wakeups     <-chan string
+	unsubscribe func()
+	ticker      Ticker
+
+	dueTimer Timer
+	dueC     <-chan time.Time
+	dueAt    time.Time
+}
+
+func newQueueWaiter(engine *Engine, interval time.Duration) *queueWaiter {
+	waiter := &queueWaiter{clock: engine.clock}
+
+	pollInterval := interval
+	if engine.notifier != nil {
+		waiter.wakeups, waiter.unsubscribe = engine.notifier.Subscribe(NotifyChannelQueue)
+		pollInterval = max(pollInterval, engine.notifyFallbackInterval)
+	}
+	waiter.ticker = engine.clock.NewTicker(pollInterval)
+
+	return waiter
+}
+
+func (q *queueWaiter) pushMode() bool {
+	return q.wakeups != nil
+}
+
+// wait returns nil when the queue should be checked, ctx.Err() or errWorkerStopped otherwise.
+// A stop wins over a wakeup that is ready at the same time, so a stopped worker claims nothing.
+func (q *queueWaiter) wait(ctx context.Context, stopCh <-chan struct{}) error {
+	if err := q.next(ctx, stopCh); err != nil {
+		return err
+	}
+
+	select {
+	case <-stopCh:
+		return errWorkerStopped
+	default:
+		return nil
+	}
+}
+
+func (q *queueWaiter) next(ctx context.Context, stopCh <-chan struct{}) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stopCh:
+			return errWorkerStopped
+		case <-q.ticker.C():
+			return nil
+		case <-q.dueC:
+			q.dueC = nil
+
+			return nil
+		case payload := <-q.wakeups:
+			scheduledAt := parseQueueNotifyPayload(payload)
+			delay := scheduledAt.Sub(q.clock.Now())
+			if delay <= 0 {
+				return nil
+			}
+
+			// Keep a single timer for the earliest announced step
+			if q.dueC == nil || scheduledAt.Before(q.dueAt) {
+				if q.dueTimer != nil {
+					q.dueTimer.Stop()
+				}
+				q.dueTimer = q.clock.NewTimer(delay)
+				q.dueC = q.dueTimer.C()
+				q.dueAt = scheduledAt
+			}
+		}
+	}
+}
+
+func (q *queueWaiter) close() {
+	q.ticker.Stop()
+	if q.dueTimer != nil {
+		q.dueTimer.Stop()
+	}
+	if q.unsubscribe != nil {
+		q.unsubscribe()
+	}
+}
+
+type WorkerPoolOption func(pool *WorkerPool)
+
+// WithTaskQueues subscribes the pool workers to the given task queues (see WithWorkerTaskQueues).
+func WithTaskQueues(queues ...string) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		pool.taskQueues = queues
+	}
+}
+
+// WithDispatcher switches the pool to dispatcher mode: a single dispatcher claims up to
+// batchSize queue items per query (DequeueSteps) and hands them to the pool workers in
+// effective priority order. A claim never exceeds the idle workers, so claimed items do not
+// wait for a worker while their lease runs. Items claimed but not yet started are released
+// on Stop.
+func WithDispatcher(batchSize int) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		if batchSize > 0 {
+			pool.batchSize = batchSize
+		}
+	}
 }
 
 type WorkerPool struct {
-	workers []*Worker
-	engine  *Engine
-	mu      sync.Mutex
+	id         string
+	workers    []*Worker
+	engine     *Engine
+	mu         sync.Mutex
+	taskQueues []string
+	interval   time.Duration
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	stopped    bool
+
+	// "" claims items of any namespace (or of the engine's namespace when bound)
+	namespace string
+
+	// Set by Start; workers added later by Resize run with the same context
+	ctx        context.Context
+	started    bool
+	running    sync.WaitGroup
+	nextWorker int
+
+	// Dispatcher mode
+	batchSize int
+	items     chan QueueItem
+	idle      *idleWorkers
+
+	// Autoscaling
+	minWorkers        int
+	maxWorkers        int
+	autoscaleInterval time.Duration
+	stats             poolStats
+
+	// Worker registry
+	version           string
+	heartbeatInterval time.Duration
+	staleTimeout      time.Duration
+	heartbeatDone     chan struct{}
+	inFlight          atomic.Int64 // handler runs of the pool workers in progress
 }
 
-func NewWorkerPool(engine *Engine, size int, interval time.Duration) *WorkerPool {
-	workers := make([]*Worker, size)
-	for i := 0; i < size; i++ {
-		workers[i] = NewWorker(engine, interval)
+func NewWorkerPool(engine *Engine, size int, interval time.Duration, opts ...WorkerPoolOption) *WorkerPool {
+	pool := &WorkerPool{
+		id:                uuid.New().String(),
+		engine:            engine,
+		interval:          interval,
+		stopCh:            make(chan struct{}),
+		autoscaleInterval: defaultAutoscaleInterval,
+		heartbeatInterval: defaultWorkerHeartbeatInterval,
+		staleTimeout:      defaultWorkerStaleTimeout,
 	}
 
-	return &WorkerPool{
-		workers: workers,
-		engine:  engine,
+	for _, opt := range opts {
+		opt(pool)
 	}
+
+	if pool.autoscaling() {
+		size = min(max(size, pool.minWorkers), pool.maxWorkers)
+	}
+
+	for i := 0; i < size; i++ {
+		pool.workers = append(pool.workers, pool.newWorker())
+	}
+
+	return pool
+}
+
+func (p *WorkerPool) newWorker() *Worker {
+	worker := NewWorker(p.engine, p.interval)
+	worker.workerID = poolMemberID(p.id, p.nextWorker)
+	worker.taskQueues = p.taskQueues
+	worker.namespace = p.namespace
+	worker.stats = &p.stats
+	p.nextWorker++
+
+	return worker
 }
 
 func (p *WorkerPool) Start(ctx context.Context) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.started || p.stopped {
+		return
+	}
+	p.started = true
+	if p.namespace != "" {
+		ctx = WithNamespace(ctx, p.namespace)
+	}
+	// The dispatcher and the autoscaler call the store directly, so they need the engine namespace too
+	ctx = p.engine.scope(ctx)
+	ctx = withInFlightCounter(ctx, &p.inFlight)
+	p.ctx = ctx
+
+	if p.heartbeatInterval > 0 {
+		p.register(ctx)
+		p.heartbeatDone = make(chan struct{})
+		go p.heartbeat(ctx)
+	}
+
+	if p.batchSize > 0 {
+		p.items = make(chan QueueItem)
+		p.idle = newIdleWorkers()
+		p.running.Add(1)
+		go func() {
+			defer p.running.Done()
+			p.dispatch(ctx)
+		}()
+	}
+
 	for _, worker := range p.workers {
-		go worker.Start(ctx)
+		p.startWorker(worker)
+	}
+
+	if p.autoscaling() {
+		go p.autoscale(ctx)
 	}
 }
 
+// startWorker runs a worker of the started pool. The caller holds p.mu.
+func (p *WorkerPool) startWorker(worker *Worker) {
+	p.running.Add(1)
+	go func() {
+		defer p.running.Done()
+
+		if p.items != nil {
+			worker.consume(p.ctx, p.items, p.idle)
+
+			return
+		}
+
+		worker.Start(p.ctx)
+	}()
+}
+
+// Stop stops claiming queue items. Steps in progress keep running; Drain waits for them.
 func (p *WorkerPool) Stop() {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	p.stopOnce.Do(func() {
+		p.mu.Lock()
+		p.stopped = true
+		close(p.stopCh)
+		for _, worker := range p.workers {
+			worker.Stop()
+		}
+		p.mu.Unlock()
 
-	for _, worker := range p.workers {
-		worker.Stop()
+		p.deregister()
+	})
+}
+
+// Drain stops claiming queue items and waits until the steps in progress are finished, or until
+// ctx is done. It is meant for rolling deploys: drain the pool, then shut the engine down.
+func (p *WorkerPool) Drain(ctx context.Context) error {
+	p.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
+// ID returns the worker registry ID of the pool.
+func (p *WorkerPool) ID() string {
+	return p.id
+}
+
 func (p *WorkerPool) Shutdown(timeout time.Duration) error {
 	p.Stop()
 
@@ -101,5 +459,154 @@ func (p *WorkerPool) Shutdown(timeout time.Duration) error {
 }
 
 func (p *WorkerPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	return len(p.workers)
 }
+
+// Resize sets the number of pool workers (at least 1, within the bounds of WithAutoscaling if set).
+// Removed workers finish the step they are running. It has no effect once the pool is stopped.
+func (p *WorkerPool) Resize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.resizeLocked(n)
+}
+
+func (p *WorkerPool) resizeLocked(n int) {
+	if p.stopped {
+		return
+	}
+
+	n = max(n, 1)
+	if p.autoscaling() {
+		n = min(max(n, p.minWorkers), p.maxWorkers)
+	}
+
+	for len(p.workers) < n {
+		worker := p.newWorker()
+		p.workers = append(p.workers, worker)
+		if p.started {
+			p.startWorker(worker)
+		}
+	}
+
+	for len(p.workers) > n {
+		last := len(p.workers) - 1
+		p.workers[last].Stop()
+		p.workers = p.workers[:last]
+	}
+}
+
+// dispatch claims batches of queue items and feeds them to the idle pool workers until stopped.
+func (p *WorkerPool) dispatch(ctx context.Context) {
+	log.Printf("Workflow dispatcher %s started (workers: %d, batch: %d)", p.id, p.Size(), p.batchSize)
+
+	waiter := newQueueWaiter(p.engine, p.interval)
+	defer waiter.close()
+
+	for {
+		n := min(p.batchSize, p.idle.count())
+		if n <= 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.stopCh:
+				return
+			case <-p.idle.changed:
+			}
+
+			continue
+		}
+
+		batch, err := p.claim(ctx, n)
+		if err != nil {
+			log.Printf("Workflow dispatcher %s error: %v", p.id, err)
+		}
+		p.stats.record(len(batch) == n)
+
+		for i := range batch {
+			if !p.handOff(ctx, batch[i]) {
+				p.release(batch[i:])
+
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		default:
+		}
+
+		// A full batch means more items are likely due
+		if len(batch) == n {
+			continue
+		}
+
+		if err := waiter.wait(ctx, p.stopCh); err != nil {
+			log.Printf("Workflow dispatcher %s stopping: %v", p.id, err)
+
+			return
+		}
+	}
+}
+
+// handOff passes a claimed item to an idle worker. It gives up when the pool stops or no
+// worker is idle anymore, because workers were removed since the claim.
+func (p *WorkerPool) handOff(ctx context.Context, item QueueItem) bool {
+	for {
+		select {
+		case p.items <- item:
+			p.idle.add(-1)
+
+			return true
+		case <-ctx.Done():
+			return false
+		case <-p.stopCh:
+			return false
+		case <-p.idle.changed:
+			if p.idle.count() <= 0 {
+				return false
+			}
+		}
+	}
+}
+
+func (p *WorkerPool) claim(ctx context.Context, limit int) ([]QueueItem, error) {
+	if p.engine.isShutdown() {
+		return nil, nil
+	}
+
+	var items []QueueItem
+	err := p.engine.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		var err error
+		items, err = p.engine.store.DequeueSteps(ctx, p.id, p.engine.pollQueues(p.taskQueues), limit)
+		if err != nil {
+			return err
+		}
+
+		for i := range items {
+			if err := p.engine.leaseQueueItem(ctx, &items[i], p.id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return items, err
+}
+
+// release returns claimed items that were not handed to a worker back to the queue.
+func (p *WorkerPool) release(items []QueueItem) {
+	ctx := context.Background()
+	for _, item := range items {
+		if err := p.engine.store.ReleaseQueueItem(ctx, item.ID); err != nil {
+			log.Printf("Workflow dispatcher %s: release queue item %d: %v", p.id, item.ID, err)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package floxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDequeueSteps checks that DequeueSteps claims due items in priority order,
+// honours the batch limit and skips claimed and delayed items.
+func testDequeueSteps(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	stepIDs := []int64{1, 2, 3, 4}
+	priorities := []Priority{PriorityLow, PriorityHigh, PriorityNormal, PriorityHigher}
+	for i, stepID := range stepIDs {
+		require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "", priorities[i], 0))
+	}
+	delayedStepID := int64(5)
+	require.NoError(t, store.EnqueueStep(ctx, 1, &delayedStepID, "", PriorityHigh, time.Hour))
+
+	items, err := store.DequeueSteps(ctx, "dispatcher-1", nil, 3)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	var claimed []int64
+	for _, item := range items {
+		require.NotNil(t, item.StepID)
+		claimed = append(claimed, *item.StepID)
+		require.NotNil(t, item.AttemptedBy)
+		assert.Equal(t, "dispatcher-1", *item.AttemptedBy)
+	}
+	assert.Equal(t, []int64{2, 4, 3}, claimed)
+
+	items, err = store.DequeueSteps(ctx, "dispatcher-2", nil, 3)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(1), *items[0].StepID)
+
+	items, err = store.DequeueSteps(ctx, "dispatcher-2", nil, 3)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestDequeueSteps_MemoryStore(t *testing.T) {
+	testDequeueSteps(t, NewMemoryStore())
+}
+
+func TestIntegration_DequeueSteps(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testDequeueSteps(t, store)
+}
+
+// testDequeueTieBreak checks that items with equal priority and scheduled_at are claimed in
+// enqueue order. The store must run on a stopped clock so that every item gets the same time.
+func testDequeueTieBreak(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	stepIDs := []int64{30, 10, 40, 20}
+	for _, stepID := range stepIDs {
+		require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "", PriorityNormal, 0))
+	}
+
+	item, err := store.DequeueStep(ctx, "worker-1", nil)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, int64(30), *item.StepID)
+
+	items, err := store.DequeueSteps(ctx, "dispatcher-1", nil, 3)
+	require.NoError(t, err)
+
+	var claimed []int64
+	for _, item := range items {
+		claimed = append(claimed, *item.StepID)
+	}
+	assert.Equal(t, []int64{10, 40, 20}, claimed)
+}
+
+func TestDequeueTieBreak_MemoryStore(t *testing.T) {
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	testDequeueTieBreak(t, NewMemoryStore(WithStoreClock(clock)))
+}
+
+func TestIntegration_DequeueTieBreak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	if useSQLite {
+		t.Skip("needs a PostgreSQL pool to build a store with a manual clock")
+	}
+
+	_, txManager, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	testDequeueTieBreak(t, NewStore(txManager.(*TxManagerImpl).pool, WithStoreClock(clock)))
+}
+
+type countingHandler struct {
+	calls atomic.Int64
+}
+
+func (h *countingHandler) Name() string { return "counting" }
+
+func (h *countingHandler) Execute(_ context.Context, _ StepContext, input json.RawMessage) (json.RawMessage, error) {
+	h.calls.Add(1)
+
+	return input, nil
+}
+
+func newWorkerPoolTestEngine(t testing.TB, store Store) (*Engine, *countingHandler) {
+	t.Helper()
+
+	handler := &countingHandler{}
+	engine := newMemoryTestEngine(t, store, []StepHandler{handler}, WithEngineAwaitPollInterval(10*time.Millisecond))
+
+	return engine, handler
+}
+
+func registerChainWorkflow(t testing.TB, ctx context.Context, engine *Engine, steps int) string {
+	t.Helper()
+
+	builder := NewBuilder("worker_pool_chain", 1).Step("step-0", "counting")
+	for i := 1; i < steps; i++ {
+		builder = builder.Then(fmt.Sprintf("step-%d", i), "counting")
+	}
+	workflowDef, err := builder.Build()
+	require.NoError(t, err)
+	require.NoError(t, engine.RegisterWorkflow(ctx, workflowDef))
+
+	return workflowDef.ID
+}
+
+func TestWorkerPool_Dispatcher(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	engine, handler := newWorkerPoolTestEngine(t, NewMemoryStore())
+	workflowID := registerChainWorkflow(t, ctx, engine, 3)
+
+	pool := NewWorkerPool(engine, 4, 10*time.Millisecond, WithDispatcher(8))
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	results := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			result, err := engine.StartAwait(ctx, workflowID, json.RawMessage(`{}`))
+			if err == nil && result.Status != StatusCompleted {
+				err = fmt.Errorf("unexpected status %s", result.Status)
+			}
+			results <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, <-results)
+	}
+
+	assert.Equal(t, int64(30), handler.calls.Load())
+}
+
+func TestWorkerPool_DispatcherReleasesOnStop(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine, _ := newWorkerPoolTestEngine(t, store)
+
+	pool := NewWorkerPool(engine, 1, time.Hour, WithDispatcher(4))
+	for stepID := int64(1); stepID <= 3; stepID++ {
+		require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "", PriorityNormal, 0))
+	}
+
+	items, err := pool.claim(ctx, 4)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	pool.release(items)
+
+	items, err = store.DequeueSteps(ctx, "other", nil, 4)
+	require.NoError(t, err)
+	assert.Len(t, items, 3)
+}
+
+func TestWorkerPool_DispatcherClaimsForIdleWorkers(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	handler := &blockingHandler{started: make(chan string, 6), release: make(chan struct{})}
+	engine.RegisterHandler(handler)
+	for i := 0; i < 6; i++ {
+		startLeaseTestWorkflow(t, engine)
+	}
+
+	pool := NewWorkerPool(engine, 2, 10*time.Millisecond, WithDispatcher(8))
+	pool.Start(ctx)
+	defer pool.Stop()
+	<-handler.started
+	<-handler.started
+
+	// Both workers are busy, so the other items stay in the queue rather than waiting
+	// for a worker while their lease runs
+	select {
+	case <-handler.started:
+		t.Fatal("more steps started than workers")
+	case <-time.After(50 * time.Millisecond):
+	}
+	length, err := store.GetQueueLength(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, length)
+
+	close(handler.release)
+	for i := 0; i < 4; i++ {
+		<-handler.started
+	}
+}
+
+func TestAutoscaleTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		current int
+		depth   int
+		hitRate float64
+		want    int
+	}{
+		{name: "deep queue grows by half", current: 4, depth: 10, want: 6},
+		{name: "busy polls grow", current: 2, depth: 0, hitRate: 0.95, want: 3},
+		{name: "growth capped at max", current: 7, depth: 100, want: 8},
+		{name: "idle shrinks by one", current: 4, depth: 0, hitRate: 0, want: 3},
+		{name: "shrink stops at min", current: 2, depth: 0, hitRate: 0, want: 2},
+		{name: "steady load keeps size", current: 4, depth: 2, hitRate: 0.5, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, autoscaleTarget(tt.current, 2, 8, tt.depth, tt.hitRate))
+		})
+	}
+}
+
+func TestWorkerPool_AutoscaleWithQueueDepth(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine, _ := newWorkerPoolTestEngine(t, store)
+
+	pool := NewWorkerPool(engine, 0, time.Hour, WithAutoscaling(1, 3), WithHeartbeatInterval(0))
+	assert.Equal(t, 1, pool.Size())
+
+	for stepID := int64(1); stepID <= 5; stepID++ {
+		require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "counting", PriorityNormal, 0))
+	}
+	// Not subscribed, so not counted
+	stepID := int64(6)
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, "other", PriorityNormal, 0))
+
+	pool.scale(ctx)
+	assert.Equal(t, 2, pool.Size())
+	pool.scale(ctx)
+	assert.Equal(t, 3, pool.Size())
+	pool.scale(ctx)
+	assert.Equal(t, 3, pool.Size())
+
+	_, err := store.DequeueSteps(ctx, "other", nil, 10)
+	require.NoError(t, err)
+
+	pool.scale(ctx)
+	assert.Equal(t, 2, pool.Size())
+}
+
+func TestWorkerPool_Resize(t *testing.T) {
+	for _, opts := range [][]WorkerPoolOption{nil, {WithDispatcher(4)}} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+		engine, handler := newWorkerPoolTestEngine(t, NewMemoryStore())
+		workflowID := registerChainWorkflow(t, ctx, engine, 2)
+
+		pool := NewWorkerPool(engine, 1, 10*time.Millisecond, opts...)
+		pool.Start(ctx)
+
+		pool.Resize(4)
+		assert.Equal(t, 4, pool.Size())
+		pool.Resize(0)
+		assert.Equal(t, 1, pool.Size())
+		pool.Resize(3)
+
+		for i := 0; i < 5; i++ {
+			result, err := engine.StartAwait(ctx, workflowID, json.RawMessage(`{}`))
+			require.NoError(t, err)
+			assert.Equal(t, StatusCompleted, result.Status)
+		}
+		assert.Equal(t, int64(10), handler.calls.Load())
+
+		pool.Stop()
+		pool.Resize(5)
+		assert.Equal(t, 3, pool.Size())
+		cancel()
+	}
+}
+
+func TestWorkerPool_Drain(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine := NewEngine(nil,
+		WithEngineStore(store),
+		WithEngineTxManager(NewMemoryTxManager()),
+	)
+	t.Cleanup(func() { _ = engine.Shutdown() })
+
+	handler := &blockingHandler{started: make(chan string, 1), release: make(chan struct{})}
+	engine.RegisterHandler(handler)
+	instanceID := startLeaseTestWorkflow(t, engine)
+
+	pool := NewWorkerPool(engine, 2, 10*time.Millisecond)
+	pool.Start(ctx)
+	<-handler.started
+
+	drained := make(chan error, 1)
+	go func() { drained <- pool.Drain(ctx) }()
+
+	select {
+	case <-drained:
+		t.Fatal("drain returned while a step was in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// No new items are claimed while draining
+	stepID := int64(100)
+	require.NoError(t, store.EnqueueStep(ctx, instanceID, &stepID, DefaultTaskQueue, PriorityNormal, 0))
+
+	close(handler.release)
+	require.NoError(t, <-drained)
+
+	status, err := engine.GetStatus(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, status)
+
+	length, err := store.GetQueueLength(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, length)
+}
+
+// testGetQueueLength checks that only due, unclaimed items of the given task queues are counted.
+func testGetQueueLength(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	stepIDs := []int64{1, 2, 3, 4}
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepIDs[0], "billing", PriorityNormal, 0))
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepIDs[1], "billing", PriorityNormal, 0))
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepIDs[2], "billing", PriorityNormal, time.Hour))
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepIDs[3], "shipping", PriorityNormal, 0))
+
+	_, err := store.DequeueStep(ctx, "worker-1", []string{"billing"})
+	require.NoError(t, err)
+
+	length, err := store.GetQueueLength(ctx, []string{"billing"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, length)
+
+	length, err = store.GetQueueLength(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+}
+
+func TestGetQueueLength_MemoryStore(t *testing.T) {
+	testGetQueueLength(t, NewMemoryStore())
+}
+
+func TestIntegration_GetQueueLength(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testGetQueueLength(t, store)
+}
+
+// benchmarkWorkerPool runs b.N single-step workflows through a pool of 8 workers
+// and reports the time until all of them completed.
+func benchmarkWorkerPool(b *testing.B, store Store, opts ...WorkerPoolOption) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	engine, handler := newWorkerPoolTestEngine(b, store)
+	workflowID := registerChainWorkflow(b, ctx, engine, 1)
+
+	for i := 0; i < b.N; i++ {
+		_, err := engine.Start(ctx, workflowID, json.RawMessage(`{}`))
+		require.NoError(b, err)
+	}
+
+	pool := NewWorkerPool(engine, 8, time.Millisecond, opts...)
+	b.ResetTimer()
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	for handler.calls.Load() < int64(b.N) {
+		time.Sleep(100 * time.Microsecond)
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "steps/s")
+}
+
+// Dispatcher mode claims a batch per transaction instead of one item per worker poll:
+//
+//	go test -run '^$' -bench WorkerPool -benchtime 2000x .
+func BenchmarkWorkerPool_PerWorkerDequeue(b *testing.B) {
+	benchmarkWorkerPool(b, NewMemoryStore())
+}
+
+func BenchmarkWorkerPool_Dispatcher(b *testing.B) {
+	benchmarkWorkerPool(b, NewMemoryStore(), WithDispatcher(64))
+}
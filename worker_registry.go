@@ -0,0 +1,169 @@
+package floxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultWorkerHeartbeatInterval = 10 * time.Second
+	defaultWorkerStaleTimeout      = time.Minute
+)
+
+// WithPoolVersion sets the version the pool reports in the worker registry, e.g. the build version of the binary.
+func WithPoolVersion(version string) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		pool.version = version
+	}
+}
+
+// WithHeartbeatInterval sets how often the pool refreshes its worker registry entry.
+// 0 disables the registration of the pool.
+func WithHeartbeatInterval(interval time.Duration) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		pool.heartbeatInterval = interval
+	}
+}
+
+// WithWorkerStaleTimeout sets after how long without a heartbeat a registered worker is marked dead.
+// Every pool marks stale workers, so the timeout should be the same across replicas and well above
+// the heartbeat interval.
+func WithWorkerStaleTimeout(timeout time.Duration) WorkerPoolOption {
+	return func(pool *WorkerPool) {
+		if timeout > 0 {
+			pool.staleTimeout = timeout
+		}
+	}
+}
+
+// WorkerRecordID returns the ID of the worker registry entry that claimed a queue item, given its
+// attempted_by: the pool workers claim items under "<pool ID>/<n>", the dispatcher under the pool ID.
+func WorkerRecordID(attemptedBy string) string {
+	if i := strings.LastIndexByte(attemptedBy, '/'); i >= 0 {
+		return attemptedBy[:i]
+	}
+
+	return attemptedBy
+}
+
+func poolMemberID(poolID string, n int) string {
+	return fmt.Sprintf("%s/%d", poolID, n)
+}
+
+// InFlight returns the number of step handlers the engine is running, for all its pools together.
+func (engine *Engine) InFlight() int {
+	return int(engine.inFlight.Load())
+}
+
+type inFlightKey struct{}
+
+// withInFlightCounter makes the handler runs started with the returned context count in counter too,
+// so that a pool reports its own in-flight steps rather than those of every pool of the engine.
+func withInFlightCounter(ctx context.Context, counter *atomic.Int64) context.Context {
+	return context.WithValue(ctx, inFlightKey{}, counter)
+}
+
+// trackInFlight counts a handler run in the engine and in the counter of the context, if any,
+// and returns the function ending it.
+func (engine *Engine) trackInFlight(ctx context.Context) func() {
+	counter, _ := ctx.Value(inFlightKey{}).(*atomic.Int64)
+
+	engine.inFlight.Add(1)
+	if counter != nil {
+		counter.Add(1)
+	}
+
+	return func() {
+		engine.inFlight.Add(-1)
+		if counter != nil {
+			counter.Add(-1)
+		}
+	}
+}
+
+func (p *WorkerPool) record(concurrency int, now time.Time) *WorkerRecord {
+	host, _ := os.Hostname()
+
+	taskQueues := p.taskQueues
+	if taskQueues == nil {
+		taskQueues = p.engine.TaskQueues()
+	}
+
+	return &WorkerRecord{
+		ID:              p.id,
+		Host:            host,
+		Version:         p.version,
+		Handlers:        p.engine.TaskQueues(),
+		TaskQueues:      taskQueues,
+		Concurrency:     concurrency,
+		InFlight:        int(p.inFlight.Load()),
+		Status:          WorkerStatusAlive,
+		StartedAt:       now,
+		LastHeartbeatAt: now,
+	}
+}
+
+// register adds the pool to the worker registry. A failure is logged only: the next heartbeat retries.
+// The caller holds p.mu.
+func (p *WorkerPool) register(ctx context.Context) {
+	if err := p.engine.store.RegisterWorker(ctx, p.record(len(p.workers), p.engine.clock.Now())); err != nil {
+		log.Printf("Workflow worker pool %s: register: %v", p.id, err)
+	}
+}
+
+// heartbeat refreshes the registry entry of the pool and marks stale workers dead until stopped.
+func (p *WorkerPool) heartbeat(ctx context.Context) {
+	defer close(p.heartbeatDone)
+
+	ticker := p.engine.clock.NewTicker(p.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case <-ticker.C():
+			p.beat(ctx, p.engine.clock.Now())
+		}
+	}
+}
+
+func (p *WorkerPool) beat(ctx context.Context, now time.Time) {
+	concurrency := p.Size()
+	err := p.engine.store.HeartbeatWorker(ctx, p.id, int(p.inFlight.Load()), concurrency, now)
+	if errors.Is(err, ErrEntityNotFound) {
+		// Registration failed on start or the entry was removed
+		err = p.engine.store.RegisterWorker(ctx, p.record(concurrency, now))
+	}
+	if err != nil {
+		log.Printf("Workflow worker pool %s: heartbeat: %v", p.id, err)
+	}
+
+	marked, err := p.engine.store.MarkStaleWorkersDead(ctx, now.Add(-p.staleTimeout))
+	if err != nil {
+		log.Printf("Workflow worker pool %s: mark stale workers: %v", p.id, err)
+	} else if marked > 0 {
+		log.Printf("Workflow worker pool %s: marked %d stale worker(s) dead", p.id, marked)
+	}
+}
+
+// deregister marks the pool as stopped once the heartbeat is over.
+func (p *WorkerPool) deregister() {
+	if p.heartbeatDone == nil {
+		return
+	}
+	<-p.heartbeatDone
+
+	err := p.engine.store.DeregisterWorker(context.Background(), p.id)
+	if err != nil && !errors.Is(err, ErrEntityNotFound) {
+		log.Printf("Workflow worker pool %s: deregister: %v", p.id, err)
+	}
+}
@@ -0,0 +1,162 @@
+package floxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWorkerRegistry checks registration, heartbeats, stale worker detection and deregistration.
+func testWorkerRegistry(t *testing.T, store Store) {
+	ctx := context.Background()
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+
+	for _, id := range []string{"pool-a", "pool-b"} {
+		require.NoError(t, store.RegisterWorker(ctx, &WorkerRecord{
+			ID:              id,
+			Host:            "node-1",
+			Version:         "v1.2.3",
+			Handlers:        []string{"charge", "ship"},
+			TaskQueues:      []string{"payments"},
+			Concurrency:     4,
+			StartedAt:       startedAt,
+			LastHeartbeatAt: startedAt,
+		}))
+	}
+
+	worker, err := store.GetWorker(ctx, "pool-a")
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", worker.Host)
+	assert.Equal(t, "v1.2.3", worker.Version)
+	assert.Equal(t, []string{"charge", "ship"}, worker.Handlers)
+	assert.Equal(t, []string{"payments"}, worker.TaskQueues)
+	assert.Equal(t, 4, worker.Concurrency)
+	assert.Equal(t, WorkerStatusAlive, worker.Status)
+	assert.True(t, worker.StartedAt.Equal(startedAt))
+
+	now := time.Now()
+	require.NoError(t, store.HeartbeatWorker(ctx, "pool-a", 3, 4, now))
+	assert.ErrorIs(t, store.HeartbeatWorker(ctx, "unknown", 0, 1, now), ErrEntityNotFound)
+
+	marked, err := store.MarkStaleWorkersDead(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	require.NoError(t, store.DeregisterWorker(ctx, "pool-a"))
+
+	workers, err := store.ListWorkers(ctx)
+	require.NoError(t, err)
+	require.Len(t, workers, 2)
+	assert.Equal(t, "pool-a", workers[0].ID)
+	assert.Equal(t, WorkerStatusStopped, workers[0].Status)
+	assert.Zero(t, workers[0].InFlight)
+	assert.Equal(t, WorkerStatusDead, workers[1].Status)
+
+	// A dead worker that heartbeats again is alive
+	require.NoError(t, store.HeartbeatWorker(ctx, "pool-b", 1, 8, now))
+	worker, err = store.GetWorker(ctx, "pool-b")
+	require.NoError(t, err)
+	assert.Equal(t, WorkerStatusAlive, worker.Status)
+	assert.Equal(t, 1, worker.InFlight)
+	assert.Equal(t, 8, worker.Concurrency)
+
+	_, err = store.GetWorker(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+}
+
+func TestWorkerRegistry_MemoryStore(t *testing.T) {
+	testWorkerRegistry(t, NewMemoryStore())
+}
+
+func TestIntegration_WorkerRegistry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	store, _, cleanup := setupTestStore(t)
+	t.Cleanup(cleanup)
+
+	testWorkerRegistry(t, store)
+}
+
+func TestWorkerRecordID(t *testing.T) {
+	assert.Equal(t, "pool", WorkerRecordID("pool/3"))
+	assert.Equal(t, "pool", WorkerRecordID("pool"))
+}
+
+func TestWorkerPool_Registry(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	engine, _ := newWorkerPoolTestEngine(t, store)
+
+	pool := NewWorkerPool(engine, 2, time.Hour,
+		WithPoolVersion("v2.0.0"),
+		WithHeartbeatInterval(time.Hour),
+		WithWorkerStaleTimeout(time.Minute),
+	)
+	pool.Start(ctx)
+
+	worker, err := store.GetWorker(ctx, pool.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0", worker.Version)
+	assert.Equal(t, []string{"counting"}, worker.Handlers)
+	assert.Equal(t, []string{"counting"}, worker.TaskQueues)
+	assert.Equal(t, 2, worker.Concurrency)
+
+	// The attempted_by of the items claimed by pool workers resolves to the pool
+	stepID := int64(1)
+	require.NoError(t, store.EnqueueStep(ctx, 1, &stepID, DefaultTaskQueue, PriorityNormal, 0))
+	item, err := store.DequeueStep(ctx, pool.workers[1].workerID, nil)
+	require.NoError(t, err)
+	require.NotNil(t, item.AttemptedBy)
+	assert.Equal(t, pool.ID(), WorkerRecordID(*item.AttemptedBy))
+
+	stale := time.Now().Add(-time.Hour)
+	require.NoError(t, store.RegisterWorker(ctx, &WorkerRecord{ID: "crashed", StartedAt: stale, LastHeartbeatAt: stale}))
+
+	pool.beat(ctx, time.Now())
+
+	crashed, err := store.GetWorker(ctx, "crashed")
+	require.NoError(t, err)
+	assert.Equal(t, WorkerStatusDead, crashed.Status)
+
+	pool.Stop()
+
+	worker, err = store.GetWorker(ctx, pool.ID())
+	require.NoError(t, err)
+	assert.Equal(t, WorkerStatusStopped, worker.Status)
+}
+
+func TestWorkerPool_RegistryInFlightPerPool(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	handler := &blockingHandler{started: make(chan string, 1), release: make(chan struct{})}
+	engine := newMemoryTestEngine(t, store, []StepHandler{handler})
+	startLeaseTestWorkflow(t, engine)
+
+	busy := NewWorkerPool(engine, 1, 10*time.Millisecond, WithHeartbeatInterval(time.Hour))
+	idle := NewWorkerPool(engine, 0, time.Hour, WithHeartbeatInterval(time.Hour))
+	busy.Start(ctx)
+	idle.Start(ctx)
+	<-handler.started
+
+	now := time.Now()
+	busy.beat(ctx, now)
+	idle.beat(ctx, now)
+
+	worker, err := store.GetWorker(ctx, busy.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, worker.InFlight)
+
+	worker, err = store.GetWorker(ctx, idle.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 0, worker.InFlight)
+	assert.Equal(t, 1, engine.InFlight())
+
+	close(handler.release)
+	require.NoError(t, busy.Drain(ctx))
+	idle.Stop()
+}
@@ -137,6 +137,7 @@ type YamlStep struct {
 	RetryDelay *int64         `yaml:"retry_delay"` // milliseconds
 	RetryStr   string         `yaml:"retry_strategy"`
 	Timeout    *int64         `yaml:"timeout"` // milliseconds
+	TaskQueue  string         `yaml:"task_queue"`
 	Metadata   map[string]any `yaml:"metadata"`
 
 	// parallel
@@ -157,6 +158,7 @@ type YamlTask struct {
 	RetryDelay *int64         `yaml:"retry_delay"` // ms
 	RetryStr   string         `yaml:"retry_strategy"`
 	Timeout    *int64         `yaml:"timeout"` // ms
+	TaskQueue  string         `yaml:"task_queue"`
 	Metadata   map[string]any `yaml:"metadata"`
 }
 
@@ -303,6 +305,9 @@ func applyTaskOptions(step *StepDefinition, st YamlStep, handlersExec map[string
 	if st.Timeout != nil {
 		step.Timeout = millisecondsToDuration(*st.Timeout)
 	}
+	if st.TaskQueue != "" {
+		step.TaskQueue = st.TaskQueue
+	}
 	// Merge metadata
 	for k, v := range st.Metadata {
 		step.Metadata[k] = v
@@ -343,6 +348,9 @@ func applyYamlTaskOptions(step *StepDefinition, t YamlTask, handlersExec map[str
 	if t.Timeout != nil {
 		step.Timeout = millisecondsToDuration(*t.Timeout)
 	}
+	if t.TaskQueue != "" {
+		step.TaskQueue = t.TaskQueue
+	}
 	for k, v := range t.Metadata {
 		step.Metadata[k] = v
 	}
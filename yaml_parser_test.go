@@ -28,6 +28,7 @@ flows:
         retry_delay: 3000
         retry_strategy: exponential
         timeout: 4500
+        task_queue: inventory
         metadata:
           k1: v1
 
@@ -83,6 +84,9 @@ flows:
 	if rs.Timeout != 4500*time.Millisecond {
 		t.Fatalf("reserve_stock timeout: %v", rs.Timeout)
 	}
+	if rs.TaskQueue != "inventory" {
+		t.Fatalf("reserve_stock task_queue: %s", rs.TaskQueue)
+	}
 
 	// Metadata should include exec from handler map and custom key
 	if rs.Metadata == nil || rs.Metadata["exec"] != "./handlers/stock_reserve.sh" || rs.Metadata["k1"] != "v1" {